@@ -0,0 +1,139 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package amqp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	AMQP "github.com/streadway/amqp"
+)
+
+// AppEventHandler is called for events
+type AppEventHandler func(subscriber Subscriber, appID string, eventType types.EventType, payload []byte)
+
+// DeviceEventHandler is called for events
+type DeviceEventHandler func(subscriber Subscriber, appID string, devID string, eventType types.EventType, payload []byte)
+
+// GatewayEventHandler is called for gateway events
+type GatewayEventHandler func(subscriber Subscriber, gatewayID string, eventType types.EventType, payload []byte)
+
+// eventField converts an event type (such as down/scheduled) to the field of a routing key (such as down.scheduled)
+func eventField(eventType types.EventType) string {
+	return strings.Replace(string(eventType), "/", ".", -1)
+}
+
+// fieldEvent converts the field of a routing key (such as down.scheduled) to an event type (such as down/scheduled)
+func fieldEvent(field string) types.EventType {
+	return types.EventType(strings.Replace(field, ".", "/", -1))
+}
+
+// PublishAppEvent publishes an event to the routing key for application events of the given type
+// it will marshal the payload to json
+func (c *DefaultPublisher) PublishAppEvent(appID string, eventType types.EventType, payload interface{}) error {
+	key := ApplicationKey{appID, AppEvents, eventField(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the message payload")
+	}
+	return c.publish(key.String(), msg, time.Now())
+}
+
+// PublishDeviceEvent publishes an event to the routing key for device events of the given type
+// it will marshal the payload to json
+func (c *DefaultPublisher) PublishDeviceEvent(appID string, devID string, eventType types.EventType, payload interface{}) error {
+	key := DeviceKey{appID, devID, DeviceEvents, eventField(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the message payload")
+	}
+	return c.publish(key.String(), msg, time.Now())
+}
+
+// PublishGatewayEvent publishes an event to the routing key for gateway events of the given type
+// it will marshal the payload to json
+func (c *DefaultPublisher) PublishGatewayEvent(gatewayID string, eventType types.EventType, payload interface{}) error {
+	key := GatewayKey{gatewayID, GatewayEvents, eventField(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the message payload")
+	}
+	return c.publish(key.String(), msg, time.Now())
+}
+
+// SubscribeAppEvents subscribes to events of the given type for the given application. In order to subscribe to
+// application events from all applications the user has access to, pass an empty string as appID. In order to
+// subscribe to all event types, pass an empty string as eventType.
+func (s *DefaultSubscriber) SubscribeAppEvents(appID string, eventType types.EventType, handler AppEventHandler) error {
+	key := ApplicationKey{appID, AppEvents, eventField(eventType)}
+	messages, err := s.subscribe(key.String())
+	if err != nil {
+		return err
+	}
+
+	go s.handleEvents(messages, func(delivery AMQP.Delivery) {
+		key, err := ParseApplicationKey(delivery.RoutingKey)
+		if err != nil {
+			s.ctx.Warnf("Received message with invalid events routing key: %s", delivery.RoutingKey)
+			return
+		}
+		handler(s, key.AppID, fieldEvent(key.Field), delivery.Body)
+	})
+	return nil
+}
+
+// SubscribeDeviceEvents subscribes to events of the given type for the given device. In order to subscribe to
+// events from all devices within an application, pass an empty string as devID. In order to subscribe to all
+// events from all devices in all applications the user has access to, pass an empty string as appID. In order
+// to subscribe to all event types, pass an empty string as eventType.
+func (s *DefaultSubscriber) SubscribeDeviceEvents(appID string, devID string, eventType types.EventType, handler DeviceEventHandler) error {
+	key := DeviceKey{appID, devID, DeviceEvents, eventField(eventType)}
+	messages, err := s.subscribe(key.String())
+	if err != nil {
+		return err
+	}
+
+	go s.handleEvents(messages, func(delivery AMQP.Delivery) {
+		key, err := ParseDeviceKey(delivery.RoutingKey)
+		if err != nil {
+			s.ctx.Warnf("Received message with invalid events routing key: %s", delivery.RoutingKey)
+			return
+		}
+		handler(s, key.AppID, key.DevID, fieldEvent(key.Field), delivery.Body)
+	})
+	return nil
+}
+
+// SubscribeGatewayEvents subscribes to events of the given type for the given gateway. In order to subscribe to
+// events from all gateways, pass an empty string as gatewayID. In order to subscribe to all event types, pass an
+// empty string as eventType.
+func (s *DefaultSubscriber) SubscribeGatewayEvents(gatewayID string, eventType types.EventType, handler GatewayEventHandler) error {
+	key := GatewayKey{gatewayID, GatewayEvents, eventField(eventType)}
+	messages, err := s.subscribe(key.String())
+	if err != nil {
+		return err
+	}
+
+	go s.handleEvents(messages, func(delivery AMQP.Delivery) {
+		key, err := ParseGatewayKey(delivery.RoutingKey)
+		if err != nil {
+			s.ctx.Warnf("Received message with invalid events routing key: %s", delivery.RoutingKey)
+			return
+		}
+		handler(s, key.GatewayID, fieldEvent(key.Field), delivery.Body)
+	})
+	return nil
+}
+
+func (s *DefaultSubscriber) handleEvents(messages <-chan AMQP.Delivery, handle func(AMQP.Delivery)) {
+	for delivery := range messages {
+		handle(delivery)
+		if err := delivery.Ack(false); err != nil {
+			s.ctx.Warnf("Could not acknowledge message (%s)", err)
+		}
+	}
+}
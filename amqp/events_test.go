@@ -0,0 +1,122 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package amqp
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestPublishSubscribeAppEvents(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "TestPublishSubscribeAppEvents"), "guest", "guest", host)
+	err := c.Connect()
+	a.So(err, ShouldBeNil)
+	defer c.Disconnect()
+
+	p := c.NewPublisher("amq.topic")
+	err = p.Open()
+	a.So(err, ShouldBeNil)
+	defer p.Close()
+
+	s := c.NewSubscriber("amq.topic", "", false, true)
+	err = s.Open()
+	a.So(err, ShouldBeNil)
+	defer s.Close()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	err = s.SubscribeAppEvents("app", "", func(_ Subscriber, appID string, eventType types.EventType, payload []byte) {
+		a.So(appID, ShouldEqual, "app")
+		a.So(eventType, ShouldEqual, "some-event")
+		a.So(string(payload), ShouldEqual, `"foo"`)
+		wg.Done()
+	})
+	a.So(err, ShouldBeNil)
+
+	err = p.PublishAppEvent("app", "some-event", "foo")
+	a.So(err, ShouldBeNil)
+
+	wg.Wait()
+}
+
+func TestPublishSubscribeDeviceEvents(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "TestPublishSubscribeDeviceEvents"), "guest", "guest", host)
+	err := c.Connect()
+	a.So(err, ShouldBeNil)
+	defer c.Disconnect()
+
+	p := c.NewPublisher("amq.topic")
+	err = p.Open()
+	a.So(err, ShouldBeNil)
+	defer p.Close()
+
+	s := c.NewSubscriber("amq.topic", "", false, true)
+	err = s.Open()
+	a.So(err, ShouldBeNil)
+	defer s.Close()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	err = s.SubscribeDeviceEvents("app", "test", "", func(_ Subscriber, appID string, devID string, eventType types.EventType, payload []byte) {
+		a.So(appID, ShouldEqual, "app")
+		a.So(devID, ShouldEqual, "test")
+		a.So(eventType, ShouldEqual, types.DownlinkScheduledEvent)
+		var data types.DownlinkEventData
+		a.So(json.Unmarshal(payload, &data), ShouldBeNil)
+		a.So(data.Payload, ShouldResemble, []byte{0x01, 0x08})
+		wg.Done()
+	})
+	a.So(err, ShouldBeNil)
+
+	err = p.PublishDeviceEvent("app", "test", types.DownlinkScheduledEvent, types.DownlinkEventData{
+		Payload: []byte{0x01, 0x08},
+	})
+	a.So(err, ShouldBeNil)
+
+	wg.Wait()
+}
+
+func TestPublishSubscribeGatewayEvents(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "TestPublishSubscribeGatewayEvents"), "guest", "guest", host)
+	err := c.Connect()
+	a.So(err, ShouldBeNil)
+	defer c.Disconnect()
+
+	p := c.NewPublisher("amq.topic")
+	err = p.Open()
+	a.So(err, ShouldBeNil)
+	defer p.Close()
+
+	s := c.NewSubscriber("amq.topic", "", false, true)
+	err = s.Open()
+	a.So(err, ShouldBeNil)
+	defer s.Close()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	err = s.SubscribeGatewayEvents("gtw", "", func(_ Subscriber, gatewayID string, eventType types.EventType, payload []byte) {
+		a.So(gatewayID, ShouldEqual, "gtw")
+		a.So(eventType, ShouldEqual, types.GatewayDisconnectEvent)
+		var data types.GatewayEventData
+		a.So(json.Unmarshal(payload, &data), ShouldBeNil)
+		a.So(data.Stream, ShouldEqual, "uplink")
+		wg.Done()
+	})
+	a.So(err, ShouldBeNil)
+
+	err = p.PublishGatewayEvent("gtw", types.GatewayDisconnectEvent, types.GatewayEventData{
+		GatewayID: "gtw",
+		Stream:    "uplink",
+	})
+	a.So(err, ShouldBeNil)
+
+	wg.Wait()
+}
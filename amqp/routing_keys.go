@@ -32,7 +32,7 @@ type DeviceKey struct {
 
 // ParseDeviceKey parses an AMQP device routing key string to a DeviceKey struct
 func ParseDeviceKey(key string) (*DeviceKey, error) {
-	pattern := regexp.MustCompile("^([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\*)\\.(devices)\\.([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\*)\\.(events|up|down)([0-9a-z\\.]+)?$")
+	pattern := regexp.MustCompile("^([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\*)\\.(devices)\\.([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\*)\\.(events|up|down)([0-9a-z\\.-]+|\\.#)?$")
 	matches := pattern.FindStringSubmatch(key)
 	if len(matches) < 5 {
 		return nil, fmt.Errorf("Invalid key format")
@@ -122,3 +122,53 @@ func (t ApplicationKey) String() string {
 	}
 	return key
 }
+
+// GatewayKeyType represents an AMQP gateway routing key
+type GatewayKeyType string
+
+// Topic types for Gateways
+const (
+	GatewayEvents GatewayKeyType = "events"
+)
+
+// GatewayKey represents an AMQP routing key for gateways
+type GatewayKey struct {
+	GatewayID string
+	Type      GatewayKeyType
+	Field     string
+}
+
+// ParseGatewayKey parses an AMQP gateway routing key string to a GatewayKey struct
+func ParseGatewayKey(key string) (*GatewayKey, error) {
+	pattern := regexp.MustCompile("^(gateways)\\.([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\*)\\.(events)([0-9a-z\\.-]+|\\.#)?$")
+	matches := pattern.FindStringSubmatch(key)
+	if len(matches) < 4 {
+		return nil, fmt.Errorf("Invalid key format")
+	}
+	var gatewayID string
+	if matches[2] != simpleWildcard {
+		gatewayID = matches[2]
+	}
+	keyType := GatewayKeyType(matches[3])
+	gatewayKey := &GatewayKey{gatewayID, keyType, ""}
+	if keyType == GatewayEvents && len(matches) > 4 {
+		gatewayKey.Field = strings.Trim(matches[4], ".")
+	}
+	return gatewayKey, nil
+}
+
+// String implements the Stringer interface
+func (t GatewayKey) String() string {
+	gatewayID := simpleWildcard
+	if t.GatewayID != "" {
+		gatewayID = t.GatewayID
+	}
+	if t.Type == GatewayEvents && t.Field == "" {
+		t.Field = wildcard
+	}
+	key := fmt.Sprintf("%s.%s.%s", "gateways", gatewayID, t.Type)
+	if t.Type == GatewayEvents && t.Field != "" {
+		key += "." + t.Field
+	}
+	return key
+}
@@ -26,6 +26,26 @@ func TestParseDeviceKey(t *testing.T) {
 	a.So(got, ShouldResemble, expected)
 }
 
+func TestParseDeviceEventsKey(t *testing.T) {
+	a := New(t)
+
+	got, err := ParseDeviceKey("app.devices.dev.events.down.scheduled")
+	a.So(err, ShouldBeNil)
+	a.So(got, ShouldResemble, &DeviceKey{
+		AppID: "app",
+		DevID: "dev",
+		Type:  DeviceEvents,
+		Field: "down.scheduled",
+	})
+
+	got, err = ParseDeviceKey("*.devices.*.events.#")
+	a.So(err, ShouldBeNil)
+	a.So(got, ShouldResemble, &DeviceKey{
+		Type:  DeviceEvents,
+		Field: "#",
+	})
+}
+
 func TestParseDeviceKeyInvalid(t *testing.T) {
 	a := New(t)
 
@@ -90,6 +110,8 @@ func TestDeviceKeyParseAndString(t *testing.T) {
 		"*.devices.*.up",
 		"*.devices.*.down",
 		"*.devices.*.events.activations",
+		"*.devices.*.events.#",
+		"app.devices.dev.events.down.scheduled",
 		// Not Wildcard
 		"0102030405060708.devices.0100000000000000.up",
 		"0102030405060708.devices.0100000000000000.down",
@@ -167,3 +189,49 @@ func TestAppKeyParseAndString(t *testing.T) {
 		a.So(key.String(), ShouldEqual, expected)
 	}
 }
+
+func TestParseGatewayKeyInvalid(t *testing.T) {
+	a := New(t)
+
+	_, err := ParseGatewayKey("gateways.gtwid:Invalid.events")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseGatewayKey("gateways.gtwid.randomstuff")
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestGatewayKeyString(t *testing.T) {
+	a := New(t)
+
+	key := &GatewayKey{
+		GatewayID: "gtwid-1",
+		Type:      GatewayEvents,
+	}
+
+	a.So(key.String(), ShouldResemble, "gateways.gtwid-1.events.#")
+
+	key = &GatewayKey{
+		GatewayID: "gtwid-1",
+		Type:      GatewayEvents,
+		Field:     "disconnect",
+	}
+
+	a.So(key.String(), ShouldResemble, "gateways.gtwid-1.events.disconnect")
+}
+
+func TestGatewayKeyParseAndString(t *testing.T) {
+	a := New(t)
+
+	expectedList := []string{
+		"gateways.*.events.#",
+		"gateways.gtwid.events.#",
+		"gateways.*.events.status-stale",
+		"gateways.gtwid.events.clock-drift",
+	}
+
+	for _, expected := range expectedList {
+		key, err := ParseGatewayKey(expected)
+		a.So(err, ShouldBeNil)
+		a.So(key.String(), ShouldEqual, expected)
+	}
+}
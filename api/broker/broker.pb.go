@@ -21,6 +21,8 @@
 		SubscribeRequest
 		StatusRequest
 		Status
+		TrafficRequest
+		TrafficMessage
 		ApplicationHandlerRegistration
 */
 package broker
@@ -628,6 +630,7 @@ type Status struct {
 	Activations       *api.Rates          `protobuf:"bytes,14,opt,name=activations" json:"activations,omitempty"`
 	ActivationsUnique *api.Rates          `protobuf:"bytes,15,opt,name=activations_unique,json=activationsUnique" json:"activations_unique,omitempty"`
 	Deduplication     *api.Percentiles    `protobuf:"bytes,16,opt,name=deduplication" json:"deduplication,omitempty"`
+	QuotaExceeded     *api.Rates          `protobuf:"bytes,17,opt,name=quota_exceeded,json=quotaExceeded" json:"quota_exceeded,omitempty"`
 	// Connections
 	ConnectedRouters  uint32 `protobuf:"varint,21,opt,name=connected_routers,json=connectedRouters,proto3" json:"connected_routers,omitempty"`
 	ConnectedHandlers uint32 `protobuf:"varint,22,opt,name=connected_handlers,json=connectedHandlers,proto3" json:"connected_handlers,omitempty"`
@@ -694,6 +697,13 @@ func (m *Status) GetDeduplication() *api.Percentiles {
 	return nil
 }
 
+func (m *Status) GetQuotaExceeded() *api.Rates {
+	if m != nil {
+		return m.QuotaExceeded
+	}
+	return nil
+}
+
 func (m *Status) GetConnectedRouters() uint32 {
 	if m != nil {
 		return m.ConnectedRouters
@@ -708,6 +718,70 @@ func (m *Status) GetConnectedHandlers() uint32 {
 	return 0
 }
 
+// message TrafficRequest is used to subscribe to the uplink and downlink
+// traffic of this Broker
+type TrafficRequest struct {
+	// Only send the traffic of this gateway
+	GatewayId string `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Only send the traffic of this device address
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,2,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+	// Only send the traffic of this application
+	AppId string `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+}
+
+func (m *TrafficRequest) Reset()                    { *m = TrafficRequest{} }
+func (m *TrafficRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrafficRequest) ProtoMessage()               {}
+func (*TrafficRequest) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{12} }
+
+func (m *TrafficRequest) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+func (m *TrafficRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+// message TrafficMessage is an uplink or downlink message of a device
+type TrafficMessage struct {
+	// Time in Unix nanoseconds
+	Time     int64                      `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Uplink   *DeduplicatedUplinkMessage `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink *DownlinkMessage           `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
+}
+
+func (m *TrafficMessage) Reset()                    { *m = TrafficMessage{} }
+func (m *TrafficMessage) String() string            { return proto.CompactTextString(m) }
+func (*TrafficMessage) ProtoMessage()               {}
+func (*TrafficMessage) Descriptor() ([]byte, []int) { return fileDescriptorBroker, []int{13} }
+
+func (m *TrafficMessage) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *TrafficMessage) GetUplink() *DeduplicatedUplinkMessage {
+	if m != nil {
+		return m.Uplink
+	}
+	return nil
+}
+
+func (m *TrafficMessage) GetDownlink() *DownlinkMessage {
+	if m != nil {
+		return m.Downlink
+	}
+	return nil
+}
+
 type ApplicationHandlerRegistration struct {
 	AppId     string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	HandlerId string `protobuf:"bytes,2,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
@@ -717,7 +791,7 @@ func (m *ApplicationHandlerRegistration) Reset()         { *m = ApplicationHandl
 func (m *ApplicationHandlerRegistration) String() string { return proto.CompactTextString(m) }
 func (*ApplicationHandlerRegistration) ProtoMessage()    {}
 func (*ApplicationHandlerRegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptorBroker, []int{12}
+	return fileDescriptorBroker, []int{14}
 }
 
 func (m *ApplicationHandlerRegistration) GetAppId() string {
@@ -747,6 +821,8 @@ func init() {
 	proto.RegisterType((*SubscribeRequest)(nil), "broker.SubscribeRequest")
 	proto.RegisterType((*StatusRequest)(nil), "broker.StatusRequest")
 	proto.RegisterType((*Status)(nil), "broker.Status")
+	proto.RegisterType((*TrafficRequest)(nil), "broker.TrafficRequest")
+	proto.RegisterType((*TrafficMessage)(nil), "broker.TrafficMessage")
 	proto.RegisterType((*ApplicationHandlerRegistration)(nil), "broker.ApplicationHandlerRegistration")
 }
 
@@ -769,6 +845,8 @@ type BrokerClient interface {
 	Publish(ctx context.Context, opts ...grpc.CallOption) (Broker_PublishClient, error)
 	// Router requests device activation
 	Activate(ctx context.Context, in *DeviceActivationRequest, opts ...grpc.CallOption) (*DeviceActivationResponse, error)
+	// Router reports that a downlink could not be scheduled on the selected gateway
+	DownlinkFailed(ctx context.Context, in *DownlinkMessage, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type brokerClient struct {
@@ -885,6 +963,15 @@ func (c *brokerClient) Activate(ctx context.Context, in *DeviceActivationRequest
 	return out, nil
 }
 
+func (c *brokerClient) DownlinkFailed(ctx context.Context, in *DownlinkMessage, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/broker.Broker/DownlinkFailed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Broker service
 
 type BrokerServer interface {
@@ -896,6 +983,8 @@ type BrokerServer interface {
 	Publish(Broker_PublishServer) error
 	// Router requests device activation
 	Activate(context.Context, *DeviceActivationRequest) (*DeviceActivationResponse, error)
+	// Router reports that a downlink could not be scheduled on the selected gateway
+	DownlinkFailed(context.Context, *DownlinkMessage) (*google_protobuf.Empty, error)
 }
 
 func RegisterBrokerServer(s *grpc.Server, srv BrokerServer) {
@@ -993,6 +1082,24 @@ func _Broker_Activate_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Broker_DownlinkFailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownlinkMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BrokerServer).DownlinkFailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/broker.Broker/DownlinkFailed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BrokerServer).DownlinkFailed(ctx, req.(*DownlinkMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Broker_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "broker.Broker",
 	HandlerType: (*BrokerServer)(nil),
@@ -1001,6 +1108,10 @@ var _Broker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Activate",
 			Handler:    _Broker_Activate_Handler,
 		},
+		{
+			MethodName: "DownlinkFailed",
+			Handler:    _Broker_DownlinkFailed_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1031,6 +1142,9 @@ type BrokerManagerClient interface {
 	RegisterApplicationHandler(ctx context.Context, in *ApplicationHandlerRegistration, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	// Network operator requests Broker status
 	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Status, error)
+	// Application owner or network operator subscribes to the live uplink and
+	// downlink traffic of the Broker
+	Traffic(ctx context.Context, in *TrafficRequest, opts ...grpc.CallOption) (BrokerManager_TrafficClient, error)
 }
 
 type brokerManagerClient struct {
@@ -1059,6 +1173,38 @@ func (c *brokerManagerClient) GetStatus(ctx context.Context, in *StatusRequest,
 	return out, nil
 }
 
+func (c *brokerManagerClient) Traffic(ctx context.Context, in *TrafficRequest, opts ...grpc.CallOption) (BrokerManager_TrafficClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_BrokerManager_serviceDesc.Streams[0], c.cc, "/broker.BrokerManager/Traffic", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &brokerManagerTrafficClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BrokerManager_TrafficClient interface {
+	Recv() (*TrafficMessage, error)
+	grpc.ClientStream
+}
+
+type brokerManagerTrafficClient struct {
+	grpc.ClientStream
+}
+
+func (x *brokerManagerTrafficClient) Recv() (*TrafficMessage, error) {
+	m := new(TrafficMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for BrokerManager service
 
 type BrokerManagerServer interface {
@@ -1067,6 +1213,9 @@ type BrokerManagerServer interface {
 	RegisterApplicationHandler(context.Context, *ApplicationHandlerRegistration) (*google_protobuf.Empty, error)
 	// Network operator requests Broker status
 	GetStatus(context.Context, *StatusRequest) (*Status, error)
+	// Application owner or network operator subscribes to the live uplink and
+	// downlink traffic of the Broker
+	Traffic(*TrafficRequest, BrokerManager_TrafficServer) error
 }
 
 func RegisterBrokerManagerServer(s *grpc.Server, srv BrokerManagerServer) {
@@ -1109,6 +1258,27 @@ func _BrokerManager_GetStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BrokerManager_Traffic_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrafficRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BrokerManagerServer).Traffic(m, &brokerManagerTrafficServer{stream})
+}
+
+type BrokerManager_TrafficServer interface {
+	Send(*TrafficMessage) error
+	grpc.ServerStream
+}
+
+type brokerManagerTrafficServer struct {
+	grpc.ServerStream
+}
+
+func (x *brokerManagerTrafficServer) Send(m *TrafficMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _BrokerManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "broker.BrokerManager",
 	HandlerType: (*BrokerManagerServer)(nil),
@@ -1122,7 +1292,13 @@ var _BrokerManager_serviceDesc = grpc.ServiceDesc{
 			Handler:    _BrokerManager_GetStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Traffic",
+			Handler:       _BrokerManager_Traffic_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/broker/broker.proto",
 }
 
@@ -2055,6 +2231,18 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n48
 	}
+	if m.QuotaExceeded != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.QuotaExceeded.Size()))
+		n49, err := m.QuotaExceeded.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
 	if m.ConnectedRouters != 0 {
 		dAtA[i] = 0xa8
 		i++
@@ -2072,6 +2260,89 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *TrafficRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrafficRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if m.DevAddr != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.DevAddr.Size()))
+		n50, err := m.DevAddr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	return i, nil
+}
+
+func (m *TrafficMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrafficMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.Time))
+	}
+	if m.Uplink != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.Uplink.Size()))
+		n51, err := m.Uplink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.Downlink != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintBroker(dAtA, i, uint64(m.Downlink.Size()))
+		n52, err := m.Downlink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	return i, nil
+}
+
 func (m *ApplicationHandlerRegistration) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2506,6 +2777,10 @@ func (m *Status) Size() (n int) {
 		l = m.Deduplication.Size()
 		n += 2 + l + sovBroker(uint64(l))
 	}
+	if m.QuotaExceeded != nil {
+		l = m.QuotaExceeded.Size()
+		n += 2 + l + sovBroker(uint64(l))
+	}
 	if m.ConnectedRouters != 0 {
 		n += 2 + sovBroker(uint64(m.ConnectedRouters))
 	}
@@ -2515,6 +2790,41 @@ func (m *Status) Size() (n int) {
 	return n
 }
 
+func (m *TrafficRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	if m.DevAddr != nil {
+		l = m.DevAddr.Size()
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	return n
+}
+
+func (m *TrafficMessage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovBroker(uint64(m.Time))
+	}
+	if m.Uplink != nil {
+		l = m.Uplink.Size()
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	if m.Downlink != nil {
+		l = m.Downlink.Size()
+		n += 1 + l + sovBroker(uint64(l))
+	}
+	return n
+}
+
 func (m *ApplicationHandlerRegistration) Size() (n int) {
 	var l int
 	_ = l
@@ -5489,6 +5799,39 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuotaExceeded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QuotaExceeded == nil {
+				m.QuotaExceeded = &api.Rates{}
+			}
+			if err := m.QuotaExceeded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 21:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ConnectedRouters", wireType)
@@ -5548,6 +5891,284 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
+func (m *TrafficRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBroker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TrafficRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TrafficRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBroker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBroker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *TrafficMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBroker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TrafficMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TrafficMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Uplink == nil {
+				m.Uplink = &DeduplicatedUplinkMessage{}
+			}
+			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBroker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBroker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Downlink == nil {
+				m.Downlink = &DownlinkMessage{}
+			}
+			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBroker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBroker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func (m *ApplicationHandlerRegistration) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5766,81 +6387,90 @@ func init() {
 }
 
 var fileDescriptorBroker = []byte{
-	// 1208 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x58, 0x4d, 0x8f, 0xdb, 0x44,
-	0x18, 0x96, 0xf7, 0x23, 0xdb, 0x7d, 0xb3, 0xd9, 0x24, 0xd3, 0xee, 0xae, 0x9b, 0xd2, 0x4d, 0x08,
-	0x52, 0x15, 0x28, 0x4d, 0xda, 0x20, 0xbe, 0x24, 0x44, 0x95, 0xed, 0x56, 0xb0, 0x48, 0x29, 0x95,
-	0x9b, 0x72, 0x40, 0x48, 0xd1, 0xc4, 0x7e, 0xeb, 0x8c, 0xea, 0xd8, 0xae, 0x67, 0x9c, 0x36, 0x7f,
-	0x80, 0x0b, 0x12, 0xbf, 0x01, 0xf8, 0x07, 0x1c, 0xb9, 0x70, 0x44, 0x1c, 0x39, 0x73, 0x00, 0xd4,
-	0x5f, 0x82, 0x3c, 0x9e, 0xb1, 0x93, 0xdd, 0xa6, 0xad, 0xaa, 0x8a, 0x0f, 0x75, 0x2f, 0x89, 0xe7,
-	0x79, 0x1f, 0x3f, 0x33, 0xf3, 0x7e, 0x79, 0x6c, 0x78, 0xdf, 0x65, 0x62, 0x1c, 0x8f, 0xda, 0x76,
-	0x30, 0xe9, 0x0c, 0xc6, 0x38, 0x18, 0x33, 0xdf, 0xe5, 0xb7, 0x50, 0x3c, 0x0c, 0xa2, 0xfb, 0x1d,
-	0x21, 0xfc, 0x0e, 0x0d, 0x59, 0x67, 0x14, 0x05, 0xf7, 0x31, 0x52, 0x7f, 0xed, 0x30, 0x0a, 0x44,
-	0x40, 0x0a, 0xe9, 0xa8, 0x76, 0xc1, 0x0d, 0x02, 0xd7, 0xc3, 0x8e, 0x44, 0x47, 0xf1, 0xbd, 0x0e,
-	0x4e, 0x42, 0x31, 0x4b, 0x49, 0xb5, 0x2b, 0x73, 0xea, 0x6e, 0xe0, 0x06, 0x39, 0x2b, 0x19, 0xc9,
-	0x81, 0xbc, 0x52, 0xf4, 0xaa, 0x9e, 0x90, 0x86, 0x4c, 0x41, 0x75, 0x0d, 0xc9, 0xa1, 0x1d, 0x78,
-	0xd9, 0x85, 0x22, 0x5c, 0xd4, 0x04, 0x97, 0x0a, 0x7c, 0x48, 0x67, 0xfa, 0x5f, 0x99, 0xcf, 0x6b,
-	0xb3, 0x88, 0xa8, 0x8d, 0xe9, 0x6f, 0x6a, 0x6a, 0x7e, 0xbd, 0x02, 0xdb, 0x87, 0xc1, 0x43, 0xdf,
-	0x63, 0xfe, 0xfd, 0xcf, 0x43, 0xc1, 0x02, 0x9f, 0xec, 0x03, 0x30, 0x07, 0x7d, 0xc1, 0xee, 0x31,
-	0x8c, 0x4c, 0xa3, 0x61, 0xb4, 0x36, 0xad, 0x39, 0x84, 0x5c, 0x04, 0x50, 0xf2, 0x43, 0xe6, 0x98,
-	0x2b, 0xd2, 0xbe, 0xa9, 0x90, 0x23, 0x87, 0x9c, 0x83, 0x75, 0x6e, 0x07, 0x11, 0x9a, 0xab, 0x0d,
-	0xa3, 0x55, 0xb2, 0xd2, 0x01, 0xa9, 0xc1, 0x19, 0x07, 0xa9, 0xe3, 0x31, 0x1f, 0xcd, 0xb5, 0x86,
-	0xd1, 0x5a, 0xb5, 0xb2, 0x31, 0x39, 0x80, 0xb2, 0xde, 0xcf, 0xd0, 0x0e, 0xfc, 0x7b, 0xcc, 0x35,
-	0xd7, 0x1b, 0x46, 0xab, 0xd8, 0x3d, 0xdf, 0xce, 0xf6, 0x39, 0x78, 0x74, 0x43, 0x5a, 0xe2, 0x88,
-	0x26, 0x8b, 0xb4, 0xb6, 0xb5, 0x25, 0x85, 0xc9, 0x75, 0xd8, 0xd6, 0x8b, 0x52, 0x12, 0x05, 0x29,
-	0x61, 0xb6, 0xb5, 0x2b, 0x8e, 0x2b, 0x94, 0x94, 0x21, 0x45, 0x9b, 0xdf, 0xae, 0x41, 0xe9, 0x6e,
-	0x98, 0xb8, 0xa1, 0x8f, 0x9c, 0x53, 0x17, 0x89, 0x09, 0x1b, 0x21, 0x9d, 0x79, 0x01, 0x75, 0xa4,
-	0x13, 0xb6, 0x2c, 0x3d, 0x24, 0x97, 0x61, 0x63, 0x92, 0x92, 0xe4, 0xf6, 0x8b, 0xdd, 0x6a, 0xbe,
-	0x50, 0x75, 0xb7, 0xa5, 0x19, 0xe4, 0x16, 0x6c, 0x38, 0x38, 0x1d, 0x62, 0xcc, 0xcc, 0x62, 0x22,
-	0x73, 0xf0, 0xee, 0xef, 0x7f, 0xd4, 0xaf, 0x3d, 0x2b, 0xe3, 0x12, 0xa7, 0x75, 0xc4, 0x2c, 0x44,
-	0xde, 0x3e, 0xc4, 0xe9, 0xcd, 0xbb, 0x47, 0x56, 0xc1, 0xc1, 0xe9, 0xcd, 0x98, 0x25, 0x7a, 0x34,
-	0x0c, 0xa5, 0xde, 0xd6, 0x0b, 0xe9, 0xf5, 0xc2, 0x50, 0xea, 0xd1, 0x30, 0x4c, 0xf4, 0x76, 0x20,
-	0xb9, 0x4a, 0x42, 0x59, 0x92, 0xa1, 0x5c, 0xa7, 0x61, 0x78, 0xe4, 0x24, 0x70, 0xb2, 0x6c, 0xe6,
-	0x98, 0xdb, 0x29, 0xec, 0xe0, 0xf4, 0xc8, 0x21, 0x3d, 0xa8, 0x66, 0xb1, 0x9a, 0xa0, 0xa0, 0x0e,
-	0x15, 0xd4, 0xdc, 0x91, 0x4e, 0x38, 0x97, 0x3b, 0xc1, 0x7a, 0xd4, 0x57, 0x36, 0xab, 0xa2, 0x41,
-	0x8d, 0x90, 0x8f, 0xa1, 0xa2, 0x43, 0x95, 0x29, 0xec, 0x4a, 0x85, 0xb3, 0x59, 0xb0, 0xe6, 0x04,
-	0xca, 0x0a, 0xcb, 0xee, 0xef, 0x41, 0xc5, 0x51, 0x19, 0x3b, 0x0c, 0x64, 0xca, 0x72, 0xb3, 0xde,
-	0x58, 0x6d, 0x15, 0xbb, 0xbb, 0x6d, 0x55, 0x9d, 0x8b, 0x19, 0x6d, 0x95, 0x9d, 0x85, 0x31, 0x27,
-	0x4d, 0x58, 0x97, 0x45, 0x60, 0xbe, 0x29, 0xe7, 0xdd, 0x6a, 0xa7, 0x25, 0x31, 0x48, 0x7e, 0xad,
-	0xd4, 0xd4, 0xfc, 0x66, 0x15, 0xca, 0x5a, 0xe7, 0x34, 0x25, 0x9e, 0x92, 0x12, 0xd7, 0xa1, 0x7c,
-	0x2c, 0x1e, 0x2a, 0x21, 0x96, 0x85, 0x63, 0x7b, 0x31, 0x1c, 0x79, 0x34, 0xea, 0xcb, 0xa3, 0xf1,
-	0x8b, 0x01, 0xe6, 0x21, 0x4e, 0x99, 0x8d, 0x3d, 0x5b, 0xb0, 0x69, 0x5a, 0xc2, 0xc8, 0xc3, 0xc0,
-	0xe7, 0x2f, 0x2d, 0x2c, 0x4f, 0xd8, 0x48, 0xf1, 0xc5, 0x36, 0xb2, 0xb3, 0x7c, 0x23, 0x3f, 0xaf,
-	0xc1, 0xf9, 0x43, 0x74, 0xe2, 0xd0, 0x63, 0x36, 0x15, 0xe8, 0x9c, 0xf6, 0x9c, 0x7f, 0xaf, 0xe7,
-	0xac, 0x3e, 0x77, 0xcf, 0xa9, 0x43, 0x91, 0x63, 0x34, 0xc5, 0x68, 0x28, 0xd8, 0x04, 0xcd, 0x3d,
-	0xf9, 0x04, 0x83, 0x14, 0x1a, 0xb0, 0x09, 0x92, 0x43, 0xa8, 0x46, 0x2a, 0x1d, 0x87, 0x02, 0x27,
-	0xa1, 0x47, 0x85, 0xce, 0xe7, 0xbd, 0xe3, 0xd9, 0xa3, 0xc3, 0x55, 0xd1, 0x77, 0x0c, 0xd4, 0x0d,
-	0xcf, 0xd5, 0x97, 0x7e, 0x5a, 0x83, 0xbd, 0x93, 0x95, 0xf0, 0x20, 0x46, 0x2e, 0x5e, 0x95, 0xf4,
-	0xf9, 0x0f, 0x3c, 0x84, 0xfa, 0x70, 0x96, 0x66, 0xee, 0xcf, 0x25, 0xf6, 0xa4, 0xc4, 0x6b, 0xf9,
-	0x22, 0xf2, 0x18, 0x65, 0x5a, 0x84, 0x9e, 0xc0, 0xfe, 0xa9, 0x67, 0xda, 0x77, 0xeb, 0xf0, 0xc6,
-	0x7c, 0xf3, 0x79, 0xc5, 0xf3, 0xe8, 0x7f, 0xd7, 0x86, 0x5e, 0x72, 0xd6, 0x1d, 0xeb, 0x6a, 0xe6,
-	0x89, 0xae, 0xd6, 0x5f, 0xde, 0xd5, 0x1a, 0x59, 0x5e, 0x2e, 0x79, 0x2a, 0xbf, 0x60, 0x7b, 0xfb,
-	0x71, 0x05, 0x6a, 0xb9, 0xd8, 0x8d, 0x31, 0xf5, 0x3c, 0xf4, 0x5d, 0x3c, 0xcd, 0xcc, 0xe5, 0x99,
-	0xd9, 0x74, 0xe0, 0xc2, 0x13, 0x5d, 0xf6, 0x52, 0x8f, 0x47, 0x4d, 0x02, 0x95, 0x3b, 0xf1, 0x88,
-	0xdb, 0x11, 0x1b, 0xe9, 0x70, 0x34, 0xcb, 0x50, 0xba, 0x23, 0xa8, 0x88, 0xb9, 0x06, 0xfe, 0x5c,
-	0x85, 0x42, 0x8a, 0x90, 0x16, 0x14, 0xf8, 0x8c, 0x0b, 0x9c, 0xc8, 0x59, 0x8b, 0xdd, 0x4a, 0x3b,
-	0x79, 0xa3, 0xbd, 0x23, 0xa1, 0x84, 0xc2, 0x2d, 0x65, 0x27, 0xd7, 0x60, 0xd3, 0x0e, 0x26, 0x61,
-	0xe0, 0xa3, 0x2f, 0xd4, 0x42, 0xce, 0x4a, 0xf2, 0x0d, 0x8d, 0xa6, 0xfc, 0x9c, 0x45, 0x9a, 0x50,
-	0x88, 0xe5, 0xc9, 0x49, 0x1d, 0xd1, 0x40, 0xf2, 0x2d, 0x2a, 0x90, 0x5b, 0xca, 0x42, 0x3a, 0x50,
-	0x4a, 0xaf, 0x86, 0xb1, 0xcf, 0x1e, 0xc4, 0x28, 0x43, 0xb3, 0x48, 0xdd, 0x4a, 0x09, 0x77, 0xa5,
-	0x9d, 0x5c, 0x82, 0x33, 0xba, 0xab, 0x4a, 0xbf, 0x2f, 0x72, 0x33, 0x1b, 0x79, 0x1b, 0x8a, 0x79,
-	0x35, 0x71, 0x19, 0x8b, 0x45, 0xea, 0xbc, 0x99, 0x7c, 0x08, 0x73, 0xb5, 0xc7, 0xf5, 0x5a, 0xca,
-	0x27, 0x6e, 0xaa, 0xce, 0xb1, 0xd4, 0x82, 0xde, 0x83, 0x92, 0x93, 0xb5, 0xeb, 0xe4, 0x3c, 0x5a,
-	0x99, 0xf3, 0xe4, 0x6d, 0x8c, 0xec, 0xe4, 0xa5, 0xdc, 0x43, 0x6e, 0x2d, 0xd2, 0xc8, 0x65, 0xa8,
-	0xda, 0x81, 0xef, 0xa3, 0x2d, 0xd0, 0x19, 0x46, 0x41, 0x2c, 0x30, 0xe2, 0xb2, 0x55, 0x95, 0xac,
-	0x4a, 0x66, 0xb0, 0x52, 0x9c, 0x5c, 0x01, 0x92, 0x93, 0xc7, 0xd4, 0x77, 0xbc, 0x84, 0xbd, 0x2b,
-	0xd9, 0xb9, 0xcc, 0xa7, 0xca, 0xd0, 0xfc, 0x02, 0xf6, 0x7b, 0x61, 0x36, 0x95, 0x82, 0x2d, 0x74,
-	0x19, 0x17, 0xe9, 0x9b, 0xf5, 0x5c, 0xf2, 0x1a, 0xf3, 0xc9, 0x7b, 0x11, 0x40, 0xa9, 0xcf, 0x7d,
-	0x37, 0x50, 0xc8, 0x91, 0xd3, 0xfd, 0x61, 0x05, 0x0a, 0x07, 0xb2, 0xa5, 0x90, 0xeb, 0xb0, 0xd9,
-	0xe3, 0x3c, 0xb0, 0x59, 0xd2, 0x34, 0x76, 0x74, 0xa3, 0x59, 0x38, 0x29, 0xd7, 0x96, 0x9d, 0xaa,
-	0x5a, 0xc6, 0x55, 0x83, 0x7c, 0x06, 0x9b, 0x59, 0xaa, 0x12, 0x53, 0x33, 0x8f, 0x67, 0x6f, 0xed,
-	0xf5, 0xbc, 0x87, 0x2d, 0x39, 0x90, 0x5f, 0x35, 0xc8, 0x47, 0xb0, 0x71, 0x3b, 0x1e, 0x79, 0x8c,
-	0x8f, 0xc9, 0xb2, 0x39, 0x6b, 0xbb, 0xed, 0xf4, 0x03, 0x50, 0x5b, 0x7f, 0xda, 0x69, 0xdf, 0x9c,
-	0x84, 0x62, 0xd6, 0x32, 0x48, 0x1f, 0xce, 0xa8, 0xd2, 0x44, 0x52, 0x5f, 0xde, 0x32, 0xd3, 0xf5,
-	0x3c, 0xb3, 0xa7, 0x76, 0xbf, 0x37, 0xa0, 0x94, 0x3a, 0xa9, 0x4f, 0x7d, 0xea, 0x62, 0x44, 0xbe,
-	0x82, 0x5a, 0xea, 0x7c, 0x8c, 0x4e, 0x86, 0x85, 0x5c, 0xd2, 0x8a, 0x4f, 0x0f, 0xd9, 0xb2, 0x0d,
-	0x90, 0x2e, 0x6c, 0x7e, 0x82, 0x42, 0x15, 0x74, 0x16, 0x89, 0x85, 0x92, 0xaf, 0x6d, 0x2f, 0xc2,
-	0x07, 0x1f, 0xfc, 0xfa, 0x78, 0xdf, 0xf8, 0xed, 0xf1, 0xbe, 0xf1, 0xd7, 0xe3, 0x7d, 0xe3, 0xcb,
-	0xb7, 0x9e, 0xff, 0xdb, 0xda, 0xa8, 0x20, 0x67, 0x7f, 0xe7, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x4b, 0x13, 0x77, 0x15, 0x90, 0x13, 0x00, 0x00,
+	// 1346 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4d, 0x6f, 0x1b, 0xc5,
+	0x1b, 0xd7, 0xe6, 0xc5, 0x69, 0x1e, 0xc7, 0x6f, 0xd3, 0x26, 0xd9, 0xba, 0xff, 0xc6, 0xf9, 0x1b,
+	0xa9, 0x32, 0x94, 0xda, 0xad, 0x2b, 0x0a, 0x15, 0x88, 0xca, 0x69, 0x02, 0x04, 0x29, 0xa5, 0x6c,
+	0x5d, 0x0e, 0x08, 0xc9, 0x1a, 0xef, 0x3c, 0x71, 0x46, 0xb5, 0x77, 0xb7, 0xbb, 0xb3, 0x6e, 0xf3,
+	0x05, 0xb8, 0x20, 0x71, 0x40, 0x5c, 0xb8, 0xf1, 0x15, 0x38, 0x72, 0xe1, 0x88, 0x38, 0x72, 0xee,
+	0xa1, 0x42, 0x95, 0xf8, 0x1e, 0x68, 0x67, 0x67, 0x76, 0xd7, 0x76, 0xdd, 0x37, 0x55, 0xbc, 0xa8,
+	0xbd, 0xc4, 0x33, 0xcf, 0xf3, 0xdb, 0x67, 0x9e, 0x79, 0x5e, 0x7e, 0x33, 0x19, 0x78, 0x77, 0xc0,
+	0xc5, 0x51, 0xd8, 0x6f, 0xda, 0xee, 0xa8, 0xd5, 0x3d, 0xc2, 0xee, 0x11, 0x77, 0x06, 0xc1, 0x0d,
+	0x14, 0xf7, 0x5c, 0xff, 0x4e, 0x4b, 0x08, 0xa7, 0x45, 0x3d, 0xde, 0xea, 0xfb, 0xee, 0x1d, 0xf4,
+	0xd5, 0x4f, 0xd3, 0xf3, 0x5d, 0xe1, 0x92, 0x5c, 0x3c, 0xab, 0x9e, 0x19, 0xb8, 0xee, 0x60, 0x88,
+	0x2d, 0x29, 0xed, 0x87, 0x87, 0x2d, 0x1c, 0x79, 0xe2, 0x38, 0x06, 0x55, 0x2f, 0x64, 0xac, 0x0f,
+	0xdc, 0x81, 0x9b, 0xa2, 0xa2, 0x99, 0x9c, 0xc8, 0x91, 0x82, 0x57, 0xf4, 0x82, 0xd4, 0xe3, 0x4a,
+	0x54, 0xd3, 0x22, 0x39, 0xb5, 0xdd, 0x61, 0x32, 0x50, 0x80, 0xb3, 0x1a, 0x30, 0xa0, 0x02, 0xef,
+	0xd1, 0x63, 0xfd, 0xab, 0xd4, 0xa7, 0xb5, 0x5a, 0xf8, 0xd4, 0xc6, 0xf8, 0x6f, 0xac, 0xaa, 0x7f,
+	0xbd, 0x00, 0xc5, 0x5d, 0xf7, 0x9e, 0x33, 0xe4, 0xce, 0x9d, 0xcf, 0x3c, 0xc1, 0x5d, 0x87, 0x6c,
+	0x01, 0x70, 0x86, 0x8e, 0xe0, 0x87, 0x1c, 0x7d, 0xd3, 0xd8, 0x36, 0x1a, 0xab, 0x56, 0x46, 0x42,
+	0xce, 0x02, 0x28, 0xf3, 0x3d, 0xce, 0xcc, 0x05, 0xa9, 0x5f, 0x55, 0x92, 0x7d, 0x46, 0x4e, 0xc1,
+	0x72, 0x60, 0xbb, 0x3e, 0x9a, 0x8b, 0xdb, 0x46, 0xa3, 0x60, 0xc5, 0x13, 0x52, 0x85, 0x13, 0x0c,
+	0x29, 0x1b, 0x72, 0x07, 0xcd, 0xa5, 0x6d, 0xa3, 0xb1, 0x68, 0x25, 0x73, 0xb2, 0x03, 0x25, 0xbd,
+	0x9f, 0x9e, 0xed, 0x3a, 0x87, 0x7c, 0x60, 0x2e, 0x6f, 0x1b, 0x8d, 0x7c, 0xfb, 0x74, 0x33, 0xd9,
+	0x67, 0xf7, 0xfe, 0x75, 0xa9, 0x09, 0x7d, 0x1a, 0x39, 0x69, 0x15, 0xb5, 0x26, 0x16, 0x93, 0x6b,
+	0x50, 0xd4, 0x4e, 0x29, 0x13, 0x39, 0x69, 0xc2, 0x6c, 0xea, 0x50, 0x4c, 0x5b, 0x28, 0x28, 0x45,
+	0x2c, 0xad, 0x7f, 0xbb, 0x04, 0x85, 0xdb, 0x5e, 0x14, 0x86, 0x03, 0x0c, 0x02, 0x3a, 0x40, 0x62,
+	0xc2, 0x8a, 0x47, 0x8f, 0x87, 0x2e, 0x65, 0x32, 0x08, 0x6b, 0x96, 0x9e, 0x92, 0xf3, 0xb0, 0x32,
+	0x8a, 0x41, 0x72, 0xfb, 0xf9, 0x76, 0x25, 0x75, 0x54, 0x7d, 0x6d, 0x69, 0x04, 0xb9, 0x01, 0x2b,
+	0x0c, 0xc7, 0x3d, 0x0c, 0xb9, 0x99, 0x8f, 0xcc, 0xec, 0xbc, 0xf3, 0xe0, 0x61, 0xed, 0xd2, 0xd3,
+	0x2a, 0x2e, 0x0a, 0x5a, 0x4b, 0x1c, 0x7b, 0x18, 0x34, 0x77, 0x71, 0xbc, 0x77, 0x7b, 0xdf, 0xca,
+	0x31, 0x1c, 0xef, 0x85, 0x3c, 0xb2, 0x47, 0x3d, 0x4f, 0xda, 0x5b, 0x7b, 0x21, 0x7b, 0x1d, 0xcf,
+	0x93, 0xf6, 0xa8, 0xe7, 0x45, 0xf6, 0xd6, 0x21, 0x1a, 0x45, 0xa9, 0x2c, 0xc8, 0x54, 0x2e, 0x53,
+	0xcf, 0xdb, 0x67, 0x91, 0x38, 0x72, 0x9b, 0x33, 0xb3, 0x18, 0x8b, 0x19, 0x8e, 0xf7, 0x19, 0xe9,
+	0x40, 0x25, 0xc9, 0xd5, 0x08, 0x05, 0x65, 0x54, 0x50, 0x73, 0x5d, 0x06, 0xe1, 0x54, 0x1a, 0x04,
+	0xeb, 0xfe, 0x81, 0xd2, 0x59, 0x65, 0x2d, 0xd4, 0x12, 0xf2, 0x21, 0x94, 0x75, 0xaa, 0x12, 0x0b,
+	0x1b, 0xd2, 0xc2, 0xc9, 0x24, 0x59, 0x19, 0x03, 0x25, 0x25, 0x4b, 0xbe, 0xef, 0x40, 0x99, 0xa9,
+	0x8a, 0xed, 0xb9, 0xb2, 0x64, 0x03, 0xb3, 0xb6, 0xbd, 0xd8, 0xc8, 0xb7, 0x37, 0x9a, 0xaa, 0x3b,
+	0x27, 0x2b, 0xda, 0x2a, 0xb1, 0x89, 0x79, 0x40, 0xea, 0xb0, 0x2c, 0x9b, 0xc0, 0x7c, 0x53, 0xae,
+	0xbb, 0xd6, 0x94, 0xb3, 0x66, 0x37, 0xfa, 0x6b, 0xc5, 0xaa, 0xfa, 0x37, 0x8b, 0x50, 0xd2, 0x76,
+	0x5e, 0x97, 0xc4, 0x13, 0x4a, 0xe2, 0x1a, 0x94, 0xa6, 0xf2, 0xa1, 0x0a, 0x62, 0x5e, 0x3a, 0x8a,
+	0x93, 0xe9, 0x48, 0xb3, 0x51, 0x9b, 0x9f, 0x8d, 0x5f, 0x0d, 0x30, 0x77, 0x71, 0xcc, 0x6d, 0xec,
+	0xd8, 0x82, 0x8f, 0xe3, 0x16, 0xc6, 0xc0, 0x73, 0x9d, 0xe0, 0xa5, 0xa5, 0xe5, 0x31, 0x1b, 0xc9,
+	0xbf, 0xd8, 0x46, 0xd6, 0xe7, 0x6f, 0xe4, 0x97, 0x25, 0x38, 0xbd, 0x8b, 0x2c, 0xf4, 0x86, 0xdc,
+	0xa6, 0x02, 0xd9, 0x6b, 0xce, 0xf9, 0xe7, 0x38, 0x67, 0xf1, 0x99, 0x39, 0xa7, 0x06, 0xf9, 0x00,
+	0xfd, 0x31, 0xfa, 0x3d, 0xc1, 0x47, 0x68, 0x6e, 0xca, 0x13, 0x0c, 0x62, 0x51, 0x97, 0x8f, 0x90,
+	0xec, 0x42, 0xc5, 0x57, 0xe5, 0xd8, 0x13, 0x38, 0xf2, 0x86, 0x54, 0xe8, 0x7a, 0xde, 0x9c, 0xae,
+	0x1e, 0x9d, 0xae, 0xb2, 0xfe, 0xa2, 0xab, 0x3e, 0x78, 0x26, 0x5e, 0xfa, 0x79, 0x09, 0x36, 0x67,
+	0x3b, 0xe1, 0x6e, 0x88, 0x81, 0x78, 0x55, 0xca, 0xe7, 0x5f, 0x70, 0x08, 0x1d, 0xc0, 0x49, 0x9a,
+	0x84, 0x3f, 0x35, 0xb1, 0x29, 0x4d, 0xfc, 0x2f, 0x75, 0x22, 0xcd, 0x51, 0x62, 0x8b, 0xd0, 0x19,
+	0xd9, 0xdf, 0x75, 0xa6, 0xfd, 0xb8, 0x0c, 0x6f, 0x64, 0xc9, 0xe7, 0x15, 0xaf, 0xa3, 0xff, 0x1c,
+	0x0d, 0xbd, 0xe4, 0xaa, 0x9b, 0x62, 0x35, 0x73, 0x86, 0xd5, 0x0e, 0xe6, 0xb3, 0xda, 0x76, 0x52,
+	0x97, 0x73, 0x4e, 0xe5, 0x17, 0xa4, 0xb7, 0x9f, 0x16, 0xa0, 0x9a, 0x1a, 0xbb, 0x7e, 0x44, 0x87,
+	0x43, 0x74, 0x06, 0xf8, 0xba, 0x32, 0xe7, 0x57, 0x66, 0x9d, 0xc1, 0x99, 0xc7, 0x86, 0xec, 0xa5,
+	0x5e, 0x8f, 0xea, 0x04, 0xca, 0xb7, 0xc2, 0x7e, 0x60, 0xfb, 0xbc, 0xaf, 0xd3, 0x51, 0x2f, 0x41,
+	0xe1, 0x96, 0xa0, 0x22, 0x0c, 0xb4, 0xe0, 0xbb, 0x25, 0xc8, 0xc5, 0x12, 0xd2, 0x80, 0x5c, 0x70,
+	0x1c, 0x08, 0x1c, 0xc9, 0x55, 0xf3, 0xed, 0x72, 0x33, 0xfa, 0x8f, 0xf6, 0x96, 0x14, 0x45, 0x90,
+	0xc0, 0x52, 0x7a, 0x72, 0x09, 0x56, 0x6d, 0x77, 0xe4, 0xb9, 0x0e, 0x3a, 0x42, 0x39, 0x72, 0x52,
+	0x82, 0xaf, 0x6b, 0x69, 0x8c, 0x4f, 0x51, 0xa4, 0x0e, 0xb9, 0x50, 0xde, 0x9c, 0xd4, 0x15, 0x0d,
+	0x24, 0xde, 0xa2, 0x02, 0x03, 0x4b, 0x69, 0x48, 0x0b, 0x0a, 0xf1, 0xa8, 0x17, 0x3a, 0xfc, 0x6e,
+	0x88, 0xe6, 0xda, 0x0c, 0x74, 0x2d, 0x06, 0xdc, 0x96, 0x7a, 0x72, 0x0e, 0x4e, 0x68, 0x56, 0x35,
+	0x0b, 0x33, 0xd8, 0x44, 0x47, 0xde, 0x86, 0x7c, 0xda, 0x4d, 0x81, 0x59, 0x9c, 0x81, 0x66, 0xd5,
+	0xe4, 0x2a, 0x64, 0x7a, 0x2f, 0xd0, 0xbe, 0x94, 0x66, 0x3e, 0xaa, 0x64, 0x50, 0xca, 0xa1, 0x2b,
+	0x50, 0x60, 0x09, 0x5d, 0x47, 0xf7, 0xd1, 0x72, 0x26, 0x92, 0x37, 0xd1, 0xb7, 0xd1, 0x11, 0x7c,
+	0x88, 0x81, 0x35, 0x09, 0x23, 0x97, 0xa0, 0x78, 0x37, 0x74, 0x05, 0xed, 0xe1, 0x7d, 0x1b, 0x91,
+	0x21, 0x33, 0x2b, 0x33, 0xcb, 0x15, 0x24, 0x62, 0x4f, 0x01, 0xc8, 0x79, 0xa8, 0xd8, 0xae, 0xe3,
+	0xa0, 0x2d, 0x90, 0xf5, 0x7c, 0x37, 0x14, 0xe8, 0x07, 0x92, 0xdd, 0x0a, 0x56, 0x39, 0x51, 0x58,
+	0xb1, 0x9c, 0x5c, 0x00, 0x92, 0x82, 0x8f, 0xa8, 0xc3, 0x86, 0x11, 0x7a, 0x43, 0xa2, 0x53, 0x33,
+	0x9f, 0x28, 0x45, 0xfd, 0x07, 0x03, 0x8a, 0x5d, 0x9f, 0x1e, 0x1e, 0x72, 0x5b, 0xf7, 0xf1, 0xe4,
+	0x23, 0x82, 0x31, 0xfd, 0x88, 0xf0, 0x79, 0xf4, 0x5c, 0x30, 0xee, 0x51, 0xc6, 0x7c, 0x59, 0x10,
+	0x6b, 0x3b, 0x57, 0x1e, 0x3c, 0xac, 0xb5, 0x9f, 0xaf, 0x41, 0x3b, 0x8c, 0xf9, 0xd6, 0x0a, 0x8b,
+	0x07, 0x99, 0x96, 0x5a, 0xcc, 0xb4, 0x54, 0xfd, 0xfb, 0xd4, 0x37, 0x7d, 0x09, 0x27, 0xb0, 0x24,
+	0xf9, 0xd0, 0x90, 0x7c, 0x28, 0xc7, 0xe4, 0xea, 0x54, 0xbd, 0xfd, 0x3f, 0xa5, 0xbf, 0x39, 0x77,
+	0xf9, 0xa4, 0x0c, 0x2f, 0x67, 0xaa, 0x6a, 0xed, 0xc9, 0x37, 0xc2, 0x04, 0x58, 0xff, 0x02, 0xb6,
+	0x3a, 0x5e, 0x92, 0x50, 0x15, 0x49, 0x0b, 0x07, 0x3c, 0x10, 0xf1, 0xfb, 0x45, 0x66, 0x3f, 0x46,
+	0x96, 0x22, 0xce, 0x02, 0xa8, 0x84, 0x64, 0x5e, 0x67, 0x94, 0x64, 0x9f, 0xb5, 0xff, 0x5c, 0x80,
+	0xdc, 0x8e, 0x5c, 0x9c, 0x5c, 0x83, 0xd5, 0x4e, 0x10, 0xb8, 0x36, 0x8f, 0xa8, 0x79, 0x5d, 0xbb,
+	0x34, 0xb1, 0x87, 0xea, 0x3c, 0x4f, 0x1b, 0xc6, 0x45, 0x83, 0x7c, 0x0a, 0xab, 0x09, 0x21, 0x10,
+	0x53, 0x23, 0xa7, 0x39, 0xa2, 0xfa, 0xf4, 0x50, 0x5d, 0x34, 0xc8, 0x07, 0xb0, 0x72, 0x33, 0xec,
+	0x0f, 0x79, 0x70, 0x44, 0xe6, 0xad, 0x59, 0xdd, 0x68, 0xc6, 0xcf, 0x6c, 0x4d, 0xfd, 0x80, 0xd6,
+	0xdc, 0x8b, 0x9e, 0xd9, 0x1a, 0x06, 0x39, 0x80, 0x13, 0x8a, 0x00, 0x91, 0xd4, 0xe6, 0x1f, 0x4c,
+	0xb1, 0x3f, 0x4f, 0x3d, 0xb9, 0x48, 0x27, 0x7d, 0x13, 0xfb, 0x88, 0xf2, 0x21, 0xb2, 0xe7, 0xf6,
+	0xa9, 0xfd, 0xd0, 0x80, 0x42, 0x1c, 0xe7, 0x03, 0xea, 0xd0, 0x01, 0xfa, 0xe4, 0x2b, 0xa8, 0xc6,
+	0xf9, 0x43, 0x7f, 0x36, 0xb3, 0xe4, 0x9c, 0x5e, 0xe0, 0xc9, 0x59, 0x9f, 0xb7, 0x1e, 0x69, 0xc3,
+	0xea, 0xc7, 0x28, 0x14, 0xf3, 0x26, 0xc9, 0x9c, 0xe0, 0xe6, 0x6a, 0x71, 0x52, 0x4c, 0xde, 0x87,
+	0x15, 0x55, 0xf9, 0x24, 0xb9, 0x65, 0x4e, 0xb6, 0x69, 0x75, 0x5a, 0x9e, 0x24, 0x6c, 0xe7, 0xbd,
+	0xdf, 0x1e, 0x6d, 0x19, 0xbf, 0x3f, 0xda, 0x32, 0xfe, 0x78, 0xb4, 0x65, 0x7c, 0xf9, 0xd6, 0xb3,
+	0xbf, 0xa0, 0xf6, 0x73, 0xd2, 0xf5, 0xcb, 0x7f, 0x0d, 0x00, 0xec, 0x5e, 0x42, 0x67, 0x76, 0x15,
+	0x00, 0x00,
 }
@@ -0,0 +1,65 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// DevAddr returns the DevAddr of the uplink or downlink message. It returns false if the message is not a LoRaWAN
+// data message.
+func (m *TrafficMessage) DevAddr() (types.DevAddr, bool) {
+	switch {
+	case m.GetUplink() != nil:
+		return pb_lorawan.DevAddrFromPayload(m.Uplink.Payload)
+	case m.GetDownlink() != nil:
+		return pb_lorawan.DevAddrFromPayload(m.Downlink.Payload)
+	}
+	return types.DevAddr{}, false
+}
+
+// AppID returns the AppID of the uplink or downlink message
+func (m *TrafficMessage) AppID() string {
+	switch {
+	case m.GetUplink() != nil:
+		return m.Uplink.AppId
+	case m.GetDownlink() != nil:
+		return m.Downlink.AppId
+	}
+	return ""
+}
+
+// HasGateway returns true if the uplink message was received by the gateway or if the downlink message is sent by
+// the gateway
+func (m *TrafficMessage) HasGateway(gatewayID string) bool {
+	if uplink := m.GetUplink(); uplink != nil {
+		for _, gateway := range uplink.GatewayMetadata {
+			if gateway.GatewayId == gatewayID {
+				return true
+			}
+		}
+	}
+	if downlink := m.GetDownlink(); downlink != nil && downlink.DownlinkOption != nil {
+		return downlink.DownlinkOption.GatewayId == gatewayID
+	}
+	return false
+}
+
+// Matches returns true if the message matches the filters of the TrafficRequest
+func (m *TrafficRequest) Matches(msg *TrafficMessage) bool {
+	if m.AppId != "" && msg.AppID() != m.AppId {
+		return false
+	}
+	if m.GatewayId != "" && !msg.HasGateway(m.GatewayId) {
+		return false
+	}
+	if m.DevAddr != nil && !m.DevAddr.IsEmpty() {
+		devAddr, ok := msg.DevAddr()
+		if !ok || devAddr != *m.DevAddr {
+			return false
+		}
+	}
+	return true
+}
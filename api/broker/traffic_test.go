@@ -0,0 +1,56 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"testing"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestTrafficRequestMatches(t *testing.T) {
+	a := New(t)
+
+	macBin := []byte{65, 4, 3, 2, 1, 0, 1, 0, 0, 1, 2, 3, 4}
+
+	uplink := &TrafficMessage{Uplink: &DeduplicatedUplinkMessage{
+		AppId:   "app",
+		Payload: macBin,
+		GatewayMetadata: []*pb_gateway.RxMetadata{
+			{GatewayId: "gtw-1"},
+			{GatewayId: "gtw-2"},
+		},
+	}}
+	downlink := &TrafficMessage{Downlink: &DownlinkMessage{
+		AppId:          "other-app",
+		Payload:        macBin,
+		DownlinkOption: &DownlinkOption{GatewayId: "gtw-2"},
+	}}
+
+	a.So(uplink.AppID(), ShouldEqual, "app")
+	a.So(uplink.HasGateway("gtw-1"), ShouldBeTrue)
+	a.So(uplink.HasGateway("gtw-3"), ShouldBeFalse)
+	a.So(downlink.HasGateway("gtw-2"), ShouldBeTrue)
+
+	all := &TrafficRequest{}
+	a.So(all.Matches(uplink), ShouldBeTrue)
+	a.So(all.Matches(downlink), ShouldBeTrue)
+
+	byApp := &TrafficRequest{AppId: "app"}
+	a.So(byApp.Matches(uplink), ShouldBeTrue)
+	a.So(byApp.Matches(downlink), ShouldBeFalse)
+
+	byGateway := &TrafficRequest{GatewayId: "gtw-1"}
+	a.So(byGateway.Matches(uplink), ShouldBeTrue)
+	a.So(byGateway.Matches(downlink), ShouldBeFalse)
+
+	byDevAddr := &TrafficRequest{DevAddr: &types.DevAddr{1, 2, 3, 4}}
+	a.So(byDevAddr.Matches(uplink), ShouldBeTrue)
+	a.So(byDevAddr.Matches(downlink), ShouldBeTrue)
+
+	otherDevAddr := &TrafficRequest{AppId: "app", DevAddr: &types.DevAddr{1, 2, 3, 5}}
+	a.So(otherDevAddr.Matches(uplink), ShouldBeFalse)
+}
@@ -98,10 +98,17 @@ func DialWithCert(address string, cert string) (*grpc.ClientConn, error) {
 	return dial(address, tlsConfig, false)
 }
 
-// WithTTNDialer creates a dialer for TTN
+// WithTTNDialer creates a dialer for TTN. Addresses that are served in this process (see ListenInProcess) are
+// dialed in-process.
 func WithTTNDialer() grpc.DialOption {
 	return grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
 		ctx := log.Get().WithField("Address", addr)
+		if conn, ok, err := dialInProcess(addr); ok {
+			if err == nil {
+				ctx.Debug("Connected to in-process gRPC server")
+			}
+			return conn, err
+		}
 		d := net.Dialer{Timeout: timeout, KeepAlive: KeepAlive}
 		var retries int
 		for {
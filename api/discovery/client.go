@@ -30,6 +30,7 @@ type Client interface {
 	AddAppID(appID string, token string) error
 	RemoveDevAddrPrefix(prefix types.DevAddrPrefix) error
 	RemoveAppID(appID string, token string) error
+	JoinAppID(appID string, token string) error
 	GetAllBrokersForDevAddr(devAddr types.DevAddr) ([]*Announcement, error)
 	GetAllHandlersForAppID(appID string) ([]*Announcement, error)
 	Close() error
@@ -201,6 +202,18 @@ func (c *DefaultClient) RemoveAppID(appID string, token string) error {
 	return err
 }
 
+// JoinAppID adds an AppID to the current component and joins the group of components that already announce it
+func (c *DefaultClient) JoinAppID(appID string, token string) error {
+	_, err := c.client.JoinMetadata(c.getContext(token), &MetadataRequest{
+		ServiceName: c.self.ServiceName,
+		Id:          c.self.Id,
+		Metadata: &Metadata{Metadata: &Metadata_AppId{
+			AppId: appID,
+		}},
+	})
+	return err
+}
+
 // GetAllBrokersForDevAddr returns all brokers that can handle the given DevAddr
 func (c *DefaultClient) GetAllBrokersForDevAddr(devAddr types.DevAddr) (announcements []*Announcement, err error) {
 	brokers, err := c.GetAll("broker")
@@ -219,7 +232,8 @@ next:
 	return
 }
 
-// GetAllHandlersForAppID returns all handlers that can handle the given AppID
+// GetAllHandlersForAppID returns all handlers that can handle the given AppID. These handlers form a handler group
+// that shares its store; the Broker selects one of them for each device.
 func (c *DefaultClient) GetAllHandlersForAppID(appID string) (announcements []*Announcement, err error) {
 	handlers, err := c.GetAll("handler")
 	if err != nil {
@@ -101,6 +101,16 @@ func (_mr *_MockClientRecorder) RemoveAppID(arg0, arg1 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveAppID", arg0, arg1)
 }
 
+func (_m *MockClient) JoinAppID(appID string, token string) error {
+	ret := _m.ctrl.Call(_m, "JoinAppID", appID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockClientRecorder) JoinAppID(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "JoinAppID", arg0, arg1)
+}
+
 func (_m *MockClient) GetAllBrokersForDevAddr(devAddr types.DevAddr) ([]*Announcement, error) {
 	ret := _m.ctrl.Call(_m, "GetAllBrokersForDevAddr", devAddr)
 	ret0, _ := ret[0].([]*Announcement)
@@ -439,6 +439,8 @@ type DiscoveryClient interface {
 	AddMetadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	// Delete metadata from an announcement
 	DeleteMetadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// Add metadata to an announcement, keeping it on the other announcements that already have it
+	JoinMetadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type discoveryClient struct {
@@ -494,6 +496,15 @@ func (c *discoveryClient) DeleteMetadata(ctx context.Context, in *MetadataReques
 	return out, nil
 }
 
+func (c *discoveryClient) JoinMetadata(ctx context.Context, in *MetadataRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/discovery.Discovery/JoinMetadata", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Discovery service
 
 type DiscoveryServer interface {
@@ -509,6 +520,8 @@ type DiscoveryServer interface {
 	AddMetadata(context.Context, *MetadataRequest) (*google_protobuf.Empty, error)
 	// Delete metadata from an announcement
 	DeleteMetadata(context.Context, *MetadataRequest) (*google_protobuf.Empty, error)
+	// Add metadata to an announcement, keeping it on the other announcements that already have it
+	JoinMetadata(context.Context, *MetadataRequest) (*google_protobuf.Empty, error)
 }
 
 func RegisterDiscoveryServer(s *grpc.Server, srv DiscoveryServer) {
@@ -605,6 +618,24 @@ func _Discovery_DeleteMetadata_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Discovery_JoinMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiscoveryServer).JoinMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/discovery.Discovery/JoinMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiscoveryServer).JoinMetadata(ctx, req.(*MetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Discovery_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "discovery.Discovery",
 	HandlerType: (*DiscoveryServer)(nil),
@@ -629,6 +660,10 @@ var _Discovery_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMetadata",
 			Handler:    _Discovery_DeleteMetadata_Handler,
 		},
+		{
+			MethodName: "JoinMetadata",
+			Handler:    _Discovery_JoinMetadata_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/discovery/discovery.proto",
@@ -2223,47 +2258,47 @@ func init() {
 
 var fileDescriptorDiscovery = []byte{
 	// 681 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x4f, 0x13, 0x4b,
-	0x18, 0x66, 0xdb, 0x43, 0x4f, 0xfb, 0xb6, 0xb4, 0x9c, 0x39, 0x07, 0xd8, 0x53, 0xa1, 0x94, 0x8d,
-	0xc6, 0xc6, 0xc4, 0x6e, 0x02, 0x89, 0x37, 0xc4, 0x98, 0x12, 0x48, 0x31, 0x0a, 0x31, 0x2b, 0xf1,
-	0xc2, 0x9b, 0x66, 0xba, 0xf3, 0x52, 0x26, 0x74, 0x67, 0x87, 0xdd, 0xd9, 0x2a, 0x21, 0xdc, 0xf8,
-	0x17, 0xfc, 0x0d, 0xde, 0xfb, 0x33, 0xbc, 0x34, 0xf1, 0x0f, 0x18, 0xe2, 0x0f, 0x31, 0xfb, 0xd9,
-	0x25, 0x58, 0x0d, 0x7a, 0xb7, 0xfb, 0xbc, 0xcf, 0xfb, 0x3c, 0x33, 0xef, 0x33, 0x33, 0xf0, 0x78,
-	0xc4, 0xd5, 0x49, 0x30, 0xec, 0xda, 0xae, 0x63, 0x1e, 0x9d, 0xe0, 0xd1, 0x09, 0x17, 0x23, 0xff,
-	0x10, 0xd5, 0x1b, 0xd7, 0x3b, 0x35, 0x95, 0x12, 0x26, 0x95, 0xdc, 0x64, 0xdc, 0xb7, 0xdd, 0x09,
-	0x7a, 0xe7, 0xd3, 0xaf, 0xae, 0xf4, 0x5c, 0xe5, 0x92, 0x4a, 0x06, 0x34, 0xef, 0x8c, 0x5c, 0x77,
-	0x34, 0x46, 0x33, 0x2a, 0x0c, 0x83, 0x63, 0x13, 0x1d, 0xa9, 0x12, 0x5e, 0x73, 0x35, 0x29, 0x86,
-	0x6a, 0x54, 0x08, 0x57, 0x51, 0xc5, 0x5d, 0xe1, 0xc7, 0x55, 0x43, 0x41, 0xf9, 0x00, 0x15, 0x65,
-	0x54, 0x51, 0xd2, 0x81, 0x06, 0xc3, 0xc9, 0x80, 0x32, 0xe6, 0x0d, 0xa4, 0x87, 0xc7, 0xfc, 0xad,
-	0xfe, 0x5f, 0x5b, 0xeb, 0xd4, 0xf6, 0xe7, 0xac, 0x05, 0x86, 0x93, 0x1e, 0x63, 0xde, 0x8b, 0x08,
-	0x26, 0x2b, 0x50, 0xa2, 0x52, 0x0e, 0x38, 0xd3, 0x5b, 0x6d, 0xad, 0x53, 0xd9, 0x9f, 0xb3, 0xe6,
-	0xa9, 0x94, 0x4f, 0x19, 0xf9, 0x1f, 0xfe, 0x0e, 0x0b, 0x18, 0x70, 0x7d, 0x3d, 0x69, 0x0d, 0x99,
-	0x7b, 0x01, 0xdf, 0x01, 0x28, 0x3b, 0x89, 0x93, 0xf1, 0xb1, 0x08, 0xb5, 0x9e, 0x10, 0x6e, 0x20,
-	0x6c, 0x74, 0x50, 0x28, 0x52, 0x87, 0x02, 0x67, 0xba, 0x16, 0x8a, 0x59, 0x05, 0xce, 0xc8, 0x06,
-	0xd4, 0x7c, 0xf4, 0x26, 0xdc, 0xc6, 0x81, 0xa0, 0x0e, 0xea, 0x85, 0xa8, 0x52, 0x4d, 0xb0, 0x43,
-	0xea, 0x20, 0xb9, 0x0f, 0x8d, 0x94, 0x32, 0x41, 0xcf, 0xe7, 0xae, 0xd0, 0x8b, 0x11, 0xab, 0x9e,
-	0xc0, 0xaf, 0x62, 0x94, 0xb4, 0xa1, 0xca, 0xd0, 0xb7, 0x3d, 0x2e, 0xc3, 0x8d, 0xeb, 0x7f, 0xc5,
-	0x52, 0x39, 0x88, 0x2c, 0x42, 0x31, 0xf0, 0xc6, 0xfa, 0x7c, 0x54, 0x09, 0x3f, 0xc9, 0x32, 0x94,
-	0x64, 0x30, 0x1c, 0x73, 0x5b, 0x2f, 0xb5, 0xb5, 0x4e, 0xd9, 0x4a, 0xfe, 0xc8, 0x3a, 0x54, 0x05,
-	0xaa, 0x68, 0x44, 0xe8, 0xfb, 0x7a, 0x35, 0xea, 0x00, 0x81, 0xaa, 0x17, 0x23, 0x64, 0x0d, 0x20,
-	0xa6, 0x0e, 0x4e, 0xf1, 0x5c, 0xaf, 0x45, 0xf5, 0x4a, 0x8c, 0x3c, 0xc3, 0xf3, 0x70, 0x2d, 0x36,
-	0x7a, 0x8a, 0x1f, 0x73, 0x9b, 0x2a, 0xd4, 0x17, 0xe2, 0xb5, 0xe4, 0xa0, 0xd0, 0x81, 0x4a, 0x9e,
-	0x39, 0xd4, 0x63, 0x07, 0x2a, 0x79, 0xea, 0xb0, 0x01, 0x35, 0xe7, 0x4c, 0x4d, 0xd7, 0xd0, 0x88,
-	0x35, 0x42, 0x2c, 0x47, 0xa1, 0xce, 0x99, 0xcc, 0x28, 0x8b, 0x31, 0x25, 0xc4, 0x52, 0x8a, 0x39,
-	0x4d, 0x43, 0x5f, 0x6e, 0x17, 0x3b, 0xd5, 0xcd, 0x7f, 0xbb, 0xd3, 0x13, 0x96, 0x1e, 0x09, 0x6b,
-	0x1a, 0xd9, 0x23, 0xf8, 0xa7, 0x8f, 0xea, 0x65, 0x3c, 0x5a, 0x0b, 0xcf, 0x02, 0xf4, 0xd5, 0x8d,
-	0x98, 0xb4, 0x1b, 0x31, 0x19, 0x4f, 0x00, 0xfa, 0xa8, 0xd2, 0x86, 0xdb, 0xe7, 0x6c, 0x04, 0xd0,
-	0xc8, 0x96, 0xf3, 0xdb, 0x2a, 0xd7, 0xf6, 0x1b, 0xa6, 0xf2, 0xcb, 0xfd, 0x3e, 0x87, 0xa5, 0xfc,
-	0x09, 0xf5, 0x2d, 0xf4, 0xa5, 0x2b, 0x7c, 0x24, 0x5b, 0x50, 0x4e, 0x84, 0x7d, 0x5d, 0x8b, 0x26,
-	0xb7, 0x92, 0x53, 0xca, 0xf7, 0x58, 0x19, 0x71, 0xf3, 0x43, 0x11, 0x2a, 0xbb, 0x29, 0x89, 0x6c,
-	0x43, 0x39, 0xe5, 0x91, 0x59, 0xcd, 0xcd, 0xe5, 0x6e, 0x7c, 0x71, 0xbb, 0xe9, 0xad, 0xee, 0xee,
-	0x85, 0xb7, 0x9a, 0xb8, 0x50, 0xea, 0xa3, 0xea, 0x8d, 0xc7, 0x64, 0x35, 0xd7, 0x7a, 0x23, 0x9b,
-	0x66, 0x7b, 0x86, 0x70, 0xb6, 0x13, 0xe3, 0xde, 0xbb, 0x2f, 0xdf, 0xde, 0x17, 0xd6, 0xc9, 0x5a,
-	0xf4, 0x2e, 0x64, 0x75, 0xf3, 0x22, 0x3f, 0xcc, 0x4b, 0x42, 0xa1, 0xd8, 0x47, 0x45, 0x96, 0xae,
-	0xbb, 0xa5, 0x36, 0xb3, 0xd6, 0x6f, 0x3c, 0x88, 0xd4, 0xef, 0x12, 0xe3, 0xa7, 0xea, 0xe6, 0x05,
-	0x67, 0x97, 0xa4, 0x07, 0xd5, 0x1e, 0x63, 0xd9, 0x43, 0xd4, 0xfc, 0x51, 0x34, 0x89, 0xdf, 0xac,
-	0xb1, 0xec, 0x42, 0x7d, 0x17, 0xc7, 0xa8, 0xf0, 0x4f, 0x54, 0x36, 0x09, 0x2c, 0x66, 0x31, 0x1d,
-	0x50, 0x41, 0x47, 0xe8, 0xed, 0x6c, 0x7f, 0xba, 0x6a, 0x69, 0x9f, 0xaf, 0x5a, 0xda, 0xd7, 0xab,
-	0x96, 0xf6, 0xfa, 0xe1, 0xad, 0x5e, 0xed, 0x61, 0x29, 0x32, 0xd8, 0xfa, 0x1e, 0x00, 0x00, 0xff,
-	0xff, 0xe7, 0xe5, 0xe6, 0xd9, 0xed, 0x05, 0x00, 0x00,
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x4e, 0x13, 0x41,
+	0x14, 0x66, 0x5b, 0xa9, 0xed, 0x69, 0x69, 0x71, 0x14, 0x58, 0x2b, 0x94, 0xd2, 0x68, 0x6c, 0x4c,
+	0xec, 0x26, 0x90, 0x78, 0x43, 0x8c, 0x29, 0x81, 0x14, 0xa3, 0x10, 0xb3, 0x12, 0x2f, 0xbc, 0x69,
+	0xa6, 0x3b, 0x87, 0x32, 0xa1, 0x3b, 0x3b, 0xec, 0xce, 0x56, 0x09, 0xe1, 0xc6, 0x57, 0xf0, 0x45,
+	0x7c, 0x0c, 0x2f, 0x4d, 0x7c, 0x01, 0x43, 0xbc, 0xf1, 0x2d, 0xcc, 0xfe, 0x76, 0x09, 0x56, 0x83,
+	0xdc, 0xb5, 0xdf, 0xf9, 0xce, 0xf7, 0x9d, 0x39, 0xdf, 0xcc, 0xc2, 0xf3, 0x21, 0x57, 0x47, 0xfe,
+	0xa0, 0x63, 0x39, 0xb6, 0x71, 0x70, 0x84, 0x07, 0x47, 0x5c, 0x0c, 0xbd, 0x7d, 0x54, 0x1f, 0x1c,
+	0xf7, 0xd8, 0x50, 0x4a, 0x18, 0x54, 0x72, 0x83, 0x71, 0xcf, 0x72, 0xc6, 0xe8, 0x9e, 0x4e, 0x7e,
+	0x75, 0xa4, 0xeb, 0x28, 0x87, 0x94, 0x52, 0xa0, 0xfe, 0x60, 0xe8, 0x38, 0xc3, 0x11, 0x1a, 0x61,
+	0x61, 0xe0, 0x1f, 0x1a, 0x68, 0x4b, 0x15, 0xf3, 0xea, 0xcb, 0x71, 0x31, 0x50, 0xa3, 0x42, 0x38,
+	0x8a, 0x2a, 0xee, 0x08, 0x2f, 0xaa, 0xb6, 0x14, 0x14, 0xf7, 0x50, 0x51, 0x46, 0x15, 0x25, 0x6d,
+	0xa8, 0x31, 0x1c, 0xf7, 0x29, 0x63, 0x6e, 0x5f, 0xba, 0x78, 0xc8, 0x3f, 0xea, 0xf7, 0x9a, 0x5a,
+	0xbb, 0xb2, 0x3b, 0x63, 0xce, 0x31, 0x1c, 0x77, 0x19, 0x73, 0xdf, 0x84, 0x30, 0x59, 0x82, 0x02,
+	0x95, 0xb2, 0xcf, 0x99, 0xde, 0x68, 0x6a, 0xed, 0xd2, 0xee, 0x8c, 0x39, 0x4b, 0xa5, 0x7c, 0xc9,
+	0xc8, 0x7d, 0xb8, 0x1d, 0x14, 0xd0, 0xe7, 0xfa, 0x6a, 0xdc, 0x1a, 0x30, 0x77, 0x7c, 0xbe, 0x05,
+	0x50, 0xb4, 0x63, 0xa7, 0xd6, 0x97, 0x3c, 0x54, 0xba, 0x42, 0x38, 0xbe, 0xb0, 0xd0, 0x46, 0xa1,
+	0x48, 0x15, 0x72, 0x9c, 0xe9, 0x5a, 0x20, 0x66, 0xe6, 0x38, 0x23, 0x6b, 0x50, 0xf1, 0xd0, 0x1d,
+	0x73, 0x0b, 0xfb, 0x82, 0xda, 0xa8, 0xe7, 0xc2, 0x4a, 0x39, 0xc6, 0xf6, 0xa9, 0x8d, 0xe4, 0x31,
+	0xd4, 0x12, 0xca, 0x18, 0x5d, 0x8f, 0x3b, 0x42, 0xcf, 0x87, 0xac, 0x6a, 0x0c, 0xbf, 0x8b, 0x50,
+	0xd2, 0x84, 0x32, 0x43, 0xcf, 0x72, 0xb9, 0x0c, 0x0e, 0xae, 0xdf, 0x8a, 0xa4, 0x32, 0x10, 0x99,
+	0x87, 0xbc, 0xef, 0x8e, 0xf4, 0xd9, 0xb0, 0x12, 0xfc, 0x24, 0x8b, 0x50, 0x90, 0xfe, 0x60, 0xc4,
+	0x2d, 0xbd, 0xd0, 0xd4, 0xda, 0x45, 0x33, 0xfe, 0x47, 0x56, 0xa1, 0x2c, 0x50, 0x85, 0x2b, 0x42,
+	0xcf, 0xd3, 0xcb, 0x61, 0x07, 0x08, 0x54, 0xdd, 0x08, 0x21, 0x2b, 0x00, 0x11, 0xb5, 0x7f, 0x8c,
+	0xa7, 0x7a, 0x25, 0xac, 0x97, 0x22, 0xe4, 0x15, 0x9e, 0x06, 0xb3, 0x58, 0xe8, 0x2a, 0x7e, 0xc8,
+	0x2d, 0xaa, 0x50, 0x9f, 0x8b, 0x66, 0xc9, 0x40, 0x81, 0x03, 0x95, 0x3c, 0x75, 0xa8, 0x46, 0x0e,
+	0x54, 0xf2, 0xc4, 0x61, 0x0d, 0x2a, 0xf6, 0x89, 0x9a, 0xcc, 0x50, 0x8b, 0x34, 0x02, 0x2c, 0x43,
+	0xa1, 0xf6, 0x89, 0x4c, 0x29, 0xf3, 0x11, 0x25, 0xc0, 0x12, 0x8a, 0x31, 0x49, 0x43, 0x5f, 0x6c,
+	0xe6, 0xdb, 0xe5, 0xf5, 0xbb, 0x9d, 0xc9, 0x0d, 0x4b, 0xae, 0x84, 0x39, 0x89, 0xec, 0x19, 0xdc,
+	0xe9, 0xa1, 0x7a, 0x1b, 0xad, 0xd6, 0xc4, 0x13, 0x1f, 0x3d, 0x75, 0x25, 0x26, 0xed, 0x4a, 0x4c,
+	0xad, 0x17, 0x00, 0x3d, 0x54, 0x49, 0xc3, 0xf5, 0x73, 0x6e, 0xf9, 0x50, 0x4b, 0xc7, 0xf9, 0x6f,
+	0x95, 0x4b, 0xe7, 0x0d, 0x52, 0xf9, 0xe7, 0x79, 0x5f, 0xc3, 0x42, 0xf6, 0x86, 0x7a, 0x26, 0x7a,
+	0xd2, 0x11, 0x1e, 0x92, 0x0d, 0x28, 0xc6, 0xc2, 0x9e, 0xae, 0x85, 0x9b, 0x5b, 0xca, 0x28, 0x65,
+	0x7b, 0xcc, 0x94, 0xb8, 0xfe, 0x2b, 0x0f, 0xa5, 0xed, 0x84, 0x44, 0x36, 0xa1, 0x98, 0xf0, 0xc8,
+	0xb4, 0xe6, 0xfa, 0x62, 0x27, 0x7a, 0xb8, 0x9d, 0xe4, 0x55, 0x77, 0x76, 0x82, 0x57, 0x4d, 0x1c,
+	0x28, 0xf4, 0x50, 0x75, 0x47, 0x23, 0xb2, 0x9c, 0x69, 0xbd, 0x92, 0x4d, 0xbd, 0x39, 0x45, 0x38,
+	0x3d, 0x49, 0xeb, 0xd1, 0xa7, 0xef, 0x3f, 0x3f, 0xe7, 0x56, 0xc9, 0x8a, 0x41, 0xb3, 0x75, 0xe3,
+	0x2c, 0xbb, 0xcc, 0x73, 0x42, 0x21, 0xdf, 0x43, 0x45, 0x16, 0x2e, 0xbb, 0x25, 0x36, 0xd3, 0xe6,
+	0x6f, 0x3d, 0x09, 0xd5, 0x1f, 0x92, 0xd6, 0x5f, 0xd5, 0x8d, 0x33, 0xce, 0xce, 0x49, 0x17, 0xca,
+	0x5d, 0xc6, 0xd2, 0x0f, 0x51, 0xfd, 0x4f, 0xd1, 0xc4, 0x7e, 0xd3, 0xd6, 0xb2, 0x0d, 0xd5, 0x6d,
+	0x1c, 0xa1, 0xc2, 0x1b, 0xa9, 0x6c, 0x41, 0x65, 0xcf, 0x19, 0xdf, 0x48, 0x63, 0x9d, 0xc0, 0x7c,
+	0x1a, 0xf5, 0x1e, 0x15, 0x74, 0x88, 0xee, 0xd6, 0xe6, 0xd7, 0x8b, 0x86, 0xf6, 0xed, 0xa2, 0xa1,
+	0xfd, 0xb8, 0x68, 0x68, 0xef, 0x9f, 0x5e, 0xeb, 0xcb, 0x3f, 0x28, 0x84, 0x06, 0x1b, 0xbf, 0x07,
+	0x00, 0x0f, 0x15, 0xd5, 0x93, 0x31, 0x06, 0x00, 0x00,
 }
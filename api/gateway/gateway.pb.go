@@ -13,6 +13,7 @@
 		RxMetadata
 		TxConfiguration
 		Status
+		Event
 */
 package gateway
 
@@ -443,12 +444,75 @@ func (m *Status_OSMetrics) GetTemperature() float32 {
 	return 0
 }
 
+// Event is a connection, status or clock event of a gateway, as detected by the Router
+type Event struct {
+	// Time in Unix nanoseconds
+	Time      int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	GatewayId string `protobuf:"bytes,2,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// The type of event: connect, disconnect, status-stale or clock-drift
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The stream that connected or disconnected: uplink, downlink or status
+	Stream string `protobuf:"bytes,11,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Time of the last status message in Unix nanoseconds (for status-stale events)
+	LastStatus int64 `protobuf:"varint,12,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
+	// Drift of the gateway clock in nanoseconds (for clock-drift events)
+	ClockDrift int64 `protobuf:"varint,13,opt,name=clock_drift,json=clockDrift,proto3" json:"clock_drift,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptorGateway, []int{4} }
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Event) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+func (m *Event) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Event) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
+func (m *Event) GetLastStatus() int64 {
+	if m != nil {
+		return m.LastStatus
+	}
+	return 0
+}
+
+func (m *Event) GetClockDrift() int64 {
+	if m != nil {
+		return m.ClockDrift
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GPSMetadata)(nil), "gateway.GPSMetadata")
 	proto.RegisterType((*RxMetadata)(nil), "gateway.RxMetadata")
 	proto.RegisterType((*TxConfiguration)(nil), "gateway.TxConfiguration")
 	proto.RegisterType((*Status)(nil), "gateway.Status")
 	proto.RegisterType((*Status_OSMetrics)(nil), "gateway.Status.OSMetrics")
+	proto.RegisterType((*Event)(nil), "gateway.Event")
 }
 func (m *GPSMetadata) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -846,6 +910,57 @@ func (m *Status_OSMetrics) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(m.Time))
+	}
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.Stream) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(len(m.Stream)))
+		i += copy(dAtA[i:], m.Stream)
+	}
+	if m.LastStatus != 0 {
+		dAtA[i] = 0x60
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(m.LastStatus))
+	}
+	if m.ClockDrift != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintGateway(dAtA, i, uint64(m.ClockDrift))
+	}
+	return i, nil
+}
+
 func encodeFixed64Gateway(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -1045,6 +1160,33 @@ func (m *Status_OSMetrics) Size() (n int) {
 	return n
 }
 
+func (m *Event) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovGateway(uint64(m.Time))
+	}
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	l = len(m.Stream)
+	if l > 0 {
+		n += 1 + l + sovGateway(uint64(l))
+	}
+	if m.LastStatus != 0 {
+		n += 1 + sovGateway(uint64(m.LastStatus))
+	}
+	if m.ClockDrift != 0 {
+		n += 1 + sovGateway(uint64(m.ClockDrift))
+	}
+	return n
+}
+
 func sovGateway(x uint64) (n int) {
 	for {
 		n++
@@ -2200,6 +2342,201 @@ func (m *Status_OSMetrics) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Event) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGateway
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGateway
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastStatus", wireType)
+			}
+			m.LastStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastStatus |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClockDrift", wireType)
+			}
+			m.ClockDrift = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGateway
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClockDrift |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGateway(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGateway
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipGateway(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2310,52 +2647,57 @@ func init() {
 }
 
 var fileDescriptorGateway = []byte{
-	// 748 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x93, 0xdb, 0x34,
-	0x14, 0x1f, 0x3b, 0xff, 0x95, 0x26, 0xbb, 0x55, 0x9b, 0x54, 0xdd, 0x81, 0xc5, 0x84, 0x01, 0x52,
-	0x16, 0x92, 0x59, 0x3a, 0x39, 0xf4, 0x4a, 0x61, 0x98, 0x3d, 0xc0, 0x76, 0xd4, 0x9c, 0xb8, 0x78,
-	0x14, 0x5b, 0x71, 0x34, 0xb1, 0x25, 0x23, 0xcb, 0x4d, 0x96, 0x8f, 0xc3, 0x85, 0xaf, 0xd2, 0x23,
-	0x1f, 0x81, 0xd9, 0x03, 0x9f, 0x83, 0xd1, 0xf3, 0x9f, 0x75, 0x99, 0xc2, 0x4e, 0x4f, 0x79, 0xbf,
-	0x3f, 0xf2, 0x7b, 0x4f, 0xef, 0x45, 0xe8, 0x45, 0x24, 0xcc, 0x2e, 0xdf, 0x2c, 0x02, 0x95, 0x2c,
-	0xd7, 0x3b, 0xbe, 0xde, 0x09, 0x19, 0x65, 0x3f, 0x73, 0x73, 0x50, 0x7a, 0xbf, 0x34, 0x46, 0x2e,
-	0x59, 0x2a, 0x96, 0x11, 0x33, 0xfc, 0xc0, 0x6e, 0xaa, 0xdf, 0x45, 0xaa, 0x95, 0x51, 0xb8, 0x57,
-	0xc2, 0xb3, 0x6f, 0x1a, 0xdf, 0x88, 0x54, 0xa4, 0x96, 0xa0, 0x6f, 0xf2, 0x2d, 0x20, 0x00, 0x10,
-	0x15, 0xe7, 0x66, 0x07, 0x34, 0xfc, 0xf1, 0xd5, 0xeb, 0x9f, 0xb8, 0x61, 0x21, 0x33, 0x0c, 0x63,
-	0xd4, 0x36, 0x22, 0xe1, 0xc4, 0xf1, 0x9c, 0x79, 0x8b, 0x42, 0x8c, 0xcf, 0x50, 0x3f, 0x66, 0x46,
-	0x98, 0x3c, 0xe4, 0xc4, 0xf5, 0x9c, 0xb9, 0x4b, 0x6b, 0x8c, 0x3f, 0x42, 0x83, 0x58, 0xc9, 0xa8,
-	0x10, 0x5b, 0x20, 0xde, 0x11, 0xf6, 0x24, 0x8b, 0xcb, 0x93, 0x6d, 0xcf, 0x99, 0x77, 0x68, 0x8d,
-	0x67, 0x7f, 0xb8, 0x08, 0xd1, 0x63, 0x9d, 0xf8, 0x63, 0x84, 0xca, 0x0e, 0x7c, 0x11, 0x42, 0xfa,
-	0x01, 0x1d, 0x94, 0xcc, 0x55, 0x88, 0xbf, 0x44, 0x27, 0x95, 0x6c, 0x74, 0x9e, 0x19, 0x1e, 0x42,
-	0x29, 0x7d, 0x3a, 0x2e, 0xe9, 0x75, 0xc1, 0xda, 0x82, 0x6c, 0xd1, 0x99, 0x61, 0x49, 0x4a, 0x86,
-	0x9e, 0x33, 0x1f, 0xd1, 0x3b, 0xa2, 0x6e, 0xef, 0x41, 0xa3, 0xbd, 0xa7, 0xa8, 0xaf, 0xb7, 0x7e,
-	0xb0, 0x63, 0x42, 0x92, 0x09, 0x1c, 0xe8, 0xe9, 0xed, 0x4b, 0x0b, 0x31, 0x41, 0xbd, 0x60, 0xc7,
-	0xa4, 0xe4, 0x31, 0x99, 0x16, 0x4a, 0x09, 0x6d, 0x9a, 0xad, 0xe6, 0xbf, 0xe6, 0x5c, 0x06, 0x37,
-	0xe4, 0x13, 0xcf, 0x99, 0xb7, 0xe9, 0x1d, 0x61, 0xd3, 0xe8, 0x2c, 0x13, 0xc4, 0x83, 0x0b, 0x81,
-	0x18, 0x9f, 0xa2, 0x56, 0x26, 0x35, 0xf9, 0x14, 0x28, 0x1b, 0xe2, 0x2f, 0x50, 0x2b, 0x4a, 0x33,
-	0xf2, 0xcc, 0x73, 0xe6, 0xc3, 0x6f, 0x1f, 0x2f, 0xaa, 0x79, 0x36, 0xc6, 0x41, 0xad, 0x61, 0xf6,
-	0xb7, 0x83, 0x4e, 0xd6, 0xc7, 0x97, 0x4a, 0x6e, 0x45, 0x94, 0x6b, 0x66, 0x84, 0x92, 0xf7, 0xb4,
-	0xf9, 0x3f, 0x2d, 0xbd, 0x53, 0xf8, 0xf4, 0xdf, 0x85, 0x3f, 0x46, 0x9d, 0x54, 0x1d, 0xb8, 0x26,
-	0x4f, 0x60, 0x5a, 0x05, 0xc0, 0x2b, 0x34, 0x4d, 0x55, 0xcc, 0xb4, 0xf8, 0x0d, 0x92, 0xfb, 0x42,
-	0xbe, 0xe1, 0x3a, 0x13, 0x4a, 0x42, 0xe7, 0x7d, 0x3a, 0x69, 0xaa, 0x57, 0x95, 0x88, 0x97, 0xe8,
-	0x51, 0xfd, 0x65, 0x3f, 0xe4, 0x6f, 0x04, 0xe8, 0x70, 0x29, 0x23, 0x8a, 0x6b, 0xe9, 0xfb, 0x4a,
-	0x99, 0xfd, 0xde, 0x41, 0xdd, 0xd7, 0x86, 0x99, 0x3c, 0x7b, 0xb7, 0x3f, 0xe7, 0xbf, 0xc6, 0xe8,
-	0x36, 0xc6, 0xf8, 0x9e, 0x0d, 0x69, 0xbd, 0x77, 0x43, 0xc6, 0xc8, 0x15, 0xf6, 0xce, 0x5a, 0xf3,
-	0x01, 0x75, 0x45, 0x6a, 0x97, 0x34, 0x8d, 0x99, 0xd9, 0x2a, 0x9d, 0xc0, 0x5e, 0x0c, 0x68, 0x8d,
-	0xf1, 0x67, 0x68, 0x14, 0x28, 0x69, 0x58, 0x60, 0x7c, 0x9e, 0x30, 0x11, 0x93, 0x11, 0x18, 0x1e,
-	0x94, 0xe4, 0x0f, 0x96, 0xc3, 0x1e, 0x1a, 0x86, 0x3c, 0x0b, 0xb4, 0x48, 0xa1, 0xbf, 0x31, 0x58,
-	0x9a, 0x14, 0x9e, 0xa2, 0xae, 0xe6, 0x91, 0x15, 0x4f, 0x40, 0x2c, 0x91, 0xe5, 0x37, 0x5a, 0x84,
-	0x11, 0x27, 0xa7, 0x05, 0x5f, 0x20, 0xf0, 0xab, 0xdc, 0x70, 0x4d, 0x1e, 0x96, 0x7e, 0x40, 0xd5,
-	0xc6, 0x4c, 0xee, 0xd9, 0x18, 0xbb, 0x6b, 0xda, 0x18, 0x98, 0xce, 0x88, 0xda, 0x10, 0x3f, 0x42,
-	0x1d, 0x7d, 0xf4, 0x85, 0x84, 0x6d, 0x1b, 0xd1, 0xb6, 0x3e, 0x5e, 0xc9, 0x92, 0x54, 0x7b, 0xf2,
-	0x55, 0x45, 0x5e, 0xef, 0x2d, 0x69, 0xc0, 0x79, 0x51, 0x90, 0xa6, 0x74, 0x1a, 0x70, 0x7e, 0x5d,
-	0x91, 0xd7, 0x7b, 0xfc, 0x0c, 0xb9, 0x2a, 0x23, 0xcf, 0xa1, 0x98, 0xa7, 0x75, 0x31, 0xc5, 0x00,
-	0x17, 0xd7, 0xb6, 0x24, 0x2d, 0x82, 0x8c, 0xba, 0x2a, 0x3b, 0x7b, 0xeb, 0xa0, 0x41, 0xcd, 0xe0,
-	0x09, 0xea, 0xc6, 0x8a, 0x85, 0xfe, 0x25, 0x4c, 0xd6, 0xa5, 0x1d, 0x8b, 0x2e, 0x6b, 0x7a, 0x55,
-	0xbe, 0x32, 0x40, 0xaf, 0xf0, 0x13, 0xd4, 0x2b, 0xdc, 0xab, 0xf2, 0x81, 0x01, 0xd7, 0xe5, 0x0a,
-	0x7f, 0x8e, 0xc6, 0x41, 0x9a, 0xfb, 0x29, 0xd7, 0x01, 0x97, 0x86, 0x45, 0x1c, 0xfe, 0x08, 0x2e,
-	0x1d, 0x05, 0x69, 0xfe, 0xaa, 0x26, 0xf1, 0x05, 0x7a, 0x98, 0xf0, 0x44, 0xe9, 0x9b, 0xa6, 0x73,
-	0x02, 0xce, 0xd3, 0x42, 0x68, 0x98, 0x3d, 0x34, 0x34, 0x3c, 0x49, 0xb9, 0x66, 0x26, 0xd7, 0x1c,
-	0x6e, 0xd0, 0xa5, 0x4d, 0xea, 0xbb, 0x17, 0x6f, 0x6f, 0xcf, 0x9d, 0x3f, 0x6f, 0xcf, 0x9d, 0xbf,
-	0x6e, 0xcf, 0x9d, 0x5f, 0x2e, 0x3e, 0xe0, 0xc5, 0xde, 0x74, 0xe1, 0xc9, 0x7d, 0xfe, 0x4f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xc3, 0x51, 0x8b, 0xc4, 0xe7, 0x05, 0x00, 0x00,
+	// 818 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x6e, 0xdc, 0x36,
+	0x10, 0x86, 0xb4, 0x5e, 0xdb, 0x3b, 0x9b, 0xb5, 0x1d, 0x26, 0xeb, 0x30, 0x46, 0x6b, 0xab, 0x5b,
+	0xb4, 0xdd, 0xd4, 0xad, 0x17, 0x6e, 0xb0, 0x87, 0x5c, 0x9b, 0x04, 0x85, 0x0f, 0xad, 0x03, 0xc6,
+	0xa7, 0x5e, 0x04, 0x5a, 0xe2, 0x6a, 0x89, 0x95, 0x48, 0x95, 0xa2, 0xfc, 0xd3, 0xc7, 0xe9, 0xa5,
+	0x7d, 0x94, 0x1c, 0xfb, 0x08, 0x85, 0x0f, 0x7d, 0x8e, 0x82, 0xa3, 0x1f, 0xcb, 0x81, 0x5b, 0xa3,
+	0x27, 0xf1, 0xfb, 0xa1, 0x38, 0xc3, 0x19, 0x8d, 0xe0, 0x55, 0x22, 0xed, 0xb2, 0x3c, 0x3f, 0x8a,
+	0x74, 0x36, 0x3b, 0x5b, 0x8a, 0xb3, 0xa5, 0x54, 0x49, 0xf1, 0x93, 0xb0, 0x97, 0xda, 0xac, 0x66,
+	0xd6, 0xaa, 0x19, 0xcf, 0xe5, 0x2c, 0xe1, 0x56, 0x5c, 0xf2, 0xeb, 0xe6, 0x79, 0x94, 0x1b, 0x6d,
+	0x35, 0xd9, 0xa8, 0xe1, 0xde, 0xb7, 0x9d, 0x77, 0x24, 0x3a, 0xd1, 0x33, 0xd4, 0xcf, 0xcb, 0x05,
+	0x22, 0x04, 0xb8, 0xaa, 0xf6, 0x4d, 0x2e, 0x61, 0xf8, 0xc3, 0xbb, 0xf7, 0x3f, 0x0a, 0xcb, 0x63,
+	0x6e, 0x39, 0x21, 0xb0, 0x66, 0x65, 0x26, 0xa8, 0x17, 0x78, 0xd3, 0x1e, 0xc3, 0x35, 0xd9, 0x83,
+	0xcd, 0x94, 0x5b, 0x69, 0xcb, 0x58, 0x50, 0x3f, 0xf0, 0xa6, 0x3e, 0x6b, 0x31, 0xf9, 0x04, 0x06,
+	0xa9, 0x56, 0x49, 0x25, 0xf6, 0x50, 0xbc, 0x25, 0xdc, 0x4e, 0x9e, 0xd6, 0x3b, 0xd7, 0x02, 0x6f,
+	0xda, 0x67, 0x2d, 0x9e, 0xfc, 0xee, 0x03, 0xb0, 0xab, 0xf6, 0xe0, 0x4f, 0x01, 0xea, 0x0c, 0x42,
+	0x19, 0xe3, 0xf1, 0x03, 0x36, 0xa8, 0x99, 0x93, 0x98, 0x7c, 0x05, 0xdb, 0x8d, 0x6c, 0x4d, 0x59,
+	0x58, 0x11, 0x63, 0x28, 0x9b, 0x6c, 0xab, 0xa6, 0xcf, 0x2a, 0xd6, 0x05, 0xe4, 0x82, 0x2e, 0x2c,
+	0xcf, 0x72, 0x3a, 0x0c, 0xbc, 0xe9, 0x88, 0xdd, 0x12, 0x6d, 0x7a, 0x8f, 0x3a, 0xe9, 0x3d, 0x87,
+	0x4d, 0xb3, 0x08, 0xa3, 0x25, 0x97, 0x8a, 0x8e, 0x71, 0xc3, 0x86, 0x59, 0xbc, 0x76, 0x90, 0x50,
+	0xd8, 0x88, 0x96, 0x5c, 0x29, 0x91, 0xd2, 0xdd, 0x4a, 0xa9, 0xa1, 0x3b, 0x66, 0x61, 0xc4, 0x2f,
+	0xa5, 0x50, 0xd1, 0x35, 0x3d, 0x08, 0xbc, 0xe9, 0x1a, 0xbb, 0x25, 0xdc, 0x31, 0xa6, 0x28, 0x24,
+	0x0d, 0xf0, 0x42, 0x70, 0x4d, 0x76, 0xa0, 0x57, 0x28, 0x43, 0x3f, 0x43, 0xca, 0x2d, 0xc9, 0x97,
+	0xd0, 0x4b, 0xf2, 0x82, 0xbe, 0x08, 0xbc, 0xe9, 0xf0, 0xbb, 0xa7, 0x47, 0x4d, 0x3d, 0x3b, 0xe5,
+	0x60, 0xce, 0x30, 0xf9, 0xdb, 0x83, 0xed, 0xb3, 0xab, 0xd7, 0x5a, 0x2d, 0x64, 0x52, 0x1a, 0x6e,
+	0xa5, 0x56, 0x0f, 0xa4, 0xf9, 0x1f, 0x29, 0xdd, 0x09, 0x7c, 0xf7, 0xe3, 0xc0, 0x9f, 0x42, 0x3f,
+	0xd7, 0x97, 0xc2, 0xd0, 0x67, 0x58, 0xad, 0x0a, 0x90, 0x39, 0xec, 0xe6, 0x3a, 0xe5, 0x46, 0xfe,
+	0x8a, 0x87, 0x87, 0x52, 0x5d, 0x08, 0x53, 0x48, 0xad, 0x30, 0xf3, 0x4d, 0x36, 0xee, 0xaa, 0x27,
+	0x8d, 0x48, 0x66, 0xf0, 0xa4, 0x7d, 0x73, 0x18, 0x8b, 0x0b, 0x89, 0x3a, 0x5e, 0xca, 0x88, 0x91,
+	0x56, 0x7a, 0xd3, 0x28, 0x93, 0xdf, 0xfa, 0xb0, 0xfe, 0xde, 0x72, 0x5b, 0x16, 0x77, 0xf3, 0xf3,
+	0xfe, 0xad, 0x8c, 0x7e, 0xa7, 0x8c, 0xf7, 0x74, 0x48, 0xef, 0xde, 0x0e, 0xd9, 0x02, 0x5f, 0xba,
+	0x3b, 0xeb, 0x4d, 0x07, 0xcc, 0x97, 0xb9, 0x6b, 0xd2, 0x3c, 0xe5, 0x76, 0xa1, 0x4d, 0x86, 0x7d,
+	0x31, 0x60, 0x2d, 0x26, 0x9f, 0xc3, 0x28, 0xd2, 0xca, 0xf2, 0xc8, 0x86, 0x22, 0xe3, 0x32, 0xa5,
+	0x23, 0x34, 0x3c, 0xaa, 0xc9, 0xb7, 0x8e, 0x23, 0x01, 0x0c, 0x63, 0x51, 0x44, 0x46, 0xe6, 0x98,
+	0xdf, 0x16, 0x5a, 0xba, 0x14, 0xd9, 0x85, 0x75, 0x23, 0x12, 0x27, 0x6e, 0xa3, 0x58, 0x23, 0xc7,
+	0x9f, 0x1b, 0x19, 0x27, 0x82, 0xee, 0x54, 0x7c, 0x85, 0xd0, 0xaf, 0x4b, 0x2b, 0x0c, 0x7d, 0x5c,
+	0xfb, 0x11, 0x35, 0x1d, 0x33, 0x7e, 0xa0, 0x63, 0x5c, 0xaf, 0x19, 0x6b, 0xb1, 0x3a, 0x23, 0xe6,
+	0x96, 0xe4, 0x09, 0xf4, 0xcd, 0x55, 0x28, 0x15, 0x76, 0xdb, 0x88, 0xad, 0x99, 0xab, 0x13, 0x55,
+	0x93, 0x7a, 0x45, 0xbf, 0x6e, 0xc8, 0xd3, 0x95, 0x23, 0x2d, 0x3a, 0x0f, 0x2b, 0xd2, 0xd6, 0x4e,
+	0x8b, 0xce, 0x6f, 0x1a, 0xf2, 0x74, 0x45, 0x5e, 0x80, 0xaf, 0x0b, 0xfa, 0x12, 0x83, 0x79, 0xde,
+	0x06, 0x53, 0x15, 0xf0, 0xe8, 0xd4, 0x85, 0x64, 0x64, 0x54, 0x30, 0x5f, 0x17, 0x7b, 0x1f, 0x3c,
+	0x18, 0xb4, 0x0c, 0x19, 0xc3, 0x7a, 0xaa, 0x79, 0x1c, 0x1e, 0x63, 0x65, 0x7d, 0xd6, 0x77, 0xe8,
+	0xb8, 0xa5, 0xe7, 0xd4, 0xbf, 0xa5, 0xe7, 0xe4, 0x19, 0x6c, 0x54, 0xee, 0x79, 0x3d, 0x60, 0xd0,
+	0x75, 0x3c, 0x27, 0x5f, 0xc0, 0x56, 0x94, 0x97, 0x61, 0x2e, 0x4c, 0x24, 0x94, 0xe5, 0x89, 0xc0,
+	0x0f, 0xc1, 0x67, 0xa3, 0x28, 0x2f, 0xdf, 0xb5, 0x24, 0x39, 0x84, 0xc7, 0x99, 0xc8, 0xb4, 0xb9,
+	0xee, 0x3a, 0xc7, 0xe8, 0xdc, 0xa9, 0x84, 0x8e, 0x39, 0x80, 0xa1, 0x15, 0x59, 0x2e, 0x0c, 0xb7,
+	0xa5, 0x11, 0x78, 0x83, 0x3e, 0xeb, 0x52, 0x93, 0x3f, 0x3c, 0xe8, 0xbf, 0xbd, 0x10, 0xca, 0xde,
+	0x3b, 0x2b, 0xef, 0x8e, 0x31, 0xff, 0xe3, 0x31, 0xe6, 0xb6, 0x5c, 0xe7, 0xd5, 0xa4, 0x1c, 0x30,
+	0x5c, 0xbb, 0x62, 0x17, 0xd6, 0x08, 0x9e, 0x61, 0xf8, 0x03, 0x56, 0x23, 0x72, 0x00, 0xc3, 0x94,
+	0x17, 0x36, 0x2c, 0xf0, 0x42, 0xeb, 0x91, 0x05, 0x8e, 0xaa, 0xbf, 0x91, 0x03, 0x18, 0x46, 0xa9,
+	0x8e, 0x56, 0x61, 0x6c, 0xe4, 0xc2, 0x62, 0x6b, 0xf6, 0x18, 0x20, 0xf5, 0xc6, 0x31, 0xdf, 0xbf,
+	0xfa, 0x70, 0xb3, 0xef, 0xfd, 0x79, 0xb3, 0xef, 0xfd, 0x75, 0xb3, 0xef, 0xfd, 0x7c, 0xf8, 0x3f,
+	0x7e, 0x2e, 0xe7, 0xeb, 0xf8, 0x77, 0x78, 0xf9, 0xcf, 0x00, 0xd3, 0xb8, 0xb0, 0xe6, 0x92, 0x06,
+	0x00, 0x00,
 }
@@ -12,6 +12,7 @@
 		DeviceActivationResponse
 		StatusRequest
 		Status
+		PayloadFunctionStatus
 		ApplicationIdentifier
 		Application
 		DeviceIdentifier
@@ -23,6 +24,14 @@
 		LogEntry
 		DryUplinkResult
 		DryDownlinkResult
+		AuditLogRequest
+		AuditLogField
+		AuditLogEntry
+		AuditLog
+		BulkSetDevicesResult
+		ApplicationExportRequest
+		ApplicationExport
+		DeviceSnapshot
 */
 package handler
 
@@ -114,11 +123,13 @@ func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorHandle
 
 // message Status is the response to the StatusRequest
 type Status struct {
-	System      *api.SystemStats    `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
-	Component   *api.ComponentStats `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
-	Uplink      *api.Rates          `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
-	Downlink    *api.Rates          `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
-	Activations *api.Rates          `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
+	System           *api.SystemStats         `protobuf:"bytes,1,opt,name=system" json:"system,omitempty"`
+	Component        *api.ComponentStats      `protobuf:"bytes,2,opt,name=component" json:"component,omitempty"`
+	Uplink           *api.Rates               `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink         *api.Rates               `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
+	Activations      *api.Rates               `protobuf:"bytes,13,opt,name=activations" json:"activations,omitempty"`
+	QuotaExceeded    *api.Rates               `protobuf:"bytes,14,opt,name=quota_exceeded,json=quotaExceeded" json:"quota_exceeded,omitempty"`
+	PayloadFunctions []*PayloadFunctionStatus `protobuf:"bytes,15,rep,name=payload_functions,json=payloadFunctions" json:"payload_functions,omitempty"`
 }
 
 func (m *Status) Reset()                    { *m = Status{} }
@@ -161,6 +172,62 @@ func (m *Status) GetActivations() *api.Rates {
 	return nil
 }
 
+func (m *Status) GetQuotaExceeded() *api.Rates {
+	if m != nil {
+		return m.QuotaExceeded
+	}
+	return nil
+}
+
+func (m *Status) GetPayloadFunctions() []*PayloadFunctionStatus {
+	if m != nil {
+		return m.PayloadFunctions
+	}
+	return nil
+}
+
+// message PayloadFunctionStatus contains the statistics of the payload functions of an application
+type PayloadFunctionStatus struct {
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Duration of running the payload functions in milliseconds
+	Duration *api.Percentiles `protobuf:"bytes,2,opt,name=duration" json:"duration,omitempty"`
+	Runs     *api.Rates       `protobuf:"bytes,3,opt,name=runs" json:"runs,omitempty"`
+	Errors   *api.Rates       `protobuf:"bytes,4,opt,name=errors" json:"errors,omitempty"`
+}
+
+func (m *PayloadFunctionStatus) Reset()                    { *m = PayloadFunctionStatus{} }
+func (m *PayloadFunctionStatus) String() string            { return proto.CompactTextString(m) }
+func (*PayloadFunctionStatus) ProtoMessage()               {}
+func (*PayloadFunctionStatus) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{3} }
+
+func (m *PayloadFunctionStatus) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *PayloadFunctionStatus) GetDuration() *api.Percentiles {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *PayloadFunctionStatus) GetRuns() *api.Rates {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+func (m *PayloadFunctionStatus) GetErrors() *api.Rates {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
 type ApplicationIdentifier struct {
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 }
@@ -168,7 +235,7 @@ type ApplicationIdentifier struct {
 func (m *ApplicationIdentifier) Reset()                    { *m = ApplicationIdentifier{} }
 func (m *ApplicationIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*ApplicationIdentifier) ProtoMessage()               {}
-func (*ApplicationIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{3} }
+func (*ApplicationIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{4} }
 
 func (m *ApplicationIdentifier) GetAppId() string {
 	if m != nil {
@@ -192,12 +259,15 @@ type Application struct {
 	Validator string `protobuf:"bytes,4,opt,name=validator,proto3" json:"validator,omitempty"`
 	// The encoder is a JavaScript function that encodes an object to a byte array.
 	Encoder string `protobuf:"bytes,5,opt,name=encoder,proto3" json:"encoder,omitempty"`
+	// The engine that runs the payload functions: "otto" (ECMAScript 5.1, the
+	// default) or "goja" (ECMAScript 2015+).
+	Engine string `protobuf:"bytes,6,opt,name=engine,proto3" json:"engine,omitempty"`
 }
 
 func (m *Application) Reset()                    { *m = Application{} }
 func (m *Application) String() string            { return proto.CompactTextString(m) }
 func (*Application) ProtoMessage()               {}
-func (*Application) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{4} }
+func (*Application) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{5} }
 
 func (m *Application) GetAppId() string {
 	if m != nil {
@@ -234,6 +304,13 @@ func (m *Application) GetEncoder() string {
 	return ""
 }
 
+func (m *Application) GetEngine() string {
+	if m != nil {
+		return m.Engine
+	}
+	return ""
+}
+
 type DeviceIdentifier struct {
 	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
 	DevId string `protobuf:"bytes,2,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
@@ -242,7 +319,7 @@ type DeviceIdentifier struct {
 func (m *DeviceIdentifier) Reset()                    { *m = DeviceIdentifier{} }
 func (m *DeviceIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*DeviceIdentifier) ProtoMessage()               {}
-func (*DeviceIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{5} }
+func (*DeviceIdentifier) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{6} }
 
 func (m *DeviceIdentifier) GetAppId() string {
 	if m != nil {
@@ -276,7 +353,7 @@ type Device struct {
 func (m *Device) Reset()                    { *m = Device{} }
 func (m *Device) String() string            { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()               {}
-func (*Device) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{6} }
+func (*Device) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{7} }
 
 type isDevice_Device interface {
 	isDevice_Device()
@@ -408,7 +485,7 @@ type DeviceList struct {
 func (m *DeviceList) Reset()                    { *m = DeviceList{} }
 func (m *DeviceList) String() string            { return proto.CompactTextString(m) }
 func (*DeviceList) ProtoMessage()               {}
-func (*DeviceList) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{7} }
+func (*DeviceList) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{8} }
 
 func (m *DeviceList) GetDevices() []*Device {
 	if m != nil {
@@ -432,7 +509,7 @@ type DryDownlinkMessage struct {
 func (m *DryDownlinkMessage) Reset()                    { *m = DryDownlinkMessage{} }
 func (m *DryDownlinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*DryDownlinkMessage) ProtoMessage()               {}
-func (*DryDownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{8} }
+func (*DryDownlinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{9} }
 
 func (m *DryDownlinkMessage) GetPayload() []byte {
 	if m != nil {
@@ -475,7 +552,7 @@ type DryUplinkMessage struct {
 func (m *DryUplinkMessage) Reset()                    { *m = DryUplinkMessage{} }
 func (m *DryUplinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*DryUplinkMessage) ProtoMessage()               {}
-func (*DryUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{9} }
+func (*DryUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{10} }
 
 func (m *DryUplinkMessage) GetPayload() []byte {
 	if m != nil {
@@ -511,7 +588,7 @@ type SimulatedUplinkMessage struct {
 func (m *SimulatedUplinkMessage) Reset()                    { *m = SimulatedUplinkMessage{} }
 func (m *SimulatedUplinkMessage) String() string            { return proto.CompactTextString(m) }
 func (*SimulatedUplinkMessage) ProtoMessage()               {}
-func (*SimulatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{10} }
+func (*SimulatedUplinkMessage) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{11} }
 
 func (m *SimulatedUplinkMessage) GetAppId() string {
 	if m != nil {
@@ -551,7 +628,7 @@ type LogEntry struct {
 func (m *LogEntry) Reset()                    { *m = LogEntry{} }
 func (m *LogEntry) String() string            { return proto.CompactTextString(m) }
 func (*LogEntry) ProtoMessage()               {}
-func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{11} }
+func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{12} }
 
 func (m *LogEntry) GetFunction() string {
 	if m != nil {
@@ -582,7 +659,7 @@ type DryUplinkResult struct {
 func (m *DryUplinkResult) Reset()                    { *m = DryUplinkResult{} }
 func (m *DryUplinkResult) String() string            { return proto.CompactTextString(m) }
 func (*DryUplinkResult) ProtoMessage()               {}
-func (*DryUplinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{12} }
+func (*DryUplinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{13} }
 
 func (m *DryUplinkResult) GetPayload() []byte {
 	if m != nil {
@@ -623,7 +700,7 @@ type DryDownlinkResult struct {
 func (m *DryDownlinkResult) Reset()                    { *m = DryDownlinkResult{} }
 func (m *DryDownlinkResult) String() string            { return proto.CompactTextString(m) }
 func (*DryDownlinkResult) ProtoMessage()               {}
-func (*DryDownlinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{13} }
+func (*DryDownlinkResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{14} }
 
 func (m *DryDownlinkResult) GetPayload() []byte {
 	if m != nil {
@@ -639,10 +716,408 @@ func (m *DryDownlinkResult) GetLogs() []*LogEntry {
 	return nil
 }
 
+// AuditLogRequest is used to query the changes to an application and its devices
+type AuditLogRequest struct {
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// Only return the changes to this device
+	DevId string `protobuf:"bytes,2,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// Only return the changes after this time (Unix nanoseconds)
+	Since int64 `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	// The maximum number of changes to return (default 100)
+	Limit uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *AuditLogRequest) Reset()                    { *m = AuditLogRequest{} }
+func (m *AuditLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*AuditLogRequest) ProtoMessage()               {}
+func (*AuditLogRequest) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{15} }
+
+func (m *AuditLogRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *AuditLogRequest) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *AuditLogRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *AuditLogRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// AuditLogField is the new value of a changed field
+type AuditLogField struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The value of the field, or <redacted> for keys
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *AuditLogField) Reset()                    { *m = AuditLogField{} }
+func (m *AuditLogField) String() string            { return proto.CompactTextString(m) }
+func (*AuditLogField) ProtoMessage()               {}
+func (*AuditLogField) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{16} }
+
+func (m *AuditLogField) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AuditLogField) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// AuditLogEntry is a change to an application or device
+type AuditLogEntry struct {
+	// When the change was made (Unix nanoseconds)
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// The component that recorded the change
+	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	ServiceId   string `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// The user or component that made the change
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// The issuer of the token of the actor
+	Issuer string `protobuf:"bytes,5,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// The type of change, for example set_device or delete_application
+	Action string `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	AppId  string `protobuf:"bytes,7,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	DevId  string `protobuf:"bytes,8,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// The target of the change if it is not an application or device, for example a component or an EUI
+	Target  string           `protobuf:"bytes,9,opt,name=target,proto3" json:"target,omitempty"`
+	Changed []*AuditLogField `protobuf:"bytes,10,rep,name=changed" json:"changed,omitempty"`
+}
+
+func (m *AuditLogEntry) Reset()                    { *m = AuditLogEntry{} }
+func (m *AuditLogEntry) String() string            { return proto.CompactTextString(m) }
+func (*AuditLogEntry) ProtoMessage()               {}
+func (*AuditLogEntry) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{17} }
+
+func (m *AuditLogEntry) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *AuditLogEntry) GetServiceName() string {
+	if m != nil {
+		return m.ServiceName
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *AuditLogEntry) GetChanged() []*AuditLogField {
+	if m != nil {
+		return m.Changed
+	}
+	return nil
+}
+
+type AuditLog struct {
+	// The changes, newest first
+	Entries []*AuditLogEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *AuditLog) Reset()                    { *m = AuditLog{} }
+func (m *AuditLog) String() string            { return proto.CompactTextString(m) }
+func (*AuditLog) ProtoMessage()               {}
+func (*AuditLog) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{18} }
+
+func (m *AuditLog) GetEntries() []*AuditLogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// BulkSetDevicesResult is the result of setting one of the devices of a BulkSetDevices stream
+type BulkSetDevicesResult struct {
+	// The index of the device in the stream, starting at 0
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	AppId string `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	DevId string `protobuf:"bytes,3,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// The device did not exist yet and was created
+	Created bool `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	// The device already existed with the same settings and was not written
+	Unchanged bool `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// The error message if the device could not be set. The other devices are still set.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *BulkSetDevicesResult) Reset()                    { *m = BulkSetDevicesResult{} }
+func (m *BulkSetDevicesResult) String() string            { return proto.CompactTextString(m) }
+func (*BulkSetDevicesResult) ProtoMessage()               {}
+func (*BulkSetDevicesResult) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{19} }
+
+func (m *BulkSetDevicesResult) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BulkSetDevicesResult) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *BulkSetDevicesResult) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *BulkSetDevicesResult) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *BulkSetDevicesResult) GetUnchanged() bool {
+	if m != nil {
+		return m.Unchanged
+	}
+	return false
+}
+
+func (m *BulkSetDevicesResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ApplicationExportRequest struct {
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// The ID of the Handler that the application will be imported into
+	HandlerId string `protobuf:"bytes,2,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+}
+
+func (m *ApplicationExportRequest) Reset()                    { *m = ApplicationExportRequest{} }
+func (m *ApplicationExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ApplicationExportRequest) ProtoMessage()               {}
+func (*ApplicationExportRequest) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{20} }
+
+func (m *ApplicationExportRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *ApplicationExportRequest) GetHandlerId() string {
+	if m != nil {
+		return m.HandlerId
+	}
+	return ""
+}
+
+// ApplicationExport is the complete state of an application on a Handler, signed by that Handler. The Handler that
+// imports the application signs the same state to confirm the import.
+type ApplicationExport struct {
+	AppId string `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// The ID of the Handler that signed the state
+	HandlerId string `protobuf:"bytes,2,opt,name=handler_id,json=handlerId,proto3" json:"handler_id,omitempty"`
+	// The JSON-encoded state of the application, its devices, their downlink queues and frame counters
+	State []byte `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// The ES256 signature of the state by the Handler that signed the state
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ApplicationExport) Reset()                    { *m = ApplicationExport{} }
+func (m *ApplicationExport) String() string            { return proto.CompactTextString(m) }
+func (*ApplicationExport) ProtoMessage()               {}
+func (*ApplicationExport) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{21} }
+
+func (m *ApplicationExport) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *ApplicationExport) GetHandlerId() string {
+	if m != nil {
+		return m.HandlerId
+	}
+	return ""
+}
+
+func (m *ApplicationExport) GetState() []byte {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *ApplicationExport) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+// DeviceSnapshot is the complete session state of a device in the Handler and the NetworkServer
+type DeviceSnapshot struct {
+	// The version of the snapshot format
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// When the snapshot was taken (Unix nanoseconds)
+	Time int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	// The settings, keys and frame counters of the device. If the Handler encrypts keys, the keys are left out here
+	// and stored in encrypted_keys instead. Otherwise the snapshot contains the keys in plaintext.
+	Device *Device `protobuf:"bytes,3,opt,name=device" json:"device,omitempty"`
+	// The MAC state of the device in the NetworkServer
+	MacState *lorawan1.MACState `protobuf:"bytes,4,opt,name=mac_state,json=macState" json:"mac_state,omitempty"`
+	// The DevNonces that were used by the device to join
+	UsedDevNonces [][]byte `protobuf:"bytes,5,rep,name=used_dev_nonces,json=usedDevNonces" json:"used_dev_nonces,omitempty"`
+	// The AppNonces that were used to accept joins of the device
+	UsedAppNonces [][]byte `protobuf:"bytes,6,rep,name=used_app_nonces,json=usedAppNonces" json:"used_app_nonces,omitempty"`
+	// Restore the snapshot even if the device has another session in the NetworkServer. This is not part of the
+	// snapshot itself, but set when restoring it.
+	Force bool `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`
+	// The AppKey, NwkSKey and AppSKey of the device, encrypted by the Handler that took the snapshot. This can only be
+	// restored to a Handler that has the key-encryption key.
+	EncryptedKeys string `protobuf:"bytes,8,opt,name=encrypted_keys,json=encryptedKeys,proto3" json:"encrypted_keys,omitempty"`
+}
+
+func (m *DeviceSnapshot) Reset()                    { *m = DeviceSnapshot{} }
+func (m *DeviceSnapshot) String() string            { return proto.CompactTextString(m) }
+func (*DeviceSnapshot) ProtoMessage()               {}
+func (*DeviceSnapshot) Descriptor() ([]byte, []int) { return fileDescriptorHandler, []int{22} }
+
+func (m *DeviceSnapshot) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *DeviceSnapshot) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *DeviceSnapshot) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *DeviceSnapshot) GetMacState() *lorawan1.MACState {
+	if m != nil {
+		return m.MacState
+	}
+	return nil
+}
+
+func (m *DeviceSnapshot) GetUsedDevNonces() [][]byte {
+	if m != nil {
+		return m.UsedDevNonces
+	}
+	return nil
+}
+
+func (m *DeviceSnapshot) GetUsedAppNonces() [][]byte {
+	if m != nil {
+		return m.UsedAppNonces
+	}
+	return nil
+}
+
+func (m *DeviceSnapshot) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *DeviceSnapshot) GetEncryptedKeys() string {
+	if m != nil {
+		return m.EncryptedKeys
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*DeviceActivationResponse)(nil), "handler.DeviceActivationResponse")
 	proto.RegisterType((*StatusRequest)(nil), "handler.StatusRequest")
 	proto.RegisterType((*Status)(nil), "handler.Status")
+	proto.RegisterType((*PayloadFunctionStatus)(nil), "handler.PayloadFunctionStatus")
 	proto.RegisterType((*ApplicationIdentifier)(nil), "handler.ApplicationIdentifier")
 	proto.RegisterType((*Application)(nil), "handler.Application")
 	proto.RegisterType((*DeviceIdentifier)(nil), "handler.DeviceIdentifier")
@@ -654,6 +1129,14 @@ func init() {
 	proto.RegisterType((*LogEntry)(nil), "handler.LogEntry")
 	proto.RegisterType((*DryUplinkResult)(nil), "handler.DryUplinkResult")
 	proto.RegisterType((*DryDownlinkResult)(nil), "handler.DryDownlinkResult")
+	proto.RegisterType((*AuditLogRequest)(nil), "handler.AuditLogRequest")
+	proto.RegisterType((*AuditLogField)(nil), "handler.AuditLogField")
+	proto.RegisterType((*AuditLogEntry)(nil), "handler.AuditLogEntry")
+	proto.RegisterType((*AuditLog)(nil), "handler.AuditLog")
+	proto.RegisterType((*BulkSetDevicesResult)(nil), "handler.BulkSetDevicesResult")
+	proto.RegisterType((*ApplicationExportRequest)(nil), "handler.ApplicationExportRequest")
+	proto.RegisterType((*ApplicationExport)(nil), "handler.ApplicationExport")
+	proto.RegisterType((*DeviceSnapshot)(nil), "handler.DeviceSnapshot")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -786,6 +1269,35 @@ type ApplicationManagerClient interface {
 	DryUplink(ctx context.Context, in *DryUplinkMessage, opts ...grpc.CallOption) (*DryUplinkResult, error)
 	// SimulateUplink simulates an uplink message
 	SimulateUplink(ctx context.Context, in *SimulatedUplinkMessage, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetDeviceMACState returns the MAC state of the device with the given identifier (app_id and dev_id), as it is known by the NetworkServer
+	GetDeviceMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*lorawan1.MACState, error)
+	// ResetDeviceMACState resets the ADR settings and the frame history of the device with the given identifier (app_id and dev_id)
+	ResetDeviceMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetAuditLog returns the changes to the application and its devices, newest first
+	GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error)
+	// BulkSetDevices creates or updates the devices of the stream, and returns a result for each device.
+	// All fields of each device must be supplied, like with SetDevice, except for the frame counters: if they are zero,
+	// the frame counters of existing devices are kept.
+	BulkSetDevices(ctx context.Context, opts ...grpc.CallOption) (ApplicationManager_BulkSetDevicesClient, error)
+	// ExportDevices returns all devices (including their keys) of the application with the given identifier (app_id)
+	ExportDevices(ctx context.Context, in *ApplicationIdentifier, opts ...grpc.CallOption) (ApplicationManager_ExportDevicesClient, error)
+	// ExportApplication returns the signed state of the application with the given identifier (app_id), so that it can
+	// be imported into the Handler with the given handler_id. Until the import is confirmed or the export expires,
+	// devices can not join and downlink is not sent.
+	ExportApplication(ctx context.Context, in *ApplicationExportRequest, opts ...grpc.CallOption) (*ApplicationExport, error)
+	// ImportApplication imports an application that was exported by another Handler, and makes this Handler
+	// responsible for the application. It returns the state signed by this Handler, which confirms the import to the
+	// Handler that exported the application.
+	ImportApplication(ctx context.Context, in *ApplicationExport, opts ...grpc.CallOption) (*ApplicationExport, error)
+	// ConfirmApplicationExport completes the export of an application with the state signed by the Handler that
+	// imported it
+	ConfirmApplicationExport(ctx context.Context, in *ApplicationExport, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetDeviceSnapshot returns the session state of the device with the given identifier (app_id and dev_id)
+	GetDeviceSnapshot(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*DeviceSnapshot, error)
+	// RestoreDeviceSnapshot restores the session state of a device from a snapshot. The device is created if it does
+	// not exist. A snapshot that is older than the current session of the device is refused, and so is a snapshot of
+	// another session, unless force is set.
+	RestoreDeviceSnapshot(ctx context.Context, in *DeviceSnapshot, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type applicationManagerClient struct {
@@ -895,6 +1407,141 @@ func (c *applicationManagerClient) SimulateUplink(ctx context.Context, in *Simul
 	return out, nil
 }
 
+func (c *applicationManagerClient) GetDeviceMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*lorawan1.MACState, error) {
+	out := new(lorawan1.MACState)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetDeviceMACState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) ResetDeviceMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/ResetDeviceMACState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) GetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error) {
+	out := new(AuditLog)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetAuditLog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) BulkSetDevices(ctx context.Context, opts ...grpc.CallOption) (ApplicationManager_BulkSetDevicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ApplicationManager_serviceDesc.Streams[0], c.cc, "/handler.ApplicationManager/BulkSetDevices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationManagerBulkSetDevicesClient{stream}
+	return x, nil
+}
+
+type ApplicationManager_BulkSetDevicesClient interface {
+	Send(*Device) error
+	Recv() (*BulkSetDevicesResult, error)
+	grpc.ClientStream
+}
+
+type applicationManagerBulkSetDevicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationManagerBulkSetDevicesClient) Send(m *Device) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *applicationManagerBulkSetDevicesClient) Recv() (*BulkSetDevicesResult, error) {
+	m := new(BulkSetDevicesResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *applicationManagerClient) ExportDevices(ctx context.Context, in *ApplicationIdentifier, opts ...grpc.CallOption) (ApplicationManager_ExportDevicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ApplicationManager_serviceDesc.Streams[1], c.cc, "/handler.ApplicationManager/ExportDevices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &applicationManagerExportDevicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApplicationManager_ExportDevicesClient interface {
+	Recv() (*Device, error)
+	grpc.ClientStream
+}
+
+type applicationManagerExportDevicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *applicationManagerExportDevicesClient) Recv() (*Device, error) {
+	m := new(Device)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *applicationManagerClient) ExportApplication(ctx context.Context, in *ApplicationExportRequest, opts ...grpc.CallOption) (*ApplicationExport, error) {
+	out := new(ApplicationExport)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/ExportApplication", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) ImportApplication(ctx context.Context, in *ApplicationExport, opts ...grpc.CallOption) (*ApplicationExport, error) {
+	out := new(ApplicationExport)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/ImportApplication", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) ConfirmApplicationExport(ctx context.Context, in *ApplicationExport, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/ConfirmApplicationExport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) GetDeviceSnapshot(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*DeviceSnapshot, error) {
+	out := new(DeviceSnapshot)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/GetDeviceSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationManagerClient) RestoreDeviceSnapshot(ctx context.Context, in *DeviceSnapshot, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/handler.ApplicationManager/RestoreDeviceSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ApplicationManager service
 
 type ApplicationManagerServer interface {
@@ -920,6 +1567,35 @@ type ApplicationManagerServer interface {
 	DryUplink(context.Context, *DryUplinkMessage) (*DryUplinkResult, error)
 	// SimulateUplink simulates an uplink message
 	SimulateUplink(context.Context, *SimulatedUplinkMessage) (*google_protobuf.Empty, error)
+	// GetDeviceMACState returns the MAC state of the device with the given identifier (app_id and dev_id), as it is known by the NetworkServer
+	GetDeviceMACState(context.Context, *DeviceIdentifier) (*lorawan1.MACState, error)
+	// ResetDeviceMACState resets the ADR settings and the frame history of the device with the given identifier (app_id and dev_id)
+	ResetDeviceMACState(context.Context, *DeviceIdentifier) (*google_protobuf.Empty, error)
+	// GetAuditLog returns the changes to the application and its devices, newest first
+	GetAuditLog(context.Context, *AuditLogRequest) (*AuditLog, error)
+	// BulkSetDevices creates or updates the devices of the stream, and returns a result for each device.
+	// All fields of each device must be supplied, like with SetDevice, except for the frame counters: if they are zero,
+	// the frame counters of existing devices are kept.
+	BulkSetDevices(ApplicationManager_BulkSetDevicesServer) error
+	// ExportDevices returns all devices (including their keys) of the application with the given identifier (app_id)
+	ExportDevices(*ApplicationIdentifier, ApplicationManager_ExportDevicesServer) error
+	// ExportApplication returns the signed state of the application with the given identifier (app_id), so that it can
+	// be imported into the Handler with the given handler_id. Until the import is confirmed or the export expires,
+	// devices can not join and downlink is not sent.
+	ExportApplication(context.Context, *ApplicationExportRequest) (*ApplicationExport, error)
+	// ImportApplication imports an application that was exported by another Handler, and makes this Handler
+	// responsible for the application. It returns the state signed by this Handler, which confirms the import to the
+	// Handler that exported the application.
+	ImportApplication(context.Context, *ApplicationExport) (*ApplicationExport, error)
+	// ConfirmApplicationExport completes the export of an application with the state signed by the Handler that
+	// imported it
+	ConfirmApplicationExport(context.Context, *ApplicationExport) (*google_protobuf.Empty, error)
+	// GetDeviceSnapshot returns the session state of the device with the given identifier (app_id and dev_id)
+	GetDeviceSnapshot(context.Context, *DeviceIdentifier) (*DeviceSnapshot, error)
+	// RestoreDeviceSnapshot restores the session state of a device from a snapshot. The device is created if it does
+	// not exist. A snapshot that is older than the current session of the device is refused, and so is a snapshot of
+	// another session, unless force is set.
+	RestoreDeviceSnapshot(context.Context, *DeviceSnapshot) (*google_protobuf.Empty, error)
 }
 
 func RegisterApplicationManagerServer(s *grpc.Server, srv ApplicationManagerServer) {
@@ -1124,6 +1800,197 @@ func _ApplicationManager_SimulateUplink_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationManager_GetDeviceMACState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetDeviceMACState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetDeviceMACState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetDeviceMACState(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_ResetDeviceMACState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).ResetDeviceMACState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/ResetDeviceMACState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).ResetDeviceMACState(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetAuditLog(ctx, req.(*AuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_BulkSetDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ApplicationManagerServer).BulkSetDevices(&applicationManagerBulkSetDevicesServer{stream})
+}
+
+type ApplicationManager_BulkSetDevicesServer interface {
+	Send(*BulkSetDevicesResult) error
+	Recv() (*Device, error)
+	grpc.ServerStream
+}
+
+type applicationManagerBulkSetDevicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationManagerBulkSetDevicesServer) Send(m *BulkSetDevicesResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *applicationManagerBulkSetDevicesServer) Recv() (*Device, error) {
+	m := new(Device)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ApplicationManager_ExportDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ApplicationIdentifier)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationManagerServer).ExportDevices(m, &applicationManagerExportDevicesServer{stream})
+}
+
+type ApplicationManager_ExportDevicesServer interface {
+	Send(*Device) error
+	grpc.ServerStream
+}
+
+type applicationManagerExportDevicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *applicationManagerExportDevicesServer) Send(m *Device) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ApplicationManager_ExportApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).ExportApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/ExportApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).ExportApplication(ctx, req.(*ApplicationExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_ImportApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationExport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).ImportApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/ImportApplication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).ImportApplication(ctx, req.(*ApplicationExport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_ConfirmApplicationExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplicationExport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).ConfirmApplicationExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/ConfirmApplicationExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).ConfirmApplicationExport(ctx, req.(*ApplicationExport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_GetDeviceSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).GetDeviceSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/GetDeviceSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).GetDeviceSnapshot(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationManager_RestoreDeviceSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationManagerServer).RestoreDeviceSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/handler.ApplicationManager/RestoreDeviceSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationManagerServer).RestoreDeviceSnapshot(ctx, req.(*DeviceSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApplicationManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "handler.ApplicationManager",
 	HandlerType: (*ApplicationManagerServer)(nil),
@@ -1172,8 +2039,52 @@ var _ApplicationManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SimulateUplink",
 			Handler:    _ApplicationManager_SimulateUplink_Handler,
 		},
+		{
+			MethodName: "GetDeviceMACState",
+			Handler:    _ApplicationManager_GetDeviceMACState_Handler,
+		},
+		{
+			MethodName: "ResetDeviceMACState",
+			Handler:    _ApplicationManager_ResetDeviceMACState_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _ApplicationManager_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "ExportApplication",
+			Handler:    _ApplicationManager_ExportApplication_Handler,
+		},
+		{
+			MethodName: "ImportApplication",
+			Handler:    _ApplicationManager_ImportApplication_Handler,
+		},
+		{
+			MethodName: "ConfirmApplicationExport",
+			Handler:    _ApplicationManager_ConfirmApplicationExport_Handler,
+		},
+		{
+			MethodName: "GetDeviceSnapshot",
+			Handler:    _ApplicationManager_GetDeviceSnapshot_Handler,
+		},
+		{
+			MethodName: "RestoreDeviceSnapshot",
+			Handler:    _ApplicationManager_RestoreDeviceSnapshot_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkSetDevices",
+			Handler:       _ApplicationManager_BulkSetDevices_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportDevices",
+			Handler:       _ApplicationManager_ExportDevices_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/handler/handler.proto",
 }
 
@@ -1392,10 +2303,32 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n9
 	}
+	if m.QuotaExceeded != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.QuotaExceeded.Size()))
+		n10, err := m.QuotaExceeded.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.PayloadFunctions) > 0 {
+		for _, msg := range m.PayloadFunctions {
+			dAtA[i] = 0x7a
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
-func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
+func (m *PayloadFunctionStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1405,7 +2338,7 @@ func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ApplicationIdentifier) MarshalTo(dAtA []byte) (int, error) {
+func (m *PayloadFunctionStatus) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1416,13 +2349,67 @@ func (m *ApplicationIdentifier) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
 		i += copy(dAtA[i:], m.AppId)
 	}
-	return i, nil
-}
-
-func (m *Application) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	if m.Duration != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Duration.Size()))
+		n15, err := m.Duration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.Runs != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Runs.Size()))
+		n16, err := m.Runs.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.Errors != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Errors.Size()))
+		n17, err := m.Errors.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+
+func (m *ApplicationIdentifier) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationIdentifier) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	return i, nil
+}
+
+func (m *Application) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
 		return nil, err
 	}
@@ -1464,6 +2451,12 @@ func (m *Application) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintHandler(dAtA, i, uint64(len(m.Encoder)))
 		i += copy(dAtA[i:], m.Encoder)
 	}
+	if len(m.Engine) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Engine)))
+		i += copy(dAtA[i:], m.Engine)
+	}
 	return i, nil
 }
 
@@ -1525,11 +2518,11 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 		i += copy(dAtA[i:], m.DevId)
 	}
 	if m.Device != nil {
-		nn10, err := m.Device.MarshalTo(dAtA[i:])
+		nn11, err := m.Device.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn10
+		i += nn11
 	}
 	if m.Latitude != 0 {
 		dAtA[i] = 0x55
@@ -1563,11 +2556,11 @@ func (m *Device_LorawanDevice) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintHandler(dAtA, i, uint64(m.LorawanDevice.Size()))
-		n11, err := m.LorawanDevice.MarshalTo(dAtA[i:])
+		n12, err := m.LorawanDevice.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
 	return i, nil
 }
@@ -1632,11 +2625,11 @@ func (m *DryDownlinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
-		n12, err := m.App.MarshalTo(dAtA[i:])
+		n13, err := m.App.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n12
+		i += n13
 	}
 	if m.Port != 0 {
 		dAtA[i] = 0x20
@@ -1671,11 +2664,11 @@ func (m *DryUplinkMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintHandler(dAtA, i, uint64(m.App.Size()))
-		n13, err := m.App.MarshalTo(dAtA[i:])
+		n14, err := m.App.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n14
 	}
 	if m.Port != 0 {
 		dAtA[i] = 0x18
@@ -1853,281 +2846,713 @@ func (m *DryDownlinkResult) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeFixed64Handler(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Handler(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintHandler(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *AuditLogRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *DeviceActivationResponse) Size() (n int) {
+
+func (m *AuditLogRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Message != nil {
-		l = m.Message.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	if m.DownlinkOption != nil {
-		l = m.DownlinkOption.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
 	}
-	if m.ActivationMetadata != nil {
-		l = m.ActivationMetadata.Size()
-		n += 2 + l + sovHandler(uint64(l))
+	if m.Since != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Since))
 	}
-	if m.Trace != nil {
-		l = m.Trace.Size()
-		n += 2 + l + sovHandler(uint64(l))
+	if m.Limit != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Limit))
 	}
-	return n
+	return i, nil
 }
 
-func (m *StatusRequest) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *AuditLogField) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func (m *Status) Size() (n int) {
+func (m *AuditLogField) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.System != nil {
-		l = m.System.Size()
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Component != nil {
-		l = m.Component.Size()
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Uplink != nil {
-		l = m.Uplink.Size()
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Downlink != nil {
-		l = m.Downlink.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	if m.Activations != nil {
-		l = m.Activations.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ApplicationIdentifier) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+func (m *AuditLogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Application) Size() (n int) {
+func (m *AuditLogEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Time))
 	}
-	l = len(m.Decoder)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.ServiceName) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.ServiceName)))
+		i += copy(dAtA[i:], m.ServiceName)
 	}
-	l = len(m.Converter)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.ServiceId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.ServiceId)))
+		i += copy(dAtA[i:], m.ServiceId)
 	}
-	l = len(m.Validator)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Actor) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Actor)))
+		i += copy(dAtA[i:], m.Actor)
 	}
-	l = len(m.Encoder)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.Issuer) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Issuer)))
+		i += copy(dAtA[i:], m.Issuer)
 	}
-	return n
+	if len(m.Action) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Action)))
+		i += copy(dAtA[i:], m.Action)
+	}
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
+	}
+	if len(m.Target) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Target)))
+		i += copy(dAtA[i:], m.Target)
+	}
+	if len(m.Changed) > 0 {
+		for _, msg := range m.Changed {
+			dAtA[i] = 0x52
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
 }
 
-func (m *DeviceIdentifier) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	l = len(m.DevId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+func (m *AuditLog) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Device) Size() (n int) {
+func (m *AuditLog) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.AppId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	l = len(m.DevId)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if m.Device != nil {
-		n += m.Device.Size()
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.Latitude != 0 {
-		n += 5
+	return i, nil
+}
+
+func (m *BulkSetDevicesResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Longitude != 0 {
-		n += 5
+	return dAtA[:n], nil
+}
+
+func (m *BulkSetDevicesResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Index))
 	}
-	if m.Altitude != 0 {
-		n += 1 + sovHandler(uint64(m.Altitude))
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 2 + l + sovHandler(uint64(l))
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
 	}
-	return n
+	if m.Created {
+		dAtA[i] = 0x20
+		i++
+		if m.Created {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Unchanged {
+		dAtA[i] = 0x28
+		i++
+		if m.Unchanged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
 }
 
-func (m *Device_LorawanDevice) Size() (n int) {
-	var l int
-	_ = l
-	if m.LorawanDevice != nil {
-		l = m.LorawanDevice.Size()
-		n += 1 + l + sovHandler(uint64(l))
+func (m *ApplicationExportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *DeviceList) Size() (n int) {
+
+func (m *ApplicationExportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Devices) > 0 {
-		for _, e := range m.Devices {
-			l = e.Size()
-			n += 1 + l + sovHandler(uint64(l))
-		}
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	return n
+	if len(m.HandlerId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.HandlerId)))
+		i += copy(dAtA[i:], m.HandlerId)
+	}
+	return i, nil
 }
 
-func (m *DryDownlinkMessage) Size() (n int) {
+func (m *ApplicationExport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplicationExport) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
 	}
-	l = len(m.Fields)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.HandlerId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.HandlerId)))
+		i += copy(dAtA[i:], m.HandlerId)
 	}
-	if m.App != nil {
-		l = m.App.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if len(m.State) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
 	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
+	if len(m.Signature) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.Signature)))
+		i += copy(dAtA[i:], m.Signature)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DryUplinkMessage) Size() (n int) {
+func (m *DeviceSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeviceSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Version))
 	}
-	if m.App != nil {
-		l = m.App.Size()
-		n += 1 + l + sovHandler(uint64(l))
+	if m.Time != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Time))
 	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
+	if m.Device != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.Device.Size()))
+		n18, err := m.Device.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
 	}
-	return n
+	if m.MacState != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(m.MacState.Size()))
+		n19, err := m.MacState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if len(m.UsedDevNonces) > 0 {
+		for _, b := range m.UsedDevNonces {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.UsedAppNonces) > 0 {
+		for _, b := range m.UsedAppNonces {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintHandler(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.Force {
+		dAtA[i] = 0x38
+		i++
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.EncryptedKeys) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintHandler(dAtA, i, uint64(len(m.EncryptedKeys)))
+		i += copy(dAtA[i:], m.EncryptedKeys)
+	}
+	return i, nil
 }
 
-func (m *SimulatedUplinkMessage) Size() (n int) {
+func encodeFixed64Handler(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Handler(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintHandler(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *DeviceActivationResponse) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.AppId)
+	l = len(m.Payload)
 	if l > 0 {
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.DevId)
-	if l > 0 {
+	if m.Message != nil {
+		l = m.Message.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.Payload)
-	if l > 0 {
+	if m.DownlinkOption != nil {
+		l = m.DownlinkOption.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.Port != 0 {
-		n += 1 + sovHandler(uint64(m.Port))
+	if m.ActivationMetadata != nil {
+		l = m.ActivationMetadata.Size()
+		n += 2 + l + sovHandler(uint64(l))
+	}
+	if m.Trace != nil {
+		l = m.Trace.Size()
+		n += 2 + l + sovHandler(uint64(l))
 	}
 	return n
 }
 
-func (m *LogEntry) Size() (n int) {
+func (m *StatusRequest) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Function)
-	if l > 0 {
-		n += 1 + l + sovHandler(uint64(l))
-	}
-	if len(m.Fields) > 0 {
-		for _, s := range m.Fields {
-			l = len(s)
-			n += 1 + l + sovHandler(uint64(l))
-		}
-	}
 	return n
 }
 
-func (m *DryUplinkResult) Size() (n int) {
+func (m *Status) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Payload)
-	if l > 0 {
+	if m.System != nil {
+		l = m.System.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	l = len(m.Fields)
-	if l > 0 {
+	if m.Component != nil {
+		l = m.Component.Size()
 		n += 1 + l + sovHandler(uint64(l))
 	}
-	if m.Valid {
-		n += 2
-	}
+	if m.Uplink != nil {
+		l = m.Uplink.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Downlink != nil {
+		l = m.Downlink.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Activations != nil {
+		l = m.Activations.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.QuotaExceeded != nil {
+		l = m.QuotaExceeded.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.PayloadFunctions) > 0 {
+		for _, e := range m.PayloadFunctions {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PayloadFunctionStatus) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Duration != nil {
+		l = m.Duration.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Runs != nil {
+		l = m.Runs.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Errors != nil {
+		l = m.Errors.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *ApplicationIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *Application) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Decoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Converter)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Validator)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Encoder)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Engine)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DeviceIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Device != nil {
+		n += m.Device.Size()
+	}
+	if m.Latitude != 0 {
+		n += 5
+	}
+	if m.Longitude != 0 {
+		n += 5
+	}
+	if m.Altitude != 0 {
+		n += 1 + sovHandler(uint64(m.Altitude))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 2 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *Device_LorawanDevice) Size() (n int) {
+	var l int
+	_ = l
+	if m.LorawanDevice != nil {
+		l = m.LorawanDevice.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+func (m *DeviceList) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DryDownlinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.App != nil {
+		l = m.App.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *DryUplinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.App != nil {
+		l = m.App.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *SimulatedUplinkMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovHandler(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *LogEntry) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Function)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DryUplinkResult) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Valid {
+		n += 2
+	}
 	if len(m.Logs) > 0 {
 		for _, e := range m.Logs {
 			l = e.Size()
@@ -2150,23 +3575,1811 @@ func (m *DryDownlinkResult) Size() (n int) {
 			n += 1 + l + sovHandler(uint64(l))
 		}
 	}
-	return n
-}
+	return n
+}
+
+func (m *AuditLogRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Since != 0 {
+		n += 1 + sovHandler(uint64(m.Since))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovHandler(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *AuditLogField) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *AuditLogEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovHandler(uint64(m.Time))
+	}
+	l = len(m.ServiceName)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.ServiceId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Actor)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.Changed) > 0 {
+		for _, e := range m.Changed {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AuditLog) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BulkSetDevicesResult) Size() (n int) {
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovHandler(uint64(m.Index))
+	}
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.Created {
+		n += 2
+	}
+	if m.Unchanged {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *ApplicationExportRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.HandlerId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *ApplicationExport) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.HandlerId)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func (m *DeviceSnapshot) Size() (n int) {
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovHandler(uint64(m.Version))
+	}
+	if m.Time != 0 {
+		n += 1 + sovHandler(uint64(m.Time))
+	}
+	if m.Device != nil {
+		l = m.Device.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if m.MacState != nil {
+		l = m.MacState.Size()
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	if len(m.UsedDevNonces) > 0 {
+		for _, b := range m.UsedDevNonces {
+			l = len(b)
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	if len(m.UsedAppNonces) > 0 {
+		for _, b := range m.UsedAppNonces {
+			l = len(b)
+			n += 1 + l + sovHandler(uint64(l))
+		}
+	}
+	if m.Force {
+		n += 2
+	}
+	l = len(m.EncryptedKeys)
+	if l > 0 {
+		n += 1 + l + sovHandler(uint64(l))
+	}
+	return n
+}
+
+func sovHandler(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozHandler(x uint64) (n int) {
+	return sovHandler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &protocol.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkOption", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DownlinkOption == nil {
+				m.DownlinkOption = &broker.DownlinkOption{}
+			}
+			if err := m.DownlinkOption.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActivationMetadata == nil {
+				m.ActivationMetadata = &protocol.ActivationMetadata{}
+			}
+			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Status) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.System == nil {
+				m.System = &api.SystemStats{}
+			}
+			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Component", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Component == nil {
+				m.Component = &api.ComponentStats{}
+			}
+			if err := m.Component.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Uplink == nil {
+				m.Uplink = &api.Rates{}
+			}
+			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Downlink == nil {
+				m.Downlink = &api.Rates{}
+			}
+			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Activations == nil {
+				m.Activations = &api.Rates{}
+			}
+			if err := m.Activations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuotaExceeded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.QuotaExceeded == nil {
+				m.QuotaExceeded = &api.Rates{}
+			}
+			if err := m.QuotaExceeded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadFunctions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PayloadFunctions = append(m.PayloadFunctions, &PayloadFunctionStatus{})
+			if err := m.PayloadFunctions[len(m.PayloadFunctions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *PayloadFunctionStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PayloadFunctionStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PayloadFunctionStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Duration == nil {
+				m.Duration = &api.Percentiles{}
+			}
+			if err := m.Duration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Runs == nil {
+				m.Runs = &api.Rates{}
+			}
+			if err := m.Runs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Errors == nil {
+				m.Errors = &api.Rates{}
+			}
+			if err := m.Errors.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplicationIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplicationIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Application) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Application: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Application: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Decoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Converter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Converter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Encoder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Engine", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Engine = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LorawanDevice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &lorawan1.Device{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Device = &Device_LorawanDevice{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.Latitude = float32(math.Float32frombits(v))
+		case 11:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.Longitude = float32(math.Float32frombits(v))
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Altitude", wireType)
+			}
+			m.Altitude = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Altitude |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DryDownlinkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DryDownlinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field App", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.App == nil {
+				m.App = &Application{}
+			}
+			if err := m.App.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovHandler(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozHandler(x uint64) (n int) {
-	return sovHandler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
+func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2189,10 +5402,10 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DryUplinkMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DryUplinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2228,9 +5441,111 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field App", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.App == nil {
+				m.App = &Application{}
+			}
+			if err := m.App.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHandler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHandler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulatedUplinkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulatedUplinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2240,30 +5555,26 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Message == nil {
-				m.Message = &protocol.Message{}
-			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownlinkOption", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2273,30 +5584,26 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DownlinkOption == nil {
-				m.DownlinkOption = &broker.DownlinkOption{}
-			}
-			if err := m.DownlinkOption.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 23:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2306,30 +5613,28 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ActivationMetadata == nil {
-				m.ActivationMetadata = &protocol.ActivationMetadata{}
-			}
-			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
-		case 31:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			var msglen int
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2339,25 +5644,11 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Port |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Trace == nil {
-				m.Trace = &trace.Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -2379,7 +5670,7 @@ func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StatusRequest) Unmarshal(dAtA []byte) error {
+func (m *LogEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2402,12 +5693,70 @@ func (m *StatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Function = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -2429,7 +5778,7 @@ func (m *StatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Status) Unmarshal(dAtA []byte) error {
+func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2452,17 +5801,17 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+			return fmt.Errorf("proto: DryUplinkResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DryUplinkResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2472,63 +5821,28 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.System == nil {
-				m.System = &api.SystemStats{}
-			}
-			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Component", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Component == nil {
-				m.Component = &api.ComponentStats{}
-			}
-			if err := m.Component.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2538,30 +5852,26 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Uplink == nil {
-				m.Uplink = &api.Rates{}
-			}
-			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Fields = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2571,28 +5881,15 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Downlink == nil {
-				m.Downlink = &api.Rates{}
-			}
-			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 13:
+			m.Valid = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Activations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2616,10 +5913,8 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Activations == nil {
-				m.Activations = &api.Rates{}
-			}
-			if err := m.Activations.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Logs = append(m.Logs, &LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2644,7 +5939,7 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
+func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2667,17 +5962,17 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ApplicationIdentifier: wiretype end group for non-group")
+			return fmt.Errorf("proto: DryDownlinkResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ApplicationIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DryDownlinkResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2687,20 +5982,53 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppId = string(dAtA[iNdEx:postIndex])
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, &LogEntry{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2723,7 +6051,7 @@ func (m *ApplicationIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Application) Unmarshal(dAtA []byte) error {
+func (m *AuditLogRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2746,10 +6074,10 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Application: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditLogRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Application: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2783,7 +6111,7 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Decoder", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2808,13 +6136,13 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Decoder = string(dAtA[iNdEx:postIndex])
+			m.DevId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Converter", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
 			}
-			var stringLen uint64
+			m.Since = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2824,55 +6152,16 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Since |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Converter = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Validator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Encoder", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var stringLen uint64
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -2882,21 +6171,11 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Limit |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Encoder = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -2918,7 +6197,8 @@ func (m *Application) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+
+func (m *AuditLogField) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2941,15 +6221,15 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditLogField: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditLogField: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2974,11 +6254,11 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppId = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3003,7 +6283,7 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
+			m.Value = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3026,7 +6306,8 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+
+func (m *AuditLogEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3049,17 +6330,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditLogEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditLogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var stringLen uint64
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3069,24 +6350,14 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Time |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AppId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3111,13 +6382,13 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
+			m.ServiceName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LorawanDevice", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3127,74 +6398,24 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &lorawan1.Device{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Device = &Device_LorawanDevice{v}
+			m.ServiceId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
-			if wireType != 5 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
-			}
-			var v uint32
-			if (iNdEx + 4) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += 4
-			v = uint32(dAtA[iNdEx-4])
-			v |= uint32(dAtA[iNdEx-3]) << 8
-			v |= uint32(dAtA[iNdEx-2]) << 16
-			v |= uint32(dAtA[iNdEx-1]) << 24
-			m.Latitude = float32(math.Float32frombits(v))
-		case 11:
-			if wireType != 5 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
-			}
-			var v uint32
-			if (iNdEx + 4) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += 4
-			v = uint32(dAtA[iNdEx-4])
-			v |= uint32(dAtA[iNdEx-3]) << 8
-			v |= uint32(dAtA[iNdEx-2]) << 16
-			v |= uint32(dAtA[iNdEx-1]) << 24
-			m.Longitude = float32(math.Float32frombits(v))
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Altitude", wireType)
-			}
-			m.Altitude = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Altitude |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 20:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3219,63 +6440,13 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Description = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHandler(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthHandler
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeviceList) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHandler
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceList: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceList: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3285,78 +6456,55 @@ func (m *DeviceList) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Devices = append(m.Devices, &Device{})
-			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Issuer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHandler(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthHandler
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHandler
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DryDownlinkMessage: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DryDownlinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3366,26 +6514,24 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3410,13 +6556,13 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = string(dAtA[iNdEx:postIndex])
+			m.DevId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field App", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3426,30 +6572,26 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.App == nil {
-				m.App = &Application{}
-			}
-			if err := m.App.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Target = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changed", wireType)
 			}
-			m.Port = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3459,11 +6601,23 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Port |= (uint32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changed = append(m.Changed, &AuditLogField{})
+			if err := m.Changed[len(m.Changed)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -3485,7 +6639,8 @@ func (m *DryDownlinkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
+
+func (m *AuditLog) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3508,46 +6663,15 @@ func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DryUplinkMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuditLog: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DryUplinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuditLog: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field App", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3571,32 +6695,11 @@ func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.App == nil {
-				m.App = &Application{}
-			}
-			if err := m.App.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Entries = append(m.Entries, &AuditLogEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
-			}
-			m.Port = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHandler
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Port |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -3618,7 +6721,8 @@ func (m *DryUplinkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
+
+func (m *BulkSetDevicesResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3641,13 +6745,32 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SimulatedUplinkMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: BulkSetDevicesResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SimulatedUplinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BulkSetDevicesResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
@@ -3676,7 +6799,7 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
 			}
@@ -3703,13 +6826,33 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DevId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Created = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unchanged", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3719,28 +6862,17 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthHandler
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			m.Unchanged = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			m.Port = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3750,11 +6882,21 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Port |= (uint32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -3776,7 +6918,8 @@ func (m *SimulatedUplinkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogEntry) Unmarshal(dAtA []byte) error {
+
+func (m *ApplicationExportRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3799,15 +6942,15 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: ApplicationExportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ApplicationExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3832,11 +6975,11 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Function = string(dAtA[iNdEx:postIndex])
+			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HandlerId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3861,7 +7004,7 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			m.HandlerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3884,7 +7027,8 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
+
+func (m *ApplicationExport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3907,17 +7051,17 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DryUplinkResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: ApplicationExport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DryUplinkResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ApplicationExport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3927,26 +7071,24 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HandlerId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3971,13 +7113,13 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fields = string(dAtA[iNdEx:postIndex])
+			m.HandlerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -3987,17 +7129,28 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Valid = bool(v != 0)
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = append(m.State[:0], dAtA[iNdEx:postIndex]...)
+			if m.State == nil {
+				m.State = []byte{}
+			}
+			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -4007,22 +7160,20 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &LogEntry{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Signature = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4045,7 +7196,8 @@ func (m *DryUplinkResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
+
+func (m *DeviceSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4068,17 +7220,55 @@ func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DryDownlinkResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeviceSnapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DryDownlinkResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeviceSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Device", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHandler
@@ -4088,26 +7278,28 @@ func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHandler
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			if m.Device == nil {
+				m.Device = &Device{}
+			}
+			if err := m.Device.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MacState", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4131,11 +7323,120 @@ func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Logs = append(m.Logs, &LogEntry{})
-			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.MacState == nil {
+				m.MacState = &lorawan1.MACState{}
+			}
+			if err := m.MacState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedDevNonces", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UsedDevNonces = append(m.UsedDevNonces, make([]byte, postIndex-iNdEx))
+			copy(m.UsedDevNonces[len(m.UsedDevNonces)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedAppNonces", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UsedAppNonces = append(m.UsedAppNonces, make([]byte, postIndex-iNdEx))
+			copy(m.UsedAppNonces[len(m.UsedAppNonces)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedKeys = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
@@ -4157,6 +7458,7 @@ func (m *DryDownlinkResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func skipHandler(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4267,83 +7569,127 @@ func init() {
 }
 
 var fileDescriptorHandler = []byte{
-	// 1248 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x6e, 0xdb, 0x46,
-	0x13, 0xfe, 0xe9, 0x83, 0x2c, 0x8d, 0x6c, 0x39, 0x5e, 0x27, 0xfa, 0x19, 0x39, 0x50, 0x54, 0x06,
-	0x49, 0x1d, 0x27, 0xa0, 0x50, 0xb5, 0x40, 0x93, 0x5c, 0xa4, 0x39, 0x38, 0x07, 0x03, 0x71, 0x0b,
-	0xd0, 0xee, 0x8d, 0x2f, 0x6a, 0xac, 0xc5, 0x31, 0x45, 0x98, 0xe2, 0xb2, 0xe4, 0x4a, 0x86, 0x10,
-	0xa4, 0x28, 0xf2, 0x0a, 0x6d, 0xdf, 0xa0, 0x77, 0x7d, 0x8e, 0x02, 0xbd, 0x2c, 0xd0, 0x07, 0x68,
-	0xe1, 0xf6, 0x09, 0xfa, 0x04, 0x05, 0x77, 0x97, 0x07, 0x9d, 0x6c, 0xab, 0xe8, 0x8d, 0xa4, 0x99,
-	0xef, 0xe3, 0x9c, 0x76, 0x66, 0x96, 0x82, 0x87, 0x8e, 0xcb, 0x3b, 0xbd, 0x23, 0xb3, 0xcd, 0xba,
-	0xcd, 0xfd, 0x0e, 0xee, 0x77, 0x5c, 0xdf, 0x89, 0x3e, 0x47, 0x7e, 0xca, 0xc2, 0x93, 0x26, 0xe7,
-	0x7e, 0x93, 0x06, 0x6e, 0xb3, 0x43, 0x7d, 0xdb, 0xc3, 0x30, 0xf9, 0x36, 0x83, 0x90, 0x71, 0x46,
-	0x96, 0x94, 0x58, 0xdb, 0x70, 0x18, 0x73, 0x3c, 0x6c, 0x0a, 0xf5, 0x51, 0xef, 0xb8, 0x89, 0xdd,
-	0x80, 0x0f, 0x24, 0xab, 0x76, 0x43, 0x81, 0xb1, 0x1d, 0xea, 0xfb, 0x8c, 0x53, 0xee, 0x32, 0x3f,
-	0x52, 0xe8, 0x5a, 0xe2, 0x82, 0x06, 0xae, 0x52, 0x6d, 0x24, 0xaa, 0xa3, 0x90, 0x9d, 0x60, 0xa8,
-	0xbe, 0x14, 0x78, 0x33, 0x01, 0x85, 0xd8, 0x66, 0x5e, 0xfa, 0x43, 0x11, 0x6e, 0x8f, 0x11, 0x3c,
-	0x16, 0xd2, 0x53, 0xea, 0x37, 0x6d, 0xec, 0xbb, 0x6d, 0x54, 0xb4, 0xeb, 0x09, 0x8d, 0x87, 0xb4,
-	0x8d, 0xf2, 0x53, 0x42, 0xc6, 0x0f, 0x73, 0xa0, 0x6f, 0x0b, 0xee, 0xd3, 0x36, 0x77, 0xfb, 0x22,
-	0x5c, 0x0b, 0xa3, 0x80, 0xf9, 0x11, 0x12, 0x1d, 0x96, 0x02, 0x3a, 0xf0, 0x18, 0xb5, 0x75, 0xad,
-	0xa1, 0x6d, 0x2e, 0x5b, 0x89, 0x48, 0xee, 0xc1, 0x52, 0x17, 0xa3, 0x88, 0x3a, 0xa8, 0xcf, 0x35,
-	0xb4, 0xcd, 0x72, 0x6b, 0xcd, 0x4c, 0x43, 0xdb, 0x95, 0x80, 0x95, 0x30, 0xc8, 0x67, 0xb0, 0x6a,
-	0xb3, 0x53, 0xdf, 0x73, 0xfd, 0x93, 0x43, 0x16, 0xc4, 0x1e, 0xf4, 0xb2, 0x78, 0xa8, 0x6a, 0xaa,
-	0x74, 0xb7, 0x15, 0xfc, 0x85, 0x40, 0xad, 0x8a, 0x3d, 0x24, 0x93, 0x5d, 0x58, 0xa7, 0x69, 0x74,
-	0x87, 0x5d, 0xe4, 0xd4, 0xa6, 0x9c, 0xea, 0xff, 0x17, 0x46, 0x6e, 0x64, 0x9e, 0xb3, 0x14, 0x76,
-	0x15, 0xc7, 0x22, 0x74, 0x4c, 0x47, 0x0c, 0x58, 0x14, 0x25, 0xd0, 0x6f, 0x0a, 0x03, 0xcb, 0xa6,
-	0x2c, 0xc8, 0x7e, 0xfc, 0x69, 0x49, 0xc8, 0x58, 0x85, 0x95, 0x3d, 0x4e, 0x79, 0x2f, 0xb2, 0xf0,
-	0xeb, 0x1e, 0x46, 0xdc, 0xf8, 0x5d, 0x83, 0x82, 0xd4, 0x90, 0x4d, 0x28, 0x44, 0x83, 0x88, 0x63,
-	0x57, 0x54, 0xa5, 0xdc, 0xba, 0x62, 0xc6, 0xe7, 0xb9, 0x27, 0x54, 0x31, 0x25, 0xb2, 0x14, 0x4e,
-	0x3e, 0x82, 0x52, 0x9b, 0x75, 0x03, 0xe6, 0xa3, 0xcf, 0x55, 0xa1, 0xd6, 0x05, 0xf9, 0x79, 0xa2,
-	0x95, 0xfc, 0x8c, 0x45, 0x0c, 0x28, 0xf4, 0x82, 0x38, 0x77, 0x55, 0x23, 0x10, 0x7c, 0x8b, 0x72,
-	0x8c, 0x2c, 0x85, 0x90, 0x3b, 0x50, 0x4c, 0x2a, 0xa4, 0x2f, 0x8f, 0xb1, 0x52, 0x8c, 0xdc, 0x87,
-	0x72, 0x96, 0x7e, 0xa4, 0xaf, 0x8c, 0x51, 0xf3, 0xb0, 0x61, 0xc2, 0xb5, 0xa7, 0x41, 0xe0, 0xb9,
-	0x6d, 0x21, 0xef, 0xd8, 0xe8, 0x73, 0xf7, 0xd8, 0xc5, 0x90, 0x5c, 0x83, 0x02, 0x0d, 0x82, 0x43,
-	0x57, 0x76, 0x41, 0xc9, 0x5a, 0xa4, 0x41, 0xb0, 0x63, 0x1b, 0xdf, 0x6b, 0x50, 0xce, 0x3d, 0x30,
-	0x85, 0x16, 0x37, 0x91, 0x8d, 0x6d, 0x66, 0x63, 0x28, 0x2a, 0x50, 0xb2, 0x12, 0x91, 0xdc, 0x88,
-	0xab, 0xe3, 0xf7, 0x31, 0xe4, 0x18, 0xea, 0xf3, 0x02, 0xcb, 0x14, 0x31, 0xda, 0xa7, 0x9e, 0x6b,
-	0x53, 0xce, 0x42, 0x7d, 0x41, 0xa2, 0xa9, 0x22, 0xb6, 0x8a, 0xbe, 0xb4, 0xba, 0x28, 0xad, 0x2a,
-	0xd1, 0x78, 0x02, 0x57, 0x64, 0x43, 0x5f, 0x98, 0x41, 0xac, 0xb6, 0xb1, 0x1f, 0xab, 0x65, 0x64,
-	0x8b, 0x36, 0xf6, 0x77, 0x6c, 0xe3, 0x6f, 0x0d, 0x0a, 0xd2, 0xc4, 0x6c, 0x0f, 0x92, 0x07, 0x50,
-	0x51, 0xf3, 0x77, 0x28, 0xe7, 0x4f, 0x64, 0x55, 0x6e, 0xad, 0x9a, 0x4a, 0x6d, 0x4a, 0xb3, 0xaf,
-	0xff, 0x67, 0xad, 0x28, 0x8d, 0xf2, 0x53, 0x83, 0xa2, 0x47, 0xb9, 0xcb, 0x7b, 0x36, 0xea, 0xd0,
-	0xd0, 0x36, 0xe7, 0xac, 0x54, 0x8e, 0x0b, 0xe1, 0x31, 0xdf, 0x91, 0x60, 0x59, 0x80, 0x99, 0x22,
-	0x7e, 0x92, 0x7a, 0xea, 0xc9, 0xb8, 0x17, 0x16, 0xad, 0x54, 0x26, 0x0d, 0x28, 0xdb, 0x18, 0xb5,
-	0x43, 0x57, 0x0e, 0xdd, 0x55, 0x11, 0x6b, 0x5e, 0xf5, 0xac, 0x28, 0x12, 0x71, 0xdb, 0x68, 0x7c,
-	0x0a, 0x20, 0x63, 0x79, 0xe3, 0x46, 0x9c, 0xdc, 0x8d, 0x0f, 0x2d, 0x96, 0x22, 0x5d, 0x6b, 0xcc,
-	0x8b, 0x14, 0x92, 0x75, 0x28, 0x59, 0x56, 0x82, 0x1b, 0xef, 0x35, 0x20, 0xdb, 0xe1, 0x20, 0x19,
-	0x61, 0x35, 0xfd, 0xe7, 0xec, 0x8e, 0x2a, 0x14, 0x8e, 0x5d, 0xf4, 0xec, 0x48, 0x15, 0x4f, 0x49,
-	0xe4, 0x0e, 0xcc, 0xd3, 0x20, 0x50, 0x25, 0xbb, 0x9a, 0xfa, 0xcb, 0xb5, 0x98, 0x15, 0x13, 0x08,
-	0x81, 0x85, 0x80, 0x85, 0x5c, 0xf4, 0xc4, 0x8a, 0x25, 0x7e, 0x1b, 0x1d, 0xb8, 0xb2, 0x1d, 0x0e,
-	0xbe, 0x0c, 0x2e, 0x17, 0x81, 0xf2, 0x34, 0x77, 0x59, 0x4f, 0xf3, 0x39, 0x4f, 0x1c, 0xaa, 0x7b,
-	0x6e, 0xb7, 0xe7, 0x51, 0x8e, 0xf6, 0xb0, 0xbf, 0xd9, 0x7a, 0x25, 0x17, 0xdd, 0xfc, 0x70, 0x74,
-	0x93, 0xf2, 0x7b, 0x0c, 0xc5, 0x37, 0xcc, 0x79, 0xe1, 0xf3, 0x70, 0x10, 0x9f, 0xf8, 0x71, 0xcf,
-	0x6f, 0x8b, 0x23, 0x95, 0x9e, 0x52, 0x79, 0xa8, 0xb6, 0xf3, 0x59, 0x6d, 0x8d, 0x6f, 0x35, 0x58,
-	0x4d, 0x0b, 0x64, 0x61, 0xd4, 0xf3, 0xf8, 0xbf, 0x38, 0xa1, 0xab, 0xb0, 0x28, 0x26, 0x50, 0x44,
-	0x5c, 0xb4, 0xa4, 0x40, 0x6e, 0xc3, 0x82, 0xc7, 0x9c, 0x48, 0x5f, 0x10, 0x8d, 0xb2, 0x96, 0x96,
-	0x33, 0x09, 0xd8, 0x12, 0xb0, 0xb1, 0x0f, 0x6b, 0xb9, 0x36, 0xb9, 0x30, 0x86, 0xc4, 0xea, 0xdc,
-	0xb9, 0x56, 0x5b, 0x3f, 0x6b, 0xb0, 0xf4, 0x5a, 0x42, 0xe4, 0x2b, 0x58, 0xcf, 0x6e, 0x80, 0xe7,
-	0x1d, 0xea, 0x79, 0xe8, 0x3b, 0x48, 0x8c, 0xe4, 0x96, 0x99, 0x00, 0xaa, 0xed, 0x5e, 0xbb, 0x75,
-	0x2e, 0x47, 0x5d, 0x87, 0x07, 0x50, 0x54, 0x30, 0x92, 0x7b, 0xe9, 0xd5, 0x85, 0x76, 0x4f, 0xb6,
-	0x0d, 0xda, 0xe3, 0x17, 0xa9, 0xb4, 0xfe, 0xc1, 0xc8, 0xf0, 0x8c, 0x5f, 0xb5, 0xad, 0x3f, 0x4b,
-	0x40, 0x72, 0xfd, 0xb7, 0x4b, 0x7d, 0xea, 0x60, 0x48, 0x1c, 0x58, 0xb7, 0xd0, 0x71, 0x23, 0x8e,
-	0x61, 0x7e, 0xd5, 0xd6, 0x27, 0xf5, 0x6c, 0xb6, 0xef, 0x6a, 0x55, 0x53, 0xbe, 0x87, 0x98, 0xc9,
-	0x4b, 0x8a, 0xf9, 0x22, 0x7e, 0x49, 0x31, 0xf4, 0xf7, 0xbf, 0xfd, 0xf5, 0xdd, 0x1c, 0x31, 0x56,
-	0x9a, 0x34, 0x7b, 0x2e, 0x7a, 0xa4, 0x6d, 0x91, 0x63, 0xa8, 0xbc, 0x42, 0x3e, 0x8b, 0x8f, 0x89,
-	0x73, 0x63, 0xd4, 0x85, 0x07, 0x9d, 0x54, 0x87, 0x3c, 0x34, 0xdf, 0xca, 0xc9, 0x78, 0x47, 0xbe,
-	0x81, 0xca, 0xde, 0xb0, 0x9f, 0x89, 0x76, 0xa6, 0x66, 0xf0, 0x58, 0xd8, 0x7f, 0x60, 0x4c, 0xb1,
-	0xff, 0x48, 0xdb, 0x3a, 0xd8, 0xa8, 0x4d, 0x07, 0xc9, 0x09, 0xac, 0x6d, 0xa3, 0x87, 0x1c, 0xff,
-	0x8b, 0x72, 0xaa, 0x64, 0xb7, 0xa6, 0x25, 0xdb, 0x81, 0xd2, 0x2b, 0xe4, 0x6a, 0xc5, 0x5f, 0x1f,
-	0x69, 0x82, 0x9c, 0xfd, 0xd1, 0xe5, 0x6a, 0x34, 0x85, 0xe1, 0xbb, 0xe4, 0xc3, 0xc9, 0x86, 0xd5,
-	0xdb, 0x5d, 0xd4, 0x7c, 0x2b, 0x37, 0xcb, 0x3b, 0x72, 0xa6, 0x41, 0x69, 0x2f, 0x75, 0x35, 0x6a,
-	0x6f, 0x6a, 0x02, 0x3f, 0x69, 0xc2, 0xd1, 0x8f, 0x9a, 0x71, 0x59, 0x4f, 0x71, 0x81, 0xef, 0xd7,
-	0x66, 0x61, 0xdf, 0x32, 0xea, 0xe7, 0xb3, 0x05, 0xa9, 0x76, 0x31, 0x89, 0x84, 0xb0, 0x2c, 0xcf,
-	0xee, 0xe2, 0x8a, 0x4e, 0x4b, 0x58, 0x15, 0x76, 0xeb, 0xd2, 0x85, 0x3d, 0x05, 0x3d, 0x3d, 0xc2,
-	0xe8, 0x25, 0x9b, 0x69, 0x0a, 0xd7, 0x47, 0xe2, 0x8b, 0x6f, 0x56, 0xe3, 0x8e, 0x88, 0xa0, 0x41,
-	0x2e, 0xc8, 0x97, 0xbc, 0x84, 0x72, 0x6e, 0x5d, 0x92, 0x8d, 0xcc, 0xd6, 0xd8, 0x5d, 0x5b, 0xab,
-	0x4d, 0x02, 0xd5, 0x86, 0x7d, 0x02, 0xa5, 0x74, 0xf1, 0xe7, 0x2b, 0x36, 0x72, 0x5b, 0xd6, 0xf4,
-	0x71, 0x48, 0x59, 0xd8, 0x81, 0x4a, 0x72, 0xe3, 0x29, 0x33, 0x37, 0x53, 0xee, 0xe4, 0xab, 0x70,
-	0x5a, 0xf9, 0x5b, 0x2f, 0xa1, 0xa2, 0x96, 0x75, 0xb2, 0xe0, 0x3e, 0x11, 0x23, 0xa2, 0x5e, 0xac,
-	0xab, 0x99, 0xdd, 0xfc, 0xbb, 0x77, 0x6e, 0x3e, 0xa4, 0xfe, 0xd9, 0xc3, 0x5f, 0xce, 0xea, 0xda,
-	0xaf, 0x67, 0x75, 0xed, 0x8f, 0xb3, 0xba, 0x76, 0x70, 0x6f, 0x86, 0x7f, 0x75, 0x47, 0x05, 0x11,
-	0xd2, 0xc7, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xb0, 0x50, 0xc3, 0x0b, 0x0e, 0x00, 0x00,
+	// 1947 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdd, 0x6e, 0xdb, 0xc8,
+	0xf5, 0xff, 0x53, 0xb2, 0x64, 0xe9, 0xc8, 0x92, 0xa2, 0x89, 0xe3, 0xe5, 0x2a, 0x89, 0xe3, 0x70,
+	0x91, 0xac, 0x37, 0x09, 0xa4, 0xac, 0xff, 0x05, 0xba, 0x09, 0x8a, 0xed, 0x3a, 0x71, 0x3e, 0x8c,
+	0x8d, 0xd3, 0x80, 0x4e, 0x6f, 0x72, 0x51, 0x61, 0x42, 0x1e, 0xcb, 0x84, 0x29, 0x92, 0xcb, 0x19,
+	0x3a, 0x31, 0x16, 0x29, 0x8a, 0x7d, 0x85, 0xa2, 0xf7, 0x05, 0xba, 0x17, 0x05, 0xda, 0xeb, 0xbe,
+	0x41, 0x81, 0x5e, 0x16, 0xe8, 0x03, 0xb4, 0x08, 0xfa, 0x04, 0x7d, 0x82, 0x62, 0xbe, 0x28, 0xea,
+	0xcb, 0x8e, 0x83, 0xde, 0xd8, 0x3c, 0x1f, 0x73, 0x3e, 0x7e, 0x73, 0xce, 0x99, 0x19, 0xc1, 0xbd,
+	0x61, 0xc0, 0x0f, 0xb3, 0xd7, 0x3d, 0x2f, 0x1e, 0xf5, 0x5f, 0x1e, 0xe2, 0xcb, 0xc3, 0x20, 0x1a,
+	0xb2, 0xe7, 0xc8, 0xdf, 0xc4, 0xe9, 0x51, 0x9f, 0xf3, 0xa8, 0x4f, 0x93, 0xa0, 0x7f, 0x48, 0x23,
+	0x3f, 0xc4, 0xd4, 0xfc, 0xef, 0x25, 0x69, 0xcc, 0x63, 0xb2, 0xac, 0xc9, 0xee, 0xe5, 0x61, 0x1c,
+	0x0f, 0x43, 0xec, 0x4b, 0xf6, 0xeb, 0xec, 0xa0, 0x8f, 0xa3, 0x84, 0x9f, 0x28, 0xad, 0xee, 0x15,
+	0x2d, 0x14, 0x76, 0x68, 0x14, 0xc5, 0x9c, 0xf2, 0x20, 0x8e, 0x98, 0x96, 0x76, 0x8c, 0x0b, 0x9a,
+	0x04, 0x9a, 0x75, 0xd9, 0xb0, 0x5e, 0xa7, 0xf1, 0x11, 0xa6, 0xfa, 0x9f, 0x16, 0x5e, 0x33, 0x42,
+	0x49, 0x7a, 0x71, 0x98, 0x7f, 0x68, 0x85, 0x1b, 0x33, 0x0a, 0x61, 0x9c, 0xd2, 0x37, 0x34, 0xea,
+	0xfb, 0x78, 0x1c, 0x78, 0xa8, 0xd5, 0x3e, 0x35, 0x6a, 0x3c, 0xa5, 0x1e, 0xaa, 0xbf, 0x4a, 0xe4,
+	0xfc, 0xae, 0x04, 0xf6, 0x8e, 0xd4, 0xdd, 0xf6, 0x78, 0x70, 0x2c, 0xc3, 0x75, 0x91, 0x25, 0x71,
+	0xc4, 0x90, 0xd8, 0xb0, 0x9c, 0xd0, 0x93, 0x30, 0xa6, 0xbe, 0x6d, 0x6d, 0x58, 0x9b, 0x2b, 0xae,
+	0x21, 0xc9, 0x6d, 0x58, 0x1e, 0x21, 0x63, 0x74, 0x88, 0x76, 0x69, 0xc3, 0xda, 0x6c, 0x6c, 0x75,
+	0x7a, 0x79, 0x68, 0x7b, 0x4a, 0xe0, 0x1a, 0x0d, 0xf2, 0x73, 0x68, 0xfb, 0xf1, 0x9b, 0x28, 0x0c,
+	0xa2, 0xa3, 0x41, 0x9c, 0x08, 0x0f, 0x76, 0x43, 0x2e, 0x5a, 0xeb, 0xe9, 0x74, 0x77, 0xb4, 0xf8,
+	0x17, 0x52, 0xea, 0xb6, 0xfc, 0x09, 0x9a, 0xec, 0xc1, 0x45, 0x9a, 0x47, 0x37, 0x18, 0x21, 0xa7,
+	0x3e, 0xe5, 0xd4, 0xfe, 0x44, 0x1a, 0xb9, 0x32, 0xf6, 0x3c, 0x4e, 0x61, 0x4f, 0xeb, 0xb8, 0x84,
+	0xce, 0xf0, 0x88, 0x03, 0x15, 0x09, 0x81, 0x7d, 0x4d, 0x1a, 0x58, 0xe9, 0x49, 0xaa, 0xf7, 0x52,
+	0xfc, 0x75, 0x95, 0xc8, 0x69, 0x43, 0x73, 0x9f, 0x53, 0x9e, 0x31, 0x17, 0xbf, 0xcb, 0x90, 0x71,
+	0xe7, 0x9f, 0x25, 0xa8, 0x2a, 0x0e, 0xd9, 0x84, 0x2a, 0x3b, 0x61, 0x1c, 0x47, 0x12, 0x95, 0xc6,
+	0xd6, 0x85, 0x9e, 0xd8, 0xcf, 0x7d, 0xc9, 0x12, 0x2a, 0xcc, 0xd5, 0x72, 0xf2, 0x25, 0xd4, 0xbd,
+	0x78, 0x94, 0xc4, 0x11, 0x46, 0x5c, 0x03, 0x75, 0x51, 0x2a, 0x3f, 0x34, 0x5c, 0xa5, 0x3f, 0xd6,
+	0x22, 0x0e, 0x54, 0xb3, 0x44, 0xe4, 0xae, 0x31, 0x02, 0xa9, 0xef, 0x52, 0x8e, 0xcc, 0xd5, 0x12,
+	0x72, 0x13, 0x6a, 0x06, 0x21, 0x7b, 0x65, 0x46, 0x2b, 0x97, 0x91, 0x3b, 0xd0, 0x18, 0xa7, 0xcf,
+	0xec, 0xe6, 0x8c, 0x6a, 0x51, 0x4c, 0xbe, 0x84, 0xd6, 0x77, 0x59, 0xcc, 0xe9, 0x00, 0xdf, 0x7a,
+	0x88, 0x3e, 0xfa, 0x76, 0x6b, 0x66, 0x41, 0x53, 0x6a, 0x3c, 0xd2, 0x0a, 0xe4, 0x5b, 0xe8, 0xe8,
+	0x8a, 0x18, 0x1c, 0x64, 0x91, 0xa7, 0xdc, 0xb4, 0x37, 0xca, 0x9b, 0x8d, 0xad, 0xf5, 0x9e, 0xe9,
+	0x9f, 0x17, 0x4a, 0xe3, 0xb1, 0x56, 0xd0, 0xb0, 0x5e, 0x48, 0x26, 0xd9, 0xcc, 0xf9, 0xbd, 0x05,
+	0x97, 0xe6, 0xea, 0x92, 0x4b, 0x50, 0xa5, 0x49, 0x32, 0x08, 0x54, 0x19, 0xd6, 0xdd, 0x0a, 0x4d,
+	0x92, 0x5d, 0x9f, 0xdc, 0x81, 0x9a, 0x9f, 0xa5, 0x32, 0x7a, 0xbb, 0x54, 0xd8, 0x89, 0x17, 0x98,
+	0x7a, 0x18, 0xf1, 0x20, 0x94, 0x60, 0x68, 0x0d, 0xb2, 0x0e, 0x4b, 0x69, 0x16, 0x31, 0xbb, 0x3c,
+	0x93, 0x94, 0xe4, 0x0b, 0xe0, 0x31, 0x4d, 0xe3, 0x94, 0xd9, 0x4b, 0xb3, 0xc0, 0x2b, 0x89, 0xd3,
+	0x83, 0x4b, 0xdb, 0x49, 0x12, 0x06, 0x9e, 0x34, 0xb9, 0xeb, 0x0b, 0x2f, 0x07, 0x01, 0xa6, 0x0b,
+	0x22, 0x74, 0xfe, 0x6c, 0x41, 0xa3, 0xb0, 0x60, 0x51, 0x22, 0x36, 0x2c, 0xfb, 0xe8, 0xc5, 0x3e,
+	0xa6, 0x32, 0x8f, 0xba, 0x6b, 0x48, 0x72, 0x45, 0x14, 0x50, 0x74, 0x8c, 0x29, 0xc7, 0x54, 0x46,
+	0x5e, 0x77, 0xc7, 0x0c, 0x21, 0x3d, 0xa6, 0x61, 0xe0, 0x53, 0x1e, 0xa7, 0x32, 0xea, 0xba, 0x3b,
+	0x66, 0x08, 0xab, 0x18, 0x29, 0xab, 0x15, 0x65, 0x55, 0x93, 0x64, 0x0d, 0xaa, 0x18, 0x0d, 0x83,
+	0x08, 0xed, 0xaa, 0x14, 0x68, 0xca, 0xf9, 0x06, 0x2e, 0xa8, 0x59, 0x70, 0x66, 0x66, 0x82, 0xed,
+	0xe3, 0xb1, 0x60, 0xab, 0x88, 0x2b, 0x3e, 0x1e, 0xef, 0xfa, 0xce, 0x7f, 0x2c, 0xa8, 0x2a, 0x13,
+	0xe7, 0x5b, 0x48, 0xbe, 0x82, 0x96, 0x1e, 0x5d, 0x03, 0x35, 0xba, 0xf4, 0x3e, 0xb5, 0x7b, 0x9a,
+	0xdd, 0x53, 0x66, 0x9f, 0xfe, 0x9f, 0xdb, 0xd4, 0x1c, 0xed, 0xa7, 0x0b, 0xb5, 0x90, 0xf2, 0x80,
+	0x67, 0x3e, 0xda, 0xb0, 0x61, 0x6d, 0x96, 0xdc, 0x9c, 0x16, 0x00, 0x85, 0x71, 0x34, 0x54, 0xc2,
+	0x86, 0x14, 0x8e, 0x19, 0x62, 0x25, 0x0d, 0xf5, 0x4a, 0xd1, 0x46, 0x15, 0x37, 0xa7, 0xc9, 0x06,
+	0x34, 0x7c, 0x64, 0x5e, 0x1a, 0xa8, 0x79, 0xb5, 0x2a, 0x63, 0x2d, 0xb2, 0x1e, 0xd4, 0x64, 0x22,
+	0x81, 0x87, 0xce, 0x4f, 0x01, 0x54, 0x2c, 0xcf, 0x02, 0xc6, 0xc9, 0x17, 0x62, 0x33, 0x05, 0xc5,
+	0x6c, 0x4b, 0x76, 0x42, 0x3b, 0xef, 0x04, 0xa5, 0xe5, 0x1a, 0xb9, 0xf3, 0x83, 0x05, 0x64, 0x27,
+	0x3d, 0x31, 0xd3, 0x4f, 0x0f, 0xce, 0x53, 0xc6, 0xee, 0x1a, 0x54, 0x0f, 0x02, 0x0c, 0x7d, 0xa6,
+	0xc1, 0xd3, 0x14, 0xb9, 0x09, 0x65, 0x9a, 0x24, 0x1a, 0xb2, 0xd5, 0xdc, 0x5f, 0xa1, 0xf4, 0x5c,
+	0xa1, 0x40, 0x08, 0x2c, 0x25, 0x71, 0xca, 0x65, 0xad, 0x34, 0x5d, 0xf9, 0xed, 0x1c, 0xc2, 0x85,
+	0x9d, 0xf4, 0xe4, 0x97, 0xc9, 0x87, 0x45, 0xa0, 0x3d, 0x95, 0x3e, 0xd4, 0x53, 0xb9, 0xe0, 0x89,
+	0xc3, 0xda, 0x7e, 0x30, 0xca, 0x42, 0xca, 0xd1, 0x9f, 0xf4, 0x77, 0xbe, 0x5a, 0x29, 0x44, 0x57,
+	0x9e, 0x8c, 0x6e, 0x5e, 0x7e, 0x5f, 0x43, 0xed, 0x59, 0x3c, 0x7c, 0x14, 0xf1, 0xf4, 0x44, 0xec,
+	0xb8, 0x99, 0x53, 0xda, 0x53, 0x4e, 0x4f, 0x60, 0x5b, 0x1e, 0x63, 0xeb, 0xfc, 0xc6, 0x82, 0x76,
+	0x0e, 0x90, 0x8b, 0x2c, 0x0b, 0xf9, 0x47, 0xec, 0xd0, 0x2a, 0x54, 0x64, 0x67, 0xca, 0x88, 0x6b,
+	0xae, 0x22, 0xc8, 0x0d, 0x58, 0x0a, 0xe3, 0xa1, 0x98, 0x38, 0x65, 0x79, 0x86, 0x1a, 0x38, 0x4d,
+	0xc0, 0xae, 0x14, 0x3b, 0x2f, 0xa1, 0x53, 0x28, 0x93, 0x33, 0x63, 0x30, 0x56, 0x4b, 0xa7, 0x5b,
+	0x3d, 0x82, 0xf6, 0x76, 0xe6, 0x07, 0xfc, 0x59, 0x3c, 0xd4, 0x87, 0xdc, 0x39, 0xf7, 0x61, 0x15,
+	0x2a, 0x2c, 0x88, 0x74, 0xab, 0x96, 0x5d, 0x45, 0x08, 0x6e, 0x18, 0x8c, 0x02, 0xb3, 0x09, 0x8a,
+	0x70, 0xee, 0x41, 0xd3, 0x38, 0x7b, 0x2c, 0x10, 0x11, 0x5b, 0x15, 0xd1, 0x11, 0x6a, 0x47, 0xf2,
+	0x5b, 0x83, 0x94, 0xa1, 0x71, 0x23, 0x09, 0xe7, 0x0f, 0xa5, 0xf1, 0x5a, 0xb5, 0x8d, 0x04, 0x96,
+	0x78, 0xa0, 0xd7, 0x96, 0x5d, 0xf9, 0x4d, 0xae, 0xc3, 0x0a, 0xc3, 0x54, 0xf4, 0xd5, 0x40, 0xda,
+	0x55, 0x26, 0x1a, 0x9a, 0xf7, 0x5c, 0x98, 0xbf, 0x0a, 0x60, 0x54, 0xf4, 0x46, 0xd4, 0xdd, 0xba,
+	0xe6, 0xa8, 0x74, 0xa8, 0x37, 0x9e, 0xa4, 0x8a, 0x10, 0x1b, 0x1a, 0x30, 0x96, 0xe5, 0x43, 0x54,
+	0x53, 0x82, 0x4f, 0x55, 0x21, 0xe9, 0x19, 0xaa, 0xa8, 0x02, 0x84, 0xcb, 0xf3, 0x21, 0xac, 0x15,
+	0x21, 0x5c, 0x83, 0x2a, 0xa7, 0xe9, 0x10, 0xb9, 0x5d, 0x57, 0x56, 0x14, 0x45, 0xee, 0xc2, 0xb2,
+	0x77, 0x48, 0xa3, 0x21, 0xfa, 0x36, 0xc8, 0x5d, 0x5c, 0x1b, 0xb7, 0x5a, 0x11, 0x46, 0xd7, 0xa8,
+	0x39, 0x3f, 0x83, 0x9a, 0x91, 0x88, 0xd5, 0x18, 0xf1, 0x34, 0xc8, 0x47, 0xd0, 0xec, 0x6a, 0x55,
+	0x08, 0x46, 0xcd, 0xf9, 0xa3, 0x05, 0xab, 0x0f, 0xb2, 0xf0, 0x68, 0x1f, 0xb9, 0x1a, 0x52, 0x4c,
+	0x57, 0xd9, 0x2a, 0x54, 0x82, 0xc8, 0xc7, 0xb7, 0x12, 0xeb, 0xa6, 0xab, 0x88, 0x42, 0x92, 0xa5,
+	0xf9, 0x49, 0x96, 0xa7, 0xfa, 0xd5, 0x4b, 0x51, 0x74, 0xbd, 0x84, 0xb6, 0xe6, 0x1a, 0x52, 0xcc,
+	0xe7, 0x2c, 0x32, 0x89, 0x56, 0xa4, 0x6c, 0xcc, 0x10, 0xbe, 0xe5, 0xb9, 0xab, 0x11, 0x56, 0x84,
+	0xf3, 0x02, 0xec, 0xc2, 0xb4, 0x79, 0xf4, 0x56, 0x34, 0xf9, 0x19, 0xf5, 0x7b, 0x15, 0x40, 0xe7,
+	0x3f, 0x0e, 0xb9, 0xae, 0x39, 0xbb, 0xbe, 0xf3, 0x0e, 0x3a, 0x33, 0x16, 0x3f, 0xce, 0x94, 0x6c,
+	0x09, 0x4e, 0x39, 0xea, 0xc1, 0xa4, 0x08, 0x91, 0x26, 0x0b, 0x86, 0x11, 0xe5, 0x59, 0x8a, 0xe6,
+	0x9c, 0xce, 0x19, 0xce, 0x8f, 0x25, 0x68, 0x29, 0xd0, 0xf7, 0x23, 0x9a, 0xb0, 0xc3, 0x58, 0xf6,
+	0xf6, 0x31, 0xa6, 0xcc, 0x8c, 0xa9, 0xa6, 0x6b, 0xc8, 0xbc, 0xf4, 0x4b, 0x85, 0xd2, 0xff, 0xdc,
+	0x9c, 0x44, 0xf9, 0x99, 0x39, 0x75, 0xe0, 0x68, 0x31, 0xe9, 0x41, 0x7d, 0x44, 0xbd, 0x81, 0x8a,
+	0x70, 0x49, 0xdf, 0xdb, 0xcd, 0xf9, 0xba, 0xb7, 0xfd, 0x50, 0x5c, 0xb7, 0xd0, 0xad, 0x8d, 0xa8,
+	0x27, 0xbf, 0xc8, 0x4d, 0x68, 0x67, 0x0c, 0x7d, 0x71, 0x22, 0x0f, 0xa2, 0x38, 0x12, 0x47, 0x5a,
+	0x65, 0xa3, 0xbc, 0xb9, 0xe2, 0x36, 0x05, 0x7b, 0x07, 0x8f, 0x9f, 0x4b, 0x66, 0xae, 0x27, 0x00,
+	0xd3, 0x7a, 0xd5, 0xb1, 0xde, 0x76, 0x92, 0x68, 0xbd, 0x55, 0xa8, 0x1c, 0xc4, 0xa9, 0x87, 0xb2,
+	0x35, 0x6a, 0xae, 0x22, 0xc8, 0x0d, 0x68, 0x61, 0xe4, 0xa5, 0x27, 0x09, 0x47, 0x7f, 0x70, 0x84,
+	0x27, 0x4c, 0xb7, 0x48, 0x33, 0xe7, 0x7e, 0x8b, 0x27, 0x6c, 0xeb, 0xaf, 0x16, 0x2c, 0x3f, 0x55,
+	0x79, 0x91, 0x5f, 0xc1, 0xc5, 0xf1, 0x5d, 0xff, 0xe1, 0x21, 0x0d, 0x43, 0x8c, 0x86, 0x48, 0x1c,
+	0xf3, 0x9e, 0x98, 0x23, 0xd4, 0x25, 0xd2, 0xfd, 0xec, 0x54, 0x1d, 0xfd, 0xf0, 0x79, 0x05, 0x35,
+	0x2d, 0x46, 0x72, 0x3b, 0x7f, 0xa4, 0xa0, 0x9f, 0xa9, 0x2a, 0x41, 0x7f, 0xf6, 0xc9, 0xa4, 0xac,
+	0x5f, 0x9f, 0x82, 0x7e, 0xf6, 0x51, 0xb5, 0xf5, 0x97, 0x36, 0x90, 0x42, 0xb9, 0xed, 0xd1, 0x88,
+	0x0e, 0x31, 0x25, 0x43, 0xb8, 0xe8, 0xe2, 0x30, 0x60, 0x1c, 0xd3, 0x82, 0x94, 0xac, 0xcf, 0x3b,
+	0x62, 0xc7, 0xd7, 0xb3, 0xee, 0x5a, 0x4f, 0xbd, 0x38, 0x7b, 0xe6, 0x39, 0xda, 0x7b, 0x24, 0x9e,
+	0xa3, 0x8e, 0xfd, 0xc3, 0x3f, 0xfe, 0xfd, 0xdb, 0x12, 0x71, 0x9a, 0x7d, 0x3a, 0x5e, 0xc7, 0xee,
+	0x5b, 0xb7, 0xc8, 0x01, 0xb4, 0x9e, 0x20, 0x3f, 0x8f, 0x8f, 0xb9, 0xc7, 0xbc, 0xb3, 0x2e, 0x3d,
+	0xd8, 0x64, 0x6d, 0xc2, 0x43, 0xff, 0x7b, 0xd5, 0x35, 0xef, 0xc8, 0xaf, 0xa1, 0xb5, 0x3f, 0xe9,
+	0x67, 0xae, 0x9d, 0x85, 0x19, 0x7c, 0x2d, 0xed, 0x7f, 0xe5, 0x2c, 0xb0, 0x7f, 0xdf, 0xba, 0xf5,
+	0xea, 0x72, 0x77, 0xb1, 0x90, 0x1c, 0x41, 0x67, 0x07, 0x43, 0xe4, 0xf8, 0xbf, 0x80, 0x53, 0x27,
+	0x7b, 0x6b, 0x51, 0xb2, 0x87, 0x50, 0x7f, 0x62, 0x46, 0x27, 0xf9, 0x74, 0xaa, 0x08, 0x0a, 0xf6,
+	0xa7, 0x5b, 0xd3, 0xe9, 0x4b, 0xc3, 0x5f, 0x90, 0xcf, 0xe7, 0x1b, 0xd6, 0xef, 0x78, 0xd6, 0xff,
+	0x5e, 0x0d, 0xd6, 0x77, 0xe4, 0xbd, 0x05, 0xf5, 0x7c, 0x4a, 0x93, 0x69, 0x7b, 0x0b, 0x13, 0xf8,
+	0x93, 0x25, 0x1d, 0xfd, 0x68, 0x39, 0x1f, 0xea, 0x49, 0x00, 0x7c, 0xa7, 0x7b, 0x1e, 0xed, 0xcf,
+	0x9c, 0xf5, 0xd3, 0xb5, 0xa5, 0x52, 0xf7, 0x6c, 0x25, 0x92, 0xc2, 0x8a, 0xda, 0xbb, 0xb3, 0x11,
+	0x5d, 0x94, 0xb0, 0x06, 0xf6, 0xd6, 0x07, 0x03, 0xfb, 0x06, 0xec, 0x7c, 0x0b, 0xd9, 0xe3, 0xf8,
+	0x5c, 0x5d, 0x78, 0x71, 0x2a, 0x3e, 0xf1, 0x10, 0x70, 0x6e, 0xca, 0x08, 0x36, 0xc8, 0x19, 0xf9,
+	0x92, 0xc7, 0xd0, 0x28, 0xdc, 0xee, 0xc8, 0xe5, 0xb1, 0xad, 0x99, 0xa7, 0x41, 0xb7, 0x3b, 0x4f,
+	0xa8, 0x8f, 0xea, 0x6f, 0xa0, 0x9e, 0xdf, 0x53, 0x8b, 0x88, 0x4d, 0x5d, 0xee, 0xbb, 0xf6, 0xac,
+	0x48, 0x5b, 0xd8, 0x85, 0x96, 0xb9, 0xa0, 0x6b, 0x33, 0xd7, 0x72, 0xdd, 0xf9, 0x37, 0xf7, 0x45,
+	0xf0, 0x93, 0x6d, 0xe8, 0xe4, 0x68, 0x9a, 0x93, 0xe5, 0xb4, 0x6d, 0x9c, 0x3d, 0x87, 0xc8, 0x53,
+	0x31, 0x11, 0xd9, 0x79, 0x8c, 0x2c, 0x0a, 0xe6, 0x3e, 0x34, 0xc4, 0xc8, 0x33, 0xd7, 0x23, 0x7b,
+	0xe6, 0x36, 0x64, 0xc6, 0x77, 0x67, 0x46, 0x42, 0x76, 0xa0, 0x35, 0x79, 0x31, 0x9a, 0xed, 0xb9,
+	0xab, 0x39, 0x63, 0xde, 0x15, 0x6a, 0xd3, 0xba, 0x6b, 0x91, 0x07, 0xd0, 0x54, 0xf7, 0x0a, 0x63,
+	0xe4, 0xac, 0x8a, 0x9a, 0x76, 0x72, 0xd7, 0x22, 0x2e, 0x74, 0x94, 0x8d, 0x62, 0x65, 0x5e, 0x9f,
+	0x67, 0x67, 0xe2, 0x52, 0xd4, 0xed, 0x2e, 0x56, 0x21, 0x4f, 0xa0, 0xb3, 0x3b, 0x9a, 0xb6, 0x79,
+	0xca, 0x82, 0x85, 0x10, 0x3f, 0x07, 0xfb, 0x61, 0x1c, 0x1d, 0x04, 0xe9, 0x68, 0xd6, 0xc9, 0xc7,
+	0xd8, 0x7b, 0x52, 0xa8, 0x9f, 0xfc, 0x5a, 0x74, 0xca, 0xd6, 0x7f, 0x32, 0x25, 0xca, 0xd7, 0x3c,
+	0x85, 0x4b, 0x2e, 0x32, 0x1e, 0xa7, 0x38, 0x25, 0x58, 0xb4, 0x62, 0x51, 0x48, 0x5b, 0x8f, 0xa1,
+	0xa5, 0xef, 0x1f, 0xe6, 0xcc, 0xfe, 0x89, 0x9c, 0xfa, 0xfa, 0x47, 0xaa, 0xf1, 0x1d, 0x7b, 0xe2,
+	0x87, 0xc3, 0x6e, 0x7b, 0x8a, 0xff, 0xe0, 0xde, 0xdf, 0xde, 0xaf, 0x5b, 0x7f, 0x7f, 0xbf, 0x6e,
+	0xfd, 0xeb, 0xfd, 0xba, 0xf5, 0xea, 0xf6, 0x39, 0x7e, 0x92, 0x7e, 0x5d, 0x95, 0x21, 0xfd, 0xff,
+	0x7f, 0x07, 0x00, 0x59, 0xd4, 0x11, 0x3e, 0xc8, 0x16, 0x00, 0x00,
 }
@@ -5,6 +5,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"os/user"
 	"sync"
@@ -127,6 +128,124 @@ func (h *ManagerClient) DeleteDevice(appID string, devID string) error {
 	return errors.Wrap(errors.FromGRPCError(err), "Could not delete device from Handler")
 }
 
+// GetDeviceMACState retrieves the MAC state of a device from the Handler
+func (h *ManagerClient) GetDeviceMACState(appID string, devID string) (*lorawan.MACState, error) {
+	res, err := h.applicationManagerClient.GetDeviceMACState(h.GetContext(), &DeviceIdentifier{AppId: appID, DevId: devID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get MAC state of device from Handler")
+	}
+	return res, nil
+}
+
+// ResetDeviceMACState resets the MAC state of a device
+func (h *ManagerClient) ResetDeviceMACState(appID string, devID string) error {
+	_, err := h.applicationManagerClient.ResetDeviceMACState(h.GetContext(), &DeviceIdentifier{AppId: appID, DevId: devID})
+	return errors.Wrap(errors.FromGRPCError(err), "Could not reset MAC state of device")
+}
+
+// GetAuditLog retrieves the audit log of an application, or of a device if a devID is given, newest first.
+// Pass a limit to indicate the maximum number of entries you want to receive.
+func (h *ManagerClient) GetAuditLog(appID string, devID string, limit int) ([]*AuditLogEntry, error) {
+	res, err := h.applicationManagerClient.GetAuditLog(h.GetContext(), &AuditLogRequest{AppId: appID, DevId: devID, Limit: uint32(limit)})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get audit log from Handler")
+	}
+	return res.Entries, nil
+}
+
+// BulkSetDevices creates or updates the devices on the Handler, and returns the result for each device, in the same
+// order. Devices that could not be set have an error in their result, but don't stop the other devices from being set.
+func (h *ManagerClient) BulkSetDevices(devices []*Device) ([]*BulkSetDevicesResult, error) {
+	stream, err := h.applicationManagerClient.BulkSetDevices(h.GetContext())
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not set devices on Handler")
+	}
+	sendErr := make(chan error, 1)
+	go func() {
+		for _, dev := range devices {
+			if err := stream.Send(dev); err != nil {
+				sendErr <- err
+				return
+			}
+		}
+		sendErr <- stream.CloseSend()
+	}()
+	results := make([]*BulkSetDevicesResult, 0, len(devices))
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return results, errors.Wrap(errors.FromGRPCError(err), "Could not set devices on Handler")
+		}
+		results = append(results, res)
+	}
+	if err := <-sendErr; err != nil && err != io.EOF {
+		return results, errors.Wrap(errors.FromGRPCError(err), "Could not send devices to Handler")
+	}
+	return results, nil
+}
+
+// ExportDevices retrieves all devices of an application from the Handler, including their keys
+func (h *ManagerClient) ExportDevices(appID string) (devices []*Device, err error) {
+	stream, err := h.applicationManagerClient.ExportDevices(h.GetContext(), &ApplicationIdentifier{AppId: appID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not export devices from Handler")
+	}
+	for {
+		dev, err := stream.Recv()
+		if err == io.EOF {
+			return devices, nil
+		}
+		if err != nil {
+			return devices, errors.Wrap(errors.FromGRPCError(err), "Could not export devices from Handler")
+		}
+		devices = append(devices, dev)
+	}
+}
+
+// ExportApplication retrieves the signed state of an application from the Handler, for importing it into the Handler
+// with the given ID
+func (h *ManagerClient) ExportApplication(appID, handlerID string) (*ApplicationExport, error) {
+	res, err := h.applicationManagerClient.ExportApplication(h.GetContext(), &ApplicationExportRequest{AppId: appID, HandlerId: handlerID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not export application from Handler")
+	}
+	return res, nil
+}
+
+// ImportApplication imports an application that was exported from another Handler, and returns the state signed by
+// this Handler for confirming the export
+func (h *ManagerClient) ImportApplication(export *ApplicationExport) (*ApplicationExport, error) {
+	res, err := h.applicationManagerClient.ImportApplication(h.GetContext(), export)
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not import application into Handler")
+	}
+	return res, nil
+}
+
+// ConfirmApplicationExport confirms the export of an application with the state signed by the Handler that imported it
+func (h *ManagerClient) ConfirmApplicationExport(receipt *ApplicationExport) error {
+	_, err := h.applicationManagerClient.ConfirmApplicationExport(h.GetContext(), receipt)
+	return errors.Wrap(errors.FromGRPCError(err), "Could not confirm application export to Handler")
+}
+
+// GetDeviceSnapshot retrieves a snapshot of the session state of a device from the Handler
+func (h *ManagerClient) GetDeviceSnapshot(appID string, devID string) (*DeviceSnapshot, error) {
+	res, err := h.applicationManagerClient.GetDeviceSnapshot(h.GetContext(), &DeviceIdentifier{AppId: appID, DevId: devID})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not get device snapshot from Handler")
+	}
+	return res, nil
+}
+
+// RestoreDeviceSnapshot restores the session state of a device from a snapshot
+func (h *ManagerClient) RestoreDeviceSnapshot(snapshot *DeviceSnapshot) error {
+	_, err := h.applicationManagerClient.RestoreDeviceSnapshot(h.GetContext(), snapshot)
+	return errors.Wrap(errors.FromGRPCError(err), "Could not restore device snapshot on Handler")
+}
+
 // GetDevicesForApplication retrieves all devices for an application from the Handler.
 // Pass a limit to indicate the maximum number of results you want to receive, and the offset to indicate how many results should be skipped.
 func (h *ManagerClient) GetDevicesForApplication(appID string, limit, offset int) (devices []*Device, err error) {
@@ -83,3 +83,71 @@ func (m *SimulatedUplinkMessage) Validate() error {
 	}
 	return nil
 }
+
+// Validate implements the api.Validator interface
+func (m *AuditLogRequest) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if m.DevId != "" {
+		if err := api.NotEmptyAndValidID(m.DevId, "DevId"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *ApplicationExportRequest) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.HandlerId, "HandlerId"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *ApplicationExport) Validate() error {
+	if err := api.NotEmptyAndValidID(m.AppId, "AppId"); err != nil {
+		return err
+	}
+	if err := api.NotEmptyAndValidID(m.HandlerId, "HandlerId"); err != nil {
+		return err
+	}
+	if len(m.State) == 0 {
+		return errors.NewErrInvalidArgument("State", "can not be empty")
+	}
+	if m.Signature == "" {
+		return errors.NewErrInvalidArgument("Signature", "can not be empty")
+	}
+	return nil
+}
+
+// Validate implements the api.Validator interface
+func (m *DeviceSnapshot) Validate() error {
+	if m.Version == 0 {
+		return errors.NewErrInvalidArgument("Version", "can not be empty")
+	}
+	if err := api.NotNilAndValid(m.Device, "Device"); err != nil {
+		return err
+	}
+	if err := api.NotNilAndValid(m.MacState, "MacState"); err != nil {
+		return err
+	}
+	dev, state := m.Device.GetLorawanDevice(), m.MacState
+	if dev.DevAddr == nil || (m.EncryptedKeys == "" && (dev.NwkSKey == nil || dev.AppSKey == nil)) {
+		return errors.NewErrInvalidArgument("Device", "has no session")
+	}
+	if state.AppId != dev.AppId || state.DevId != dev.DevId || *state.AppEui != *dev.AppEui || *state.DevEui != *dev.DevEui {
+		return errors.NewErrInvalidArgument("MacState", "is not for the same device")
+	}
+	if *state.DevAddr != *dev.DevAddr {
+		return errors.NewErrInvalidArgument("MacState", "is not for the same session")
+	}
+	if state.FCntUp != dev.FCntUp || state.FCntDown != dev.FCntDown {
+		return errors.NewErrInvalidArgument("MacState", "frame counters do not match the device")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net"
+	"sync"
+)
+
+var errInProcessListenerClosed = errors.New("In-process listener closed")
+
+var inProcessListeners = struct {
+	sync.RWMutex
+	listeners map[string]*inProcessListener
+}{listeners: make(map[string]*inProcessListener)}
+
+// ListenInProcess returns a listener for the connections that are dialed to the address from this process. These
+// connections use in-memory pipes instead of the network.
+func ListenInProcess(address string) net.Listener {
+	l := &inProcessListener{
+		address: address,
+		conns:   make(chan net.Conn),
+		closed:  make(chan struct{}),
+	}
+	inProcessListeners.Lock()
+	inProcessListeners.listeners[address] = l
+	inProcessListeners.Unlock()
+	return l
+}
+
+// dialInProcess dials the in-process listener of the address, if there is one
+func dialInProcess(address string) (conn net.Conn, ok bool, err error) {
+	inProcessListeners.RLock()
+	l, ok := inProcessListeners.listeners[address]
+	inProcessListeners.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+	conn, err = l.dial()
+	return conn, true, err
+}
+
+type inProcessListener struct {
+	address string
+	conns   chan net.Conn
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func (l *inProcessListener) dial() (net.Conn, error) {
+	server, client := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		return nil, errInProcessListenerClosed
+	}
+}
+
+func (l *inProcessListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errInProcessListenerClosed
+	}
+}
+
+func (l *inProcessListener) Close() error {
+	l.once.Do(func() {
+		inProcessListeners.Lock()
+		if inProcessListeners.listeners[l.address] == l {
+			delete(inProcessListeners.listeners, l.address)
+		}
+		inProcessListeners.Unlock()
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *inProcessListener) Addr() net.Addr {
+	return inProcessAddr(l.address)
+}
+
+type inProcessAddr string
+
+func (a inProcessAddr) Network() string { return "inprocess" }
+func (a inProcessAddr) String() string  { return string(a) }
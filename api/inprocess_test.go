@@ -0,0 +1,46 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestInProcess(t *testing.T) {
+	a := New(t)
+
+	_, ok, _ := dialInProcess("localhost:1234")
+	a.So(ok, ShouldBeFalse)
+
+	lis := ListenInProcess("localhost:1234")
+	a.So(lis.Addr().String(), ShouldEqual, "localhost:1234")
+
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4)
+		conn.Read(buf)
+		conn.Write(buf)
+	}()
+
+	conn, ok, err := dialInProcess("localhost:1234")
+	a.So(ok, ShouldBeTrue)
+	a.So(err, ShouldBeNil)
+	conn.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	conn.Read(buf)
+	a.So(string(buf), ShouldEqual, "ping")
+	conn.Close()
+
+	lis.Close()
+	_, ok, _ = dialInProcess("localhost:1234")
+	a.So(ok, ShouldBeFalse)
+	_, err = lis.Accept()
+	a.So(err, ShouldNotBeNil)
+}
@@ -194,6 +194,14 @@ type gatewayClient struct {
 		cancel func()
 		sync.RWMutex
 	}
+
+	event struct {
+		init        sync.Once
+		ch          chan *gateway.Event
+		cancel      func()
+		unsupported bool // The monitor does not implement the GatewayEvent RPC
+		sync.RWMutex
+	}
 }
 
 // GatewayClient is used as the main client for Gateways to communicate with the monitor
@@ -203,6 +211,7 @@ type GatewayClient interface {
 	SendStatus(status *gateway.Status) (err error)
 	SendUplink(msg *router.UplinkMessage) (err error)
 	SendDownlink(msg *router.DownlinkMessage) (err error)
+	SendEvent(event *gateway.Event) (err error)
 	Close() (err error)
 }
 
@@ -223,6 +232,7 @@ func (cl *gatewayClient) Close() (err error) {
 	cl.closeStatus()
 	cl.closeUplink()
 	cl.closeDownlink()
+	cl.closeEvent()
 	return err
 }
 
@@ -6,6 +6,7 @@ package monitor
 import (
 	"fmt"
 	"math/rand"
+	"net"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/api/router"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
+	"google.golang.org/grpc"
 )
 
 func TestClient(t *testing.T) {
@@ -149,6 +151,39 @@ func TestClient(t *testing.T) {
 		time.Sleep(100 * time.Millisecond)
 	}
 
+	{
+		client, _ := NewClient(ctx, fmt.Sprintf("localhost:%d", port))
+		defer client.Close()
+		gtw := client.GatewayClient("dev")
+
+		err := gtw.SendEvent(&gateway.Event{})
+		a.So(err, ShouldBeNil)
+
+		gtw.SetToken("SOME.AWESOME.JWT")
+
+		// The first two events are OK
+		for i := 0; i < 2; i++ {
+			err = gtw.SendEvent(&gateway.Event{})
+			a.So(err, ShouldBeNil)
+		}
+
+		// The next one will cause an error on the test server
+		err = gtw.SendEvent(&gateway.Event{})
+		time.Sleep(10 * time.Millisecond)
+
+		// Then, we are going to buffer 10 events locally
+		for i := 0; i < 10; i++ {
+			err = gtw.SendEvent(&gateway.Event{})
+			a.So(err, ShouldBeNil)
+		}
+
+		// After which events will get dropped
+		err = gtw.SendEvent(&gateway.Event{})
+		a.So(err, ShouldNotBeNil)
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
 	{
 		client, _ := NewClient(ctx, fmt.Sprintf("localhost:%d", port))
 		defer client.Close()
@@ -209,3 +244,40 @@ func TestClient(t *testing.T) {
 		time.Sleep(100 * time.Millisecond)
 	}
 }
+
+type noEventServer struct {
+	*exampleServer
+}
+
+func (s *noEventServer) GatewayEvent(stream Monitor_GatewayEventServer) error {
+	return errNotImplemented
+}
+
+func TestClientEventUnsupported(t *testing.T) {
+	a := New(t)
+
+	ctx := GetLogger(t, "Monitor Client")
+	rand.Seed(time.Now().UnixNano())
+	port := rand.Intn(1000) + 11000
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	a.So(err, ShouldBeNil)
+	srv := grpc.NewServer()
+	RegisterMonitorServer(srv, &noEventServer{newExampleServer(2)})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	client, _ := NewClient(ctx, fmt.Sprintf("localhost:%d", port))
+	defer client.Close()
+	gtw := client.GatewayClient("dev")
+	gtw.SetToken("SOME.AWESOME.JWT")
+
+	err = gtw.SendEvent(&gateway.Event{})
+	a.So(err, ShouldBeNil)
+	time.Sleep(100 * time.Millisecond)
+
+	// Events are no longer buffered for a monitor that does not implement them
+	for i := 0; i < 20; i++ {
+		err = gtw.SendEvent(&gateway.Event{})
+		a.So(err, ShouldBeNil)
+	}
+}
@@ -24,6 +24,7 @@ func newExampleServer(channelSize int) *exampleServer {
 		gatewayStatuses:  make(chan *gateway.Status, channelSize),
 		uplinkMessages:   make(chan *router.UplinkMessage, channelSize),
 		downlinkMessages: make(chan *router.DownlinkMessage, channelSize),
+		gatewayEvents:    make(chan *gateway.Event, channelSize),
 	}
 }
 
@@ -31,6 +32,7 @@ type exampleServer struct {
 	gatewayStatuses  chan *gateway.Status
 	uplinkMessages   chan *router.UplinkMessage
 	downlinkMessages chan *router.DownlinkMessage
+	gatewayEvents    chan *gateway.Event
 
 	brokerUplinkMessages   chan *broker.DeduplicatedUplinkMessage
 	brokerDownlinkMessages chan *broker.DownlinkMessage
@@ -93,6 +95,25 @@ func (s *exampleServer) GatewayDownlink(stream Monitor_GatewayDownlinkServer) er
 	}
 }
 
+func (s *exampleServer) GatewayEvent(stream Monitor_GatewayEventServer) error {
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&empty.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case s.gatewayEvents <- event:
+			fmt.Println("Saving gateway event to database and alerting the gateway owner")
+		default:
+			fmt.Println("Warning: Dropping gateway event [full buffer]")
+			return errBufferFull
+		}
+	}
+}
+
 func (s *exampleServer) BrokerUplink(stream Monitor_BrokerUplinkServer) error {
 	for {
 		uplink, err := stream.Recv()
@@ -0,0 +1,131 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/utils/backoff"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func (cl *gatewayClient) initEvent() {
+	cl.event.ch = make(chan *gateway.Event, BufferSize)
+	go cl.monitorEvent()
+}
+
+func (cl *gatewayClient) monitorEvent() {
+	var retries int
+newStream:
+	for {
+		ctx, cancel := context.WithCancel(cl.Context())
+		cl.event.Lock()
+		cl.event.cancel = cancel
+		cl.event.Unlock()
+
+		stream, err := cl.client.client.GatewayEvent(ctx)
+		if err != nil {
+			if grpc.Code(err) == codes.Unimplemented {
+				cancel()
+				cl.setEventUnsupported(err)
+				return
+			}
+			cl.Ctx.WithError(errors.FromGRPCError(err)).Warn("Failed to open new monitor event stream")
+
+			retries++
+			time.Sleep(backoff.Backoff(retries))
+
+			continue
+		}
+		retries = 0
+		cl.Ctx.Debug("Opened new monitor event stream")
+
+		// The actual stream
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-cl.event.ch:
+					if ok {
+						stream.Send(event)
+						cl.Ctx.Debug("Sent event to monitor")
+					}
+				}
+			}
+		}()
+
+		msg := new(empty.Empty)
+		for {
+			if err := stream.RecvMsg(&msg); err != nil {
+				unsupported := grpc.Code(err) == codes.Unimplemented
+				if !unsupported {
+					cl.Ctx.WithError(errors.FromGRPCError(err)).Warn("Received error on monitor event stream, closing...")
+				}
+				stream.CloseSend()
+				cl.Ctx.Debug("Closed monitor event stream")
+
+				cl.event.Lock()
+				cl.event.cancel()
+				cl.event.cancel = nil
+				cl.event.Unlock()
+
+				if unsupported {
+					cl.setEventUnsupported(err)
+					return
+				}
+
+				retries++
+				time.Sleep(backoff.Backoff(retries))
+
+				continue newStream
+			}
+		}
+	}
+}
+
+// setEventUnsupported stops sending events to a monitor that does not implement the GatewayEvent RPC
+func (cl *gatewayClient) setEventUnsupported(err error) {
+	cl.Ctx.WithError(errors.FromGRPCError(err)).Info("Monitor does not support gateway events, not sending them")
+	cl.event.Lock()
+	cl.event.unsupported = true
+	cl.event.Unlock()
+}
+
+func (cl *gatewayClient) closeEvent() {
+	cl.event.Lock()
+	defer cl.event.Unlock()
+	if cl.event.cancel != nil {
+		cl.event.cancel()
+	}
+}
+
+// SendEvent sends a gateway event to the monitor
+func (cl *gatewayClient) SendEvent(event *gateway.Event) (err error) {
+	if !cl.IsConfigured() {
+		return nil
+	}
+
+	cl.event.init.Do(cl.initEvent)
+
+	cl.event.RLock()
+	unsupported := cl.event.unsupported
+	cl.event.RUnlock()
+	if unsupported {
+		return nil
+	}
+
+	select {
+	case cl.event.ch <- event:
+	default:
+		cl.Ctx.Warn("Not sending event to monitor, buffer full")
+		return errors.New("Not sending event to monitor, buffer full")
+	}
+	return
+}
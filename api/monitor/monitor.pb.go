@@ -50,6 +50,7 @@ type MonitorClient interface {
 	GatewayStatus(ctx context.Context, opts ...grpc.CallOption) (Monitor_GatewayStatusClient, error)
 	GatewayUplink(ctx context.Context, opts ...grpc.CallOption) (Monitor_GatewayUplinkClient, error)
 	GatewayDownlink(ctx context.Context, opts ...grpc.CallOption) (Monitor_GatewayDownlinkClient, error)
+	GatewayEvent(ctx context.Context, opts ...grpc.CallOption) (Monitor_GatewayEventClient, error)
 	BrokerUplink(ctx context.Context, opts ...grpc.CallOption) (Monitor_BrokerUplinkClient, error)
 	BrokerDownlink(ctx context.Context, opts ...grpc.CallOption) (Monitor_BrokerDownlinkClient, error)
 }
@@ -164,8 +165,42 @@ func (x *monitorGatewayDownlinkClient) CloseAndRecv() (*google_protobuf1.Empty,
 	return m, nil
 }
 
+func (c *monitorClient) GatewayEvent(ctx context.Context, opts ...grpc.CallOption) (Monitor_GatewayEventClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Monitor_serviceDesc.Streams[3], c.cc, "/monitor.Monitor/GatewayEvent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &monitorGatewayEventClient{stream}
+	return x, nil
+}
+
+type Monitor_GatewayEventClient interface {
+	Send(*gateway.Event) error
+	CloseAndRecv() (*google_protobuf1.Empty, error)
+	grpc.ClientStream
+}
+
+type monitorGatewayEventClient struct {
+	grpc.ClientStream
+}
+
+func (x *monitorGatewayEventClient) Send(m *gateway.Event) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *monitorGatewayEventClient) CloseAndRecv() (*google_protobuf1.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(google_protobuf1.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *monitorClient) BrokerUplink(ctx context.Context, opts ...grpc.CallOption) (Monitor_BrokerUplinkClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Monitor_serviceDesc.Streams[3], c.cc, "/monitor.Monitor/BrokerUplink", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Monitor_serviceDesc.Streams[4], c.cc, "/monitor.Monitor/BrokerUplink", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +234,7 @@ func (x *monitorBrokerUplinkClient) CloseAndRecv() (*google_protobuf1.Empty, err
 }
 
 func (c *monitorClient) BrokerDownlink(ctx context.Context, opts ...grpc.CallOption) (Monitor_BrokerDownlinkClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Monitor_serviceDesc.Streams[4], c.cc, "/monitor.Monitor/BrokerDownlink", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Monitor_serviceDesc.Streams[5], c.cc, "/monitor.Monitor/BrokerDownlink", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +273,7 @@ type MonitorServer interface {
 	GatewayStatus(Monitor_GatewayStatusServer) error
 	GatewayUplink(Monitor_GatewayUplinkServer) error
 	GatewayDownlink(Monitor_GatewayDownlinkServer) error
+	GatewayEvent(Monitor_GatewayEventServer) error
 	BrokerUplink(Monitor_BrokerUplinkServer) error
 	BrokerDownlink(Monitor_BrokerDownlinkServer) error
 }
@@ -324,6 +360,32 @@ func (x *monitorGatewayDownlinkServer) Recv() (*router.DownlinkMessage, error) {
 	return m, nil
 }
 
+func _Monitor_GatewayEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MonitorServer).GatewayEvent(&monitorGatewayEventServer{stream})
+}
+
+type Monitor_GatewayEventServer interface {
+	SendAndClose(*google_protobuf1.Empty) error
+	Recv() (*gateway.Event, error)
+	grpc.ServerStream
+}
+
+type monitorGatewayEventServer struct {
+	grpc.ServerStream
+}
+
+func (x *monitorGatewayEventServer) SendAndClose(m *google_protobuf1.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *monitorGatewayEventServer) Recv() (*gateway.Event, error) {
+	m := new(gateway.Event)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Monitor_BrokerUplink_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(MonitorServer).BrokerUplink(&monitorBrokerUplinkServer{stream})
 }
@@ -396,6 +458,11 @@ var _Monitor_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Monitor_GatewayDownlink_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "GatewayEvent",
+			Handler:       _Monitor_GatewayEvent_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "BrokerUplink",
 			Handler:       _Monitor_BrokerUplink_Handler,
@@ -415,23 +482,24 @@ func init() {
 }
 
 var fileDescriptorMonitor = []byte{
-	// 285 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x4a, 0xf4, 0x30,
-	0x14, 0x86, 0xe9, 0xb7, 0xf8, 0x06, 0x82, 0x3a, 0x50, 0x50, 0xa1, 0x62, 0xc1, 0x9d, 0x20, 0x24,
-	0xa0, 0xab, 0x71, 0x25, 0x63, 0xc5, 0x85, 0x8c, 0x1b, 0xc7, 0x8d, 0xbb, 0x74, 0xe6, 0x98, 0x86,
-	0xfe, 0x9c, 0x92, 0x9e, 0x50, 0xbc, 0x43, 0x97, 0xde, 0x81, 0xd2, 0x2b, 0x11, 0x9b, 0x44, 0xc4,
-	0x85, 0x8e, 0xab, 0x97, 0xf3, 0xd3, 0xa7, 0x4f, 0x42, 0xd8, 0x4c, 0x69, 0x2a, 0x6c, 0xce, 0x57,
-	0x58, 0x8b, 0x65, 0x01, 0xcb, 0x42, 0x37, 0xaa, 0xbb, 0x05, 0xea, 0xd1, 0x94, 0x82, 0xa8, 0x11,
-	0xb2, 0xd5, 0xa2, 0xc6, 0x46, 0x13, 0x9a, 0x90, 0xbc, 0x35, 0x48, 0x18, 0x4f, 0x7c, 0x99, 0x1c,
-	0x86, 0x3d, 0x25, 0x09, 0x7a, 0xf9, 0x14, 0xd2, 0xed, 0x25, 0x07, 0x61, 0x6c, 0xd0, 0x12, 0x18,
-	0x1f, 0xdf, 0x87, 0xb9, 0xc1, 0x12, 0x8c, 0x8f, 0x30, 0x54, 0x88, 0xaa, 0x02, 0x31, 0x56, 0xb9,
-	0x7d, 0x14, 0x50, 0xb7, 0xe4, 0xb1, 0xa7, 0xaf, 0xff, 0xd8, 0x64, 0xe1, 0x0c, 0xe2, 0x73, 0xb6,
-	0x7d, 0xed, 0xfe, 0x79, 0x47, 0x92, 0x6c, 0x17, 0x4f, 0x79, 0x70, 0x70, 0x8d, 0x64, 0x8f, 0x3b,
-	0x16, 0x0f, 0x2c, 0x7e, 0xf5, 0xc1, 0x3a, 0x8e, 0xe2, 0x8b, 0xcf, 0x6f, 0xef, 0xdb, 0x4a, 0x37,
-	0x65, 0xbc, 0xcb, 0xbd, 0xa1, 0xab, 0x17, 0xd0, 0x75, 0x52, 0xc1, 0x0f, 0x84, 0x8c, 0x4d, 0x3d,
-	0x21, 0xc3, 0xbe, 0x19, 0x19, 0xfb, 0x81, 0x11, 0x3a, 0xbf, 0x53, 0x6e, 0xd8, 0xd6, 0x7c, 0x3c,
-	0xbc, 0xd7, 0x38, 0xe2, 0xfe, 0x2e, 0x32, 0x58, 0xdb, 0xb6, 0xd2, 0x2b, 0x49, 0xb0, 0xde, 0x54,
-	0xe9, 0x92, 0xed, 0x38, 0xd8, 0x17, 0xa3, 0x80, 0xdb, 0xd4, 0x68, 0x3e, 0x7b, 0x1e, 0xd2, 0xe8,
-	0x65, 0x48, 0xa3, 0xb7, 0x21, 0x8d, 0x1e, 0x4e, 0xfe, 0xf0, 0x52, 0xf2, 0xff, 0x23, 0xec, 0xec,
-	0x3d, 0x00, 0x00, 0xff, 0xff, 0xf2, 0x07, 0x36, 0xdd, 0x5f, 0x02, 0x00, 0x00,
+	// 301 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4a, 0xc3, 0x40,
+	0x10, 0x86, 0x09, 0x82, 0x85, 0xa5, 0xb6, 0xb0, 0xa0, 0x42, 0xc5, 0x82, 0x37, 0x41, 0xd8, 0x05,
+	0xbd, 0x58, 0x4f, 0x52, 0x53, 0x3c, 0x48, 0xbd, 0x58, 0x2f, 0xde, 0x36, 0xed, 0xb8, 0x59, 0x92,
+	0xec, 0x86, 0xcd, 0xc4, 0xe0, 0xa3, 0xf8, 0x46, 0x1e, 0x7d, 0x04, 0xc9, 0x93, 0x88, 0xd9, 0xdd,
+	0x22, 0x1e, 0x6a, 0x3d, 0xfd, 0xfc, 0x3b, 0x33, 0x5f, 0xe6, 0x1f, 0x42, 0x26, 0x52, 0x61, 0x5a,
+	0x27, 0x6c, 0x69, 0x0a, 0xbe, 0x48, 0x61, 0x91, 0x2a, 0x2d, 0xab, 0x7b, 0xc0, 0xc6, 0xd8, 0x8c,
+	0x23, 0x6a, 0x2e, 0x4a, 0xc5, 0x0b, 0xa3, 0x15, 0x1a, 0x1b, 0x94, 0x95, 0xd6, 0xa0, 0xa1, 0x3d,
+	0x6f, 0x47, 0xc7, 0xa1, 0x4f, 0x0a, 0x84, 0x46, 0xbc, 0x06, 0x75, 0x7d, 0xa3, 0xa3, 0x50, 0xb6,
+	0xa6, 0x46, 0xb0, 0x5e, 0x7e, 0x17, 0x13, 0x6b, 0x32, 0xb0, 0x5e, 0x42, 0x51, 0x1a, 0x23, 0x73,
+	0xe0, 0x9d, 0x4b, 0xea, 0x67, 0x0e, 0x45, 0x89, 0x1e, 0x7b, 0xfe, 0xb6, 0x43, 0x7a, 0x73, 0xb7,
+	0x01, 0xbd, 0x22, 0x7b, 0xb7, 0xee, 0x9b, 0x0f, 0x28, 0xb0, 0xae, 0xe8, 0x90, 0x85, 0x1d, 0xdc,
+	0xc3, 0xe8, 0x80, 0x39, 0x16, 0x0b, 0x2c, 0x36, 0xfb, 0x66, 0x9d, 0x46, 0xf4, 0x7a, 0x3d, 0xfb,
+	0x58, 0xe6, 0x4a, 0x67, 0x74, 0x9f, 0xf9, 0x0d, 0x9d, 0x9f, 0x43, 0x55, 0x09, 0x09, 0x1b, 0x08,
+	0x31, 0x19, 0x7a, 0x42, 0x6c, 0x1a, 0xdd, 0x31, 0x0e, 0x03, 0x23, 0xbc, 0xfc, 0x4d, 0xb9, 0x24,
+	0x7d, 0x4f, 0x99, 0xbd, 0x80, 0x46, 0x3a, 0x58, 0x47, 0xe8, 0xfc, 0x86, 0xc9, 0x3b, 0xd2, 0x9f,
+	0x76, 0x67, 0xf3, 0x01, 0x4e, 0x98, 0xbf, 0x62, 0x0c, 0xab, 0xba, 0xcc, 0xd5, 0x52, 0x20, 0xac,
+	0xb6, 0x0d, 0x73, 0x43, 0x06, 0x0e, 0xf6, 0x23, 0x4b, 0xc0, 0x6d, 0x9b, 0x65, 0x3a, 0x79, 0x6f,
+	0xc7, 0xd1, 0x47, 0x3b, 0x8e, 0x3e, 0xdb, 0x71, 0xf4, 0x74, 0xf6, 0x8f, 0x7f, 0x2c, 0xd9, 0xed,
+	0x60, 0x17, 0x5f, 0x03, 0x00, 0x91, 0x64, 0x9b, 0x2d, 0x99, 0x02, 0x00, 0x00,
 }
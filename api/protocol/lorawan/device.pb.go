@@ -11,6 +11,9 @@
 	It has these top-level messages:
 		DeviceIdentifier
 		Device
+		ADRSettings
+		Frame
+		MACState
 */
 package lorawan
 
@@ -147,9 +150,231 @@ func (m *Device) GetLastSeen() int64 {
 	return 0
 }
 
+type ADRSettings struct {
+	// The band (frequency plan) of the device.
+	Band string `protobuf:"bytes,1,opt,name=band,proto3" json:"band,omitempty"`
+	// The SNR margin (in dB) that is used for ADR.
+	Margin int32 `protobuf:"varint,2,opt,name=margin,proto3" json:"margin,omitempty"`
+	// The (desired) data rate of the device.
+	DataRate string `protobuf:"bytes,3,opt,name=data_rate,json=dataRate,proto3" json:"data_rate,omitempty"`
+	// The (desired) transmit power (in dBm) of the device.
+	TxPower int32 `protobuf:"varint,4,opt,name=tx_power,json=txPower,proto3" json:"tx_power,omitempty"`
+	// The (desired) number of transmissions for each uplink.
+	NbTrans int32 `protobuf:"varint,5,opt,name=nb_trans,json=nbTrans,proto3" json:"nb_trans,omitempty"`
+	// Indicates whether the NetworkServer should send a LinkADRReq when possible.
+	SendReq bool `protobuf:"varint,6,opt,name=send_req,json=sendReq,proto3" json:"send_req,omitempty"`
+	// The number of failed ADR attempts.
+	Failed int32 `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (m *ADRSettings) Reset()                    { *m = ADRSettings{} }
+func (m *ADRSettings) String() string            { return proto.CompactTextString(m) }
+func (*ADRSettings) ProtoMessage()               {}
+func (*ADRSettings) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{2} }
+
+func (m *ADRSettings) GetBand() string {
+	if m != nil {
+		return m.Band
+	}
+	return ""
+}
+
+func (m *ADRSettings) GetMargin() int32 {
+	if m != nil {
+		return m.Margin
+	}
+	return 0
+}
+
+func (m *ADRSettings) GetDataRate() string {
+	if m != nil {
+		return m.DataRate
+	}
+	return ""
+}
+
+func (m *ADRSettings) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *ADRSettings) GetNbTrans() int32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *ADRSettings) GetSendReq() bool {
+	if m != nil {
+		return m.SendReq
+	}
+	return false
+}
+
+func (m *ADRSettings) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+type Frame struct {
+	// The frame counter of the uplink.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// The best SNR of the uplink.
+	Snr float32 `protobuf:"fixed32,2,opt,name=snr,proto3" json:"snr,omitempty"`
+	// The number of gateways that received the uplink.
+	GatewayCount uint32 `protobuf:"varint,3,opt,name=gateway_count,json=gatewayCount,proto3" json:"gateway_count,omitempty"`
+}
+
+func (m *Frame) Reset()                    { *m = Frame{} }
+func (m *Frame) String() string            { return proto.CompactTextString(m) }
+func (*Frame) ProtoMessage()               {}
+func (*Frame) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{3} }
+
+func (m *Frame) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *Frame) GetSnr() float32 {
+	if m != nil {
+		return m.Snr
+	}
+	return 0
+}
+
+func (m *Frame) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+type MACState struct {
+	// The AppEUI is a unique, 8 byte identifier for the application a device belongs to.
+	AppEui *github_com_TheThingsNetwork_ttn_core_types.AppEUI `protobuf:"bytes,1,opt,name=app_eui,json=appEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.AppEUI" json:"app_eui,omitempty"`
+	// The DevEUI is a unique, 8 byte identifier for the device.
+	DevEui *github_com_TheThingsNetwork_ttn_core_types.DevEUI `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevEUI" json:"dev_eui,omitempty"`
+	// The AppID is a unique identifier for the application a device belongs to. It can contain lowercase letters, numbers, - and _.
+	AppId string `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	// The DevID is a unique identifier for the device. It can contain lowercase letters, numbers, - and _.
+	DevId string `protobuf:"bytes,4,opt,name=dev_id,json=devId,proto3" json:"dev_id,omitempty"`
+	// The DevAddr is a dynamic, 4 byte session address for the device.
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,5,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+	// FCntUp is the uplink frame counter for a device session.
+	FCntUp uint32 `protobuf:"varint,9,opt,name=f_cnt_up,json=fCntUp,proto3" json:"f_cnt_up,omitempty"`
+	// FCntDown is the downlink frame counter for a device session.
+	FCntDown uint32 `protobuf:"varint,10,opt,name=f_cnt_down,json=fCntDown,proto3" json:"f_cnt_down,omitempty"`
+	// The DisableFCntCheck option disables the frame counter check.
+	DisableFCntCheck bool `protobuf:"varint,11,opt,name=disable_f_cnt_check,json=disableFCntCheck,proto3" json:"disable_f_cnt_check,omitempty"`
+	// The Uses32BitFCnt option indicates that the device keeps track of full 32 bit frame counters.
+	Uses32BitFCnt bool `protobuf:"varint,12,opt,name=uses32_bit_f_cnt,json=uses32BitFCnt,proto3" json:"uses32_bit_f_cnt,omitempty"`
+	// When the device was last seen (Unix nanoseconds)
+	LastSeen int64 `protobuf:"varint,21,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// The ADR settings of the device.
+	Adr *ADRSettings `protobuf:"bytes,31,opt,name=adr" json:"adr,omitempty"`
+	// The frame history that is used for ADR (most recent first).
+	FrameHistory []*Frame `protobuf:"bytes,32,rep,name=frame_history,json=frameHistory" json:"frame_history,omitempty"`
+	// The percentage of uplink frames that was lost, calculated from the frame history.
+	LossPercentage uint32 `protobuf:"varint,33,opt,name=loss_percentage,json=lossPercentage,proto3" json:"loss_percentage,omitempty"`
+	// The MAC commands that will be sent in the next downlink.
+	PendingMacCommands []string `protobuf:"bytes,34,rep,name=pending_mac_commands,json=pendingMacCommands" json:"pending_mac_commands,omitempty"`
+}
+
+func (m *MACState) Reset()                    { *m = MACState{} }
+func (m *MACState) String() string            { return proto.CompactTextString(m) }
+func (*MACState) ProtoMessage()               {}
+func (*MACState) Descriptor() ([]byte, []int) { return fileDescriptorDevice, []int{4} }
+
+func (m *MACState) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *MACState) GetDevId() string {
+	if m != nil {
+		return m.DevId
+	}
+	return ""
+}
+
+func (m *MACState) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
+
+func (m *MACState) GetFCntDown() uint32 {
+	if m != nil {
+		return m.FCntDown
+	}
+	return 0
+}
+
+func (m *MACState) GetDisableFCntCheck() bool {
+	if m != nil {
+		return m.DisableFCntCheck
+	}
+	return false
+}
+
+func (m *MACState) GetUses32BitFCnt() bool {
+	if m != nil {
+		return m.Uses32BitFCnt
+	}
+	return false
+}
+
+func (m *MACState) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *MACState) GetAdr() *ADRSettings {
+	if m != nil {
+		return m.Adr
+	}
+	return nil
+}
+
+func (m *MACState) GetFrameHistory() []*Frame {
+	if m != nil {
+		return m.FrameHistory
+	}
+	return nil
+}
+
+func (m *MACState) GetLossPercentage() uint32 {
+	if m != nil {
+		return m.LossPercentage
+	}
+	return 0
+}
+
+func (m *MACState) GetPendingMacCommands() []string {
+	if m != nil {
+		return m.PendingMacCommands
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DeviceIdentifier)(nil), "lorawan.DeviceIdentifier")
 	proto.RegisterType((*Device)(nil), "lorawan.Device")
+	proto.RegisterType((*ADRSettings)(nil), "lorawan.ADRSettings")
+	proto.RegisterType((*Frame)(nil), "lorawan.Frame")
+	proto.RegisterType((*MACState)(nil), "lorawan.MACState")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -166,6 +391,13 @@ type DeviceManagerClient interface {
 	GetDevice(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*Device, error)
 	SetDevice(ctx context.Context, in *Device, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 	DeleteDevice(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// GetMACState returns the MAC state of the device, as it is known by the NetworkServer
+	GetMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*MACState, error)
+	// ResetMACState resets the ADR settings and the frame history of the device
+	ResetMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// RestoreMACState restores the ADR settings, the frame history and the last seen time of the device from a MACState
+	// that was returned by GetMACState. The DevAddr must match the current session of the device.
+	RestoreMACState(ctx context.Context, in *MACState, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type deviceManagerClient struct {
@@ -203,12 +435,46 @@ func (c *deviceManagerClient) DeleteDevice(ctx context.Context, in *DeviceIdenti
 	return out, nil
 }
 
+func (c *deviceManagerClient) GetMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*MACState, error) {
+	out := new(MACState)
+	err := grpc.Invoke(ctx, "/lorawan.DeviceManager/GetMACState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceManagerClient) ResetMACState(ctx context.Context, in *DeviceIdentifier, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/lorawan.DeviceManager/ResetMACState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceManagerClient) RestoreMACState(ctx context.Context, in *MACState, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/lorawan.DeviceManager/RestoreMACState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for DeviceManager service
 
 type DeviceManagerServer interface {
 	GetDevice(context.Context, *DeviceIdentifier) (*Device, error)
 	SetDevice(context.Context, *Device) (*google_protobuf.Empty, error)
 	DeleteDevice(context.Context, *DeviceIdentifier) (*google_protobuf.Empty, error)
+	// GetMACState returns the MAC state of the device, as it is known by the NetworkServer
+	GetMACState(context.Context, *DeviceIdentifier) (*MACState, error)
+	// ResetMACState resets the ADR settings and the frame history of the device
+	ResetMACState(context.Context, *DeviceIdentifier) (*google_protobuf.Empty, error)
+	// RestoreMACState restores the ADR settings, the frame history and the last seen time of the device from a MACState
+	// that was returned by GetMACState. The DevAddr must match the current session of the device.
+	RestoreMACState(context.Context, *MACState) (*google_protobuf.Empty, error)
 }
 
 func RegisterDeviceManagerServer(s *grpc.Server, srv DeviceManagerServer) {
@@ -269,6 +535,60 @@ func _DeviceManager_DeleteDevice_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeviceManager_GetMACState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceManagerServer).GetMACState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lorawan.DeviceManager/GetMACState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceManagerServer).GetMACState(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceManager_ResetMACState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceManagerServer).ResetMACState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lorawan.DeviceManager/ResetMACState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceManagerServer).ResetMACState(ctx, req.(*DeviceIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceManager_RestoreMACState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MACState)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceManagerServer).RestoreMACState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lorawan.DeviceManager/RestoreMACState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceManagerServer).RestoreMACState(ctx, req.(*MACState))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DeviceManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lorawan.DeviceManager",
 	HandlerType: (*DeviceManagerServer)(nil),
@@ -285,6 +605,18 @@ var _DeviceManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDevice",
 			Handler:    _DeviceManager_DeleteDevice_Handler,
 		},
+		{
+			MethodName: "GetMACState",
+			Handler:    _DeviceManager_GetMACState_Handler,
+		},
+		{
+			MethodName: "ResetMACState",
+			Handler:    _DeviceManager_ResetMACState_Handler,
+		},
+		{
+			MethodName: "RestoreMACState",
+			Handler:    _DeviceManager_RestoreMACState_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/protocol/lorawan/device.proto",
@@ -461,50 +793,290 @@ func (m *Device) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeFixed64Device(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32Device(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintDevice(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ADRSettings) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *DeviceIdentifier) Size() (n int) {
+
+func (m *ADRSettings) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.AppEui != nil {
-		l = m.AppEui.Size()
-		n += 1 + l + sovDevice(uint64(l))
+	if len(m.Band) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.Band)))
+		i += copy(dAtA[i:], m.Band)
 	}
-	if m.DevEui != nil {
-		l = m.DevEui.Size()
-		n += 1 + l + sovDevice(uint64(l))
+	if m.Margin != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Margin))
 	}
-	return n
-}
-
-func (m *Device) Size() (n int) {
-	var l int
-	_ = l
+	if len(m.DataRate) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.DataRate)))
+		i += copy(dAtA[i:], m.DataRate)
+	}
+	if m.TxPower != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.TxPower))
+	}
+	if m.NbTrans != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.NbTrans))
+	}
+	if m.SendReq {
+		dAtA[i] = 0x30
+		i++
+		if m.SendReq {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Failed != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Failed))
+	}
+	return i, nil
+}
+
+func (m *Frame) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Frame) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.FCnt != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.FCnt))
+	}
+	if m.Snr != 0 {
+		dAtA[i] = 0x15
+		i++
+		i = encodeFixed32Device(dAtA, i, uint32(math.Float32bits(float32(m.Snr))))
+	}
+	if m.GatewayCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.GatewayCount))
+	}
+	return i, nil
+}
+
+func (m *MACState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MACState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.AppEui != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.AppEui.Size()))
+		n9, err := m.AppEui.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.DevEui != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.DevEui.Size()))
+		n10, err := m.DevEui.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if len(m.AppId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.AppId)))
+		i += copy(dAtA[i:], m.AppId)
+	}
+	if len(m.DevId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(len(m.DevId)))
+		i += copy(dAtA[i:], m.DevId)
+	}
+	if m.DevAddr != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.DevAddr.Size()))
+		n11, err := m.DevAddr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.FCntUp != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.FCntUp))
+	}
+	if m.FCntDown != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.FCntDown))
+	}
+	if m.DisableFCntCheck {
+		dAtA[i] = 0x58
+		i++
+		if m.DisableFCntCheck {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Uses32BitFCnt {
+		dAtA[i] = 0x60
+		i++
+		if m.Uses32BitFCnt {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.LastSeen != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.LastSeen))
+	}
+	if m.Adr != nil {
+		dAtA[i] = 0xfa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.Adr.Size()))
+		n12, err := m.Adr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if len(m.FrameHistory) > 0 {
+		for _, msg := range m.FrameHistory {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x2
+			i++
+			i = encodeVarintDevice(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.LossPercentage != 0 {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x2
+		i++
+		i = encodeVarintDevice(dAtA, i, uint64(m.LossPercentage))
+	}
+	if len(m.PendingMacCommands) > 0 {
+		for _, s := range m.PendingMacCommands {
+			dAtA[i] = 0x92
+			i++
+			dAtA[i] = 0x2
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64Device(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32Device(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintDevice(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *DeviceIdentifier) Size() (n int) {
+	var l int
+	_ = l
+	if m.AppEui != nil {
+		l = m.AppEui.Size()
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.DevEui != nil {
+		l = m.DevEui.Size()
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	var l int
+	_ = l
 	if m.AppEui != nil {
 		l = m.AppEui.Size()
 		n += 1 + l + sovDevice(uint64(l))
@@ -556,23 +1128,872 @@ func (m *Device) Size() (n int) {
 	if m.LastSeen != 0 {
 		n += 2 + sovDevice(uint64(m.LastSeen))
 	}
-	return n
-}
+	return n
+}
+
+func (m *ADRSettings) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Band)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.Margin != 0 {
+		n += 1 + sovDevice(uint64(m.Margin))
+	}
+	l = len(m.DataRate)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.TxPower != 0 {
+		n += 1 + sovDevice(uint64(m.TxPower))
+	}
+	if m.NbTrans != 0 {
+		n += 1 + sovDevice(uint64(m.NbTrans))
+	}
+	if m.SendReq {
+		n += 2
+	}
+	if m.Failed != 0 {
+		n += 1 + sovDevice(uint64(m.Failed))
+	}
+	return n
+}
+
+func (m *Frame) Size() (n int) {
+	var l int
+	_ = l
+	if m.FCnt != 0 {
+		n += 1 + sovDevice(uint64(m.FCnt))
+	}
+	if m.Snr != 0 {
+		n += 5
+	}
+	if m.GatewayCount != 0 {
+		n += 1 + sovDevice(uint64(m.GatewayCount))
+	}
+	return n
+}
+
+func (m *MACState) Size() (n int) {
+	var l int
+	_ = l
+	if m.AppEui != nil {
+		l = m.AppEui.Size()
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.DevEui != nil {
+		l = m.DevEui.Size()
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	l = len(m.AppId)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	l = len(m.DevId)
+	if l > 0 {
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.DevAddr != nil {
+		l = m.DevAddr.Size()
+		n += 1 + l + sovDevice(uint64(l))
+	}
+	if m.FCntUp != 0 {
+		n += 1 + sovDevice(uint64(m.FCntUp))
+	}
+	if m.FCntDown != 0 {
+		n += 1 + sovDevice(uint64(m.FCntDown))
+	}
+	if m.DisableFCntCheck {
+		n += 2
+	}
+	if m.Uses32BitFCnt {
+		n += 2
+	}
+	if m.LastSeen != 0 {
+		n += 2 + sovDevice(uint64(m.LastSeen))
+	}
+	if m.Adr != nil {
+		l = m.Adr.Size()
+		n += 2 + l + sovDevice(uint64(l))
+	}
+	if len(m.FrameHistory) > 0 {
+		for _, e := range m.FrameHistory {
+			l = e.Size()
+			n += 2 + l + sovDevice(uint64(l))
+		}
+	}
+	if m.LossPercentage != 0 {
+		n += 2 + sovDevice(uint64(m.LossPercentage))
+	}
+	if len(m.PendingMacCommands) > 0 {
+		for _, s := range m.PendingMacCommands {
+			l = len(s)
+			n += 2 + l + sovDevice(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDevice(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozDevice(x uint64) (n int) {
+	return sovDevice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
+			m.AppEui = &v
+			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Device) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
+			m.AppEui = &v
+			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DevId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NwkSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.NwkSKey
+			m.NwkSKey = &v
+			if err := m.NwkSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppSKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppSKey
+			m.AppSKey = &v
+			if err := m.AppSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppKey
+			m.AppKey = &v
+			if err := m.AppKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntUp", wireType)
+			}
+			m.FCntUp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FCntUp |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
+			}
+			m.FCntDown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FCntDown |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableFCntCheck", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableFCntCheck = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uses32BitFCnt", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Uses32BitFCnt = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationConstraints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActivationConstraints = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+			}
+			m.LastSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ADRSettings) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDevice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ADRSettings: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ADRSettings: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Band", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Band = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Margin", wireType)
+			}
+			m.Margin = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Margin |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DataRate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxPower", wireType)
+			}
+			m.TxPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxPower |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NbTrans", wireType)
+			}
+			m.NbTrans = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NbTrans |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendReq", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SendReq = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDevice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDevice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDevice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovDevice(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozDevice(x uint64) (n int) {
-	return sovDevice(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
+
+func (m *Frame) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -595,17 +2016,17 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceIdentifier: wiretype end group for non-group")
+			return fmt.Errorf("proto: Frame: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Frame: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCnt", wireType)
 			}
-			var byteLen int
+			m.FCnt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -615,29 +2036,30 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.FCnt |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snr", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
+			var v uint32
+			if (iNdEx + 4) > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
-			m.AppEui = &v
-			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			iNdEx += 4
+			v = uint32(dAtA[iNdEx-4])
+			v |= uint32(dAtA[iNdEx-3]) << 8
+			v |= uint32(dAtA[iNdEx-2]) << 16
+			v |= uint32(dAtA[iNdEx-1]) << 24
+			m.Snr = float32(math.Float32frombits(v))
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayCount", wireType)
 			}
-			var byteLen int
+			m.GatewayCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -647,24 +2069,11 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.GatewayCount |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
-			m.DevEui = &v
-			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDevice(dAtA[iNdEx:])
@@ -686,7 +2095,8 @@ func (m *DeviceIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+
+func (m *MACState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -709,10 +2119,10 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: MACState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MACState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -869,11 +2279,11 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NwkSKey", wireType)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntUp", wireType)
 			}
-			var byteLen int
+			m.FCntUp = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -883,29 +2293,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.FCntUp |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.NwkSKey
-			m.NwkSKey = &v
-			if err := m.NwkSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppSKey", wireType)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
 			}
-			var byteLen int
+			m.FCntDown = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -915,29 +2312,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.FCntDown |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppSKey
-			m.AppSKey = &v
-			if err := m.AppSKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppKey", wireType)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableFCntCheck", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -947,29 +2331,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppKey
-			m.AppKey = &v
-			if err := m.AppKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 9:
+			m.DisableFCntCheck = bool(v != 0)
+		case 12:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FCntUp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Uses32BitFCnt", wireType)
 			}
-			m.FCntUp = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -979,16 +2351,17 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FCntUp |= (uint32(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
+			m.Uses32BitFCnt = bool(v != 0)
+		case 21:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FCntDown", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
 			}
-			m.FCntDown = 0
+			m.LastSeen = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -998,16 +2371,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FCntDown |= (uint32(b) & 0x7F) << shift
+				m.LastSeen |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DisableFCntCheck", wireType)
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Adr", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1017,17 +2390,30 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DisableFCntCheck = bool(v != 0)
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uses32BitFCnt", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthDevice
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Adr == nil {
+				m.Adr = &ADRSettings{}
+			}
+			if err := m.Adr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 32:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrameHistory", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1037,17 +2423,28 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Uses32BitFCnt = bool(v != 0)
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationConstraints", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthDevice
 			}
-			var stringLen uint64
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FrameHistory = append(m.FrameHistory, &Frame{})
+			if err := m.FrameHistory[len(m.FrameHistory)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 33:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LossPercentage", wireType)
+			}
+			m.LossPercentage = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1057,26 +2454,16 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.LossPercentage |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDevice
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ActivationConstraints = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 21:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+		case 34:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingMacCommands", wireType)
 			}
-			m.LastSeen = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDevice
@@ -1086,11 +2473,21 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastSeen |= (int64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDevice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingMacCommands = append(m.PendingMacCommands, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDevice(dAtA[iNdEx:])
@@ -1112,6 +2509,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func skipDevice(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1222,42 +2620,62 @@ func init() {
 }
 
 var fileDescriptorDevice = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x94, 0x4f, 0x6f, 0xd3, 0x3e,
-	0x18, 0xc7, 0x95, 0xdf, 0x7e, 0x6b, 0x13, 0xb3, 0x8a, 0xc9, 0x68, 0x93, 0xe9, 0xd0, 0x56, 0xed,
-	0x42, 0x2f, 0x4b, 0xc4, 0xfe, 0xc0, 0xb9, 0xff, 0x40, 0x15, 0x62, 0x12, 0xd9, 0x76, 0xe1, 0x12,
-	0xb9, 0xf1, 0xd3, 0xd4, 0x6a, 0x66, 0x5b, 0x89, 0x93, 0xa8, 0x2f, 0x80, 0x37, 0xc4, 0x3b, 0xe0,
-	0xc6, 0x91, 0xf3, 0x0e, 0x13, 0xda, 0x2b, 0x41, 0x8e, 0x3b, 0x86, 0x2a, 0xa1, 0x89, 0x9e, 0xb8,
-	0x3d, 0xf9, 0x7e, 0xbf, 0xfe, 0x3c, 0x76, 0x5d, 0x3f, 0xa8, 0x97, 0x70, 0x3d, 0x2b, 0x26, 0x7e,
-	0x2c, 0xaf, 0x83, 0xcb, 0x19, 0x5c, 0xce, 0xb8, 0x48, 0xf2, 0x73, 0xd0, 0x95, 0xcc, 0xe6, 0x81,
-	0xd6, 0x22, 0xa0, 0x8a, 0x07, 0x2a, 0x93, 0x5a, 0xc6, 0x32, 0x0d, 0x52, 0x99, 0xd1, 0x8a, 0x8a,
-	0x80, 0x41, 0xc9, 0x63, 0xf0, 0x6b, 0x1d, 0x37, 0x97, 0x6a, 0x7b, 0x2f, 0x91, 0x32, 0x49, 0xc1,
-	0xc6, 0x27, 0xc5, 0x34, 0x80, 0x6b, 0xa5, 0x17, 0x36, 0xd5, 0x3e, 0xfa, 0xad, 0x51, 0x22, 0x13,
-	0xf9, 0x90, 0x32, 0x5f, 0xf5, 0x47, 0x5d, 0xd9, 0xf8, 0xe1, 0x17, 0x07, 0x6d, 0x0f, 0xeb, 0x2e,
-	0x63, 0x06, 0x42, 0xf3, 0x29, 0x87, 0x0c, 0x9f, 0xa3, 0x26, 0x55, 0x2a, 0x82, 0x82, 0x13, 0xa7,
-	0xe3, 0x74, 0xb7, 0xfa, 0x67, 0x37, 0xb7, 0x07, 0xaf, 0x1e, 0x3b, 0x41, 0x2c, 0x33, 0x08, 0xf4,
-	0x42, 0x41, 0xee, 0xf7, 0x94, 0x1a, 0x5d, 0x8d, 0xc3, 0x06, 0x55, 0x6a, 0x54, 0x70, 0xc3, 0x63,
-	0x50, 0xd6, 0xbc, 0xff, 0xd6, 0xe2, 0x0d, 0xa1, 0xac, 0x79, 0x0c, 0xca, 0x51, 0xc1, 0x0f, 0x3f,
-	0x37, 0x50, 0xc3, 0x6e, 0xfa, 0x5f, 0xdf, 0x2a, 0xde, 0x41, 0x86, 0x1c, 0x71, 0x46, 0x36, 0x3a,
-	0x4e, 0xd7, 0x0b, 0x37, 0xa9, 0x52, 0x63, 0x66, 0x64, 0xd3, 0x86, 0x33, 0xf2, 0xbf, 0x95, 0x19,
-	0x94, 0x63, 0x86, 0x3f, 0x22, 0xd7, 0xc8, 0x94, 0xb1, 0x8c, 0x6c, 0xd6, 0xed, 0x5f, 0xdf, 0xdc,
-	0x1e, 0x1c, 0xff, 0x5d, 0xfb, 0x1e, 0x63, 0x59, 0x68, 0x4e, 0x61, 0x0a, 0x1c, 0x22, 0x4f, 0x54,
-	0xf3, 0x28, 0x8f, 0xe6, 0xb0, 0x20, 0x8d, 0xb5, 0x98, 0xe7, 0xd5, 0xfc, 0xe2, 0x3d, 0x2c, 0xc2,
-	0xa6, 0xb0, 0x85, 0x61, 0x9a, 0x43, 0x59, 0x66, 0x73, 0x2d, 0x66, 0x4f, 0x29, 0xcb, 0xa4, 0xb6,
-	0xb8, 0xbf, 0x48, 0x43, 0x74, 0xd7, 0xbd, 0x48, 0x03, 0x34, 0x3f, 0xb7, 0xe1, 0x11, 0xe4, 0x4e,
-	0xa3, 0x58, 0xe8, 0xa8, 0x50, 0xc4, 0xeb, 0x38, 0xdd, 0x56, 0xd8, 0x98, 0x0e, 0x84, 0xbe, 0x52,
-	0xf8, 0x05, 0x42, 0xd6, 0x61, 0xb2, 0x12, 0x04, 0xd5, 0x9e, 0x6b, 0xbc, 0xa1, 0xac, 0x04, 0x3e,
-	0x42, 0xcf, 0x18, 0xcf, 0xe9, 0x24, 0x85, 0xc8, 0xa6, 0xe2, 0x19, 0xc4, 0x73, 0xf2, 0xa4, 0xe3,
-	0x74, 0xdd, 0x70, 0x7b, 0x69, 0xbd, 0x1d, 0x08, 0x3d, 0x30, 0x3a, 0x7e, 0x89, 0xb6, 0x8b, 0x1c,
-	0xf2, 0x93, 0xe3, 0x68, 0xc2, 0xb5, 0x5d, 0x41, 0xb6, 0xea, 0x6c, 0xcb, 0xea, 0x7d, 0xae, 0x4d,
-	0x1a, 0x9f, 0xa1, 0x5d, 0x1a, 0x6b, 0x5e, 0x52, 0xcd, 0xa5, 0x88, 0x62, 0x29, 0x72, 0x9d, 0x51,
-	0x2e, 0x74, 0x4e, 0x5a, 0xf5, 0x3f, 0x60, 0xe7, 0xc1, 0x1d, 0x3c, 0x98, 0x78, 0x0f, 0x79, 0x29,
-	0xcd, 0x75, 0x94, 0x03, 0x08, 0xb2, 0xd3, 0x71, 0xba, 0x1b, 0xa1, 0x6b, 0x84, 0x0b, 0x00, 0x71,
-	0xfc, 0xd5, 0x41, 0x2d, 0xfb, 0x0e, 0x3e, 0x50, 0x41, 0x13, 0xc8, 0xf0, 0x1b, 0xe4, 0xbd, 0x03,
-	0xbd, 0x7c, 0x1b, 0xcf, 0xfd, 0xe5, 0xc4, 0xf0, 0x57, 0x5f, 0x78, 0xfb, 0xe9, 0x8a, 0x85, 0x4f,
-	0x91, 0x77, 0xf1, 0x6b, 0xe1, 0xaa, 0xdb, 0xde, 0xf5, 0xed, 0xc8, 0xf1, 0xef, 0x87, 0x89, 0x3f,
-	0x32, 0x23, 0x07, 0xf7, 0xd0, 0xd6, 0x10, 0x52, 0xd0, 0xf0, 0x78, 0xc7, 0x3f, 0x20, 0xfa, 0xfd,
-	0x6f, 0x77, 0xfb, 0xce, 0xf7, 0xbb, 0x7d, 0xe7, 0xc7, 0xdd, 0xbe, 0xf3, 0xe9, 0x74, 0x9d, 0x31,
-	0x39, 0x69, 0xd4, 0xca, 0xc9, 0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x75, 0x3e, 0x90, 0x84, 0x65,
-	0x05, 0x00, 0x00,
+	// 910 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xbf, 0x6f, 0x1b, 0x37,
+	0x14, 0xc6, 0x45, 0x96, 0x74, 0xa2, 0xa5, 0xd8, 0x65, 0x62, 0xe3, 0xe2, 0x14, 0xb6, 0xaa, 0x02,
+	0x8d, 0x96, 0x48, 0xad, 0x9c, 0xb4, 0x43, 0xbb, 0xe8, 0x87, 0x93, 0x1a, 0x85, 0x8d, 0x94, 0x72,
+	0x96, 0x2e, 0x07, 0xea, 0xf8, 0x74, 0x22, 0x24, 0x91, 0x0c, 0x8f, 0x92, 0xa2, 0xad, 0x4b, 0xf7,
+	0xfe, 0x2d, 0xfd, 0x07, 0xba, 0x76, 0xec, 0x9c, 0x21, 0x28, 0xfc, 0x97, 0x14, 0x24, 0x15, 0x39,
+	0x70, 0xe1, 0xb8, 0xd5, 0x94, 0xa1, 0x1b, 0xdf, 0xf7, 0x7d, 0xfc, 0xde, 0xe3, 0xf1, 0xf1, 0x48,
+	0xd4, 0x4e, 0xb9, 0x19, 0xcd, 0x06, 0x8d, 0x44, 0x4e, 0x9b, 0x17, 0x23, 0xb8, 0x18, 0x71, 0x91,
+	0x66, 0xe7, 0x60, 0x16, 0x52, 0x8f, 0x9b, 0xc6, 0x88, 0x26, 0x55, 0xbc, 0xa9, 0xb4, 0x34, 0x32,
+	0x91, 0x93, 0xe6, 0x44, 0x6a, 0xba, 0xa0, 0xa2, 0xc9, 0x60, 0xce, 0x13, 0x68, 0x38, 0x1c, 0x17,
+	0x57, 0xe8, 0xc1, 0xc3, 0x54, 0xca, 0x74, 0x02, 0x5e, 0x3e, 0x98, 0x0d, 0x9b, 0x30, 0x55, 0x66,
+	0xe9, 0x55, 0x07, 0x8f, 0xdf, 0x4b, 0x94, 0xca, 0x54, 0x5e, 0xa9, 0x6c, 0xe4, 0x02, 0x37, 0xf2,
+	0xf2, 0xda, 0x6f, 0x01, 0xda, 0xed, 0xb9, 0x2c, 0xa7, 0x0c, 0x84, 0xe1, 0x43, 0x0e, 0x1a, 0x9f,
+	0xa3, 0x22, 0x55, 0x2a, 0x86, 0x19, 0x8f, 0x82, 0x6a, 0x50, 0x2f, 0x77, 0x9e, 0xbe, 0x79, 0x7b,
+	0xf4, 0xd5, 0x6d, 0x2b, 0x48, 0xa4, 0x86, 0xa6, 0x59, 0x2a, 0xc8, 0x1a, 0x6d, 0xa5, 0x4e, 0x5e,
+	0x9e, 0x92, 0x02, 0x55, 0xea, 0x64, 0xc6, 0xad, 0x1f, 0x83, 0xb9, 0xf3, 0xbb, 0xb3, 0x91, 0x5f,
+	0x0f, 0xe6, 0xce, 0x8f, 0xc1, 0xfc, 0x64, 0xc6, 0x6b, 0xbf, 0x14, 0x50, 0xc1, 0x17, 0xfd, 0xb1,
+	0x97, 0x8a, 0xf7, 0x90, 0x75, 0x8e, 0x39, 0x8b, 0x72, 0xd5, 0xa0, 0x5e, 0x22, 0x79, 0xaa, 0xd4,
+	0x29, 0xb3, 0xb0, 0x4d, 0xc3, 0x59, 0xb4, 0xe5, 0x61, 0x06, 0xf3, 0x53, 0x86, 0x7f, 0x44, 0xa1,
+	0x85, 0x29, 0x63, 0x3a, 0xca, 0xbb, 0xf4, 0x5f, 0xbf, 0x79, 0x7b, 0xd4, 0xfa, 0x6f, 0xe9, 0xdb,
+	0x8c, 0x69, 0x52, 0x64, 0x7e, 0x80, 0x09, 0x2a, 0x89, 0xc5, 0x38, 0xce, 0xe2, 0x31, 0x2c, 0xa3,
+	0xc2, 0x46, 0x9e, 0xe7, 0x8b, 0x71, 0xff, 0x07, 0x58, 0x92, 0xa2, 0xf0, 0x03, 0xeb, 0x69, 0x17,
+	0xe5, 0x3d, 0x8b, 0x1b, 0x79, 0xb6, 0x95, 0xf2, 0x9e, 0xd4, 0x0f, 0xde, 0x6d, 0xa4, 0x75, 0x0c,
+	0x37, 0xdd, 0x48, 0x6b, 0x68, 0x3f, 0xb7, 0xf5, 0x8b, 0x50, 0x38, 0x8c, 0x13, 0x61, 0xe2, 0x99,
+	0x8a, 0x4a, 0xd5, 0xa0, 0x5e, 0x21, 0x85, 0x61, 0x57, 0x98, 0x97, 0x0a, 0x7f, 0x8a, 0x90, 0x67,
+	0x98, 0x5c, 0x88, 0x08, 0x39, 0x2e, 0xb4, 0x5c, 0x4f, 0x2e, 0x04, 0x7e, 0x8c, 0xee, 0x31, 0x9e,
+	0xd1, 0xc1, 0x04, 0x62, 0xaf, 0x4a, 0x46, 0x90, 0x8c, 0xa3, 0xed, 0x6a, 0x50, 0x0f, 0xc9, 0xee,
+	0x8a, 0x7a, 0xd6, 0x15, 0xa6, 0x6b, 0x71, 0xfc, 0x08, 0xed, 0xce, 0x32, 0xc8, 0x8e, 0x5b, 0xf1,
+	0x80, 0x1b, 0x3f, 0x23, 0x2a, 0x3b, 0x6d, 0xc5, 0xe3, 0x1d, 0x6e, 0xac, 0x1a, 0x3f, 0x45, 0xfb,
+	0x34, 0x31, 0x7c, 0x4e, 0x0d, 0x97, 0x22, 0x4e, 0xa4, 0xc8, 0x8c, 0xa6, 0x5c, 0x98, 0x2c, 0xaa,
+	0xb8, 0x0e, 0xd8, 0xbb, 0x62, 0xbb, 0x57, 0x24, 0x7e, 0x88, 0x4a, 0x13, 0x9a, 0x99, 0x38, 0x03,
+	0x10, 0xd1, 0x5e, 0x35, 0xa8, 0xe7, 0x48, 0x68, 0x81, 0x3e, 0x80, 0xa8, 0xfd, 0x1e, 0xa0, 0xed,
+	0x76, 0x8f, 0xf4, 0xc1, 0x18, 0xfb, 0x61, 0x30, 0x46, 0x5b, 0x03, 0x2a, 0x98, 0x3b, 0x09, 0x25,
+	0xe2, 0xc6, 0x78, 0x1f, 0x15, 0xa6, 0x54, 0xa7, 0x5c, 0xb8, 0x7e, 0xce, 0x93, 0x55, 0x64, 0x8d,
+	0x19, 0x35, 0x34, 0xd6, 0xd4, 0xc0, 0xaa, 0x37, 0x43, 0x0b, 0x10, 0x6a, 0x00, 0x3f, 0x40, 0xa1,
+	0x79, 0x1d, 0x2b, 0xb9, 0x00, 0xed, 0x1a, 0x34, 0x4f, 0x8a, 0xe6, 0xf5, 0x0b, 0x1b, 0x5a, 0x4a,
+	0x0c, 0x62, 0xa3, 0xa9, 0xc8, 0x5c, 0x8b, 0xe6, 0x49, 0x51, 0x0c, 0x2e, 0x6c, 0x68, 0xa9, 0x0c,
+	0x04, 0x8b, 0x35, 0xbc, 0x72, 0x9d, 0x16, 0x92, 0xa2, 0x8d, 0x09, 0xbc, 0xb2, 0x55, 0x0c, 0x29,
+	0x9f, 0x00, 0x73, 0xed, 0x92, 0x27, 0xab, 0xa8, 0xd6, 0x47, 0xf9, 0x67, 0x9a, 0x4e, 0x01, 0xdf,
+	0x43, 0x79, 0xff, 0xf1, 0x02, 0xb7, 0x1f, 0x5b, 0x76, 0x3f, 0xf0, 0x2e, 0xca, 0x65, 0x42, 0xbb,
+	0xc2, 0xef, 0x10, 0x3b, 0xc4, 0x9f, 0xa3, 0x4a, 0x4a, 0x0d, 0x2c, 0xe8, 0x32, 0x4e, 0xe4, 0x4c,
+	0x18, 0x57, 0x79, 0x85, 0x94, 0x57, 0x60, 0xd7, 0x62, 0xb5, 0x5f, 0xf3, 0x28, 0x3c, 0x6b, 0x77,
+	0xfb, 0xc6, 0x2e, 0xe5, 0xff, 0x1f, 0xc4, 0x2d, 0x3f, 0x88, 0x8f, 0xed, 0xa0, 0x7c, 0xa8, 0xe3,
+	0xf1, 0x17, 0x28, 0x47, 0x99, 0x8e, 0x8e, 0xaa, 0x41, 0x7d, 0xbb, 0x75, 0xbf, 0xb1, 0xba, 0x11,
+	0x1b, 0xef, 0x1d, 0x02, 0x62, 0x05, 0xf8, 0x18, 0x55, 0x86, 0xb6, 0xaf, 0xe2, 0x11, 0xcf, 0x8c,
+	0xd4, 0xcb, 0xa8, 0x5a, 0xcd, 0xd5, 0xb7, 0x5b, 0x77, 0xd7, 0x33, 0x5c, 0xd7, 0x91, 0xb2, 0x13,
+	0x7d, 0xef, 0x35, 0xf8, 0x11, 0xda, 0x99, 0xc8, 0x2c, 0x8b, 0x15, 0xe8, 0x04, 0x84, 0xa1, 0x29,
+	0x44, 0x9f, 0xb9, 0x45, 0xdf, 0xb5, 0xf0, 0x8b, 0x35, 0x8a, 0xbf, 0x44, 0xf7, 0x15, 0x08, 0xc6,
+	0x45, 0x1a, 0x4f, 0x69, 0x12, 0x27, 0x72, 0x3a, 0xa5, 0x82, 0x65, 0x51, 0xad, 0x9a, 0xab, 0x97,
+	0x08, 0x5e, 0x71, 0x67, 0x34, 0xe9, 0xae, 0x98, 0xd6, 0xcf, 0x39, 0x54, 0xf1, 0x37, 0xd6, 0x19,
+	0x15, 0x34, 0x05, 0x8d, 0xbf, 0x41, 0xa5, 0xe7, 0x60, 0x3c, 0x86, 0x1f, 0xac, 0xeb, 0xba, 0x7e,
+	0x17, 0x1f, 0xec, 0x5c, 0xa3, 0xf0, 0x13, 0x54, 0xea, 0xaf, 0x27, 0x5e, 0x67, 0x0f, 0xf6, 0x1b,
+	0xfe, 0x71, 0xd0, 0x78, 0x77, 0xed, 0x37, 0x4e, 0xec, 0xe3, 0x00, 0xb7, 0x51, 0xb9, 0x07, 0x13,
+	0x30, 0x70, 0x7b, 0xc6, 0x9b, 0x2c, 0xbe, 0x45, 0xdb, 0xcf, 0xc1, 0xac, 0x0f, 0xd6, 0x07, 0x1c,
+	0x3e, 0x59, 0x53, 0x6b, 0x75, 0x07, 0x55, 0x08, 0x64, 0xff, 0x6e, 0xfa, 0x4d, 0x05, 0x7c, 0x87,
+	0x76, 0x08, 0xd8, 0xad, 0x82, 0xb5, 0xcb, 0x3f, 0x33, 0xdd, 0x34, 0xbb, 0xd3, 0xf9, 0xe3, 0xf2,
+	0x30, 0xf8, 0xf3, 0xf2, 0x30, 0xf8, 0xeb, 0xf2, 0x30, 0xf8, 0xe9, 0xc9, 0x26, 0xef, 0xb1, 0x41,
+	0xc1, 0x21, 0xc7, 0x7f, 0x0f, 0x00, 0xd2, 0xb6, 0xb7, 0x90, 0xce, 0x09, 0x00, 0x00,
 }
@@ -81,3 +81,17 @@ func (m *Message) EncryptFRMPayload(appSKey types.AppSKey) error {
 func (m *Message) DecryptFRMPayload(appSKey types.AppSKey) error {
 	return m.cryptFRMPayload(appSKey)
 }
+
+// DevAddrFromPayload returns the DevAddr of a LoRaWAN data message. It returns false if the payload is not a LoRaWAN
+// data message.
+func DevAddrFromPayload(payload []byte) (devAddr types.DevAddr, ok bool) {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(payload); err != nil {
+		return
+	}
+	macPayload, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return
+	}
+	return types.DevAddr(macPayload.FHDR.DevAddr), true
+}
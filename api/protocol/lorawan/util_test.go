@@ -52,3 +52,20 @@ func TestEncryptDecrypt(t *testing.T) {
 		a.So(m.GetMacPayload().FrmPayload, ShouldResemble, payload)
 	}
 }
+
+func TestDevAddrFromPayload(t *testing.T) {
+	a := New(t)
+
+	macBin := []byte{65, 4, 3, 2, 1, 0, 1, 0, 0, 1, 2, 3, 4}
+	joinReqBin := []byte{1, 8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1, 2, 1, 1, 2, 3, 4}
+
+	devAddr, ok := DevAddrFromPayload(macBin)
+	a.So(ok, ShouldBeTrue)
+	a.So(devAddr, ShouldEqual, types.DevAddr{1, 2, 3, 4})
+
+	_, ok = DevAddrFromPayload(joinReqBin)
+	a.So(ok, ShouldBeFalse)
+
+	_, ok = DevAddrFromPayload([]byte{1, 2, 3})
+	a.So(ok, ShouldBeFalse)
+}
@@ -37,6 +37,23 @@ func (m *Device) Validate() error {
 	return nil
 }
 
+// Validate implements the api.Validator interface
+func (m *MACState) Validate() error {
+	if m.AppEui == nil || m.AppEui.IsEmpty() {
+		return errors.NewErrInvalidArgument("AppEui", "can not be empty")
+	}
+	if m.DevEui == nil || m.DevEui.IsEmpty() {
+		return errors.NewErrInvalidArgument("DevEui", "can not be empty")
+	}
+	if m.DevAddr == nil {
+		return errors.NewErrInvalidArgument("DevAddr", "can not be empty")
+	}
+	if m.Adr == nil {
+		return errors.NewErrInvalidArgument("Adr", "can not be empty")
+	}
+	return nil
+}
+
 // Validate implements the api.Validator interface
 func (m *Metadata) Validate() error {
 	switch m.Modulation {
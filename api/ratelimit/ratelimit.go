@@ -42,9 +42,23 @@ func (r *Registry) getOrCreate(id string, createFunc func() *ratelimit.Bucket) *
 }
 
 func (r *Registry) newFunc() *ratelimit.Bucket {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return ratelimit.NewBucketWithQuantum(r.per, int64(r.rate), int64(r.rate))
 }
 
+// SetRate changes the rate limit. If the rate limit changes, the limits of all entities are reset.
+func (r *Registry) SetRate(rate int, per time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rate == r.rate && per == r.per {
+		return
+	}
+	r.rate = rate
+	r.per = per
+	r.entities = make(map[string]*ratelimit.Bucket)
+}
+
 // Limit returns true if the ratelimit for the given entity has been reached
 func (r *Registry) Limit(id string) bool {
 	return r.Wait(id) != 0
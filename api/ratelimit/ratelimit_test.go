@@ -0,0 +1,30 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRegistry(t *testing.T) {
+	a := New(t)
+
+	r := NewRegistry(2, time.Hour)
+	a.So(r.Limit("a"), ShouldBeFalse)
+	a.So(r.Limit("a"), ShouldBeFalse)
+	a.So(r.Limit("a"), ShouldBeTrue)
+	a.So(r.Limit("b"), ShouldBeFalse)
+
+	r.SetRate(2, time.Hour)
+	a.So(r.Limit("a"), ShouldBeTrue)
+
+	r.SetRate(3, time.Hour)
+	a.So(r.Limit("a"), ShouldBeFalse)
+	a.So(r.Limit("a"), ShouldBeFalse)
+	a.So(r.Limit("a"), ShouldBeFalse)
+	a.So(r.Limit("a"), ShouldBeTrue)
+}
@@ -16,6 +16,14 @@
 		DeviceActivationResponse
 		GatewayStatusRequest
 		GatewayStatusResponse
+		GatewayHistoryRequest
+		GatewayTraffic
+		GatewayChannelTraffic
+		GatewayDataRateTraffic
+		GatewayConnection
+		GatewayHistoryResponse
+		TrafficRequest
+		TrafficMessage
 		StatusRequest
 		Status
 */
@@ -263,6 +271,267 @@ func (m *GatewayStatusResponse) GetStatus() *gateway.Status {
 	return nil
 }
 
+// message GatewayHistoryRequest is used to request the traffic, connection and
+// status history of a gateway from this Router
+type GatewayHistoryRequest struct {
+	GatewayId string `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Only return the history after this time (Unix nanoseconds)
+	Since int64 `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (m *GatewayHistoryRequest) Reset()                    { *m = GatewayHistoryRequest{} }
+func (m *GatewayHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*GatewayHistoryRequest) ProtoMessage()               {}
+func (*GatewayHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{7} }
+
+func (m *GatewayHistoryRequest) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+func (m *GatewayHistoryRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+// message GatewayTraffic is the traffic of a gateway in one hour
+type GatewayTraffic struct {
+	// Start of the hour (Unix nanoseconds)
+	Hour     int64  `protobuf:"varint,1,opt,name=hour,proto3" json:"hour,omitempty"`
+	Uplink   uint32 `protobuf:"varint,2,opt,name=uplink,proto3" json:"uplink,omitempty"`
+	Downlink uint32 `protobuf:"varint,3,opt,name=downlink,proto3" json:"downlink,omitempty"`
+	// Uplink messages per channel
+	Channels []*GatewayChannelTraffic `protobuf:"bytes,4,rep,name=channels" json:"channels,omitempty"`
+	// Uplink messages per data rate
+	DataRates []*GatewayDataRateTraffic `protobuf:"bytes,5,rep,name=data_rates,json=dataRates" json:"data_rates,omitempty"`
+}
+
+func (m *GatewayTraffic) Reset()                    { *m = GatewayTraffic{} }
+func (m *GatewayTraffic) String() string            { return proto.CompactTextString(m) }
+func (*GatewayTraffic) ProtoMessage()               {}
+func (*GatewayTraffic) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{8} }
+
+func (m *GatewayTraffic) GetHour() int64 {
+	if m != nil {
+		return m.Hour
+	}
+	return 0
+}
+
+func (m *GatewayTraffic) GetUplink() uint32 {
+	if m != nil {
+		return m.Uplink
+	}
+	return 0
+}
+
+func (m *GatewayTraffic) GetDownlink() uint32 {
+	if m != nil {
+		return m.Downlink
+	}
+	return 0
+}
+
+func (m *GatewayTraffic) GetChannels() []*GatewayChannelTraffic {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *GatewayTraffic) GetDataRates() []*GatewayDataRateTraffic {
+	if m != nil {
+		return m.DataRates
+	}
+	return nil
+}
+
+type GatewayChannelTraffic struct {
+	// Frequency in Hz
+	Frequency uint64 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Uplink    uint32 `protobuf:"varint,2,opt,name=uplink,proto3" json:"uplink,omitempty"`
+}
+
+func (m *GatewayChannelTraffic) Reset()                    { *m = GatewayChannelTraffic{} }
+func (m *GatewayChannelTraffic) String() string            { return proto.CompactTextString(m) }
+func (*GatewayChannelTraffic) ProtoMessage()               {}
+func (*GatewayChannelTraffic) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{9} }
+
+func (m *GatewayChannelTraffic) GetFrequency() uint64 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *GatewayChannelTraffic) GetUplink() uint32 {
+	if m != nil {
+		return m.Uplink
+	}
+	return 0
+}
+
+type GatewayDataRateTraffic struct {
+	DataRate string `protobuf:"bytes,1,opt,name=data_rate,json=dataRate,proto3" json:"data_rate,omitempty"`
+	Uplink   uint32 `protobuf:"varint,2,opt,name=uplink,proto3" json:"uplink,omitempty"`
+}
+
+func (m *GatewayDataRateTraffic) Reset()                    { *m = GatewayDataRateTraffic{} }
+func (m *GatewayDataRateTraffic) String() string            { return proto.CompactTextString(m) }
+func (*GatewayDataRateTraffic) ProtoMessage()               {}
+func (*GatewayDataRateTraffic) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{10} }
+
+func (m *GatewayDataRateTraffic) GetDataRate() string {
+	if m != nil {
+		return m.DataRate
+	}
+	return ""
+}
+
+func (m *GatewayDataRateTraffic) GetUplink() uint32 {
+	if m != nil {
+		return m.Uplink
+	}
+	return 0
+}
+
+// message GatewayConnection is a connect or disconnect of a gateway stream
+type GatewayConnection struct {
+	// Time in Unix nanoseconds
+	Time int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	// The stream: uplink, downlink or status
+	Stream    string `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
+	Connected bool   `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+}
+
+func (m *GatewayConnection) Reset()                    { *m = GatewayConnection{} }
+func (m *GatewayConnection) String() string            { return proto.CompactTextString(m) }
+func (*GatewayConnection) ProtoMessage()               {}
+func (*GatewayConnection) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{11} }
+
+func (m *GatewayConnection) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *GatewayConnection) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
+func (m *GatewayConnection) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+type GatewayHistoryResponse struct {
+	// Hourly traffic, oldest first
+	Traffic []*GatewayTraffic `protobuf:"bytes,1,rep,name=traffic" json:"traffic,omitempty"`
+	// Connects and disconnects, oldest first
+	Connections []*GatewayConnection `protobuf:"bytes,2,rep,name=connections" json:"connections,omitempty"`
+	// Status messages, oldest first
+	Statuses []*gateway.Status `protobuf:"bytes,3,rep,name=statuses" json:"statuses,omitempty"`
+}
+
+func (m *GatewayHistoryResponse) Reset()                    { *m = GatewayHistoryResponse{} }
+func (m *GatewayHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*GatewayHistoryResponse) ProtoMessage()               {}
+func (*GatewayHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{12} }
+
+func (m *GatewayHistoryResponse) GetTraffic() []*GatewayTraffic {
+	if m != nil {
+		return m.Traffic
+	}
+	return nil
+}
+
+func (m *GatewayHistoryResponse) GetConnections() []*GatewayConnection {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+func (m *GatewayHistoryResponse) GetStatuses() []*gateway.Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+// message TrafficRequest is used to subscribe to the uplink and downlink
+// traffic of this Router
+type TrafficRequest struct {
+	// Only send the traffic of this gateway
+	GatewayId string `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Only send the traffic of this device address
+	DevAddr *github_com_TheThingsNetwork_ttn_core_types.DevAddr `protobuf:"bytes,2,opt,name=dev_addr,json=devAddr,proto3,customtype=github.com/TheThingsNetwork/ttn/core/types.DevAddr" json:"dev_addr,omitempty"`
+}
+
+func (m *TrafficRequest) Reset()                    { *m = TrafficRequest{} }
+func (m *TrafficRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrafficRequest) ProtoMessage()               {}
+func (*TrafficRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{13} }
+
+func (m *TrafficRequest) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+// message TrafficMessage is an uplink or downlink message of a gateway
+type TrafficMessage struct {
+	// Time in Unix nanoseconds
+	Time      int64            `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	GatewayId string           `protobuf:"bytes,2,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	Uplink    *UplinkMessage   `protobuf:"bytes,11,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink  *DownlinkMessage `protobuf:"bytes,12,opt,name=downlink" json:"downlink,omitempty"`
+}
+
+func (m *TrafficMessage) Reset()                    { *m = TrafficMessage{} }
+func (m *TrafficMessage) String() string            { return proto.CompactTextString(m) }
+func (*TrafficMessage) ProtoMessage()               {}
+func (*TrafficMessage) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{14} }
+
+func (m *TrafficMessage) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *TrafficMessage) GetGatewayId() string {
+	if m != nil {
+		return m.GatewayId
+	}
+	return ""
+}
+
+func (m *TrafficMessage) GetUplink() *UplinkMessage {
+	if m != nil {
+		return m.Uplink
+	}
+	return nil
+}
+
+func (m *TrafficMessage) GetDownlink() *DownlinkMessage {
+	if m != nil {
+		return m.Downlink
+	}
+	return nil
+}
+
 // message StatusRequest is used to request the status of this Router
 type StatusRequest struct {
 }
@@ -270,7 +539,7 @@ type StatusRequest struct {
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{7} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{15} }
 
 // message Status is the response to the StatusRequest
 type Status struct {
@@ -288,7 +557,7 @@ type Status struct {
 func (m *Status) Reset()                    { *m = Status{} }
 func (m *Status) String() string            { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()               {}
-func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{8} }
+func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorRouter, []int{16} }
 
 func (m *Status) GetSystem() *api.SystemStats {
 	if m != nil {
@@ -354,6 +623,14 @@ func init() {
 	proto.RegisterType((*DeviceActivationResponse)(nil), "router.DeviceActivationResponse")
 	proto.RegisterType((*GatewayStatusRequest)(nil), "router.GatewayStatusRequest")
 	proto.RegisterType((*GatewayStatusResponse)(nil), "router.GatewayStatusResponse")
+	proto.RegisterType((*GatewayHistoryRequest)(nil), "router.GatewayHistoryRequest")
+	proto.RegisterType((*GatewayTraffic)(nil), "router.GatewayTraffic")
+	proto.RegisterType((*GatewayChannelTraffic)(nil), "router.GatewayChannelTraffic")
+	proto.RegisterType((*GatewayDataRateTraffic)(nil), "router.GatewayDataRateTraffic")
+	proto.RegisterType((*GatewayConnection)(nil), "router.GatewayConnection")
+	proto.RegisterType((*GatewayHistoryResponse)(nil), "router.GatewayHistoryResponse")
+	proto.RegisterType((*TrafficRequest)(nil), "router.TrafficRequest")
+	proto.RegisterType((*TrafficMessage)(nil), "router.TrafficMessage")
 	proto.RegisterType((*StatusRequest)(nil), "router.StatusRequest")
 	proto.RegisterType((*Status)(nil), "router.Status")
 }
@@ -643,6 +920,12 @@ type RouterManagerClient interface {
 	// Gateway owner or network operator requests Gateway status from Router Manager
 	// Deprecated: Use monitor API (NOC) instead of this
 	GatewayStatus(ctx context.Context, in *GatewayStatusRequest, opts ...grpc.CallOption) (*GatewayStatusResponse, error)
+	// Gateway owner or network operator requests Gateway traffic, connection
+	// and status history from Router Manager
+	GatewayHistory(ctx context.Context, in *GatewayHistoryRequest, opts ...grpc.CallOption) (*GatewayHistoryResponse, error)
+	// Gateway owner or network operator subscribes to the live uplink and
+	// downlink traffic of the Router
+	Traffic(ctx context.Context, in *TrafficRequest, opts ...grpc.CallOption) (RouterManager_TrafficClient, error)
 	// Network operator requests Router status
 	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Status, error)
 }
@@ -664,6 +947,47 @@ func (c *routerManagerClient) GatewayStatus(ctx context.Context, in *GatewayStat
 	return out, nil
 }
 
+func (c *routerManagerClient) GatewayHistory(ctx context.Context, in *GatewayHistoryRequest, opts ...grpc.CallOption) (*GatewayHistoryResponse, error) {
+	out := new(GatewayHistoryResponse)
+	err := grpc.Invoke(ctx, "/router.RouterManager/GatewayHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerManagerClient) Traffic(ctx context.Context, in *TrafficRequest, opts ...grpc.CallOption) (RouterManager_TrafficClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RouterManager_serviceDesc.Streams[0], c.cc, "/router.RouterManager/Traffic", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routerManagerTrafficClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RouterManager_TrafficClient interface {
+	Recv() (*TrafficMessage, error)
+	grpc.ClientStream
+}
+
+type routerManagerTrafficClient struct {
+	grpc.ClientStream
+}
+
+func (x *routerManagerTrafficClient) Recv() (*TrafficMessage, error) {
+	m := new(TrafficMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *routerManagerClient) GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Status, error) {
 	out := new(Status)
 	err := grpc.Invoke(ctx, "/router.RouterManager/GetStatus", in, out, c.cc, opts...)
@@ -679,6 +1003,12 @@ type RouterManagerServer interface {
 	// Gateway owner or network operator requests Gateway status from Router Manager
 	// Deprecated: Use monitor API (NOC) instead of this
 	GatewayStatus(context.Context, *GatewayStatusRequest) (*GatewayStatusResponse, error)
+	// Gateway owner or network operator requests Gateway traffic, connection
+	// and status history from Router Manager
+	GatewayHistory(context.Context, *GatewayHistoryRequest) (*GatewayHistoryResponse, error)
+	// Gateway owner or network operator subscribes to the live uplink and
+	// downlink traffic of the Router
+	Traffic(*TrafficRequest, RouterManager_TrafficServer) error
 	// Network operator requests Router status
 	GetStatus(context.Context, *StatusRequest) (*Status, error)
 }
@@ -705,6 +1035,45 @@ func _RouterManager_GatewayStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RouterManager_GatewayHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GatewayHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterManagerServer).GatewayHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/router.RouterManager/GatewayHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterManagerServer).GatewayHistory(ctx, req.(*GatewayHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterManager_Traffic_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrafficRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouterManagerServer).Traffic(m, &routerManagerTrafficServer{stream})
+}
+
+type RouterManager_TrafficServer interface {
+	Send(*TrafficMessage) error
+	grpc.ServerStream
+}
+
+type routerManagerTrafficServer struct {
+	grpc.ServerStream
+}
+
+func (x *routerManagerTrafficServer) Send(m *TrafficMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RouterManager_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatusRequest)
 	if err := dec(in); err != nil {
@@ -731,12 +1100,22 @@ var _RouterManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GatewayStatus",
 			Handler:    _RouterManager_GatewayStatus_Handler,
 		},
+		{
+			MethodName: "GatewayHistory",
+			Handler:    _RouterManager_GatewayHistory_Handler,
+		},
 		{
 			MethodName: "GetStatus",
 			Handler:    _RouterManager_GetStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Traffic",
+			Handler:       _RouterManager_Traffic_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "github.com/TheThingsNetwork/ttn/api/router/router.proto",
 }
 
@@ -1067,7 +1446,7 @@ func (m *GatewayStatusResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
+func (m *GatewayHistoryRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1077,15 +1456,26 @@ func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GatewayHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if m.Since != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Since))
+	}
 	return i, nil
 }
 
-func (m *Status) Marshal() (dAtA []byte, err error) {
+func (m *GatewayTraffic) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1095,50 +1485,358 @@ func (m *Status) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Status) MarshalTo(dAtA []byte) (int, error) {
+func (m *GatewayTraffic) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.System != nil {
-		dAtA[i] = 0xa
+	if m.Hour != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRouter(dAtA, i, uint64(m.System.Size()))
-		n17, err := m.System.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n17
+		i = encodeVarintRouter(dAtA, i, uint64(m.Hour))
 	}
-	if m.Component != nil {
-		dAtA[i] = 0x12
+	if m.Uplink != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRouter(dAtA, i, uint64(m.Component.Size()))
-		n18, err := m.Component.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
+		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink))
 	}
-	if m.GatewayStatus != nil {
-		dAtA[i] = 0x5a
+	if m.Downlink != 0 {
+		dAtA[i] = 0x18
 		i++
-		i = encodeVarintRouter(dAtA, i, uint64(m.GatewayStatus.Size()))
-		n19, err := m.GatewayStatus.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		i = encodeVarintRouter(dAtA, i, uint64(m.Downlink))
+	}
+	if len(m.Channels) > 0 {
+		for _, msg := range m.Channels {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n19
 	}
-	if m.Uplink != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink.Size()))
-		n20, err := m.Uplink.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.DataRates) > 0 {
+		for _, msg := range m.DataRates {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n20
+	}
+	return i, nil
+}
+
+func (m *GatewayChannelTraffic) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GatewayChannelTraffic) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Frequency != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Frequency))
+	}
+	if m.Uplink != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink))
+	}
+	return i, nil
+}
+
+func (m *GatewayDataRateTraffic) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GatewayDataRateTraffic) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DataRate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.DataRate)))
+		i += copy(dAtA[i:], m.DataRate)
+	}
+	if m.Uplink != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink))
+	}
+	return i, nil
+}
+
+func (m *GatewayConnection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GatewayConnection) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Time))
+	}
+	if len(m.Stream) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.Stream)))
+		i += copy(dAtA[i:], m.Stream)
+	}
+	if m.Connected {
+		dAtA[i] = 0x18
+		i++
+		if m.Connected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *GatewayHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GatewayHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Traffic) > 0 {
+		for _, msg := range m.Traffic {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Connections) > 0 {
+		for _, msg := range m.Connections {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Statuses) > 0 {
+		for _, msg := range m.Statuses {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintRouter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *TrafficRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrafficRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if m.DevAddr != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.DevAddr.Size()))
+		n23, err := m.DevAddr.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	return i, nil
+}
+
+func (m *TrafficMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TrafficMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Time != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Time))
+	}
+	if len(m.GatewayId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(len(m.GatewayId)))
+		i += copy(dAtA[i:], m.GatewayId)
+	}
+	if m.Uplink != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink.Size()))
+		n24, err := m.Uplink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.Downlink != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Downlink.Size()))
+		n25, err := m.Downlink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	return i, nil
+}
+
+func (m *StatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *Status) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Status) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.System != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.System.Size()))
+		n17, err := m.System.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.Component != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Component.Size()))
+		n18, err := m.Component.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.GatewayStatus != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.GatewayStatus.Size()))
+		n19, err := m.GatewayStatus.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.Uplink != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRouter(dAtA, i, uint64(m.Uplink.Size()))
+		n20, err := m.Uplink.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
 	}
 	if m.Downlink != nil {
 		dAtA[i] = 0x6a
@@ -1329,25 +2027,165 @@ func (m *GatewayStatusResponse) Size() (n int) {
 	return n
 }
 
-func (m *StatusRequest) Size() (n int) {
+func (m *GatewayHistoryRequest) Size() (n int) {
 	var l int
 	_ = l
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Since != 0 {
+		n += 1 + sovRouter(uint64(m.Since))
+	}
 	return n
 }
 
-func (m *Status) Size() (n int) {
+func (m *GatewayTraffic) Size() (n int) {
 	var l int
 	_ = l
-	if m.System != nil {
-		l = m.System.Size()
-		n += 1 + l + sovRouter(uint64(l))
+	if m.Hour != 0 {
+		n += 1 + sovRouter(uint64(m.Hour))
 	}
-	if m.Component != nil {
-		l = m.Component.Size()
-		n += 1 + l + sovRouter(uint64(l))
+	if m.Uplink != 0 {
+		n += 1 + sovRouter(uint64(m.Uplink))
 	}
-	if m.GatewayStatus != nil {
-		l = m.GatewayStatus.Size()
+	if m.Downlink != 0 {
+		n += 1 + sovRouter(uint64(m.Downlink))
+	}
+	if len(m.Channels) > 0 {
+		for _, e := range m.Channels {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	if len(m.DataRates) > 0 {
+		for _, e := range m.DataRates {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GatewayChannelTraffic) Size() (n int) {
+	var l int
+	_ = l
+	if m.Frequency != 0 {
+		n += 1 + sovRouter(uint64(m.Frequency))
+	}
+	if m.Uplink != 0 {
+		n += 1 + sovRouter(uint64(m.Uplink))
+	}
+	return n
+}
+
+func (m *GatewayDataRateTraffic) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.DataRate)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Uplink != 0 {
+		n += 1 + sovRouter(uint64(m.Uplink))
+	}
+	return n
+}
+
+func (m *GatewayConnection) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovRouter(uint64(m.Time))
+	}
+	l = len(m.Stream)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Connected {
+		n += 2
+	}
+	return n
+}
+
+func (m *GatewayHistoryResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Traffic) > 0 {
+		for _, e := range m.Traffic {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	if len(m.Connections) > 0 {
+		for _, e := range m.Connections {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	if len(m.Statuses) > 0 {
+		for _, e := range m.Statuses {
+			l = e.Size()
+			n += 1 + l + sovRouter(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TrafficRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.DevAddr != nil {
+		l = m.DevAddr.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	return n
+}
+
+func (m *TrafficMessage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Time != 0 {
+		n += 1 + sovRouter(uint64(m.Time))
+	}
+	l = len(m.GatewayId)
+	if l > 0 {
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Uplink != nil {
+		l = m.Uplink.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Downlink != nil {
+		l = m.Downlink.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	return n
+}
+
+func (m *StatusRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *Status) Size() (n int) {
+	var l int
+	_ = l
+	if m.System != nil {
+		l = m.System.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.Component != nil {
+		l = m.Component.Size()
+		n += 1 + l + sovRouter(uint64(l))
+	}
+	if m.GatewayStatus != nil {
+		l = m.GatewayStatus.Size()
 		n += 1 + l + sovRouter(uint64(l))
 	}
 	if m.Uplink != nil {
@@ -1607,25 +2445,877 @@ func (m *UplinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DownlinkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DownlinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &protocol.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolConfiguration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProtocolConfiguration == nil {
+				m.ProtocolConfiguration = &protocol.TxConfiguration{}
+			}
+			if err := m.ProtocolConfiguration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayConfiguration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GatewayConfiguration == nil {
+				m.GatewayConfiguration = &gateway.TxConfiguration{}
+			}
+			if err := m.GatewayConfiguration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceActivationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceActivationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &protocol.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
+			m.DevEui = &v
+			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
+			m.AppEui = &v
+			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProtocolMetadata == nil {
+				m.ProtocolMetadata = &protocol.RxMetadata{}
+			}
+			if err := m.ProtocolMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GatewayMetadata == nil {
+				m.GatewayMetadata = &gateway.RxMetadata{}
+			}
+			if err := m.GatewayMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ActivationMetadata == nil {
+				m.ActivationMetadata = &protocol.ActivationMetadata{}
+			}
+			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trace == nil {
+				m.Trace = &trace.Trace{}
+			}
+			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GatewayStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+			}
+			m.LastSeen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSeen |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &gateway.Status{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRouter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GatewayHistoryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Since |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRouter
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Trace == nil {
-				m.Trace = &trace.Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRouter(dAtA[iNdEx:])
@@ -1647,7 +3337,8 @@ func (m *UplinkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
+
+func (m *GatewayTraffic) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1670,17 +3361,17 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DownlinkMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: GatewayTraffic: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DownlinkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GatewayTraffic: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hour", wireType)
 			}
-			var byteLen int
+			m.Hour = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1690,28 +3381,16 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Hour |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRouter
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
 			}
-			var msglen int
+			m.Uplink = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1721,30 +3400,16 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Uplink |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRouter
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Message == nil {
-				m.Message = &protocol.Message{}
-			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolConfiguration", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
 			}
-			var msglen int
+			m.Downlink = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1754,28 +3419,14 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Downlink |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRouter
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ProtocolConfiguration == nil {
-				m.ProtocolConfiguration = &protocol.TxConfiguration{}
-			}
-			if err := m.ProtocolConfiguration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 12:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayConfiguration", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Channels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1799,16 +3450,14 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.GatewayConfiguration == nil {
-				m.GatewayConfiguration = &gateway.TxConfiguration{}
-			}
-			if err := m.GatewayConfiguration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Channels = append(m.Channels, &GatewayChannelTraffic{})
+			if err := m.Channels[len(m.Channels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 21:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRates", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1832,10 +3481,8 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Trace == nil {
-				m.Trace = &trace.Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.DataRates = append(m.DataRates, &GatewayDataRateTraffic{})
+			if err := m.DataRates[len(m.DataRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1860,7 +3507,8 @@ func (m *DownlinkMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
+
+func (m *GatewayChannelTraffic) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1883,17 +3531,17 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceActivationRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GatewayChannelTraffic: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceActivationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GatewayChannelTraffic: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frequency", wireType)
 			}
-			var byteLen int
+			m.Frequency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1903,28 +3551,86 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Frequency |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
+			}
+			m.Uplink = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Uplink |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRouter
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *GatewayDataRateTraffic) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
 			}
-			iNdEx = postIndex
-		case 2:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayDataRateTraffic: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayDataRateTraffic: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRate", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1934,30 +3640,26 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRouter
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Message == nil {
-				m.Message = &protocol.Message{}
-			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DataRate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DevEui", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
 			}
-			var byteLen int
+			m.Uplink = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1967,29 +3669,86 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Uplink |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRouter
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v github_com_TheThingsNetwork_ttn_core_types.DevEUI
-			m.DevEui = &v
-			if err := m.DevEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *GatewayConnection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayConnection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayConnection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppEui", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -1999,29 +3758,26 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRouter
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			var v github_com_TheThingsNetwork_ttn_core_types.AppEUI
-			m.AppEui = &v
-			if err := m.AppEui.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Stream = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 21:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolMetadata", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connected", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -2031,28 +3787,66 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.Connected = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRouter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRouter
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ProtocolMetadata == nil {
-				m.ProtocolMetadata = &protocol.RxMetadata{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *GatewayHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRouter
 			}
-			if err := m.ProtocolMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 22:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GatewayHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GatewayHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayMetadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Traffic", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2076,16 +3870,14 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.GatewayMetadata == nil {
-				m.GatewayMetadata = &gateway.RxMetadata{}
-			}
-			if err := m.GatewayMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Traffic = append(m.Traffic, &GatewayTraffic{})
+			if err := m.Traffic[len(m.Traffic)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 23:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivationMetadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Connections", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2109,16 +3901,14 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ActivationMetadata == nil {
-				m.ActivationMetadata = &protocol.ActivationMetadata{}
-			}
-			if err := m.ActivationMetadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Connections = append(m.Connections, &GatewayConnection{})
+			if err := m.Connections[len(m.Connections)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 31:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Trace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2142,10 +3932,8 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Trace == nil {
-				m.Trace = &trace.Trace{}
-			}
-			if err := m.Trace.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Statuses = append(m.Statuses, &gateway.Status{})
+			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2170,57 +3958,8 @@ func (m *DeviceActivationRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeviceActivationResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRouter
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeviceActivationResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeviceActivationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRouter(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRouter
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
 
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GatewayStatusRequest) Unmarshal(dAtA []byte) error {
+func (m *TrafficRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2243,10 +3982,10 @@ func (m *GatewayStatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GatewayStatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrafficRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GatewayStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrafficRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2278,6 +4017,38 @@ func (m *GatewayStatusRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.GatewayId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DevAddr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_TheThingsNetwork_ttn_core_types.DevAddr
+			m.DevAddr = &v
+			if err := m.DevAddr.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRouter(dAtA[iNdEx:])
@@ -2299,7 +4070,8 @@ func (m *GatewayStatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
+
+func (m *TrafficMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2322,17 +4094,17 @@ func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GatewayStatusResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TrafficMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GatewayStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TrafficMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastSeen", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			m.LastSeen = 0
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRouter
@@ -2342,14 +4114,43 @@ func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastSeen |= (int64(b) & 0x7F) << shift
+				m.Time |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uplink", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2373,10 +4174,43 @@ func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Status == nil {
-				m.Status = &gateway.Status{}
+			if m.Uplink == nil {
+				m.Uplink = &UplinkMessage{}
 			}
-			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Uplink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downlink", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRouter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRouter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Downlink == nil {
+				m.Downlink = &DownlinkMessage{}
+			}
+			if err := m.Downlink.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2401,6 +4235,7 @@ func (m *GatewayStatusResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+
 func (m *StatusRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2847,61 +4682,83 @@ func init() {
 }
 
 var fileDescriptorRouter = []byte{
-	// 884 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x56, 0xcb, 0x6e, 0xdb, 0x46,
-	0x14, 0x05, 0x1d, 0x94, 0xb6, 0xae, 0x25, 0x5b, 0x1e, 0x5b, 0x36, 0xa3, 0xc4, 0x0f, 0x70, 0xd1,
-	0x1a, 0x4d, 0x43, 0xd5, 0x2a, 0x82, 0x3e, 0x16, 0x45, 0xed, 0xd8, 0x08, 0x02, 0x54, 0x41, 0x41,
-	0x3b, 0x9b, 0x02, 0x85, 0x30, 0xa2, 0x6e, 0x68, 0xc2, 0x12, 0x87, 0xe5, 0x0c, 0xe5, 0xe8, 0x2f,
-	0xda, 0x0f, 0xe8, 0xff, 0x74, 0xd9, 0x75, 0x17, 0x45, 0xe1, 0x0f, 0xe8, 0xb2, 0xbb, 0x02, 0x05,
-	0xe7, 0x41, 0x8a, 0x92, 0xdd, 0xa6, 0x8f, 0x6c, 0x44, 0xce, 0x3d, 0xe7, 0x1e, 0xce, 0x9c, 0xb9,
-	0x33, 0x57, 0xf0, 0x71, 0x18, 0x89, 0xcb, 0x6c, 0xe0, 0x05, 0x6c, 0xdc, 0xb9, 0xb8, 0xc4, 0x8b,
-	0xcb, 0x28, 0x0e, 0xf9, 0x0b, 0x14, 0xd7, 0x2c, 0xbd, 0xea, 0x08, 0x11, 0x77, 0x68, 0x12, 0x75,
-	0x52, 0x96, 0x09, 0x4c, 0xf5, 0xc3, 0x4b, 0x52, 0x26, 0x18, 0xb1, 0xd5, 0xa8, 0xfd, 0x20, 0x64,
-	0x2c, 0x1c, 0x61, 0x47, 0x46, 0x07, 0xd9, 0xab, 0x0e, 0x8e, 0x13, 0x31, 0x55, 0xa4, 0xf6, 0xe3,
-	0x19, 0xf5, 0x90, 0x85, 0xac, 0x64, 0xe5, 0x23, 0x39, 0x90, 0x6f, 0x9a, 0xbe, 0x61, 0x3e, 0x48,
-	0x93, 0x48, 0x87, 0xf6, 0x4d, 0x48, 0x0e, 0x03, 0x36, 0x2a, 0x5e, 0x34, 0x61, 0xd7, 0x10, 0x42,
-	0x2a, 0xf0, 0x9a, 0x4e, 0xcd, 0x53, 0xc3, 0xf7, 0x0d, 0x2c, 0x52, 0x1a, 0xa0, 0xfa, 0x55, 0x90,
-	0x4b, 0xa0, 0x79, 0x9e, 0x0d, 0x78, 0x90, 0x46, 0x03, 0xf4, 0xf1, 0xdb, 0x0c, 0xb9, 0x70, 0xff,
-	0xb0, 0xa0, 0xf1, 0x32, 0x19, 0x45, 0xf1, 0x55, 0x0f, 0x39, 0xa7, 0x21, 0x12, 0x07, 0x96, 0x13,
-	0x3a, 0x1d, 0x31, 0x3a, 0x74, 0xac, 0x03, 0xeb, 0xb0, 0xee, 0x9b, 0x21, 0x79, 0x04, 0xcb, 0x63,
-	0x45, 0x72, 0x96, 0x0e, 0xac, 0xc3, 0xd5, 0xee, 0x86, 0x57, 0xcc, 0x4d, 0x67, 0xfb, 0x86, 0x41,
-	0x8e, 0x61, 0xc3, 0x80, 0xfd, 0x31, 0x0a, 0x3a, 0xa4, 0x82, 0x3a, 0xab, 0x32, 0x6d, 0xab, 0x4c,
-	0xf3, 0x5f, 0xf7, 0x34, 0xe6, 0x37, 0x4d, 0xd0, 0x44, 0xc8, 0xe7, 0xd0, 0xd4, 0x6b, 0x2b, 0x15,
-	0xea, 0x52, 0x61, 0xd3, 0x33, 0x8b, 0x9e, 0x11, 0x58, 0xd7, 0xb1, 0x22, 0xdf, 0x85, 0x77, 0xe4,
-	0xf2, 0x9d, 0x96, 0x4c, 0xaa, 0x7b, 0xca, 0x8c, 0x8b, 0xfc, 0xd7, 0x57, 0x90, 0xfb, 0xc3, 0x12,
-	0xac, 0x9f, 0xb2, 0xeb, 0xf8, 0x2d, 0x38, 0xf0, 0x15, 0x6c, 0x17, 0x0e, 0x04, 0x2c, 0x7e, 0x15,
-	0x85, 0x59, 0x4a, 0x45, 0xc4, 0x62, 0x6d, 0xc3, 0xfd, 0x32, 0xf7, 0xe2, 0xf5, 0xd3, 0x59, 0x82,
-	0xdf, 0x32, 0x48, 0x25, 0x4c, 0x7a, 0xd0, 0x32, 0x86, 0x54, 0x05, 0x95, 0x2b, 0x4e, 0xe1, 0xca,
-	0xbc, 0xde, 0x96, 0x06, 0xaa, 0x72, 0x6f, 0xe2, 0xcf, 0xef, 0xf7, 0x60, 0xe7, 0x14, 0x27, 0x51,
-	0x80, 0xc7, 0x81, 0x88, 0x26, 0x4a, 0x4e, 0xd5, 0xce, 0xff, 0xe5, 0xd3, 0x0b, 0x58, 0x1e, 0xe2,
-	0xa4, 0x8f, 0x59, 0x24, 0x8d, 0xa9, 0x9f, 0x3c, 0xf9, 0xf9, 0x97, 0xfd, 0xa3, 0xbf, 0x3b, 0xa6,
-	0x01, 0x4b, 0xb1, 0x23, 0xa6, 0x09, 0x72, 0xef, 0x14, 0x27, 0x67, 0x2f, 0x9f, 0xfb, 0xf6, 0x10,
-	0x27, 0x67, 0x59, 0x94, 0xeb, 0xd1, 0x24, 0x91, 0x7a, 0xf5, 0x7f, 0xa5, 0x77, 0x9c, 0x24, 0x52,
-	0x8f, 0x26, 0x49, 0xae, 0x77, 0x6b, 0x25, 0xb7, 0xfe, 0x73, 0x25, 0x6f, 0xff, 0x83, 0x4a, 0xee,
-	0xc1, 0x26, 0x2d, 0xec, 0x2f, 0x25, 0x76, 0xa4, 0xc4, 0xc3, 0x72, 0x12, 0xe5, 0x1e, 0x15, 0x5a,
-	0x84, 0x2e, 0xc4, 0xca, 0x8d, 0xdf, 0xbf, 0x7b, 0xe3, 0xdb, 0xe0, 0x2c, 0xee, 0x3b, 0x4f, 0x58,
-	0xcc, 0xd1, 0x7d, 0x02, 0x5b, 0xcf, 0xd4, 0x0c, 0xcf, 0x05, 0x15, 0x19, 0x37, 0x05, 0xb1, 0x0b,
-	0x60, 0x96, 0x19, 0xa9, 0x9a, 0xa8, 0xf9, 0x35, 0x1d, 0x79, 0x3e, 0x74, 0xbf, 0x81, 0xd6, 0x5c,
-	0x9a, 0xd2, 0x23, 0x0f, 0xa0, 0x36, 0xa2, 0x5c, 0xf4, 0x39, 0x62, 0x2c, 0xd3, 0xee, 0xf9, 0x2b,
-	0x79, 0xe0, 0x1c, 0x31, 0x26, 0xef, 0x81, 0xcd, 0x25, 0x5d, 0x97, 0xd2, 0x7a, 0xe1, 0x98, 0x56,
-	0xd1, 0xb0, 0xbb, 0x0e, 0x8d, 0xca, 0x74, 0xdc, 0xdf, 0x96, 0xc0, 0x56, 0x11, 0x72, 0x08, 0x36,
-	0x9f, 0x72, 0x81, 0x63, 0x29, 0xbf, 0xda, 0x6d, 0x7a, 0xf9, 0x8d, 0x7b, 0x2e, 0x43, 0x39, 0x25,
-	0x57, 0x91, 0x03, 0x72, 0x04, 0xb5, 0x80, 0x8d, 0x13, 0x16, 0x63, 0x2c, 0xf4, 0x17, 0x37, 0x25,
-	0xf9, 0xa9, 0x89, 0x2a, 0x7e, 0xc9, 0x22, 0x47, 0xb0, 0x66, 0x96, 0xad, 0x67, 0xaa, 0x0e, 0x38,
-	0xc8, 0x3c, 0x9f, 0x0a, 0xe4, 0x7e, 0x23, 0x9c, 0x5d, 0x39, 0x71, 0xc1, 0xce, 0xe4, 0xad, 0xab,
-	0x8f, 0xee, 0x2c, 0x55, 0x23, 0xe4, 0x5d, 0x58, 0x19, 0xea, 0x9b, 0xc9, 0x69, 0x2c, 0xb0, 0x0a,
-	0x8c, 0x7c, 0x00, 0xab, 0xe5, 0x1e, 0x73, 0x67, 0x6d, 0x81, 0x3a, 0x0b, 0x93, 0xc7, 0x40, 0x02,
-	0x16, 0xc7, 0x18, 0x08, 0x1c, 0xf6, 0xf5, 0xa4, 0xb8, 0x2c, 0xe7, 0x86, 0xbf, 0x51, 0x20, 0x7a,
-	0x9f, 0x38, 0x79, 0x04, 0x65, 0xb0, 0x3f, 0x48, 0xd9, 0x15, 0xa6, 0x5c, 0x96, 0x6e, 0xc3, 0x6f,
-	0x16, 0xc0, 0x89, 0x8a, 0x77, 0xbf, 0x5b, 0x02, 0xdb, 0x97, 0x5d, 0x92, 0x7c, 0x06, 0x8d, 0xca,
-	0x5e, 0x93, 0xf9, 0x6d, 0x6b, 0x6f, 0x7b, 0xaa, 0x91, 0x7a, 0xa6, 0x45, 0x7a, 0x67, 0x79, 0x23,
-	0x3d, 0xb4, 0xc8, 0xa7, 0x60, 0xab, 0x96, 0x44, 0x5a, 0x9e, 0x6e, 0xc1, 0x95, 0x16, 0xf5, 0x17,
-	0xa9, 0x5f, 0x40, 0xad, 0x68, 0x71, 0xc4, 0x31, 0xd9, 0xf3, 0x5d, 0xaf, 0xbd, 0x63, 0x90, 0xb9,
-	0xab, 0xff, 0x43, 0x8b, 0xf4, 0x60, 0x45, 0x57, 0x3c, 0x92, 0xfd, 0x82, 0x76, 0xfb, 0x0d, 0xd8,
-	0x3e, 0xb8, 0x9b, 0xa0, 0x4a, 0xbb, 0xfb, 0xbd, 0x05, 0x0d, 0x65, 0x49, 0x8f, 0xc6, 0x34, 0xc4,
-	0x94, 0x7c, 0x39, 0xef, 0xcc, 0x43, 0x23, 0x72, 0xdb, 0x99, 0x6a, 0xef, 0xde, 0x81, 0xea, 0xa3,
-	0xd3, 0x85, 0xda, 0x33, 0x14, 0x5a, 0xa9, 0xb0, 0xab, 0x2a, 0xb1, 0x56, 0x0d, 0x9f, 0x7c, 0xf2,
-	0xe3, 0xcd, 0x9e, 0xf5, 0xd3, 0xcd, 0x9e, 0xf5, 0xeb, 0xcd, 0x9e, 0xf5, 0xf5, 0xfb, 0x6f, 0xfe,
-	0x87, 0x68, 0x60, 0x4b, 0xc3, 0x3f, 0xfa, 0x33, 0x00, 0x00, 0xff, 0xff, 0x83, 0xd2, 0x76, 0xdc,
-	0x45, 0x09, 0x00, 0x00,
+	// 1244 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcb, 0x6e, 0xdb, 0x46,
+	0x17, 0x06, 0x25, 0x47, 0x97, 0xa3, 0x8b, 0xed, 0x89, 0xa5, 0x30, 0x4a, 0x7c, 0x01, 0x17, 0xff,
+	0x6f, 0x34, 0x0d, 0x95, 0x28, 0x48, 0xdb, 0x34, 0x68, 0x51, 0x3b, 0x0e, 0xd2, 0x00, 0x51, 0xda,
+	0xd2, 0xce, 0xa6, 0x40, 0x20, 0x8c, 0xc8, 0xb1, 0x4c, 0x44, 0x22, 0x59, 0xce, 0xc8, 0x89, 0xb6,
+	0x7d, 0x82, 0xbe, 0x40, 0xd1, 0x65, 0x5f, 0xa0, 0x0f, 0xd1, 0x65, 0xd1, 0x65, 0x16, 0x41, 0xe1,
+	0x07, 0xe8, 0xb2, 0xbb, 0x02, 0x05, 0xe7, 0x46, 0x91, 0x96, 0x5a, 0xa7, 0x97, 0x8d, 0xc5, 0x39,
+	0xe7, 0x3b, 0x1f, 0xcf, 0x7c, 0xe7, 0xf0, 0xcc, 0x18, 0xde, 0x1f, 0xf9, 0xec, 0x64, 0x3a, 0xb4,
+	0xdd, 0x70, 0xd2, 0x3d, 0x3a, 0x21, 0x47, 0x27, 0x7e, 0x30, 0xa2, 0x4f, 0x09, 0x7b, 0x19, 0xc6,
+	0x2f, 0xba, 0x8c, 0x05, 0x5d, 0x1c, 0xf9, 0xdd, 0x38, 0x9c, 0x32, 0x12, 0xcb, 0x1f, 0x3b, 0x8a,
+	0x43, 0x16, 0xa2, 0x92, 0x58, 0x75, 0xae, 0x8d, 0xc2, 0x70, 0x34, 0x26, 0x5d, 0x6e, 0x1d, 0x4e,
+	0x8f, 0xbb, 0x64, 0x12, 0xb1, 0x99, 0x00, 0x75, 0x6e, 0xce, 0xb1, 0x8f, 0xc2, 0x51, 0x98, 0xa2,
+	0x92, 0x15, 0x5f, 0xf0, 0x27, 0x09, 0x5f, 0x57, 0x2f, 0xc4, 0x91, 0x2f, 0x4d, 0xdb, 0xca, 0xc4,
+	0x97, 0x6e, 0x38, 0xd6, 0x0f, 0x12, 0xb0, 0xa9, 0x00, 0x23, 0xcc, 0xc8, 0x4b, 0x3c, 0x53, 0xbf,
+	0xd2, 0x7d, 0x55, 0xb9, 0x59, 0x8c, 0x5d, 0x22, 0xfe, 0x0a, 0x97, 0x85, 0x60, 0xed, 0x70, 0x3a,
+	0xa4, 0x6e, 0xec, 0x0f, 0x89, 0x43, 0xbe, 0x9a, 0x12, 0xca, 0xac, 0xdf, 0x0d, 0x68, 0x3c, 0x8b,
+	0xc6, 0x7e, 0xf0, 0xa2, 0x4f, 0x28, 0xc5, 0x23, 0x82, 0x4c, 0x28, 0x47, 0x78, 0x36, 0x0e, 0xb1,
+	0x67, 0x1a, 0x3b, 0xc6, 0x6e, 0xdd, 0x51, 0x4b, 0x74, 0x03, 0xca, 0x13, 0x01, 0x32, 0x0b, 0x3b,
+	0xc6, 0x6e, 0xad, 0xb7, 0x6e, 0xeb, 0xdc, 0x64, 0xb4, 0xa3, 0x10, 0x68, 0x0f, 0xd6, 0x95, 0x73,
+	0x30, 0x21, 0x0c, 0x7b, 0x98, 0x61, 0xb3, 0xc6, 0xc3, 0x36, 0xd2, 0x30, 0xe7, 0x55, 0x5f, 0xfa,
+	0x9c, 0x35, 0x65, 0x54, 0x16, 0xf4, 0x31, 0xac, 0xc9, 0xbd, 0xa5, 0x0c, 0x75, 0xce, 0x70, 0xd9,
+	0x56, 0x9b, 0x9e, 0x23, 0x58, 0x95, 0x36, 0x1d, 0x6f, 0xc1, 0x25, 0xbe, 0x7d, 0xb3, 0xc5, 0x83,
+	0xea, 0x36, 0x5f, 0xd9, 0x47, 0xc9, 0x5f, 0x47, 0xb8, 0xac, 0x6f, 0x0b, 0xb0, 0x7a, 0x10, 0xbe,
+	0x0c, 0xfe, 0x03, 0x05, 0x3e, 0x87, 0xb6, 0x56, 0xc0, 0x0d, 0x83, 0x63, 0x7f, 0x34, 0x8d, 0x31,
+	0xf3, 0xc3, 0x40, 0xca, 0x70, 0x35, 0x8d, 0x3d, 0x7a, 0xf5, 0x60, 0x1e, 0xe0, 0xb4, 0x94, 0x27,
+	0x63, 0x46, 0x7d, 0x68, 0x29, 0x41, 0xb2, 0x84, 0x42, 0x15, 0x53, 0xab, 0x92, 0xe7, 0xdb, 0x90,
+	0x8e, 0x2c, 0xdd, 0x45, 0xf4, 0xf9, 0xad, 0x08, 0x57, 0x0e, 0xc8, 0xa9, 0xef, 0x92, 0x3d, 0x97,
+	0xf9, 0xa7, 0x82, 0x4e, 0xf4, 0xce, 0xbf, 0xa5, 0xd3, 0x53, 0x28, 0x7b, 0xe4, 0x74, 0x40, 0xa6,
+	0x3e, 0x17, 0xa6, 0xbe, 0x7f, 0xf7, 0xf5, 0x9b, 0xed, 0xdb, 0x7f, 0xf5, 0x99, 0xba, 0x61, 0x4c,
+	0xba, 0x6c, 0x16, 0x11, 0x6a, 0x1f, 0x90, 0xd3, 0x87, 0xcf, 0x1e, 0x3b, 0x25, 0x8f, 0x9c, 0x3e,
+	0x9c, 0xfa, 0x09, 0x1f, 0x8e, 0x22, 0xce, 0x57, 0xff, 0x5b, 0x7c, 0x7b, 0x51, 0xc4, 0xf9, 0x70,
+	0x14, 0x25, 0x7c, 0x0b, 0x3b, 0xb9, 0xf5, 0x8f, 0x3b, 0xb9, 0xfd, 0x16, 0x9d, 0xdc, 0x87, 0xcb,
+	0x58, 0xcb, 0x9f, 0x52, 0x5c, 0xe1, 0x14, 0xd7, 0xd3, 0x24, 0xd2, 0x1a, 0x69, 0x2e, 0x84, 0xcf,
+	0xd9, 0xd2, 0xc2, 0x6f, 0x2f, 0x2f, 0x7c, 0x07, 0xcc, 0xf3, 0x75, 0xa7, 0x51, 0x18, 0x50, 0x62,
+	0xdd, 0x85, 0x8d, 0x47, 0x22, 0xc3, 0x43, 0x86, 0xd9, 0x94, 0xaa, 0x86, 0xd8, 0x04, 0x50, 0xdb,
+	0xf4, 0x45, 0x4f, 0x54, 0x9d, 0xaa, 0xb4, 0x3c, 0xf6, 0xac, 0xe7, 0xd0, 0xca, 0x85, 0x09, 0x3e,
+	0x74, 0x0d, 0xaa, 0x63, 0x4c, 0xd9, 0x80, 0x12, 0x12, 0xf0, 0xb0, 0xa2, 0x53, 0x49, 0x0c, 0x87,
+	0x84, 0x04, 0xe8, 0xff, 0x50, 0xa2, 0x1c, 0x2e, 0x5b, 0x69, 0x55, 0x2b, 0x26, 0x59, 0xa4, 0xdb,
+	0x7a, 0xa2, 0xe9, 0x3f, 0xf5, 0x29, 0x0b, 0xe3, 0xd9, 0xc5, 0xd2, 0x42, 0x1b, 0x70, 0x89, 0xfa,
+	0x81, 0x2b, 0x5a, 0xb5, 0xe8, 0x88, 0x85, 0xf5, 0xb3, 0x01, 0x4d, 0x49, 0x77, 0x14, 0xe3, 0xe3,
+	0x63, 0xdf, 0x45, 0x08, 0x56, 0x4e, 0xc2, 0x69, 0x2c, 0x33, 0xe4, 0xcf, 0xa8, 0x0d, 0xa5, 0x29,
+	0x1f, 0x9f, 0x3c, 0xba, 0xe1, 0xc8, 0x15, 0xea, 0x40, 0xc5, 0x93, 0x63, 0xc5, 0x2c, 0x72, 0x8f,
+	0x5e, 0xa3, 0x7b, 0x50, 0x71, 0x4f, 0x70, 0x10, 0x90, 0x31, 0x35, 0x57, 0x76, 0x8a, 0xbb, 0xb5,
+	0xde, 0xa6, 0x2d, 0x8f, 0x1a, 0xf9, 0xc6, 0x07, 0xc2, 0x2d, 0x5f, 0xec, 0x68, 0x38, 0xfa, 0x08,
+	0x20, 0xa9, 0xe0, 0x20, 0xc6, 0x8c, 0x50, 0xf3, 0x12, 0x0f, 0xde, 0xca, 0x05, 0x1f, 0x24, 0x65,
+	0xc7, 0x8c, 0xa8, 0xe8, 0xaa, 0x27, 0x0d, 0xd4, 0xea, 0x43, 0x6b, 0xe1, 0x1b, 0xd0, 0x75, 0xa8,
+	0x1e, 0xc7, 0x89, 0x5c, 0x81, 0x3b, 0xe3, 0xfb, 0x5b, 0x71, 0x52, 0xc3, 0xb2, 0x4d, 0x5a, 0x7d,
+	0x68, 0x2f, 0x7e, 0x67, 0x52, 0x51, 0x9d, 0xa7, 0x54, 0xbc, 0xa2, 0xd2, 0x58, 0x4a, 0xf7, 0x1c,
+	0xd6, 0x1f, 0xe9, 0x39, 0x15, 0x10, 0x97, 0x0f, 0x29, 0x04, 0x2b, 0xcc, 0x9f, 0x10, 0x25, 0x7a,
+	0xf2, 0x9c, 0x10, 0x50, 0x16, 0x13, 0x3c, 0xe1, 0x04, 0x55, 0x47, 0xae, 0x92, 0x5d, 0xb8, 0x22,
+	0x92, 0x78, 0x5c, 0xf5, 0x8a, 0x93, 0x1a, 0xac, 0x1f, 0x0c, 0x68, 0xe7, 0x1b, 0x44, 0x36, 0xe0,
+	0x2d, 0x28, 0x33, 0x91, 0xb9, 0x69, 0x70, 0x4d, 0xdb, 0x39, 0x4d, 0x95, 0x96, 0x0a, 0x86, 0xee,
+	0x43, 0xcd, 0xd5, 0x49, 0x26, 0xad, 0x59, 0xe4, 0x13, 0x3d, 0x57, 0x46, 0x8d, 0x70, 0xe6, 0xd1,
+	0xe8, 0x06, 0x54, 0x44, 0xcf, 0x12, 0x6a, 0x16, 0x77, 0x8a, 0x8b, 0x9a, 0x5a, 0x03, 0xac, 0xaf,
+	0x0d, 0x68, 0xaa, 0xd7, 0x5f, 0xac, 0xa1, 0xbf, 0x80, 0x4a, 0x32, 0x50, 0xb1, 0xe7, 0xc5, 0x5c,
+	0xa0, 0xfa, 0xfe, 0x7b, 0xaf, 0xdf, 0x6c, 0xf7, 0xde, 0x6e, 0xa2, 0xee, 0x79, 0x5e, 0xec, 0x94,
+	0x3d, 0xf1, 0x60, 0x7d, 0x9f, 0x26, 0xa1, 0x4e, 0xc9, 0x45, 0x85, 0xc9, 0x26, 0x56, 0xc8, 0x27,
+	0x76, 0x53, 0x17, 0x5e, 0x9c, 0x80, 0x2d, 0xa5, 0x57, 0xe6, 0x06, 0xa2, 0xbf, 0xa1, 0x3b, 0x73,
+	0xdf, 0x90, 0x38, 0xe1, 0xae, 0xa8, 0x80, 0xdc, 0x91, 0x9d, 0x7e, 0x5c, 0xd6, 0x2a, 0x34, 0x32,
+	0x43, 0xc9, 0xfa, 0xb5, 0x00, 0x25, 0x61, 0x41, 0xbb, 0x50, 0xa2, 0x33, 0xca, 0xc8, 0x84, 0x27,
+	0x5d, 0xeb, 0xad, 0xd9, 0xc9, 0xbd, 0xeb, 0x90, 0x9b, 0x12, 0x48, 0x32, 0x4b, 0xf8, 0x02, 0xdd,
+	0x4e, 0x3a, 0x69, 0x12, 0x85, 0x01, 0x09, 0x98, 0x9c, 0x3b, 0x97, 0x39, 0xf8, 0x81, 0xb2, 0x0a,
+	0x7c, 0x8a, 0x42, 0xb7, 0xa1, 0xa9, 0xf6, 0x2e, 0xe7, 0x95, 0xd8, 0x24, 0xf0, 0x38, 0xfe, 0xfd,
+	0x39, 0x8d, 0xd1, 0xfc, 0xfc, 0x43, 0x96, 0xd6, 0xa3, 0x7e, 0x0e, 0xaa, 0x44, 0xf8, 0xdf, 0x9c,
+	0x08, 0x8d, 0x73, 0x28, 0xed, 0x43, 0xef, 0x42, 0x2d, 0x9d, 0xf4, 0xd4, 0x6c, 0x9e, 0x83, 0xce,
+	0xbb, 0xd1, 0x4d, 0x40, 0xfa, 0xc3, 0x18, 0xc8, 0xa4, 0x28, 0x3f, 0xd4, 0x1a, 0xce, 0xba, 0xf6,
+	0xc8, 0x36, 0x4e, 0x1a, 0x36, 0x35, 0x0e, 0x86, 0x71, 0xf8, 0x82, 0xc4, 0x94, 0x1f, 0x60, 0x0d,
+	0x67, 0x4d, 0x3b, 0xf6, 0x85, 0xbd, 0xf7, 0x4d, 0x01, 0x4a, 0x0e, 0x2f, 0x13, 0xfa, 0x10, 0x1a,
+	0x99, 0x89, 0x8f, 0xf2, 0x7d, 0xde, 0x69, 0xdb, 0xe2, 0x3a, 0x6d, 0xab, 0x8b, 0xb2, 0xfd, 0x30,
+	0xb9, 0x4e, 0xef, 0x1a, 0xe8, 0x1e, 0x94, 0x44, 0x5b, 0xa0, 0xc5, 0x6d, 0xf2, 0x27, 0xa1, 0x9f,
+	0x40, 0x55, 0x5f, 0x74, 0x91, 0xa9, 0xa2, 0xf3, 0x77, 0xdf, 0xce, 0xb2, 0x6e, 0xba, 0x65, 0xa0,
+	0x3e, 0x54, 0xe4, 0xb9, 0x47, 0xd0, 0xb6, 0x86, 0x2d, 0xbe, 0x07, 0x75, 0x76, 0x96, 0x03, 0xc4,
+	0x7c, 0xe9, 0x7d, 0x57, 0x80, 0x86, 0x90, 0xa4, 0x8f, 0x03, 0x3c, 0x22, 0x31, 0x7a, 0x92, 0x57,
+	0xe6, 0x7a, 0x6e, 0x76, 0x64, 0x9a, 0xb8, 0xb3, 0xb9, 0xc4, 0x2b, 0xe7, 0xd7, 0x67, 0xd0, 0xcc,
+	0x4e, 0x36, 0x94, 0x0f, 0xc8, 0x1e, 0x89, 0x9d, 0xad, 0x65, 0x6e, 0x49, 0x78, 0x1f, 0xca, 0x6a,
+	0x94, 0xeb, 0x51, 0x98, 0x1d, 0x42, 0x9d, 0xbc, 0x3d, 0x15, 0xaf, 0x07, 0xd5, 0x47, 0x84, 0xc9,
+	0x7d, 0xe9, 0xe2, 0x65, 0x37, 0xd4, 0xcc, 0x9a, 0xf7, 0x3f, 0xf8, 0xf1, 0x6c, 0xcb, 0xf8, 0xe9,
+	0x6c, 0xcb, 0xf8, 0xe5, 0x6c, 0xcb, 0xf8, 0xf2, 0x9d, 0x8b, 0xff, 0x93, 0x36, 0x2c, 0xf1, 0xf2,
+	0xdf, 0xf9, 0x63, 0x00, 0x51, 0x2f, 0x17, 0xaa, 0xd9, 0x0d, 0x00, 0x00,
 }
@@ -0,0 +1,35 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// DevAddr returns the DevAddr of the uplink or downlink message. It returns false if the message is not a LoRaWAN
+// data message.
+func (m *TrafficMessage) DevAddr() (types.DevAddr, bool) {
+	switch {
+	case m.GetUplink() != nil:
+		return pb_lorawan.DevAddrFromPayload(m.Uplink.Payload)
+	case m.GetDownlink() != nil:
+		return pb_lorawan.DevAddrFromPayload(m.Downlink.Payload)
+	}
+	return types.DevAddr{}, false
+}
+
+// Matches returns true if the message matches the filters of the TrafficRequest
+func (m *TrafficRequest) Matches(msg *TrafficMessage) bool {
+	if m.GatewayId != "" && msg.GatewayId != m.GatewayId {
+		return false
+	}
+	if m.DevAddr != nil && !m.DevAddr.IsEmpty() {
+		devAddr, ok := msg.DevAddr()
+		if !ok || devAddr != *m.DevAddr {
+			return false
+		}
+	}
+	return true
+}
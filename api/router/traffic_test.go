@@ -0,0 +1,46 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestTrafficRequestMatches(t *testing.T) {
+	a := New(t)
+
+	macBin := []byte{65, 4, 3, 2, 1, 0, 1, 0, 0, 1, 2, 3, 4}
+	joinReqBin := []byte{1, 8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1, 2, 1, 1, 2, 3, 4}
+
+	uplink := &TrafficMessage{GatewayId: "gtw-1", Uplink: &UplinkMessage{Payload: macBin}}
+	downlink := &TrafficMessage{GatewayId: "gtw-2", Downlink: &DownlinkMessage{Payload: macBin}}
+	join := &TrafficMessage{GatewayId: "gtw-1", Uplink: &UplinkMessage{Payload: joinReqBin}}
+
+	devAddr, ok := uplink.DevAddr()
+	a.So(ok, ShouldBeTrue)
+	a.So(devAddr, ShouldEqual, types.DevAddr{1, 2, 3, 4})
+	_, ok = join.DevAddr()
+	a.So(ok, ShouldBeFalse)
+
+	all := &TrafficRequest{}
+	a.So(all.Matches(uplink), ShouldBeTrue)
+	a.So(all.Matches(downlink), ShouldBeTrue)
+	a.So(all.Matches(join), ShouldBeTrue)
+
+	byGateway := &TrafficRequest{GatewayId: "gtw-1"}
+	a.So(byGateway.Matches(uplink), ShouldBeTrue)
+	a.So(byGateway.Matches(downlink), ShouldBeFalse)
+	a.So(byGateway.Matches(join), ShouldBeTrue)
+
+	byDevAddr := &TrafficRequest{DevAddr: &types.DevAddr{1, 2, 3, 4}}
+	a.So(byDevAddr.Matches(uplink), ShouldBeTrue)
+	a.So(byDevAddr.Matches(downlink), ShouldBeTrue)
+	a.So(byDevAddr.Matches(join), ShouldBeFalse)
+
+	otherDevAddr := &TrafficRequest{DevAddr: &types.DevAddr{1, 2, 3, 5}}
+	a.So(otherDevAddr.Matches(uplink), ShouldBeFalse)
+}
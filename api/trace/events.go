@@ -10,6 +10,7 @@ const (
 	CheckMICEvent      = "check mic"
 	DeduplicateEvent   = "deduplicate"
 	DropEvent          = "drop"
+	FailoverEvent      = "failover"
 	ForwardEvent       = "forward"
 	HandleMACEvent     = "handle mac command"
 	ReceiveEvent       = "receive"
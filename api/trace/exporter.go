@@ -0,0 +1,248 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+)
+
+// Exporter exports spans to a tracing backend
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+// ExportBufferSize is the number of spans that can wait to be exported. Spans are dropped when the buffer is full.
+var ExportBufferSize = 1024
+
+// ExportBatchSize is the maximum number of spans that is exported at once
+var ExportBatchSize = 100
+
+// ExportInterval is the maximum time that spans wait before they are exported
+var ExportInterval = time.Second
+
+// exportedSpans is the number of recently exported span IDs that is remembered, so that spans are not exported
+// again when a later message has the same events in its trace
+const exportedSpans = 4096
+
+var exporterMu sync.RWMutex
+var _exporter *exportWorker
+
+// SetExporter sets the exporter of the traces of this process. Spans are exported in batches in the
+// background. Passing a nil exporter disables exporting.
+func SetExporter(ctx ttnlog.Interface, exporter Exporter) {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+	if _exporter != nil {
+		close(_exporter.spans)
+		_exporter = nil
+	}
+	if exporter == nil {
+		return
+	}
+	_exporter = &exportWorker{
+		ctx:      ctx,
+		exporter: exporter,
+		spans:    make(chan *Span, ExportBufferSize),
+		exported: make(map[string]struct{}),
+	}
+	go _exporter.run()
+}
+
+// Export exports the spans of the events in the trace that were added by the service. It does not block;
+// spans are dropped if the exporter can not keep up.
+func Export(service Service, t *Trace) {
+	if t == nil {
+		return
+	}
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+	if _exporter == nil {
+		return
+	}
+	for _, span := range t.Spans() {
+		if span.ServiceName != service.Name || span.ServiceID != service.ID {
+			continue
+		}
+		if !_exporter.markExported(span.SpanID) {
+			continue
+		}
+		select {
+		case _exporter.spans <- span:
+		default:
+			_exporter.ctx.Debug("Dropping span, export buffer is full")
+		}
+	}
+}
+
+type exportWorker struct {
+	ctx      ttnlog.Interface
+	exporter Exporter
+	spans    chan *Span
+
+	mu            sync.Mutex
+	exported      map[string]struct{}
+	exportedOrder []string
+}
+
+// markExported returns false if the span was already exported
+func (w *exportWorker) markExported(spanID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.exported[spanID]; ok {
+		return false
+	}
+	w.exported[spanID] = struct{}{}
+	w.exportedOrder = append(w.exportedOrder, spanID)
+	if len(w.exportedOrder) > exportedSpans {
+		delete(w.exported, w.exportedOrder[0])
+		w.exportedOrder = w.exportedOrder[1:]
+	}
+	return true
+}
+
+func (w *exportWorker) run() {
+	ticker := time.NewTicker(ExportInterval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, ExportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.exporter.Export(batch); err != nil {
+			w.ctx.WithError(err).WithField("Spans", len(batch)).Warn("Could not export spans")
+		}
+		batch = make([]*Span, 0, ExportBatchSize)
+	}
+	for {
+		select {
+		case span, ok := <-w.spans:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= ExportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type writerExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterExporter returns an Exporter that writes the spans as JSON objects to w, one per line
+func NewWriterExporter(w io.Writer) Exporter {
+	return &writerExporter{w: w}
+}
+
+func (e *writerExporter) Export(spans []*Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	encoder := json.NewEncoder(e.w)
+	for _, span := range spans {
+		if err := encoder.Encode(span); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type zipkinExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewZipkinExporter returns an Exporter that posts the spans to the Zipkin v2 HTTP API at the given URL, such as
+// http://localhost:9411/api/v2/spans. Jaeger collectors accept spans on the same API.
+func NewZipkinExporter(url string) Exporter {
+	return &zipkinExporter{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration,omitempty"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+func (e *zipkinExporter) Export(spans []*Span) error {
+	zipkinSpans := make([]zipkinSpan, 0, len(spans))
+	for _, span := range spans {
+		zipkinSpan := zipkinSpan{
+			TraceID:       span.TraceID,
+			ID:            span.SpanID,
+			Name:          span.Operation,
+			Timestamp:     span.Start.UnixNano() / int64(time.Microsecond),
+			Duration:      int64(span.Duration / time.Microsecond),
+			LocalEndpoint: zipkinEndpoint{ServiceName: span.ServiceName},
+			Tags:          map[string]string{"service.id": span.ServiceID},
+		}
+		for k, v := range span.Tags {
+			zipkinSpan.Tags[k] = v
+		}
+		if len(span.ParentIDs) > 0 {
+			// Zipkin supports only one parent; the others are added as a tag
+			zipkinSpan.ParentID = span.ParentIDs[0]
+			if len(span.ParentIDs) > 1 {
+				zipkinSpan.Tags["parents"] = strings.Join(span.ParentIDs, ",")
+			}
+		}
+		zipkinSpans = append(zipkinSpans, zipkinSpan)
+	}
+	body, err := json.Marshal(zipkinSpans)
+	if err != nil {
+		return err
+	}
+	res, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("Collector returned status %s", res.Status)
+	}
+	return nil
+}
+
+type multiExporter []Exporter
+
+// NewMultiExporter returns an Exporter that exports the spans to all given exporters
+func NewMultiExporter(exporters ...Exporter) Exporter {
+	return multiExporter(exporters)
+}
+
+func (e multiExporter) Export(spans []*Span) error {
+	var firstErr error
+	for _, exporter := range e {
+		if err := exporter.Export(spans); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,128 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+type testExporter struct {
+	sync.Mutex
+	spans []*Span
+}
+
+func (e *testExporter) Export(spans []*Span) error {
+	e.Lock()
+	defer e.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *testExporter) exported() []*Span {
+	e.Lock()
+	defer e.Unlock()
+	return e.spans
+}
+
+func TestExport(t *testing.T) {
+	a := New(t)
+
+	broker := Service{Name: "broker", ID: "test"}
+
+	interval := ExportInterval
+	ExportInterval = 10 * time.Millisecond
+	defer func() {
+		ExportInterval = interval
+	}()
+
+	exporter := &testExporter{}
+	SetExporter(GetLogger(t, "TestExport"), exporter)
+	defer SetExporter(nil, nil)
+
+	other := &Trace{ServiceName: "router", ServiceId: "test", Time: 1000, Event: ReceiveEvent}
+	received := other.WithEvent(broker, ReceiveEvent)
+	Export(broker, received)
+	Export(broker, received.WithEvent(broker, ForwardEvent))
+
+	// Events of other services in the same process are exported by those services
+	Export(Service{Name: "handler", ID: "test"}, received)
+
+	time.Sleep(50 * time.Millisecond)
+
+	spans := exporter.exported()
+	a.So(spans, ShouldHaveLength, 2)
+	a.So(spans[0].Operation, ShouldEqual, ReceiveEvent)
+	a.So(spans[0].ServiceName, ShouldEqual, "broker")
+	a.So(spans[1].Operation, ShouldEqual, ForwardEvent)
+}
+
+func TestWriterExporter(t *testing.T) {
+	a := New(t)
+
+	buf := new(bytes.Buffer)
+	err := NewWriterExporter(buf).Export([]*Span{
+		{TraceID: "a", SpanID: "a", Operation: ReceiveEvent},
+		{TraceID: "a", SpanID: "b", ParentIDs: []string{"a"}, Operation: ForwardEvent},
+	})
+	a.So(err, ShouldBeNil)
+
+	decoder := json.NewDecoder(buf)
+	var span Span
+	a.So(decoder.Decode(&span), ShouldBeNil)
+	a.So(span.SpanID, ShouldEqual, "a")
+	a.So(decoder.Decode(&span), ShouldBeNil)
+	a.So(span.ParentIDs, ShouldResemble, []string{"a"})
+}
+
+func TestZipkinExporter(t *testing.T) {
+	a := New(t)
+
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/spans" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := NewZipkinExporter(server.URL + "/api/v2/spans").Export([]*Span{
+		{
+			TraceID:     "0000000000000001",
+			SpanID:      "0000000000000003",
+			ParentIDs:   []string{"0000000000000001", "0000000000000002"},
+			ServiceName: "broker",
+			ServiceID:   "test",
+			Operation:   DeduplicateEvent,
+			Start:       time.Unix(1, 0),
+			Duration:    2 * time.Millisecond,
+		},
+	})
+	a.So(err, ShouldBeNil)
+	a.So(received, ShouldHaveLength, 1)
+	a.So(received[0]["parentId"], ShouldEqual, "0000000000000001")
+	a.So(received[0]["timestamp"], ShouldEqual, 1000000)
+	a.So(received[0]["duration"], ShouldEqual, 2000)
+	a.So(received[0]["localEndpoint"], ShouldResemble, map[string]interface{}{"serviceName": "broker"})
+	a.So(received[0]["tags"], ShouldResemble, map[string]interface{}{
+		"service.id": "test",
+		"parents":    "0000000000000001,0000000000000002",
+	})
+
+	err = NewZipkinExporter(server.URL + "/notfound").Export(nil)
+	a.So(err, ShouldNotBeNil)
+}
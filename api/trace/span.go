@@ -0,0 +1,95 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// Span is an operation in the OpenTracing model that is built from an event in a Trace. It starts at the time
+// of the event and ends at the time of the first event that has it as its parent.
+type Span struct {
+	TraceID     string            `json:"trace_id"`
+	SpanID      string            `json:"span_id"`
+	ParentIDs   []string          `json:"parent_ids,omitempty"`
+	ServiceName string            `json:"service_name"`
+	ServiceID   string            `json:"service_id"`
+	Operation   string            `json:"operation"`
+	Start       time.Time         `json:"start"`
+	Duration    time.Duration     `json:"duration"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// SpanID returns the ID of the span of the event. The ID is derived from the event, so that components that
+// see the same event get the same ID for it.
+func (m *Trace) SpanID() string {
+	if m.Id != "" {
+		return m.Id
+	}
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s|%s|%d|%s", m.ServiceName, m.ServiceId, m.Time, m.Event)
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// Spans returns the spans of all events in the trace, sorted by start time. All spans get the ID of the span of
+// the earliest event as their trace ID.
+//
+// When the trace contains deduplicated messages, it has multiple earliest events; those messages might have been
+// exported with a different trace ID by the components that handled them before deduplication, but the spans
+// still refer to each other with their parent IDs.
+func (m *Trace) Spans() []*Span {
+	if m == nil {
+		return nil
+	}
+
+	var events []*Trace
+	seen := make(map[*Trace]bool)
+	ends := make(map[*Trace]int64)
+	var walk func(t *Trace)
+	walk = func(t *Trace) {
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		events = append(events, t)
+		for _, parent := range t.Parents {
+			if end, ok := ends[parent]; !ok || t.Time < end {
+				ends[parent] = t.Time
+			}
+			walk(parent)
+		}
+	}
+	walk(m)
+	sort.Stable(byTime(events))
+
+	traceID := events[0].SpanID()
+	spans := make([]*Span, 0, len(events))
+	for _, event := range events {
+		span := &Span{
+			TraceID:     traceID,
+			SpanID:      event.SpanID(),
+			ServiceName: event.ServiceName,
+			ServiceID:   event.ServiceId,
+			Operation:   event.Event,
+			Start:       time.Unix(0, event.Time),
+		}
+		if end, ok := ends[event]; ok && end > event.Time {
+			span.Duration = time.Duration(end - event.Time)
+		}
+		for _, parent := range event.Parents {
+			span.ParentIDs = append(span.ParentIDs, parent.SpanID())
+		}
+		if len(event.Metadata) > 0 {
+			span.Tags = make(map[string]string, len(event.Metadata))
+			for k, v := range event.Metadata {
+				span.Tags[k] = v
+			}
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
@@ -0,0 +1,48 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package trace
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSpans(t *testing.T) {
+	a := New(t)
+
+	var nilTrace *Trace
+	a.So(nilTrace.Spans(), ShouldBeEmpty)
+
+	first := &Trace{ServiceName: "router", ServiceId: "r1", Time: 1000, Event: ReceiveEvent}
+	second := &Trace{ServiceName: "router", ServiceId: "r2", Time: 2000, Event: ReceiveEvent}
+	deduplicate := &Trace{ServiceName: "broker", ServiceId: "b", Time: 5000, Event: DeduplicateEvent, Parents: []*Trace{first, second}}
+	check := &Trace{ServiceName: "broker", ServiceId: "b", Time: 9000, Event: CheckMICEvent, Metadata: map[string]string{"mic checks": "1"}, Parents: []*Trace{deduplicate}}
+
+	spans := check.Spans()
+	a.So(spans, ShouldHaveLength, 4)
+
+	a.So(spans[0].SpanID, ShouldEqual, first.SpanID())
+	a.So(spans[1].SpanID, ShouldEqual, second.SpanID())
+	a.So(spans[0].SpanID, ShouldNotEqual, spans[1].SpanID)
+	for _, span := range spans {
+		a.So(span.TraceID, ShouldEqual, first.SpanID())
+	}
+
+	a.So(spans[0].Start, ShouldResemble, time.Unix(0, 1000))
+	a.So(spans[0].Duration, ShouldEqual, 4000)
+	a.So(spans[1].Duration, ShouldEqual, 3000)
+
+	a.So(spans[2].Operation, ShouldEqual, DeduplicateEvent)
+	a.So(spans[2].ServiceName, ShouldEqual, "broker")
+	a.So(spans[2].ParentIDs, ShouldResemble, []string{first.SpanID(), second.SpanID()})
+	a.So(spans[2].Duration, ShouldEqual, 4000)
+
+	a.So(spans[3].Duration, ShouldEqual, 0)
+	a.So(spans[3].Tags, ShouldResemble, map[string]string{"mic checks": "1"})
+
+	// The same event gets the same ID everywhere
+	a.So((&Trace{ServiceName: "router", ServiceId: "r1", Time: 1000, Event: ReceiveEvent}).SpanID(), ShouldEqual, first.SpanID())
+}
@@ -10,20 +10,17 @@ import (
 	"time"
 )
 
-var _serviceID = ""
-var _serviceName = ""
-
-// SetComponent sets the component information
-func SetComponent(serviceName, serviceID string) {
-	_serviceName = serviceName
-	_serviceID = serviceID
+// Service identifies the component that adds events to traces
+type Service struct {
+	Name string
+	ID   string
 }
 
-// WithEvent returns a new Trace for the event and its metadata, with the original trace as its parent
-func (m *Trace) WithEvent(event string, keyvalue ...interface{}) *Trace {
+// WithEvent returns a new Trace for the event of the service and its metadata, with the original trace as its parent
+func (m *Trace) WithEvent(service Service, event string, keyvalue ...interface{}) *Trace {
 	t := &Trace{
-		ServiceName: _serviceName,
-		ServiceId:   _serviceID,
+		ServiceName: service.Name,
+		ServiceId:   service.ID,
 		Time:        time.Now().UnixNano(),
 		Event:       event,
 	}
@@ -0,0 +1,280 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api"
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/security"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"gopkg.in/redis.v5"
+)
+
+// allInOneIssuer is the issuer of the tokens that are generated by the all-in-one command
+const allInOneIssuer = "local"
+
+// allInOneCmd represents the all-in-one command
+var allInOneCmd = &cobra.Command{
+	Use:   "all-in-one",
+	Short: "Run a complete private network in one process",
+	Long: `ttn all-in-one runs a discovery server, networkserver, broker, router and handler in one process.
+
+The components call each other in-process, and listen on the ports that are
+configured for them for gateways, applications and ttnctl. They use one
+Redis database. A keypair is generated in the key-dir if it does not exist
+yet, and the tokens that the components need to announce themselves and to
+connect to each other are generated when the command starts.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		ctx.WithFields(ttnlog.Fields{
+			"ID":       allInOneID(),
+			"Database": fmt.Sprintf("%s/%d", viper.GetString("all-in-one.redis-address"), viper.GetInt("all-in-one.redis-db")),
+			"KeyDir":   viper.GetString("key-dir"),
+		}).Info("Initializing All-in-One")
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx.Info("Starting")
+
+		id := allInOneID()
+		viper.Set("id", id)
+
+		// Keys
+		privKey, err := allInOneKeys(viper.GetString("key-dir"))
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not initialize keys")
+		}
+
+		// Let the components trust each other
+		authServers := viper.GetStringMapString("auth-servers")
+		authServers[allInOneIssuer] = "file://" + filepath.Clean(viper.GetString("key-dir")+"/server.pub")
+		viper.Set("auth-servers", authServers)
+		masterAuthServers := viper.GetStringSlice("discovery.master-auth-servers")
+		viper.Set("discovery.master-auth-servers", append(masterAuthServers, allInOneIssuer))
+		viper.Set("discovery-address", fmt.Sprintf("localhost:%d", viper.GetInt("discovery.server-port")))
+		viper.Set("broker.networkserver-address", fmt.Sprintf("localhost:%d", viper.GetInt("networkserver.server-port")))
+		viper.Set("broker.networkserver-cert", "")
+		nsToken, err := allInOneNetworkServerToken(privKey, id)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not generate networkserver token")
+		}
+		viper.Set("broker.networkserver-token", nsToken)
+		viper.Set("handler.broker-id", id)
+
+		// Redis Client
+		client := redis.NewClient(&redis.Options{
+			Addr:     viper.GetString("all-in-one.redis-address"),
+			Password: "", // no password set
+			DB:       viper.GetInt("all-in-one.redis-db"),
+		})
+
+		if err := connectRedis(client); err != nil {
+			ctx.WithError(err).Fatal("Could not connect to Redis")
+		}
+
+		// Discovery
+		discoveryComponent := newAllInOneComponent(privKey, "discovery", fmt.Sprintf("localhost:%d", viper.GetInt("discovery.server-port")))
+		discovery := newDiscovery(client)
+		if err := discovery.Init(discoveryComponent); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize discovery")
+		}
+		discoveryServer := serveAllInOne(discoveryComponent, "discovery", discovery.RegisterRPC)
+
+		// Network Server
+		networkserverComponent := newAllInOneComponent(privKey, "networkserver", fmt.Sprintf("%s:%d", viper.GetString("networkserver.server-address-announce"), viper.GetInt("networkserver.server-port")))
+		networkserver := newNetworkServer(client)
+		if err := networkserver.Init(networkserverComponent); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize networkserver")
+		}
+		networkserverServer := serveAllInOne(networkserverComponent, "networkserver", networkserver.RegisterRPC, networkserver.RegisterManager)
+
+		// Broker
+		brokerComponent := newAllInOneComponent(privKey, "broker", fmt.Sprintf("%s:%d", viper.GetString("broker.server-address-announce"), viper.GetInt("broker.server-port")))
+		broker := newBroker(brokerComponent)
+		if err := broker.Init(brokerComponent); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize broker")
+		}
+		brokerServer := serveAllInOne(brokerComponent, "broker", broker.RegisterRPC, broker.RegisterManager)
+
+		// The Broker handles all DevAddr prefixes of the Network Server
+		for prefix := range viper.GetStringMapString("networkserver.prefixes") {
+			prefix, err := types.ParseDevAddrPrefix(prefix)
+			if err != nil {
+				continue
+			}
+			err = discovery.AddMetadata("broker", id, &pb_discovery.Metadata{Metadata: &pb_discovery.Metadata_DevAddrPrefix{
+				DevAddrPrefix: prefix.Bytes(),
+			}})
+			if err != nil {
+				ctx.WithError(err).WithField("Prefix", prefix).Fatal("Could not register prefix")
+			}
+		}
+
+		// Router
+		routerComponent := newAllInOneComponent(privKey, "router", fmt.Sprintf("%s:%d", viper.GetString("router.server-address-announce"), viper.GetInt("router.server-port")))
+		router := newRouter(client)
+		if err := router.Init(routerComponent); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize router")
+		}
+		routerServer := serveAllInOne(routerComponent, "router", router.RegisterRPC, router.RegisterManager)
+
+		// Handler
+		handlerComponent := newAllInOneComponent(privKey, "handler", fmt.Sprintf("%s:%d", viper.GetString("handler.server-address-announce"), viper.GetInt("handler.server-port")))
+		handler := newHandler(handlerComponent, client)
+		if err := handler.Init(handlerComponent); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize handler")
+		}
+		handlerServer := serveAllInOne(handlerComponent, "handler", handler.RegisterRPC, handler.RegisterManager)
+
+		ctx.Info("Started")
+
+		waitForShutdown()
+
+		// Shut down in the direction of the uplink messages, so that each component has handled the messages of the
+		// previous one before it shuts down
+		for _, c := range []*component.Component{routerComponent, brokerComponent, networkserverComponent, handlerComponent, discoveryComponent} {
+			c.SetStatus(component.StatusUnhealthy)
+		}
+		gracefulShutdown(routerComponent, routerServer, router.Shutdown)
+		gracefulShutdown(brokerComponent, brokerServer, broker.Shutdown)
+		gracefulShutdown(networkserverComponent, networkserverServer, networkserver.Shutdown)
+		gracefulShutdown(handlerComponent, handlerServer, handler.Shutdown)
+		gracefulShutdown(discoveryComponent, discoveryServer, discovery.Shutdown)
+	},
+}
+
+// allInOneID returns the ID of the components of the all-in-one command
+func allInOneID() string {
+	if id := viper.GetString("id"); id != "" {
+		return id
+	}
+	return "local"
+}
+
+// allInOneKeys loads the keypair from keyDir, or generates it if it does not exist. If TLS is enabled, a
+// certificate is generated as well, and added to the trusted root certificates.
+func allInOneKeys(keyDir string) (*ecdsa.PrivateKey, error) {
+	if err := os.MkdirAll(keyDir, 0755); err != nil {
+		return nil, err
+	}
+	privKey, err := security.LoadKeypair(keyDir)
+	if err != nil {
+		ctx.WithField("KeyDir", keyDir).Info("Generating keypair")
+		if err := security.GenerateKeypair(keyDir); err != nil {
+			return nil, err
+		}
+		if privKey, err = security.LoadKeypair(keyDir); err != nil {
+			return nil, err
+		}
+	}
+	if !viper.GetBool("tls") {
+		return privKey, nil
+	}
+	cert, err := security.LoadCert(keyDir)
+	if err != nil {
+		ctx.WithField("KeyDir", keyDir).Info("Generating certificate")
+		names := []string{"localhost"}
+		for _, service := range []string{"networkserver", "broker", "router", "handler"} {
+			if announce := viper.GetString(service + ".server-address-announce"); announce != "" && announce != "localhost" {
+				names = append(names, announce)
+			}
+		}
+		if err := security.GenerateCert(keyDir, names...); err != nil {
+			return nil, err
+		}
+		if cert, err = security.LoadCert(keyDir); err != nil {
+			return nil, err
+		}
+	}
+	api.RootCAs.AppendCertsFromPEM(cert)
+	return privKey, nil
+}
+
+// allInOneToken generates the token that a component uses to announce itself to the Discovery server
+func allInOneToken(privKey *ecdsa.PrivateKey, serviceName, id string) (string, error) {
+	var claims claims.ComponentClaims
+	claims.Subject = id
+	claims.Type = serviceName
+	claims.Issuer = allInOneIssuer
+	claims.IssuedAt = time.Now().Unix()
+	claims.NotBefore = time.Now().Unix()
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(privKey)
+}
+
+// allInOneNetworkServerToken generates the token that the Broker uses to connect to the Network Server
+func allInOneNetworkServerToken(privKey *ecdsa.PrivateKey, id string) (string, error) {
+	claims := jwt.StandardClaims{
+		Subject:   id,
+		Issuer:    id,
+		IssuedAt:  time.Now().Unix(),
+		NotBefore: time.Now().Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(privKey)
+}
+
+// newAllInOneComponent creates a component of the all-in-one command with a generated token
+func newAllInOneComponent(privKey *ecdsa.PrivateKey, serviceName, announcedAddress string) *component.Component {
+	c, err := component.New(ctx.WithField("Component", serviceName), serviceName, announcedAddress)
+	if err != nil {
+		ctx.WithError(err).WithField("Component", serviceName).Fatal("Could not initialize component")
+	}
+	if serviceName != "discovery" && serviceName != "networkserver" {
+		c.AccessToken, err = allInOneToken(privKey, serviceName, c.Identity.Id)
+		if err != nil {
+			ctx.WithError(err).WithField("Component", serviceName).Fatal("Could not generate token")
+		}
+	}
+	return c
+}
+
+// serveAllInOne starts a gRPC server for a component of the all-in-one command. The server is also served
+// in-process on the addresses that the other components dial, so that they don't have to go through the network.
+func serveAllInOne(c *component.Component, serviceName string, register ...func(s *grpc.Server)) *grpc.Server {
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", viper.GetString(serviceName+".server-address"), viper.GetInt(serviceName+".server-port")))
+	if err != nil {
+		ctx.WithError(err).WithField("Component", serviceName).Fatal("Could not start gRPC server")
+	}
+	s := grpc.NewServer(c.ServerOptions()...)
+	c.RegisterHealthServer(s)
+	c.RegisterAdminServer(s)
+	for _, register := range register {
+		register(s)
+	}
+	go s.Serve(lis)
+	for _, address := range allInOneAddresses(c, serviceName) {
+		go s.Serve(api.ListenInProcess(address))
+	}
+	return s
+}
+
+// allInOneAddresses returns the addresses on which the other components dial a component of the all-in-one command
+func allInOneAddresses(c *component.Component, serviceName string) []string {
+	addresses := []string{fmt.Sprintf("localhost:%d", viper.GetInt(serviceName+".server-port"))}
+	if announced := strings.Split(c.Identity.NetAddress, ",")[0]; announced != "" && announced != addresses[0] {
+		addresses = append(addresses, announced)
+	}
+	return addresses
+}
+
+func init() {
+	RootCmd.AddCommand(allInOneCmd)
+
+	allInOneCmd.Flags().String("redis-address", "localhost:6379", "Redis server and port")
+	viper.BindPFlag("all-in-one.redis-address", allInOneCmd.Flags().Lookup("redis-address"))
+	allInOneCmd.Flags().Int("redis-db", 0, "Redis database")
+	viper.BindPFlag("all-in-one.redis-db", allInOneCmd.Flags().Lookup("redis-db"))
+}
@@ -0,0 +1,40 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestAllInOneTokens(t *testing.T) {
+	a := New(t)
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	a.So(err, ShouldBeNil)
+	keyFunc := func(*jwt.Token) (interface{}, error) { return &privKey.PublicKey, nil }
+
+	token, err := allInOneToken(privKey, "broker", "local")
+	a.So(err, ShouldBeNil)
+	var componentClaims claims.ComponentClaims
+	_, err = jwt.ParseWithClaims(token, &componentClaims, keyFunc)
+	a.So(err, ShouldBeNil)
+	a.So(componentClaims.Subject, ShouldEqual, "local")
+	a.So(componentClaims.Type, ShouldEqual, "broker")
+	a.So(componentClaims.Issuer, ShouldEqual, allInOneIssuer)
+
+	token, err = allInOneNetworkServerToken(privKey, "local")
+	a.So(err, ShouldBeNil)
+	var standardClaims jwt.StandardClaims
+	_, err = jwt.ParseWithClaims(token, &standardClaims, keyFunc)
+	a.So(err, ShouldBeNil)
+	a.So(standardClaims.Subject, ShouldEqual, "local")
+	a.So(standardClaims.Issuer, ShouldEqual, "local")
+}
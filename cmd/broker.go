@@ -4,12 +4,11 @@
 package cmd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
@@ -17,8 +16,12 @@ import (
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/core/broker"
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/core/roaming"
+	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/redis.v5"
 )
 
 // brokerCmd represents the broker command
@@ -43,20 +46,8 @@ var brokerCmd = &cobra.Command{
 			ctx.WithError(err).Fatal("Could not initialize component")
 		}
 
-		var nsCert string
-		if nsCertFile := viper.GetString("broker.networkserver-cert"); nsCertFile != "" {
-			contents, err := ioutil.ReadFile(nsCertFile)
-			if err != nil {
-				ctx.WithError(err).Fatal("Could not get Networkserver certificate")
-			}
-			nsCert = string(contents)
-		}
-
 		// Broker
-		broker := broker.NewBroker(
-			time.Duration(viper.GetInt("broker.deduplication-delay")) * time.Millisecond,
-		)
-		broker.SetNetworkServer(viper.GetString("broker.networkserver-address"), nsCert, viper.GetString("broker.networkserver-token"))
+		broker := newBroker(component)
 		err = broker.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize broker")
@@ -71,19 +62,127 @@ var brokerCmd = &cobra.Command{
 
 		// Register and Listen
 		component.RegisterHealthServer(grpc)
+		component.RegisterAdminServer(grpc)
 		broker.RegisterRPC(grpc)
 		broker.RegisterManager(grpc)
 		go grpc.Serve(lis)
 
-		sigChan := make(chan os.Signal)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		ctx.WithField("signal", <-sigChan).Info("signal received")
+		// Roaming Server
+		var httpServers []*httpServer
+		if roamingHandler := broker.RoamingHandler(); roamingHandler != nil && viper.GetString("broker.roaming-address") != "" {
+			lis, err := listenRoaming(viper.GetString("broker.roaming-address"))
+			if err != nil {
+				ctx.WithError(err).Fatal("Could not start roaming server")
+			}
+			httpServers = append(httpServers, serveHTTP(lis, roamingHandler))
+		}
+
+		waitForShutdown()
 
-		grpc.Stop()
-		broker.Shutdown()
+		gracefulShutdown(component, grpc, broker.Shutdown, httpServers...)
 	},
 }
 
+// newBroker creates a Broker that connects to the configured Network Server
+func newBroker(component *component.Component) broker.Broker {
+	var nsCert string
+	if nsCertFile := viper.GetString("broker.networkserver-cert"); nsCertFile != "" {
+		contents, err := ioutil.ReadFile(nsCertFile)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get Networkserver certificate")
+		}
+		nsCert = string(contents)
+	}
+
+	b := broker.NewBroker(
+		time.Duration(viper.GetInt("broker.deduplication-delay")) * time.Millisecond,
+	)
+	b.SetNetworkServer(viper.GetString("broker.networkserver-address"), nsCert, viper.GetString("broker.networkserver-token"))
+
+	if ttl := viper.GetDuration("broker.devices-cache-ttl"); ttl > 0 {
+		b.UseDevicesCache(ttl)
+	}
+
+	if partners := viper.GetStringMapString("broker.roaming-partners"); len(partners) > 0 {
+		id := viper.GetInt("broker.net-id")
+		netID := types.NetID{byte(id >> 16), byte(id >> 8), byte(id)}
+		var roamingPartners []*roaming.Partner
+		for partnerNetID, url := range partners {
+			partnerNetID, err := types.ParseNetID(partnerNetID)
+			if err != nil {
+				ctx.WithError(err).Fatal("Invalid NetID of roaming partner")
+			}
+			partner, err := roaming.NewPartner(partnerNetID, url)
+			if err != nil {
+				ctx.WithError(err).WithField("NetID", partnerNetID).Fatal("Invalid roaming partner")
+			}
+			if !partner.HasCredentials() && viper.GetString("broker.roaming-client-ca") == "" {
+				ctx.WithField("NetID", partnerNetID).Warn("Roaming partner has no credentials in its URL and no roaming client CA is configured, its requests will be refused")
+			}
+			ctx.WithField("NetID", partnerNetID).WithField("Prefix", partnerNetID.DevAddrPrefix()).Info("Roaming with partner")
+			roamingPartners = append(roamingPartners, partner)
+		}
+		b.UseRoaming(netID, roamingPartners...)
+	}
+
+	var client *redis.Client
+	if redisAddress := viper.GetString("broker.redis-address"); redisAddress != "" {
+		client = redis.NewClient(&redis.Options{
+			Addr:     redisAddress,
+			Password: "", // no password set
+			DB:       viper.GetInt("broker.redis-db"),
+		})
+		connectRedis(client)
+		b.SetRedisDeduplication(client, "broker")
+	}
+
+	if application, device, enabled := getQuotaLimits("broker"); enabled {
+		var store quota.Store
+		if client != nil {
+			store = quota.NewRedisStore(client, "broker")
+		} else {
+			ctx.Warn("Quota usage is kept in memory, configure a Redis address to store it persistently")
+			store = quota.NewMemoryStore()
+		}
+		b.SetQuotas(store, application, device)
+		component.OnReload(quotaKeys("broker"), reloadQuotas("broker", b.SetQuotaLimits))
+	}
+	return b
+}
+
+// listenRoaming listens for the requests of roaming partners. If a certificate is configured, the listener uses TLS,
+// and if a client CA is configured, partners can authenticate with a client certificate that is signed by it.
+func listenRoaming(address string) (net.Listener, error) {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	certFile := viper.GetString("broker.roaming-cert")
+	if certFile == "" {
+		return lis, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, viper.GetString("broker.roaming-key"))
+	if err != nil {
+		lis.Close()
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if caFile := viper.GetString("broker.roaming-client-ca"); caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			lis.Close()
+			return nil, err
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(ca) {
+			lis.Close()
+			return nil, fmt.Errorf("No certificates in %s", caFile)
+		}
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tls.NewListener(lis, tlsConfig), nil
+}
+
 func init() {
 	RootCmd.AddCommand(brokerCmd)
 
@@ -93,10 +192,33 @@ func init() {
 	viper.BindPFlag("broker.networkserver-cert", brokerCmd.Flags().Lookup("networkserver-cert"))
 	brokerCmd.Flags().String("networkserver-token", "", "Networkserver token to use")
 	viper.BindPFlag("broker.networkserver-token", brokerCmd.Flags().Lookup("networkserver-token"))
+	brokerCmd.Flags().Duration("devices-cache-ttl", 0, "How long to cache the devices that the Networkserver returns for a DevAddr (0 to disable)")
+	viper.BindPFlag("broker.devices-cache-ttl", brokerCmd.Flags().Lookup("devices-cache-ttl"))
+
+	brokerCmd.Flags().Int("net-id", 19, "LoRaWAN NetID")
+	viper.BindPFlag("broker.net-id", brokerCmd.Flags().Lookup("net-id"))
+	brokerCmd.Flags().String("roaming-address", "", "The IP address and port to listen for roaming partners (e.g. 0.0.0.0:1905). Partners are configured as NetID: URL in broker.roaming-partners in the config file")
+	viper.BindPFlag("broker.roaming-address", brokerCmd.Flags().Lookup("roaming-address"))
+	brokerCmd.Flags().String("roaming-cert", "", "Certificate file for TLS on the roaming address")
+	viper.BindPFlag("broker.roaming-cert", brokerCmd.Flags().Lookup("roaming-cert"))
+	brokerCmd.Flags().String("roaming-key", "", "Key file for TLS on the roaming address")
+	viper.BindPFlag("broker.roaming-key", brokerCmd.Flags().Lookup("roaming-key"))
+	brokerCmd.Flags().String("roaming-client-ca", "", "CA file for the client certificates of roaming partners that have no credentials in their URL")
+	viper.BindPFlag("broker.roaming-client-ca", brokerCmd.Flags().Lookup("roaming-client-ca"))
 
 	brokerCmd.Flags().Int("deduplication-delay", 200, "Deduplication delay (in ms)")
 	viper.BindPFlag("broker.deduplication-delay", brokerCmd.Flags().Lookup("deduplication-delay"))
 
+	brokerCmd.Flags().Int("client-rate", 5000, "The maximum number of API requests per client per hour")
+	viper.BindPFlag("broker.client-rate", brokerCmd.Flags().Lookup("client-rate"))
+
+	brokerCmd.Flags().String("redis-address", "", "Redis host and port for deduplicating messages with other Brokers and storing quota usage. Leave empty to keep it in memory")
+	viper.BindPFlag("broker.redis-address", brokerCmd.Flags().Lookup("redis-address"))
+	brokerCmd.Flags().Int("redis-db", 0, "Redis database")
+	viper.BindPFlag("broker.redis-db", brokerCmd.Flags().Lookup("redis-db"))
+
+	addQuotaFlags(brokerCmd, "broker")
+
 	brokerCmd.Flags().String("server-address", "0.0.0.0", "The IP address to listen for communication")
 	brokerCmd.Flags().String("server-address-announce", "localhost", "The public IP address to announce")
 	brokerCmd.Flags().Int("server-port", 1902, "The port for communication")
@@ -8,9 +8,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb "github.com/TheThingsNetwork/ttn/api/discovery"
@@ -57,11 +54,7 @@ var discoveryCmd = &cobra.Command{
 		}
 
 		// Discovery Server
-		discovery := discovery.NewRedisDiscovery(client)
-		if viper.GetBool("discovery.cache") {
-			discovery.WithCache(announcement.DefaultCacheOptions)
-		}
-		discovery.WithMasterAuthServers(viper.GetStringSlice("discovery.master-auth-servers")...)
+		discovery := newDiscovery(client)
 		err = discovery.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize discovery")
@@ -76,6 +69,7 @@ var discoveryCmd = &cobra.Command{
 
 		// Register and Listen
 		component.RegisterHealthServer(grpc)
+		component.RegisterAdminServer(grpc)
 		discovery.RegisterRPC(grpc)
 		go grpc.Serve(lis)
 
@@ -105,14 +99,22 @@ var discoveryCmd = &cobra.Command{
 			}()
 		}
 
-		sigChan := make(chan os.Signal)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		ctx.WithField("signal", <-sigChan).Info("signal received")
+		waitForShutdown()
 
-		grpc.Stop()
+		gracefulShutdown(component, grpc, discovery.Shutdown)
 	},
 }
 
+// newDiscovery creates a Discovery server with the configured cache and master auth servers
+func newDiscovery(client *redis.Client) discovery.Discovery {
+	d := discovery.NewRedisDiscovery(client)
+	if viper.GetBool("discovery.cache") {
+		d.WithCache(announcement.DefaultCacheOptions)
+	}
+	d.WithMasterAuthServers(viper.GetStringSlice("discovery.master-auth-servers")...)
+	return d
+}
+
 func init() {
 	RootCmd.AddCommand(discoveryCmd)
 
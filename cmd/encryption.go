@@ -0,0 +1,76 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"io/ioutil"
+
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/redis.v5"
+)
+
+// addKeyEncryptionFlags adds the flags for the key-encryption keys of a component. The flags are persistent, so that
+// they can also be used by the rewrap-keys command of the component.
+func addKeyEncryptionFlags(cmd *cobra.Command, component string) {
+	cmd.PersistentFlags().String("kek", "", "Key-encryption keys for the device keys in the format <ID>:<hex key>, separated by commas. The first KEK encrypts new keys")
+	cmd.PersistentFlags().String("kek-file", "", "File with the key-encryption keys for the device keys in the format <ID>:<hex key>, one per line. The first KEK encrypts new keys")
+	viper.BindPFlag(component+".kek", cmd.PersistentFlags().Lookup("kek"))
+	viper.BindPFlag(component+".kek-file", cmd.PersistentFlags().Lookup("kek-file"))
+}
+
+// getKeyRing returns the configured KeyRing of a component, or nil if keys are not encrypted
+func getKeyRing(component string) *storage.KeyRing {
+	keks := viper.GetString(component + ".kek")
+	if file := viper.GetString(component + ".kek-file"); file != "" {
+		contents, err := ioutil.ReadFile(file)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not read KEK file")
+		}
+		keks = string(contents)
+	}
+	if keks == "" {
+		return nil
+	}
+	keys, err := storage.ParseKeyRing(keks)
+	if err != nil {
+		ctx.WithError(err).Fatal("Invalid KEKs")
+	}
+	ctx.WithField("KEK", keys.CurrentID()).Info("Encrypting device keys")
+	return keys
+}
+
+// newRewrapKeysCmd returns a command that wraps the device keys in the store of a component with the current KEK
+func newRewrapKeysCmd(component string, rewrap func(client *redis.Client, keys *storage.KeyRing) (int, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewrap-keys",
+		Short: "Wrap the device keys with the current key-encryption key",
+		Long: `ttn ` + component + ` rewrap-keys wraps the keys of all devices with the first (current) key-encryption key.
+Keys that are not encrypted yet are encrypted. Run this command after configuring the first KEK,
+and after adding a new KEK, before removing the previous KEK from the configuration.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			keys := getKeyRing(component)
+			if keys == nil {
+				ctx.Fatal("No KEKs configured")
+			}
+
+			client := redis.NewClient(&redis.Options{
+				Addr:     viper.GetString(component + ".redis-address"),
+				Password: "", // no password set
+				DB:       viper.GetInt(component + ".redis-db"),
+			})
+			if err := connectRedis(client); err != nil {
+				ctx.WithError(err).Fatal("Could not connect to Redis")
+			}
+			defer client.Close()
+
+			changed, err := rewrap(client, keys)
+			if err != nil {
+				ctx.WithError(err).Fatal("Could not rewrap device keys")
+			}
+			ctx.WithField("Devices", changed).Info("Rewrapped device keys")
+		},
+	}
+}
@@ -7,16 +7,16 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/proxy"
 	"github.com/TheThingsNetwork/ttn/core/proxy/jsonpb"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/utils/parse"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/spf13/cobra"
@@ -66,54 +66,11 @@ var handlerCmd = &cobra.Command{
 		}
 
 		// Handler
-		handler := handler.NewRedisHandler(
-			client,
-			viper.GetString("handler.broker-id"),
-		)
-		if viper.GetString("handler.mqtt-address") != "" {
-			handler = handler.WithMQTT(
-				viper.GetString("handler.mqtt-username"),
-				viper.GetString("handler.mqtt-password"),
-				viper.GetString("handler.mqtt-address"),
-			)
-
-			mqttPort, err := parse.Port(viper.GetString("handler.mqtt-address"))
-			if err != nil {
-				ctx.WithError(err).Error("Could not announce the handler")
-			}
-			if announceAddr := viper.GetString("handler.mqtt-address-announce"); announceAddr != "" {
-				component.Identity.MqttAddress = fmt.Sprintf("%s:%d", announceAddr, mqttPort)
-			} else {
-				component.Identity.MqttAddress = fmt.Sprintf("%s:%d", viper.GetString("handler.server-address-announce"), mqttPort)
-			}
-		} else {
-			ctx.Warn("MQTT is not enabled in your configuration")
-		}
-		if viper.GetString("handler.amqp-address") != "" {
-			handler = handler.WithAMQP(
-				viper.GetString("handler.amqp-username"),
-				viper.GetString("handler.amqp-password"),
-				viper.GetString("handler.amqp-address"),
-				viper.GetString("handler.amqp-exchange"),
-			)
-
-			amqpPort, err := parse.Port(viper.GetString("handler.amqp-address"))
-			if err != nil {
-				ctx.WithError(err).Error("Could not announce the handler")
-			}
-			if announceAddr := viper.GetString("handler.amqp-address-announce"); announceAddr != "" {
-				component.Identity.AmqpAddress = fmt.Sprintf("%s:%d", announceAddr, amqpPort)
-			} else {
-				component.Identity.AmqpAddress = fmt.Sprintf("%s:%d", viper.GetString("handler.server-address-announce"), amqpPort)
-			}
-		} else {
-			ctx.Warn("AMQP is not enabled in your configuration")
-		}
+		handler := newHandler(component, client)
 		err = handler.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize handler")
 		}
-		defer handler.Shutdown()
 
 		// gRPC Server
 		lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", viper.GetString("handler.server-address"), viper.GetInt("handler.server-port")))
@@ -124,10 +81,10 @@ var handlerCmd = &cobra.Command{
 
 		// Register and Listen
 		component.RegisterHealthServer(grpc)
+		component.RegisterAdminServer(grpc)
 		handler.RegisterRPC(grpc)
 		handler.RegisterManager(grpc)
 		go grpc.Serve(lis)
-		defer grpc.Stop()
 
 		if httpActive {
 			proxyConn, err := component.Identity.Dial()
@@ -156,13 +113,76 @@ var handlerCmd = &cobra.Command{
 			}()
 		}
 
-		sigChan := make(chan os.Signal)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		ctx.WithField("signal", <-sigChan).Info("signal received")
+		waitForShutdown()
 
+		gracefulShutdown(component, grpc, handler.Shutdown)
 	},
 }
 
+// newHandler creates a Handler with the configured MQTT and AMQP integrations, and announces their addresses
+func newHandler(component *component.Component, client *redis.Client) handler.Handler {
+	h := handler.NewRedisHandler(
+		client,
+		viper.GetString("handler.broker-id"),
+	)
+	if viper.GetString("handler.mqtt-address") != "" {
+		h = h.WithMQTT(
+			viper.GetString("handler.mqtt-username"),
+			viper.GetString("handler.mqtt-password"),
+			viper.GetString("handler.mqtt-address"),
+		)
+
+		mqttPort, err := parse.Port(viper.GetString("handler.mqtt-address"))
+		if err != nil {
+			ctx.WithError(err).Error("Could not announce the handler")
+		}
+		if announceAddr := viper.GetString("handler.mqtt-address-announce"); announceAddr != "" {
+			component.Identity.MqttAddress = fmt.Sprintf("%s:%d", announceAddr, mqttPort)
+		} else {
+			component.Identity.MqttAddress = fmt.Sprintf("%s:%d", viper.GetString("handler.server-address-announce"), mqttPort)
+		}
+	} else {
+		ctx.Warn("MQTT is not enabled in your configuration")
+	}
+	if viper.GetString("handler.amqp-address") != "" {
+		h = h.WithAMQP(
+			viper.GetString("handler.amqp-username"),
+			viper.GetString("handler.amqp-password"),
+			viper.GetString("handler.amqp-address"),
+			viper.GetString("handler.amqp-exchange"),
+		)
+
+		amqpPort, err := parse.Port(viper.GetString("handler.amqp-address"))
+		if err != nil {
+			ctx.WithError(err).Error("Could not announce the handler")
+		}
+		if announceAddr := viper.GetString("handler.amqp-address-announce"); announceAddr != "" {
+			component.Identity.AmqpAddress = fmt.Sprintf("%s:%d", announceAddr, amqpPort)
+		} else {
+			component.Identity.AmqpAddress = fmt.Sprintf("%s:%d", viper.GetString("handler.server-address-announce"), amqpPort)
+		}
+	} else {
+		ctx.Warn("AMQP is not enabled in your configuration")
+	}
+	if application, device, enabled := getQuotaLimits("handler"); enabled {
+		h = h.WithQuotas(application, device)
+		component.OnReload(quotaKeys("handler"), reloadQuotas("handler", h.SetQuotaLimits))
+	}
+	if viper.GetBool("handler.join-group") {
+		h = h.WithGroup()
+	}
+	if keys := getKeyRing("handler"); keys != nil {
+		h = h.WithKeyEncryption(keys)
+	}
+	h = h.WithJoinLimits(handler.JoinLimits{
+		DevNonceRetention: viper.GetInt("handler.devnonce-retention"),
+		MonotonicDevNonce: viper.GetBool("handler.devnonce-monotonic"),
+		JoinInterval:      viper.GetDuration("handler.join-interval"),
+		MaxJoinInterval:   viper.GetDuration("handler.max-join-interval"),
+	})
+	return h
+}
+
 func init() {
 	RootCmd.AddCommand(handlerCmd)
 
@@ -174,6 +194,18 @@ func init() {
 	handlerCmd.Flags().String("broker-id", "dev", "The ID of the TTN Broker as announced in the Discovery server")
 	viper.BindPFlag("handler.broker-id", handlerCmd.Flags().Lookup("broker-id"))
 
+	handlerCmd.Flags().Bool("join-group", false, "Join the Handler group of applications that are already registered by a Handler that uses the same Redis database")
+	viper.BindPFlag("handler.join-group", handlerCmd.Flags().Lookup("join-group"))
+
+	handlerCmd.Flags().Duration("response-deadline", 100*time.Millisecond, "How long to wait for a downlink after an uplink")
+	handlerCmd.Flags().Duration("function-timeout", 100*time.Millisecond, "The maximum time a payload function is allowed to run")
+	handlerCmd.Flags().Int("application-rate", 5000, "The maximum number of API requests per application per hour")
+	handlerCmd.Flags().Int("client-rate", 5000, "The maximum number of API requests per client per hour")
+	viper.BindPFlag("handler.response-deadline", handlerCmd.Flags().Lookup("response-deadline"))
+	viper.BindPFlag("handler.function-timeout", handlerCmd.Flags().Lookup("function-timeout"))
+	viper.BindPFlag("handler.application-rate", handlerCmd.Flags().Lookup("application-rate"))
+	viper.BindPFlag("handler.client-rate", handlerCmd.Flags().Lookup("client-rate"))
+
 	handlerCmd.Flags().String("mqtt-address", "", "MQTT host and port. Leave empty to disable MQTT")
 	handlerCmd.Flags().String("mqtt-address-announce", "", "MQTT address to announce (takes value of server-address-announce if empty while enabled)")
 	handlerCmd.Flags().String("mqtt-username", "", "MQTT username")
@@ -194,6 +226,24 @@ func init() {
 	viper.BindPFlag("handler.amqp-password", handlerCmd.Flags().Lookup("amqp-password"))
 	viper.BindPFlag("handler.amqp-exchange", handlerCmd.Flags().Lookup("amqp-exchange"))
 
+	handlerCmd.Flags().Int("devnonce-retention", 0, "The number of used DevNonces and AppNonces to keep per device (0 to keep all)")
+	handlerCmd.Flags().Bool("devnonce-monotonic", false, "Require the DevNonce of each join request to be higher than the previous one (for devices that use a DevNonce counter, which wraps around after 0xFFFF)")
+	handlerCmd.Flags().Duration("join-interval", 0, "The minimum time between joins of a device, doubled for every join in quick succession (0 for unlimited)")
+	handlerCmd.Flags().Duration("max-join-interval", time.Hour, "The maximum time between joins of a device that joins repeatedly")
+	viper.BindPFlag("handler.devnonce-retention", handlerCmd.Flags().Lookup("devnonce-retention"))
+	viper.BindPFlag("handler.devnonce-monotonic", handlerCmd.Flags().Lookup("devnonce-monotonic"))
+	viper.BindPFlag("handler.join-interval", handlerCmd.Flags().Lookup("join-interval"))
+	viper.BindPFlag("handler.max-join-interval", handlerCmd.Flags().Lookup("max-join-interval"))
+
+	addQuotaFlags(handlerCmd, "handler")
+	addKeyEncryptionFlags(handlerCmd, "handler")
+
+	handlerCmd.AddCommand(newRewrapKeysCmd("handler", func(client *redis.Client, keys *storage.KeyRing) (int, error) {
+		devices := device.NewRedisDeviceStore(client, "handler")
+		devices.SetKeyRing(keys)
+		return devices.RewrapKeys()
+	}))
+
 	handlerCmd.Flags().String("server-address", "0.0.0.0", "The IP address to listen for communication")
 	handlerCmd.Flags().String("server-address-announce", "localhost", "The public IP address to announce")
 	handlerCmd.Flags().Int("server-port", 1904, "The port for communication")
@@ -6,14 +6,14 @@ package cmd
 import (
 	"fmt"
 	"net"
-	"os"
-	"os/signal"
 	"strings"
-	"syscall"
+	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/networkserver"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -52,23 +52,7 @@ var networkserverCmd = &cobra.Command{
 		}
 
 		// networkserver Server
-		networkserver := networkserver.NewRedisNetworkServer(client, viper.GetInt("networkserver.net-id"))
-
-		// Register Prefixes
-		for prefix, usage := range viper.GetStringMapString("networkserver.prefixes") {
-			prefix, err := types.ParseDevAddrPrefix(prefix)
-			if err != nil {
-				ctx.WithError(err).Warn("Could not use DevAddr Prefix. Skipping.")
-				continue
-			}
-			err = networkserver.UsePrefix(prefix, strings.Split(usage, ","))
-			if err != nil {
-				ctx.WithError(err).Fatal("Could not initialize networkserver")
-				continue
-			}
-			ctx.Infof("Using DevAddr prefix %s (%v)", prefix, usage)
-		}
-
+		networkserver := newNetworkServer(client)
 		err = networkserver.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize networkserver")
@@ -83,19 +67,54 @@ var networkserverCmd = &cobra.Command{
 
 		// Register and Listen
 		component.RegisterHealthServer(grpc)
+		component.RegisterAdminServer(grpc)
 		networkserver.RegisterRPC(grpc)
 		networkserver.RegisterManager(grpc)
 		go grpc.Serve(lis)
 
-		sigChan := make(chan os.Signal)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		ctx.WithField("signal", <-sigChan).Info("signal received")
+		waitForShutdown()
 
-		grpc.Stop()
-		networkserver.Shutdown()
+		gracefulShutdown(component, grpc, networkserver.Shutdown)
 	},
 }
 
+// newNetworkServer creates a Network Server that uses the configured NetID and DevAddr prefixes
+func newNetworkServer(client *redis.Client) networkserver.NetworkServer {
+	ns := networkserver.NewRedisNetworkServer(client, viper.GetInt("networkserver.net-id"))
+
+	// Register Prefixes
+	for prefix, usage := range viper.GetStringMapString("networkserver.prefixes") {
+		prefix, err := types.ParseDevAddrPrefix(prefix)
+		if err != nil {
+			ctx.WithError(err).Warn("Could not use DevAddr Prefix. Skipping.")
+			continue
+		}
+		err = ns.UsePrefix(prefix, strings.Split(usage, ","))
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not initialize networkserver")
+			continue
+		}
+		ctx.Infof("Using DevAddr prefix %s (%v)", prefix, usage)
+	}
+
+	if keys := getKeyRing("networkserver"); keys != nil {
+		ns.UseKeyEncryption(keys)
+	}
+
+	if ttl := viper.GetDuration("networkserver.devices-cache-ttl"); ttl > 0 {
+		if err := ns.UseDevicesCache(client, ttl); err != nil {
+			ctx.WithError(err).Fatal("Could not initialize devices cache")
+		}
+	}
+
+	// The default allocator compares DefaultDevAddrCandidates DevAddrs
+	if candidates := viper.GetInt("networkserver.devaddr-candidates"); candidates != networkserver.DefaultDevAddrCandidates {
+		ns.SetDevAddrCandidates(candidates)
+	}
+
+	return ns
+}
+
 func init() {
 	RootCmd.AddCommand(networkserverCmd)
 
@@ -107,6 +126,25 @@ func init() {
 	networkserverCmd.Flags().Int("net-id", 19, "LoRaWAN NetID")
 	viper.BindPFlag("networkserver.net-id", networkserverCmd.Flags().Lookup("net-id"))
 
+	networkserverCmd.Flags().Int("adr-margin", 15, "The default SNR margin for ADR (in dB)")
+	viper.BindPFlag("networkserver.adr-margin", networkserverCmd.Flags().Lookup("adr-margin"))
+	networkserverCmd.Flags().Int("client-rate", 5000, "The maximum number of API requests per client per hour")
+	viper.BindPFlag("networkserver.client-rate", networkserverCmd.Flags().Lookup("client-rate"))
+
+	networkserverCmd.Flags().Duration("devices-cache-ttl", time.Minute, "How long to keep devices in the in-memory DevAddr index (0 to disable)")
+	viper.BindPFlag("networkserver.devices-cache-ttl", networkserverCmd.Flags().Lookup("devices-cache-ttl"))
+
+	networkserverCmd.Flags().Int("devaddr-candidates", networkserver.DefaultDevAddrCandidates, "The number of DevAddrs that are compared when allocating the DevAddr with the fewest devices (0 to allocate at random)")
+	viper.BindPFlag("networkserver.devaddr-candidates", networkserverCmd.Flags().Lookup("devaddr-candidates"))
+
+	addKeyEncryptionFlags(networkserverCmd, "networkserver")
+
+	networkserverCmd.AddCommand(newRewrapKeysCmd("networkserver", func(client *redis.Client, keys *storage.KeyRing) (int, error) {
+		devices := device.NewRedisDeviceStore(client, "ns").(*device.RedisDeviceStore)
+		devices.SetKeyRing(keys)
+		return devices.RewrapKeys()
+	}))
+
 	viper.SetDefault("networkserver.prefixes", map[string]string{
 		"26000000/20": "otaa,abp,world,local,private,testing",
 	})
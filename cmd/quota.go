@@ -0,0 +1,65 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// addQuotaFlags adds the flags for the daily application and device quotas of a component
+func addQuotaFlags(cmd *cobra.Command, component string) {
+	cmd.Flags().Duration("quota-app-airtime", 0, "Uplink airtime per application per day (0 for unlimited)")
+	cmd.Flags().Int("quota-app-downlinks", 0, "Downlinks per application per day (0 for unlimited)")
+	cmd.Flags().Duration("quota-dev-airtime", 0, "Uplink airtime per device per day, for example 30s (0 for unlimited)")
+	cmd.Flags().Int("quota-dev-downlinks", 0, "Downlinks per device per day, for example 10 (0 for unlimited)")
+	viper.BindPFlag(component+".quota-app-airtime", cmd.Flags().Lookup("quota-app-airtime"))
+	viper.BindPFlag(component+".quota-app-downlinks", cmd.Flags().Lookup("quota-app-downlinks"))
+	viper.BindPFlag(component+".quota-dev-airtime", cmd.Flags().Lookup("quota-dev-airtime"))
+	viper.BindPFlag(component+".quota-dev-downlinks", cmd.Flags().Lookup("quota-dev-downlinks"))
+}
+
+// getQuotaLimits returns the configured quota limits of a component and whether any quota is enabled
+func getQuotaLimits(component string) (application, device quota.Limits, enabled bool) {
+	application = quota.Limits{
+		UplinkAirtime: viper.GetDuration(component + ".quota-app-airtime"),
+		Downlinks:     uint64(viper.GetInt(component + ".quota-app-downlinks")),
+	}
+	device = quota.Limits{
+		UplinkAirtime: viper.GetDuration(component + ".quota-dev-airtime"),
+		Downlinks:     uint64(viper.GetInt(component + ".quota-dev-downlinks")),
+	}
+	enabled = application != quota.Limits{} || device != quota.Limits{}
+	return
+}
+
+// quotaKeys returns the configuration keys of the quota limits of a component
+func quotaKeys(serviceName string) []string {
+	return []string{
+		serviceName + ".quota-app-airtime",
+		serviceName + ".quota-app-downlinks",
+		serviceName + ".quota-dev-airtime",
+		serviceName + ".quota-dev-downlinks",
+	}
+}
+
+// reloadQuotas returns a function that validates the configured quota limits of a component when the
+// configuration is reloaded, and applies them with setLimits
+func reloadQuotas(serviceName string, setLimits func(application, device quota.Limits)) component.ReloadFunc {
+	return func() (func(), error) {
+		application, device, _ := getQuotaLimits(serviceName)
+		if application.UplinkAirtime < 0 || device.UplinkAirtime < 0 {
+			return nil, errors.NewErrInvalidArgument("Quota", "airtime can not be negative")
+		}
+		if viper.GetInt(serviceName+".quota-app-downlinks") < 0 || viper.GetInt(serviceName+".quota-dev-downlinks") < 0 {
+			return nil, errors.NewErrInvalidArgument("Quota", "downlinks can not be negative")
+		}
+		return func() {
+			setLimits(application, device)
+		}, nil
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/utils/security"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// reloadCmd represents the reload command
+var reloadCmd = &cobra.Command{
+	Use:   "reload [address]",
+	Short: "Reload the configuration of a running component",
+	Long: `ttn reload asks the component at the given address to re-read its configuration and apply the settings that
+can be changed at runtime. It uses the id and the keys in the key-dir of the component.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			cmd.UsageFunc()(cmd)
+			return
+		}
+
+		privKey, err := security.LoadKeypair(viper.GetString("key-dir"))
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not load security keys")
+		}
+		privPEM, err := security.PrivatePEM(privKey)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not load security keys")
+		}
+		token, err := security.BuildJWT(viper.GetString("id"), 20*time.Second, privPEM)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not build token")
+		}
+
+		var conn *grpc.ClientConn
+		if cert, certErr := security.LoadCert(viper.GetString("key-dir")); certErr == nil {
+			conn, err = api.DialWithCert(args[0], string(cert))
+		} else {
+			conn, err = api.Dial(args[0])
+		}
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not connect to component")
+		}
+		defer conn.Close()
+
+		if err := component.AdminReload(conn, token); err != nil {
+			ctx.WithError(err).Fatal("Could not reload configuration")
+		}
+
+		ctx.WithField("Address", args[0]).Info("Reloaded configuration")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(reloadCmd)
+}
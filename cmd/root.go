@@ -134,7 +134,15 @@ func init() {
 	RootCmd.PersistentFlags().String("discovery-address", "discover.thethingsnetwork.org:1900", "The address of the Discovery server")
 	RootCmd.PersistentFlags().String("auth-token", "", "The JWT token to be used for the discovery server")
 
-	RootCmd.PersistentFlags().Int("health-port", 0, "The port number where the health server should be started")
+	RootCmd.PersistentFlags().String("trace-file", "", "Location of the file where traces are exported to (- for stdout)")
+	RootCmd.PersistentFlags().String("trace-collector", "", "URL of a Zipkin-compatible collector (such as Jaeger) where traces are exported to")
+
+	RootCmd.PersistentFlags().String("audit-file", "", "Location of the file where the audit log is written to")
+	RootCmd.PersistentFlags().String("audit-elasticsearch", "", "Location of Elasticsearch server where the audit log is written to")
+
+	RootCmd.PersistentFlags().Int("health-port", 0, "The port number where the health server (with /healthz and /metrics endpoints) should be started")
+
+	RootCmd.PersistentFlags().Duration("shutdown-timeout", 10*time.Second, "How long to wait for in-flight messages when shutting down")
 
 	viper.SetDefault("auth-servers", map[string]string{
 		"ttn-account-v2": "https://account.thethingsnetwork.org",
@@ -6,16 +6,16 @@ package cmd
 import (
 	"fmt"
 	"net"
-	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/router"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"gopkg.in/redis.v5"
 )
 
 // routerCmd represents the router command
@@ -38,8 +38,19 @@ var routerCmd = &cobra.Command{
 			ctx.WithError(err).Fatal("Could not initialize component")
 		}
 
+		// Redis Client
+		var client *redis.Client
+		if redisAddress := viper.GetString("router.redis-address"); redisAddress != "" {
+			client = redis.NewClient(&redis.Options{
+				Addr:     redisAddress,
+				Password: "", // no password set
+				DB:       viper.GetInt("router.redis-db"),
+			})
+			connectRedis(client)
+		}
+
 		// Router
-		router := router.NewRouter()
+		router := newRouter(client)
 		err = router.Init(component)
 		if err != nil {
 			ctx.WithError(err).Fatal("Could not initialize router")
@@ -54,27 +65,83 @@ var routerCmd = &cobra.Command{
 
 		// Register and Listen
 		component.RegisterHealthServer(grpc)
+		component.RegisterAdminServer(grpc)
 		router.RegisterRPC(grpc)
 		router.RegisterManager(grpc)
 		go grpc.Serve(lis)
 
-		sigChan := make(chan os.Signal)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		ctx.WithField("signal", <-sigChan).Info("signal received")
+		waitForShutdown()
 
-		grpc.Stop()
-		router.Shutdown()
+		gracefulShutdown(component, grpc, router.Shutdown)
 	},
 }
 
+// newRouter creates a Router that keeps the gateway history in Redis if a client is given, and publishes gateway
+// events to the configured MQTT and AMQP brokers
+func newRouter(client *redis.Client) router.Router {
+	r := router.NewRouter()
+	if client != nil {
+		r.SetGatewayHistory(gateway.NewRedisHistoryStore(client, "router"))
+	} else {
+		ctx.Warn("Gateway history is kept in memory, configure a Redis address to store it persistently")
+	}
+	if viper.GetString("router.mqtt-address") != "" {
+		r = r.WithMQTT(
+			viper.GetString("router.mqtt-username"),
+			viper.GetString("router.mqtt-password"),
+			viper.GetString("router.mqtt-address"),
+		)
+	}
+	if viper.GetString("router.amqp-address") != "" {
+		r = r.WithAMQP(
+			viper.GetString("router.amqp-username"),
+			viper.GetString("router.amqp-password"),
+			viper.GetString("router.amqp-address"),
+			viper.GetString("router.amqp-exchange"),
+		)
+	}
+	return r
+}
+
 func init() {
 	RootCmd.AddCommand(routerCmd)
 	routerCmd.Flags().String("server-address", "0.0.0.0", "The IP address to listen for communication")
 	routerCmd.Flags().String("server-address-announce", "localhost", "The public IP address to announce")
 	routerCmd.Flags().Int("server-port", 1901, "The port for communication")
 	routerCmd.Flags().Bool("skip-verify-gateway-token", false, "Skip verification of the gateway token")
+	routerCmd.Flags().Duration("downlink-deadline", 400*time.Millisecond, "How long before the transmission a downlink is sent to the gateway")
+	routerCmd.Flags().Int("uplink-rate", 1500, "The maximum number of uplink messages per gateway per minute")
+	routerCmd.Flags().Int("status-rate", 10, "The maximum number of status messages per gateway per minute")
 	viper.BindPFlag("router.server-address", routerCmd.Flags().Lookup("server-address"))
 	viper.BindPFlag("router.server-address-announce", routerCmd.Flags().Lookup("server-address-announce"))
 	viper.BindPFlag("router.server-port", routerCmd.Flags().Lookup("server-port"))
 	viper.BindPFlag("router.skip-verify-gateway-token", routerCmd.Flags().Lookup("skip-verify-gateway-token"))
+	viper.BindPFlag("router.downlink-deadline", routerCmd.Flags().Lookup("downlink-deadline"))
+	viper.BindPFlag("router.uplink-rate", routerCmd.Flags().Lookup("uplink-rate"))
+	viper.BindPFlag("router.status-rate", routerCmd.Flags().Lookup("status-rate"))
+	routerCmd.Flags().String("redis-address", "", "Redis host and port for storing gateway history. Leave empty to keep it in memory")
+	viper.BindPFlag("router.redis-address", routerCmd.Flags().Lookup("redis-address"))
+	routerCmd.Flags().Int("redis-db", 0, "Redis database")
+	viper.BindPFlag("router.redis-db", routerCmd.Flags().Lookup("redis-db"))
+
+	routerCmd.Flags().Duration("status-timeout", 5*time.Minute, "The time without status messages after which the status of a gateway is stale")
+	routerCmd.Flags().Duration("clock-drift-threshold", 500*time.Millisecond, "The drift of a gateway clock above which a clock-drift event is emitted")
+	viper.BindPFlag("router.status-timeout", routerCmd.Flags().Lookup("status-timeout"))
+	viper.BindPFlag("router.clock-drift-threshold", routerCmd.Flags().Lookup("clock-drift-threshold"))
+
+	routerCmd.Flags().String("mqtt-address", "", "MQTT host and port for publishing gateway events. Leave empty to disable MQTT")
+	routerCmd.Flags().String("mqtt-username", "", "MQTT username")
+	routerCmd.Flags().String("mqtt-password", "", "MQTT password")
+	viper.BindPFlag("router.mqtt-address", routerCmd.Flags().Lookup("mqtt-address"))
+	viper.BindPFlag("router.mqtt-username", routerCmd.Flags().Lookup("mqtt-username"))
+	viper.BindPFlag("router.mqtt-password", routerCmd.Flags().Lookup("mqtt-password"))
+
+	routerCmd.Flags().String("amqp-address", "", "AMQP host and port for publishing gateway events. Leave empty to disable AMQP")
+	routerCmd.Flags().String("amqp-username", "guest", "AMQP username")
+	routerCmd.Flags().String("amqp-password", "guest", "AMQP password")
+	routerCmd.Flags().String("amqp-exchange", "ttn.router", "AMQP exchange")
+	viper.BindPFlag("router.amqp-address", routerCmd.Flags().Lookup("amqp-address"))
+	viper.BindPFlag("router.amqp-username", routerCmd.Flags().Lookup("amqp-username"))
+	viper.BindPFlag("router.amqp-password", routerCmd.Flags().Lookup("amqp-password"))
+	viper.BindPFlag("router.amqp-exchange", routerCmd.Flags().Lookup("amqp-exchange"))
 }
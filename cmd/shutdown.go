@@ -0,0 +1,85 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"google.golang.org/grpc"
+)
+
+// waitForShutdown blocks until the process receives a SIGINT or SIGTERM. When it receives a SIGHUP, it reloads the
+// configuration.
+func waitForShutdown() {
+	sigChan := make(chan os.Signal)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigChan {
+		ctx.WithField("signal", sig).Info("signal received")
+		if sig != syscall.SIGHUP {
+			return
+		}
+		if err := component.Reload(ctx); err != nil {
+			ctx.WithError(err).Warn("Could not reload configuration")
+		}
+	}
+}
+
+// gracefulShutdown stops a component and its gRPC server. The component first reports that it is no longer
+// serving, and the servers stop accepting new streams and requests. The existing streams stay open while the
+// component handles its in-flight messages, and are closed when shutdown returns. The HTTP servers of the
+// component then get until the shutdown deadline to finish their requests.
+func gracefulShutdown(c *component.Component, server *grpc.Server, shutdown func(), httpServers ...*httpServer) {
+	c.SetStatus(component.StatusUnhealthy)
+	for _, httpServer := range httpServers {
+		httpServer.stop()
+	}
+	go server.GracefulStop()
+	shutdown()
+	for _, httpServer := range httpServers {
+		if dropped := httpServer.inFlight.Close(c.ShutdownDeadline()); dropped > 0 {
+			ctx.WithField("Requests", dropped).Warn("Dropped HTTP requests that were still being handled")
+		}
+	}
+	server.Stop()
+}
+
+// httpServer is an HTTP server that is stopped by gracefulShutdown
+type httpServer struct {
+	lis      net.Listener
+	server   *http.Server
+	inFlight component.InFlight
+	stopped  int32 // Accessed atomically
+}
+
+// serveHTTP serves the handler on the listener until the server is stopped by gracefulShutdown
+func serveHTTP(lis net.Listener, handler http.Handler) *httpServer {
+	s := &httpServer{lis: lis}
+	s.server = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.inFlight.Add() {
+			http.Error(w, "Shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		defer s.inFlight.Done()
+		handler.ServeHTTP(w, r)
+	})}
+	go func() {
+		if err := s.server.Serve(lis); err != nil && atomic.LoadInt32(&s.stopped) == 0 {
+			ctx.WithError(err).WithField("Address", lis.Addr()).Fatal("Error in HTTP server")
+		}
+	}()
+	return s
+}
+
+// stop stops accepting new connections and requests
+func (s *httpServer) stop() {
+	atomic.StoreInt32(&s.stopped, 1)
+	s.server.SetKeepAlivesEnabled(false)
+	s.lis.Close()
+}
@@ -0,0 +1,201 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package audit records changes to security-sensitive state, such as device keys, payload functions and prefixes
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/fatih/structs"
+)
+
+// Action is the type of change that is recorded
+type Action string
+
+// Actions that are recorded in the audit log
+const (
+	RegisterApplication Action = "register_application"
+	SetApplication      Action = "set_application"
+	DeleteApplication   Action = "delete_application"
+	SetDevice           Action = "set_device"
+	DeleteDevice        Action = "delete_device"
+	AddMetadata         Action = "add_metadata"
+	DeleteMetadata      Action = "delete_metadata"
+	JoinMetadata        Action = "join_metadata"
+	ExportApplication   Action = "export_application"
+	ImportApplication   Action = "import_application"
+	MigrateApplication  Action = "migrate_application"
+)
+
+// Redacted replaces the values of keys in the audit log
+const Redacted = "<redacted>"
+
+// Entry in the audit log
+type Entry struct {
+	Time        time.Time         `json:"time"`
+	ServiceName string            `json:"service_name"`
+	ServiceID   string            `json:"service_id"`
+	Actor       string            `json:"actor"`
+	Issuer      string            `json:"issuer,omitempty"`
+	Action      Action            `json:"action"`
+	AppID       string            `json:"app_id,omitempty"`
+	DevID       string            `json:"dev_id,omitempty"`
+	Target      string            `json:"target,omitempty"`
+	Changed     map[string]string `json:"changed,omitempty"`
+}
+
+// SetActor sets the actor of the entry to the subject of the claims of a token
+func (e *Entry) SetActor(claims *claims.Claims) {
+	if claims == nil {
+		return
+	}
+	e.Actor = claims.Subject
+	if claims.Type != "" {
+		e.Actor = fmt.Sprintf("%s:%s", claims.Type, claims.Subject)
+	}
+	e.Issuer = claims.Issuer
+}
+
+// ignoredFields are the fields that change with every update
+var ignoredFields = map[string]bool{
+	"CreatedAt": true,
+	"UpdatedAt": true,
+}
+
+// Model is implemented by the models that keep track of their changes
+type Model interface {
+	ChangedFields() []string
+}
+
+// Changes returns the new values of the changed fields of a model. The values of keys are redacted.
+func Changes(model Model) map[string]string {
+	changed := make(map[string]string)
+	s := structs.New(model)
+	for _, name := range model.ChangedFields() {
+		if ignoredFields[name] {
+			continue
+		}
+		field, ok := s.FieldOk(name)
+		if !ok {
+			continue
+		}
+		if strings.HasSuffix(name, "Key") {
+			changed[name] = Redacted
+			continue
+		}
+		changed[name] = fmt.Sprintf("%v", field.Value())
+	}
+	return changed
+}
+
+// Sink records entries of the audit log
+type Sink interface {
+	Record(entry *Entry) error
+}
+
+// Filter for querying the audit log
+type Filter struct {
+	AppID string
+	DevID string
+	Since time.Time
+	Limit int
+}
+
+// DefaultLimit is the number of entries that is returned by a query without limit
+var DefaultLimit = 100
+
+// Match returns true if the entry matches the filter
+func (f Filter) Match(entry *Entry) bool {
+	if f.AppID != "" && entry.AppID != f.AppID {
+		return false
+	}
+	if f.DevID != "" && entry.DevID != f.DevID {
+		return false
+	}
+	if !f.Since.IsZero() && !entry.Time.After(f.Since) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) limit() int {
+	if f.Limit <= 0 {
+		return DefaultLimit
+	}
+	return f.Limit
+}
+
+// Store is a Sink that can also be queried
+type Store interface {
+	Sink
+	// Query returns the entries that match the filter, newest first
+	Query(filter Filter) ([]*Entry, error)
+}
+
+type multiSink []Sink
+
+// NewMultiSink returns a Sink that records the entries in all given sinks. It is queried through the first sink
+// that is a Store.
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+func (s multiSink) Record(entry *Entry) error {
+	var firstErr error
+	for _, sink := range s {
+		if err := sink.Record(entry); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s multiSink) Query(filter Filter) ([]*Entry, error) {
+	for _, sink := range s {
+		if store, ok := sink.(Store); ok {
+			return store.Query(filter)
+		}
+	}
+	return nil, errNotQueryable
+}
+
+var errNotQueryable = errors.NewErrInternal("The audit log can not be queried")
+
+var sinkMu sync.RWMutex
+var _sink Sink
+
+// SetSink sets the sink of the audit log of this process. Passing a nil sink disables the audit log.
+func SetSink(sink Sink) {
+	sinkMu.Lock()
+	defer sinkMu.Unlock()
+	_sink = sink
+}
+
+// Record records the entry in the audit log. The time of the entry is set if it is empty.
+func Record(entry *Entry) error {
+	sinkMu.RLock()
+	defer sinkMu.RUnlock()
+	if _sink == nil {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	return _sink.Record(entry)
+}
+
+// Query returns the entries of the audit log that match the filter, newest first
+func Query(filter Filter) ([]*Entry, error) {
+	sinkMu.RLock()
+	defer sinkMu.RUnlock()
+	if store, ok := _sink.(Store); ok {
+		return store.Query(filter)
+	}
+	return nil, errNotQueryable
+}
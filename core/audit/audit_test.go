@@ -0,0 +1,156 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	. "github.com/smartystreets/assertions"
+)
+
+type testModel struct {
+	DevID     string
+	AppKey    string
+	FCntUp    uint32
+	UpdatedAt time.Time
+	changed   []string
+}
+
+func (m *testModel) ChangedFields() []string {
+	return m.changed
+}
+
+func TestChanges(t *testing.T) {
+	a := New(t)
+
+	changes := Changes(&testModel{
+		DevID:     "dev",
+		AppKey:    "00112233445566778899AABBCCDDEEFF",
+		FCntUp:    42,
+		UpdatedAt: time.Now(),
+		changed:   []string{"DevID", "AppKey", "FCntUp", "UpdatedAt", "Unknown"},
+	})
+	a.So(changes, ShouldResemble, map[string]string{
+		"DevID":  "dev",
+		"AppKey": Redacted,
+		"FCntUp": "42",
+	})
+}
+
+func TestSetActor(t *testing.T) {
+	a := New(t)
+
+	entry := &Entry{}
+	entry.SetActor(nil)
+	a.So(entry.Actor, ShouldBeEmpty)
+
+	entry.SetActor(&claims.Claims{Type: "user", Subject: "alice", Issuer: "ttn-account"})
+	a.So(entry.Actor, ShouldEqual, "user:alice")
+	a.So(entry.Issuer, ShouldEqual, "ttn-account")
+}
+
+func TestFilter(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	entry := &Entry{Time: now, AppID: "app", DevID: "dev"}
+
+	a.So(Filter{}.Match(entry), ShouldBeTrue)
+	a.So(Filter{AppID: "app"}.Match(entry), ShouldBeTrue)
+	a.So(Filter{AppID: "other"}.Match(entry), ShouldBeFalse)
+	a.So(Filter{AppID: "app", DevID: "dev"}.Match(entry), ShouldBeTrue)
+	a.So(Filter{AppID: "app", DevID: "other"}.Match(entry), ShouldBeFalse)
+	a.So(Filter{Since: now.Add(-time.Second)}.Match(entry), ShouldBeTrue)
+	a.So(Filter{Since: now}.Match(entry), ShouldBeFalse)
+
+	a.So(Filter{}.limit(), ShouldEqual, DefaultLimit)
+	a.So(Filter{Limit: 5}.limit(), ShouldEqual, 5)
+}
+
+func TestFileSink(t *testing.T) {
+	a := New(t)
+
+	dir, err := ioutil.TempDir("", "audit")
+	a.So(err, ShouldBeNil)
+	defer os.RemoveAll(dir)
+
+	sink, err := NewFileSink(filepath.Join(dir, "audit.log"))
+	a.So(err, ShouldBeNil)
+
+	start := time.Now()
+	for i, devID := range []string{"dev-1", "dev-2", "dev-1", "dev-3"} {
+		err := sink.Record(&Entry{
+			Time:    start.Add(time.Duration(i) * time.Second),
+			Action:  SetDevice,
+			AppID:   "app",
+			DevID:   devID,
+			Changed: map[string]string{"AppKey": Redacted},
+		})
+		a.So(err, ShouldBeNil)
+	}
+
+	entries, err := sink.Query(Filter{AppID: "app"})
+	a.So(err, ShouldBeNil)
+	a.So(entries, ShouldHaveLength, 4)
+	a.So(entries[0].DevID, ShouldEqual, "dev-3")
+	a.So(entries[0].Changed, ShouldResemble, map[string]string{"AppKey": Redacted})
+
+	entries, err = sink.Query(Filter{AppID: "app", DevID: "dev-1"})
+	a.So(err, ShouldBeNil)
+	a.So(entries, ShouldHaveLength, 2)
+	a.So(entries[0].Time.After(entries[1].Time), ShouldBeTrue)
+
+	entries, err = sink.Query(Filter{AppID: "app", Limit: 2})
+	a.So(err, ShouldBeNil)
+	a.So(entries, ShouldHaveLength, 2)
+	a.So(entries[0].DevID, ShouldEqual, "dev-3")
+	a.So(entries[1].DevID, ShouldEqual, "dev-1")
+
+	entries, err = sink.Query(Filter{AppID: "other"})
+	a.So(err, ShouldBeNil)
+	a.So(entries, ShouldBeEmpty)
+}
+
+type memorySink []*Entry
+
+func (s *memorySink) Record(entry *Entry) error {
+	*s = append(*s, entry)
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	a := New(t)
+
+	defer SetSink(nil)
+
+	a.So(Record(&Entry{Action: SetDevice}), ShouldBeNil)
+	_, err := Query(Filter{})
+	a.So(err, ShouldNotBeNil)
+
+	dir, err := ioutil.TempDir("", "audit")
+	a.So(err, ShouldBeNil)
+	defer os.RemoveAll(dir)
+
+	memory := new(memorySink)
+	file, err := NewFileSink(filepath.Join(dir, "audit.log"))
+	a.So(err, ShouldBeNil)
+	SetSink(NewMultiSink(memory, file))
+
+	a.So(Record(&Entry{Action: SetDevice, AppID: "app"}), ShouldBeNil)
+	a.So(*memory, ShouldHaveLength, 1)
+	a.So((*memory)[0].Time.IsZero(), ShouldBeFalse)
+
+	entries, err := Query(Filter{AppID: "app"})
+	a.So(err, ShouldBeNil)
+	a.So(entries, ShouldHaveLength, 1)
+
+	SetSink(NewMultiSink(memory))
+	_, err = Query(Filter{})
+	a.So(err, ShouldNotBeNil)
+}
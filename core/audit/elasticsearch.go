@@ -0,0 +1,107 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	esHandler "github.com/TheThingsNetwork/ttn/utils/elasticsearch/handler"
+	"github.com/apex/log"
+	"github.com/tj/go-elastic"
+)
+
+// ElasticsearchPrefix is the prefix of the indexes of the audit log in Elasticsearch
+var ElasticsearchPrefix = "audit"
+
+type elasticsearchSink struct {
+	url     string
+	client  *http.Client
+	handler *esHandler.Handler
+}
+
+// NewElasticsearchSink returns a Store that indexes the entries in the Elasticsearch server at the given URL. The
+// entries are indexed as log entries with the entry in the "audit" field.
+func NewElasticsearchSink(url string) Store {
+	client := &http.Client{Timeout: 5 * time.Second}
+	esClient := elastic.New(url)
+	esClient.HTTPClient = client
+	return &elasticsearchSink{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+		handler: esHandler.New(&esHandler.Config{
+			Client:     esClient,
+			Prefix:     ElasticsearchPrefix,
+			BufferSize: 1, // Entries are rare and should not get lost
+		}),
+	}
+}
+
+func (s *elasticsearchSink) Record(entry *Entry) error {
+	return s.handler.HandleLog(&log.Entry{
+		Fields:    log.Fields{"audit": entry},
+		Level:     log.InfoLevel,
+		Timestamp: entry.Time,
+		Message:   string(entry.Action),
+	})
+}
+
+type elasticsearchQueryResult struct {
+	Hits struct {
+		Hits []struct {
+			Source struct {
+				Fields struct {
+					Audit *Entry `json:"audit"`
+				} `json:"fields"`
+			} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (s *elasticsearchSink) Query(filter Filter) ([]*Entry, error) {
+	must := []interface{}{}
+	if filter.AppID != "" {
+		must = append(must, map[string]interface{}{"match_phrase": map[string]string{"fields.audit.app_id": filter.AppID}})
+	}
+	if filter.DevID != "" {
+		must = append(must, map[string]interface{}{"match_phrase": map[string]string{"fields.audit.dev_id": filter.DevID}})
+	}
+	if !filter.Since.IsZero() {
+		must = append(must, map[string]interface{}{"range": map[string]interface{}{"timestamp": map[string]interface{}{"gt": filter.Since.UTC()}}})
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"bool": map[string]interface{}{"must": must}},
+		"sort":  []interface{}{map[string]interface{}{"timestamp": map[string]string{"order": "desc"}}},
+		"size":  filter.limit(),
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Post(fmt.Sprintf("%s/%s-*/_search", s.url, ElasticsearchPrefix), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("Elasticsearch returned status %s", res.Status)
+	}
+	var result elasticsearchQueryResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	// Phrase matches are not exact, so the entries are filtered again
+	entries := make([]*Entry, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		if entry := hit.Source.Fields.Audit; entry != nil && filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -37,17 +37,25 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 	defer func() {
 		if err != nil {
 			if deduplicatedActivationRequest != nil {
-				deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(trace.DropEvent, "reason", err)
+				deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(b.TraceService(), trace.DropEvent, "reason", err)
 			}
 			ctx.WithError(err).Warn("Could not handle activation")
 		} else {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled activation")
 		}
+		if deduplicatedActivationRequest != nil {
+			trace.Export(b.TraceService(), deduplicatedActivationRequest.Trace)
+		}
 	}()
 
 	b.status.activations.Mark(1)
 
-	activation.Trace = activation.Trace.WithEvent(trace.ReceiveEvent)
+	if !b.inFlight.Add() {
+		return nil, errors.NewErrInternal("Broker is shutting down")
+	}
+	defer b.inFlight.Done()
+
+	activation.Trace = activation.Trace.WithEvent(b.TraceService(), trace.ReceiveEvent)
 
 	// De-duplicate uplink messages
 	duplicates := b.deduplicateActivation(activation)
@@ -63,7 +71,7 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 	deduplicatedActivationRequest.AppEui = duplicates[0].AppEui
 	deduplicatedActivationRequest.ProtocolMetadata = duplicates[0].ProtocolMetadata
 	deduplicatedActivationRequest.ActivationMetadata = duplicates[0].ActivationMetadata
-	deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(trace.DeduplicateEvent,
+	deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(b.TraceService(), trace.DeduplicateEvent,
 		"duplicates", len(duplicates),
 	)
 	for _, duplicate := range duplicates {
@@ -79,11 +87,12 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 		downlinkOptions = append(downlinkOptions, duplicate.DownlinkOptions...)
 	}
 
-	// Select best DownlinkOption
+	// Select best DownlinkOption and keep the others for failover
 	if len(downlinkOptions) > 0 {
 		deduplicatedActivationRequest.ResponseTemplate = &pb.DeviceActivationResponse{
 			DownlinkOption: selectBestDownlink(downlinkOptions),
 		}
+		b.downlinkOptions.Set(downlinkOptions)
 	}
 
 	// Send Activate to NS
@@ -163,7 +172,12 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 		close(responses)
 	}()
 
-	var gotFirst bool
+	// Handlers of the same group all accept the Activation, so we select the preferred Handler for the device
+	rank := make(map[string]int)
+	for i, announcement := range rankHandlers(deduplicatedActivationRequest.DevId, announcements) {
+		rank[announcement.Id] = i
+	}
+
 	var joinHandler *pb_discovery.Announcement
 	var joinHandlerClient pb_handler.HandlerClient
 	for res := range responses {
@@ -176,23 +190,20 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 			continue
 		}
 
-		if gotFirst {
-			ctx.Warn("Duplicate Activation Response")
-		} else {
-			gotFirst = true
+		if joinHandler == nil || rank[res.handler.Id] < rank[joinHandler.Id] {
 			joinHandler = res.handler
 			joinHandlerClient = res.client
 		}
 	}
 
 	// Activation not accepted by any broker
-	if !gotFirst {
+	if joinHandler == nil {
 		ctx.Debug("Activation not accepted by any Handler")
 		return nil, errors.New("Activation not accepted by any Handler")
 	}
 
 	ctx.WithField("HandlerID", joinHandler.Id).Debug("Forward Activation")
-	deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(trace.ForwardEvent,
+	deduplicatedActivationRequest.Trace = deduplicatedActivationRequest.Trace.WithEvent(b.TraceService(), trace.ForwardEvent,
 		"handler", joinHandler.Id,
 	)
 
@@ -201,14 +212,14 @@ func (b *broker) HandleActivation(activation *pb.DeviceActivationRequest) (res *
 		return nil, errors.Wrap(errors.FromGRPCError(err), "Handler refused activation")
 	}
 
-	handlerResponse.Trace = handlerResponse.Trace.WithEvent(trace.ReceiveEvent)
+	handlerResponse.Trace = handlerResponse.Trace.WithEvent(b.TraceService(), trace.ReceiveEvent)
 
 	handlerResponse, err = b.ns.Activate(b.Component.GetContext(b.nsToken), handlerResponse)
 	if err != nil {
 		return nil, errors.Wrap(errors.FromGRPCError(err), "NetworkServer refused activation")
 	}
 
-	handlerResponse.Trace = handlerResponse.Trace.WithEvent(trace.ForwardEvent)
+	handlerResponse.Trace = handlerResponse.Trace.WithEvent(b.TraceService(), trace.ForwardEvent)
 
 	res = &pb.DeviceActivationResponse{
 		Payload:        handlerResponse.Payload,
@@ -44,10 +44,19 @@ func TestHandleActivation(t *testing.T) {
 		AppEui:           &appEUI,
 		GatewayMetadata:  &gateway.RxMetadata{Snr: 1.2, GatewayId: gtwID},
 		ProtocolMetadata: &protocol.RxMetadata{},
+		DownlinkOptions: []*pb_broker.DownlinkOption{
+			&pb_broker.DownlinkOption{Identifier: "router:rx2", Score: 20},
+			&pb_broker.DownlinkOption{Identifier: "router:rx1", Score: 10},
+		},
 	})
 	a.So(err, ShouldNotBeNil)
 	a.So(res, ShouldBeNil)
 
+	// The other DownlinkOptions are kept for failover
+	next := b.downlinkOptions.Next("router:rx1")
+	a.So(next, ShouldNotBeNil)
+	a.So(next.Identifier, ShouldEqual, "router:rx2")
+
 	b.ctrl.Finish()
 
 	// TODO: Integration test with Handler
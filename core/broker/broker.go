@@ -5,6 +5,7 @@ package broker
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,9 +15,13 @@ import (
 	"github.com/TheThingsNetwork/ttn/api/networkserver"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/core/roaming"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/gogo/protobuf/proto"
 	"google.golang.org/grpc"
+	"gopkg.in/redis.v5"
 )
 
 type Broker interface {
@@ -24,23 +29,35 @@ type Broker interface {
 	component.ManagementInterface
 
 	SetNetworkServer(addr, cert, token string)
+	SetQuotas(store quota.Store, application, device quota.Limits)
+	SetQuotaLimits(application, device quota.Limits)
+	SetRedisDeduplication(client *redis.Client, prefix string)
+	UseDevicesCache(ttl time.Duration)
+	UseRoaming(netID types.NetID, partners ...*roaming.Partner)
+	RoamingHandler() http.Handler
 
 	HandleUplink(uplink *pb.UplinkMessage) error
 	HandleDownlink(downlink *pb.DownlinkMessage) error
+	HandleDownlinkFailure(downlink *pb.DownlinkMessage) error
 	HandleActivation(activation *pb.DeviceActivationRequest) (*pb.DeviceActivationResponse, error)
 
 	ActivateRouter(id string) (<-chan *pb.DownlinkMessage, error)
 	DeactivateRouter(id string) error
 	ActivateHandlerUplink(id string) (<-chan *pb.DeduplicatedUplinkMessage, error)
 	DeactivateHandlerUplink(id string) error
+
+	SubscribeTraffic(subscriptionID string, filter *pb.TrafficRequest) (<-chan *pb.TrafficMessage, error)
+	UnsubscribeTraffic(subscriptionID string) error
 }
 
 func NewBroker(timeout time.Duration) Broker {
 	return &broker{
 		routers:                make(map[string]chan *pb.DownlinkMessage),
 		handlers:               make(map[string]*handler),
+		deduplicationDelay:     timeout,
 		uplinkDeduplicator:     NewDeduplicator(timeout),
 		activationDeduplicator: NewDeduplicator(timeout),
+		downlinkOptions:        NewDownlinkOptionsStore(),
 	}
 }
 
@@ -50,6 +67,27 @@ func (b *broker) SetNetworkServer(addr, cert, token string) {
 	b.nsToken = token
 }
 
+func (b *broker) SetQuotas(store quota.Store, application, device quota.Limits) {
+	b.quota = quota.NewEnforcer(store, application, device)
+}
+
+func (b *broker) SetQuotaLimits(application, device quota.Limits) {
+	if b.quota != nil {
+		b.quota.SetLimits(application, device)
+	}
+}
+
+// SetRedisDeduplication makes the Broker deduplicate uplink messages and activation requests together with the other
+// Brokers that use the same Redis, so that multiple Brokers can handle the traffic of the same Routers
+func (b *broker) SetRedisDeduplication(client *redis.Client, prefix string) {
+	b.uplinkDeduplicator = NewRedisDeduplicator(client, prefix+":uplink", b.deduplicationDelay, func() proto.Message {
+		return new(pb.UplinkMessage)
+	})
+	b.activationDeduplicator = NewRedisDeduplicator(client, prefix+":activation", b.deduplicationDelay, func() proto.Message {
+		return new(pb.DeviceActivationRequest)
+	})
+}
+
 type broker struct {
 	*component.Component
 	routers                map[string]chan *pb.DownlinkMessage
@@ -61,9 +99,17 @@ type broker struct {
 	nsToken                string
 	nsConn                 *grpc.ClientConn
 	ns                     networkserver.NetworkServerClient
+	deduplicationDelay     time.Duration
 	uplinkDeduplicator     Deduplicator
 	activationDeduplicator Deduplicator
+	downlinkOptions        DownlinkOptionsStore
+	devicesCache           *devicesCache
+	roaming                *roamingState
+	quota                  *quota.Enforcer
 	status                 *status
+	inFlight               component.InFlight
+	traffic                map[string]*trafficSubscription
+	trafficLock            sync.RWMutex
 }
 
 func (b *broker) checkPrefixAnnouncements() error {
@@ -82,6 +128,15 @@ func (b *broker) checkPrefixAnnouncements() error {
 		nsPrefixes[prefix] = strings.Join(mapping.Usage, ",")
 	}
 
+	// Devices on any of the prefixes of the NS are ours, also when they are not in our NetID
+	if b.roaming != nil {
+		homePrefixes := make([]types.DevAddrPrefix, 0, len(nsPrefixes))
+		for prefix := range nsPrefixes {
+			homePrefixes = append(homePrefixes, prefix)
+		}
+		b.roaming.setHomePrefixes(homePrefixes)
+	}
+
 	// Get self from Discovery
 	self, err := b.Component.Discover("broker", b.Component.Identity.Id)
 	if err != nil {
@@ -103,12 +158,26 @@ nextPrefix:
 		b.Ctx.WithField("Prefix", nsPrefix).Warn("Prefix not announced in Discovery")
 	}
 
+	if b.roaming != nil {
+	nextPartner:
+		for netID := range b.roaming.partners {
+			partnerPrefix := netID.DevAddrPrefix()
+			for _, announcedPrefix := range announcedPrefixes {
+				if partnerPrefix.DevAddr == announcedPrefix.DevAddr && partnerPrefix.Length == announcedPrefix.Length {
+					continue nextPartner
+				}
+			}
+			b.Ctx.WithField("NetID", netID).WithField("Prefix", partnerPrefix).Warn("Prefix of roaming partner not announced in Discovery")
+		}
+	}
+
 	return nil
 }
 
 func (b *broker) Init(c *component.Component) error {
 	b.Component = c
 	b.InitStatus()
+	b.Component.RegisterMetrics(newCollector(b))
 	err := b.Component.UpdateTokenKey()
 	if err != nil {
 		return err
@@ -135,7 +204,11 @@ func (b *broker) Init(c *component.Component) error {
 	return nil
 }
 
-func (b *broker) Shutdown() {}
+func (b *broker) Shutdown() {
+	if dropped := b.inFlight.Close(b.ShutdownDeadline()); dropped > 0 {
+		b.Ctx.WithField("Messages", dropped).Warn("Dropped messages that were still being deduplicated")
+	}
+}
 
 func (b *broker) ActivateRouter(id string) (<-chan *pb.DownlinkMessage, error) {
 	b.routersLock.Lock()
@@ -170,6 +243,7 @@ func (b *broker) getRouter(id string) (chan<- *pb.DownlinkMessage, error) {
 type handler struct {
 	conn   *grpc.ClientConn
 	uplink chan *pb.DeduplicatedUplinkMessage
+	health handlerHealth
 	sync.Mutex
 }
 
@@ -4,8 +4,12 @@
 package broker
 
 import (
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/redis.v5"
 )
 
 type collection struct {
@@ -43,6 +47,8 @@ func (c *collection) wait() {
 	<-c.ready
 }
 
+// Deduplicator collects the values with the same key that are added within a timeout. Deduplicate returns the
+// collected values to the caller that added the first value, and nil to the others.
 type Deduplicator interface {
 	Deduplicate(key string, value interface{}) []interface{}
 }
@@ -91,3 +97,100 @@ func NewDeduplicator(timeout time.Duration) Deduplicator {
 		collections: map[string]*collection{},
 	}
 }
+
+type redisDeduplicator struct {
+	prefix   string
+	client   *redis.Client
+	timeout  time.Duration
+	newValue func() proto.Message
+	local    Deduplicator
+}
+
+// NewRedisDeduplicator returns a Deduplicator that collects the values of all Brokers that use the same Redis. The
+// first Broker that adds a value for a key collects the values of all Brokers, the others drop theirs. The values
+// are stored as protocol buffers, newValue should return an empty value of the type that is deduplicated. If Redis
+// is not available, values are deduplicated in memory.
+func NewRedisDeduplicator(client *redis.Client, prefix string, timeout time.Duration, newValue func() proto.Message) Deduplicator {
+	if !strings.HasSuffix(prefix, ":") {
+		prefix += ":"
+	}
+	return &redisDeduplicator{
+		prefix:   prefix + "deduplicate:",
+		client:   client,
+		timeout:  timeout,
+		newValue: newValue,
+		local:    NewDeduplicator(timeout),
+	}
+}
+
+func (d *redisDeduplicator) valuesKey(key string) string {
+	return d.prefix + key + ":values"
+}
+
+func (d *redisDeduplicator) lockKey(key string) string {
+	return d.prefix + key + ":lock"
+}
+
+// add appends the value to the list of the key and returns true if this Broker was the first to add a value
+func (d *redisDeduplicator) add(key string, value proto.Message) (isFirst bool, err error) {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	pipe := d.client.Pipeline()
+	defer pipe.Close()
+
+	pipe.RPush(d.valuesKey(key), data)
+	pipe.PExpire(d.valuesKey(key), 2*d.timeout)
+	lock := pipe.SetNX(d.lockKey(key), 1, 2*d.timeout)
+
+	if _, err := pipe.Exec(); err != nil {
+		return false, err
+	}
+	return lock.Val(), nil
+}
+
+// collect returns and removes the values of the key
+func (d *redisDeduplicator) collect(key string) (values []interface{}, err error) {
+	var res *redis.StringSliceCmd
+	err = d.client.Watch(func(tx *redis.Tx) error {
+		_, err := tx.Pipelined(func(pipe *redis.Pipeline) error {
+			res = pipe.LRange(d.valuesKey(key), 0, -1)
+			pipe.Del(d.valuesKey(key))
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range res.Val() {
+		value := d.newValue()
+		if err := proto.Unmarshal([]byte(data), value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (d *redisDeduplicator) Deduplicate(key string, value interface{}) (values []interface{}) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return d.local.Deduplicate(key, value)
+	}
+	isFirst, err := d.add(key, msg)
+	if err != nil {
+		return d.local.Deduplicate(key, value)
+	}
+	if !isFirst {
+		return nil
+	}
+	<-time.After(d.timeout)
+	values, err = d.collect(key)
+	if err != nil || len(values) == 0 {
+		return []interface{}{value}
+	}
+	return values
+}
@@ -4,13 +4,30 @@
 package broker
 
 import (
+	"fmt"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/gogo/protobuf/proto"
 	. "github.com/smartystreets/assertions"
+	"gopkg.in/redis.v5"
 )
 
+func getRedisClient() *redis.Client {
+	host := os.Getenv("REDIS_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:6379", host),
+		Password: "", // no password set
+		DB:       1,  // use default DB
+	})
+}
+
 func TestCollectionAdd(t *testing.T) {
 	a := New(t)
 	c := newCollection()
@@ -68,3 +85,32 @@ func TestDeduplicatorDeduplicate(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRedisDeduplicatorDeduplicate(t *testing.T) {
+	a := New(t)
+	client := getRedisClient()
+	newValue := func() proto.Message { return new(pb.UplinkMessage) }
+
+	// Two Brokers that use the same Redis
+	d1 := NewRedisDeduplicator(client, "test-broker", 10*time.Millisecond, newValue).(*redisDeduplicator)
+	d2 := NewRedisDeduplicator(client, "test-broker", 10*time.Millisecond, newValue).(*redisDeduplicator)
+	client.Del(d1.valuesKey("key"), d1.lockKey("key"))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		res := d1.Deduplicate("key", &pb.UplinkMessage{Payload: []byte{1}})
+		a.So(res, ShouldHaveLength, 3)
+		for i, value := range res {
+			a.So(value.(*pb.UplinkMessage).Payload, ShouldResemble, []byte{byte(i + 1)})
+		}
+		wg.Done()
+	}()
+
+	<-time.After(5 * time.Millisecond)
+
+	a.So(d2.Deduplicate("key", &pb.UplinkMessage{Payload: []byte{2}}), ShouldBeNil)
+	a.So(d1.Deduplicate("key", &pb.UplinkMessage{Payload: []byte{3}}), ShouldBeNil)
+
+	wg.Wait()
+}
@@ -0,0 +1,160 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api/networkserver"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/fcnt"
+)
+
+// devicesCache keeps the devices that the NetworkServer returned for a DevAddr for a short time. The FCntUp of the
+// cached devices is updated by the uplink messages that this Broker handles, but uplink messages that other Brokers
+// handle are only seen after the entry expires. An uplink message that passes the FCnt check against a stale entry is
+// rejected by the NetworkServer, which checks the FCnt against its database; the entry is then invalidated.
+type devicesCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[types.DevAddr]*devicesCacheEntry
+	nextSweep time.Time
+}
+
+type devicesCacheEntry struct {
+	devices []pb_lorawan.Device
+	expires time.Time
+}
+
+func newDevicesCache(ttl time.Duration) *devicesCache {
+	return &devicesCache{
+		ttl:     ttl,
+		entries: make(map[types.DevAddr]*devicesCacheEntry),
+	}
+}
+
+// get returns copies of the cached devices for a DevAddr
+func (c *devicesCache) get(devAddr types.DevAddr) ([]*pb_lorawan.Device, bool) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep(now)
+	entry, ok := c.entries[devAddr]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	devices := make([]*pb_lorawan.Device, 0, len(entry.devices))
+	for _, device := range entry.devices {
+		device := device
+		devices = append(devices, &device)
+	}
+	return devices, true
+}
+
+func (c *devicesCache) set(devAddr types.DevAddr, devices []*pb_lorawan.Device) {
+	entry := &devicesCacheEntry{
+		devices: make([]pb_lorawan.Device, 0, len(devices)),
+		expires: time.Now().Add(c.ttl),
+	}
+	for _, device := range devices {
+		entry.devices = append(entry.devices, *device)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[devAddr] = entry
+}
+
+func (c *devicesCache) invalidate(devAddr types.DevAddr) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, devAddr)
+}
+
+// updateFCntUp updates the FCntUp of a cached device after an uplink message was handled
+func (c *devicesCache) updateFCntUp(devAddr types.DevAddr, device *pb_lorawan.Device, fCntUp uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[devAddr]
+	if !ok {
+		return
+	}
+	for i, cached := range entry.devices {
+		if cached.AppEui == nil || cached.DevEui == nil || device.AppEui == nil || device.DevEui == nil {
+			continue
+		}
+		if *cached.AppEui == *device.AppEui && *cached.DevEui == *device.DevEui {
+			entry.devices[i].FCntUp = fCntUp
+		}
+	}
+}
+
+// sweep removes expired entries. It should be called with the lock held.
+func (c *devicesCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for devAddr, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, devAddr)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
+
+// filterDevices returns the devices that could have sent an uplink message with the given FCnt, in the same way as
+// the NetworkServer does
+func filterDevices(devices []*pb_lorawan.Device, fCnt uint32) []*pb_lorawan.Device {
+	res := make([]*pb_lorawan.Device, 0, len(devices))
+	for _, device := range devices {
+		switch {
+		case device.DisableFCntCheck:
+		case device.FCntUp <= fCnt:
+		case device.Uses32BitFCnt && device.FCntUp <= fcnt.GetFull(device.FCntUp, uint16(fCnt)):
+		default:
+			continue
+		}
+		res = append(res, device)
+	}
+	return res
+}
+
+// UseDevicesCache makes the Broker keep the devices that the NetworkServer returns for a DevAddr for the given time
+func (b *broker) UseDevicesCache(ttl time.Duration) {
+	b.devicesCache = newDevicesCache(ttl)
+}
+
+// getDevices returns the devices with the DevAddr that could have sent an uplink message with the given FCnt. It
+// returns true if the devices came from the cache.
+func (b *broker) getDevices(devAddr types.DevAddr, fCnt uint32) ([]*pb_lorawan.Device, bool, error) {
+	if b.devicesCache == nil {
+		res, err := b.ns.GetDevices(b.Component.GetContext(b.nsToken), &networkserver.DevicesRequest{
+			DevAddr: &devAddr,
+			FCnt:    fCnt,
+		})
+		if err != nil {
+			return nil, false, errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not return devices")
+		}
+		return res.Results, false, nil
+	}
+
+	if devices, ok := b.devicesCache.get(devAddr); ok {
+		return filterDevices(devices, fCnt), true, nil
+	}
+
+	// We cache all devices with the DevAddr, so that the entry can be used for the next FCnt
+	res, err := b.ns.GetDevices(b.Component.GetContext(b.nsToken), &networkserver.DevicesRequest{
+		DevAddr: &devAddr,
+		FCnt:    math.MaxUint32,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not return devices")
+	}
+	b.devicesCache.set(devAddr, res.Results)
+	return filterDevices(res.Results, fCnt), false, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	pb_networkserver "github.com/TheThingsNetwork/ttn/api/networkserver"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDevicesCache(t *testing.T) {
+	a := New(t)
+
+	c := newDevicesCache(20 * time.Millisecond)
+	devAddr := types.DevAddr{1, 2, 3, 4}
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	devEUI := types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8}
+
+	_, ok := c.get(devAddr)
+	a.So(ok, ShouldBeFalse)
+
+	c.set(devAddr, []*pb_lorawan.Device{{AppEui: &appEUI, DevEui: &devEUI, FCntUp: 1}})
+
+	devices, ok := c.get(devAddr)
+	a.So(ok, ShouldBeTrue)
+	a.So(devices, ShouldHaveLength, 1)
+
+	// Changing the returned device does not change the cache
+	devices[0].FCntUp = 10
+	devices, _ = c.get(devAddr)
+	a.So(devices[0].FCntUp, ShouldEqual, 1)
+
+	c.updateFCntUp(devAddr, &pb_lorawan.Device{AppEui: &appEUI, DevEui: &devEUI}, 5)
+	devices, _ = c.get(devAddr)
+	a.So(devices[0].FCntUp, ShouldEqual, 5)
+
+	c.invalidate(devAddr)
+	_, ok = c.get(devAddr)
+	a.So(ok, ShouldBeFalse)
+
+	c.set(devAddr, []*pb_lorawan.Device{{AppEui: &appEUI, DevEui: &devEUI, FCntUp: 1}})
+	time.Sleep(30 * time.Millisecond)
+	_, ok = c.get(devAddr)
+	a.So(ok, ShouldBeFalse)
+}
+
+func TestFilterDevices(t *testing.T) {
+	a := New(t)
+
+	devices := []*pb_lorawan.Device{
+		{DevId: "low", FCntUp: 1},
+		{DevId: "high", FCntUp: 100},
+		{DevId: "disabled", FCntUp: 100, DisableFCntCheck: true},
+		{DevId: "32bit", FCntUp: 65540, Uses32BitFCnt: true},
+	}
+
+	res := filterDevices(devices, 10)
+	var ids []string
+	for _, device := range res {
+		ids = append(ids, device.DevId)
+	}
+	a.So(ids, ShouldResemble, []string{"low", "disabled", "32bit"})
+}
+
+func TestGetDevicesCached(t *testing.T) {
+	a := New(t)
+
+	b := getTestBroker(t)
+	defer b.ctrl.Finish()
+	b.UseDevicesCache(time.Minute)
+
+	devAddr := types.DevAddr{1, 2, 3, 4}
+
+	b.ns.EXPECT().GetDevices(gomock.Any(), &pb_networkserver.DevicesRequest{
+		DevAddr: &devAddr,
+		FCnt:    math.MaxUint32,
+	}).Return(&pb_networkserver.DevicesResponse{
+		Results: []*pb_lorawan.Device{
+			{DevId: "low", FCntUp: 1},
+			{DevId: "high", FCntUp: 100},
+		},
+	}, nil)
+
+	devices, cached, err := b.getDevices(devAddr, 10)
+	a.So(err, ShouldBeNil)
+	a.So(cached, ShouldBeFalse)
+	a.So(devices, ShouldHaveLength, 1)
+
+	// The second call does not go to the NetworkServer
+	devices, cached, err = b.getDevices(devAddr, 200)
+	a.So(err, ShouldBeNil)
+	a.So(cached, ShouldBeTrue)
+	a.So(devices, ShouldHaveLength, 2)
+}
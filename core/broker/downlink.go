@@ -4,9 +4,11 @@
 package broker
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/fields"
 	"github.com/TheThingsNetwork/ttn/api/trace"
@@ -31,36 +33,118 @@ func (b *broker) HandleDownlink(downlink *pb.DownlinkMessage) error {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled downlink")
 		}
 		if downlink != nil {
+			trace.Export(b.TraceService(), downlink.Trace)
 			for _, monitor := range b.Monitors.BrokerClients() {
 				ctx.Debug("Sending downlink to monitor")
 				go monitor.SendDownlink(downlink)
 			}
+			b.handleTraffic(&pb.TrafficMessage{Time: time.Now().UnixNano(), Downlink: downlink})
 		}
 	}()
 
 	b.status.downlink.Mark(1)
 
-	downlink.Trace = downlink.Trace.WithEvent(trace.ReceiveEvent)
+	downlink.Trace = downlink.Trace.WithEvent(b.TraceService(), trace.ReceiveEvent)
+
+	if b.quota != nil {
+		appID, devID := downlink.AppId, downlink.DevId
+		if _, err = b.quota.Downlink(appID, devID); err != nil {
+			if errors.GetErrType(err) == errors.ResourceExhausted {
+				b.status.quotaExceeded.Mark(1)
+			}
+			return err
+		}
+		// The quota is charged before the downlink is forwarded, so that concurrent downlinks can not exceed it
+		defer func() {
+			if err != nil {
+				b.refundDownlink(ctx, appID, devID)
+			}
+		}()
+	}
 
 	downlink, err = b.ns.Downlink(b.Component.GetContext(b.nsToken), downlink)
 	if err != nil {
 		return errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not handle downlink")
 	}
 
+	err = b.sendDownlink(ctx, downlink)
+	return err
+}
+
+func (b *broker) HandleDownlinkFailure(downlink *pb.DownlinkMessage) (err error) {
+	ctx := b.Ctx.WithFields(fields.Get(downlink))
+	defer func() {
+		if err != nil {
+			ctx.WithError(err).Warn("Could not fail over downlink")
+			b.refundDownlink(ctx, downlink.AppId, downlink.DevId)
+		} else {
+			ctx.Info("Failed over downlink")
+		}
+		trace.Export(b.TraceService(), downlink.Trace)
+	}()
+
+	if downlink.DownlinkOption == nil {
+		return errors.NewErrInvalidArgument("Downlink", "does not contain a DownlinkOption")
+	}
+
+	next := b.downlinkOptions.Next(downlink.DownlinkOption.Identifier)
+	if next == nil {
+		return errors.NewErrNotFound(fmt.Sprintf("DownlinkOption after %s", downlink.DownlinkOption.Identifier))
+	}
+	downlink.Trace = downlink.Trace.WithEvent(b.TraceService(), trace.FailoverEvent, "reason", "router could not schedule downlink")
+	downlink.DownlinkOption = next
+
+	return b.sendDownlink(ctx, downlink)
+}
+
+// refundDownlink refunds the downlink quota of a downlink that could not be sent
+func (b *broker) refundDownlink(ctx ttnlog.Interface, appID, devID string) {
+	// The downlink of an activation is not accounted
+	if b.quota == nil || appID == "" {
+		return
+	}
+	if err := b.quota.RefundDownlink(appID, devID); err != nil {
+		ctx.WithError(err).Warn("Could not refund downlink quota")
+	}
+}
+
+// sendDownlink forwards the downlink to the router of its DownlinkOption. If
+// that fails, the next-best DownlinkOption of the same uplink is tried.
+func (b *broker) sendDownlink(ctx ttnlog.Interface, downlink *pb.DownlinkMessage) error {
+	for {
+		err := b.forwardDownlink(downlink)
+		if err == nil {
+			return nil
+		}
+		next := b.downlinkOptions.Next(downlink.DownlinkOption.Identifier)
+		if next == nil {
+			return err
+		}
+		ctx.WithError(err).WithField("Identifier", next.Identifier).Debug("Fail over to next DownlinkOption")
+		downlink.Trace = downlink.Trace.WithEvent(b.TraceService(), trace.FailoverEvent, "reason", err)
+		downlink.DownlinkOption = next
+	}
+}
+
+// forwardDownlink forwards the downlink to the router of the selected DownlinkOption
+func (b *broker) forwardDownlink(downlink *pb.DownlinkMessage) error {
+	if strings.HasPrefix(downlink.DownlinkOption.Identifier, roamingIdentifierPrefix) {
+		return b.sendRoamingDownlink(downlink)
+	}
+
 	var routerID string
 	if id := strings.Split(downlink.DownlinkOption.Identifier, ":"); len(id) == 2 {
 		routerID = id[0]
 	} else {
 		return errors.NewErrInvalidArgument("DownlinkOption Identifier", "invalid format")
 	}
-	ctx = ctx.WithField("RouterID", routerID)
 
 	router, err := b.getRouter(routerID)
 	if err != nil {
 		return err
 	}
 
-	downlink.Trace = downlink.Trace.WithEvent(trace.ForwardEvent, "router", routerID)
+	downlink.Trace = downlink.Trace.WithEvent(b.TraceService(), trace.ForwardEvent, "router", routerID)
 
 	router <- downlink
 
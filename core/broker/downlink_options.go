@@ -0,0 +1,82 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+)
+
+// DownlinkOptionsTTL is used to expire the ranked DownlinkOptions of an uplink
+// if the Routers did not set a deadline on them
+var DownlinkOptionsTTL = 7 * time.Second
+
+// DownlinkOptionsStore keeps the ranked DownlinkOptions of deduplicated uplink
+// messages, so that the Broker can fail over to the next-best option
+type DownlinkOptionsStore interface {
+	// Set the DownlinkOptions for a deduplicated uplink. The options should be sorted by score
+	Set(options []*pb.DownlinkOption)
+	// Next returns the next-best DownlinkOption after the option with the given identifier,
+	// or nil if there is no such option that can still be used
+	Next(identifier string) *pb.DownlinkOption
+}
+
+type downlinkOptionsStore struct {
+	sync.Mutex
+	next map[string][]*pb.DownlinkOption
+}
+
+// NewDownlinkOptionsStore returns a new in-memory DownlinkOptionsStore
+func NewDownlinkOptionsStore() DownlinkOptionsStore {
+	return &downlinkOptionsStore{
+		next: make(map[string][]*pb.DownlinkOption),
+	}
+}
+
+func (s *downlinkOptionsStore) Set(options []*pb.DownlinkOption) {
+	if len(options) < 2 {
+		return
+	}
+
+	// The options are kept until the deadline of the last option (usually RX2)
+	var deadline int64
+	for _, option := range options {
+		if option.Deadline == 0 {
+			deadline = time.Now().Add(DownlinkOptionsTTL).UnixNano()
+			break
+		}
+		if option.Deadline > deadline {
+			deadline = option.Deadline
+		}
+	}
+	expires := time.Unix(0, deadline)
+
+	s.Lock()
+	defer s.Unlock()
+	for i, option := range options[:len(options)-1] {
+		s.next[option.Identifier] = options[i+1:]
+	}
+
+	time.AfterFunc(expires.Sub(time.Now()), func() {
+		s.Lock()
+		defer s.Unlock()
+		for _, option := range options {
+			delete(s.next, option.Identifier)
+		}
+	})
+}
+
+func (s *downlinkOptionsStore) Next(identifier string) *pb.DownlinkOption {
+	s.Lock()
+	defer s.Unlock()
+	now := time.Now().UnixNano()
+	for _, option := range s.next[identifier] {
+		if option.Deadline == 0 || option.Deadline > now {
+			return option
+		}
+	}
+	return nil
+}
@@ -5,11 +5,14 @@ package broker
 
 import (
 	"testing"
+	"time"
 
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_monitor "github.com/TheThingsNetwork/ttn/api/monitor"
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/quota"
 	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
 )
@@ -31,6 +34,7 @@ func TestDownlink(t *testing.T) {
 		routers: map[string]chan *pb.DownlinkMessage{
 			"routerID": dlch,
 		},
+		downlinkOptions: NewDownlinkOptionsStore(),
 	}
 	b.InitStatus()
 
@@ -62,3 +66,108 @@ func TestDownlink(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(len(dlch), ShouldEqual, 1)
 }
+
+func TestDownlinkFailover(t *testing.T) {
+	a := New(t)
+
+	appEUI := types.AppEUI{0, 1, 2, 3, 4, 5, 6, 7}
+	devEUI := types.DevEUI{0, 1, 2, 3, 4, 5, 6, 7}
+
+	dlch := make(chan *pb.DownlinkMessage, 2)
+	logger := GetLogger(t, "TestDownlinkFailover")
+	b := &broker{
+		Component: &component.Component{
+			Ctx:      logger,
+			Monitors: pb_monitor.NewRegistry(logger),
+		},
+		ns: &mockNetworkServer{},
+		routers: map[string]chan *pb.DownlinkMessage{
+			"routerID": dlch,
+		},
+		downlinkOptions: NewDownlinkOptionsStore(),
+	}
+	b.InitStatus()
+
+	options := []*pb.DownlinkOption{
+		&pb.DownlinkOption{Identifier: "otherRouterID:first", Score: 10},
+		&pb.DownlinkOption{Identifier: "routerID:second", Score: 20},
+		&pb.DownlinkOption{Identifier: "routerID:expired", Score: 30, Deadline: time.Now().Add(-1 * time.Second).UnixNano()},
+		&pb.DownlinkOption{Identifier: "routerID:third", Score: 40},
+	}
+	b.downlinkOptions.Set(options)
+
+	// Router of the best option is not active
+	err := b.HandleDownlink(&pb.DownlinkMessage{
+		DevEui:         &devEUI,
+		AppEui:         &appEUI,
+		DownlinkOption: options[0],
+	})
+	a.So(err, ShouldBeNil)
+	a.So(len(dlch), ShouldEqual, 1)
+	dl := <-dlch
+	a.So(dl.DownlinkOption.Identifier, ShouldEqual, "routerID:second")
+
+	// Router could not schedule the second option, expired option is skipped
+	err = b.HandleDownlinkFailure(dl)
+	a.So(err, ShouldBeNil)
+	a.So(len(dlch), ShouldEqual, 1)
+	dl = <-dlch
+	a.So(dl.DownlinkOption.Identifier, ShouldEqual, "routerID:third")
+
+	// No options left
+	err = b.HandleDownlinkFailure(dl)
+	a.So(err, ShouldNotBeNil)
+	a.So(len(dlch), ShouldEqual, 0)
+}
+
+func TestDownlinkQuota(t *testing.T) {
+	a := New(t)
+
+	appEUI := types.AppEUI{0, 1, 2, 3, 4, 5, 6, 7}
+	devEUI := types.DevEUI{0, 1, 2, 3, 4, 5, 6, 7}
+
+	dlch := make(chan *pb.DownlinkMessage, 2)
+	logger := GetLogger(t, "TestDownlinkQuota")
+	b := &broker{
+		Component: &component.Component{
+			Ctx:      logger,
+			Monitors: pb_monitor.NewRegistry(logger),
+		},
+		ns: &mockNetworkServer{},
+		routers: map[string]chan *pb.DownlinkMessage{
+			"routerID": dlch,
+		},
+		downlinkOptions: NewDownlinkOptionsStore(),
+	}
+	b.InitStatus()
+	b.SetQuotas(quota.NewMemoryStore(), quota.Limits{}, quota.Limits{Downlinks: 1})
+
+	downlink := func() *pb.DownlinkMessage {
+		return &pb.DownlinkMessage{
+			DevEui: &devEUI,
+			AppEui: &appEUI,
+			AppId:  "appid",
+			DevId:  "devid",
+			DownlinkOption: &pb.DownlinkOption{
+				Identifier: "routerID:scheduleID",
+			},
+		}
+	}
+
+	// A downlink that can not be forwarded does not use the quota
+	dl := downlink()
+	dl.DownlinkOption.Identifier = "otherRouterID:scheduleID"
+	err := b.HandleDownlink(dl)
+	a.So(err, ShouldNotBeNil)
+	a.So(errors.GetErrType(err), ShouldNotEqual, errors.ResourceExhausted)
+
+	err = b.HandleDownlink(downlink())
+	a.So(err, ShouldBeNil)
+	a.So(len(dlch), ShouldEqual, 1)
+
+	err = b.HandleDownlink(downlink())
+	a.So(err, ShouldNotBeNil)
+	a.So(errors.GetErrType(err), ShouldEqual, errors.ResourceExhausted)
+	a.So(len(dlch), ShouldEqual, 1)
+	a.So(b.status.quotaExceeded.Count(), ShouldEqual, 1)
+}
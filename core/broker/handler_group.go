@@ -0,0 +1,116 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/api/health"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// All Handlers that announce the same AppID form a Handler group. Handlers in a group share their store and
+// coordinate among themselves, so the Broker can send the messages of a device to any of them.
+
+type rankedHandler struct {
+	announcement *pb_discovery.Announcement
+	weight       uint64
+}
+
+type rankedHandlers []rankedHandler
+
+func (r rankedHandlers) Len() int      { return len(r) }
+func (r rankedHandlers) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r rankedHandlers) Less(i, j int) bool {
+	if r[i].weight == r[j].weight {
+		return r[i].announcement.Id < r[j].announcement.Id
+	}
+	return r[i].weight > r[j].weight
+}
+
+// rankHandlers orders the Handlers of a group by preference for a device (rendezvous hashing). All Brokers rank the
+// Handlers in the same order, so a device sticks to the same Handler, and only the devices of a Handler move to
+// other Handlers when it leaves the group.
+func rankHandlers(devID string, announcements []*pb_discovery.Announcement) []*pb_discovery.Announcement {
+	ranked := make(rankedHandlers, 0, len(announcements))
+	for _, announcement := range announcements {
+		sum := md5.Sum([]byte(devID + "\x00" + announcement.Id))
+		ranked = append(ranked, rankedHandler{announcement, binary.BigEndian.Uint64(sum[:8])})
+	}
+	sort.Sort(ranked)
+	res := make([]*pb_discovery.Announcement, 0, len(ranked))
+	for _, r := range ranked {
+		res = append(res, r.announcement)
+	}
+	return res
+}
+
+// HandlerHealthInterval indicates how often the Broker checks the health status of the Handlers in a group
+var HandlerHealthInterval = 10 * time.Second
+
+// handlerHealth is the last known health status of a Handler, as reported by its gRPC health service
+type handlerHealth struct {
+	healthy  bool
+	checked  time.Time
+	checking bool
+}
+
+// isHandlerHealthy returns the last known health status of a Handler and checks it again in the background when it
+// is older than HandlerHealthInterval. A Handler is considered healthy until its first check completes.
+func (b *broker) isHandlerHealthy(id string) bool {
+	hdl := b.getHandler(id)
+	hdl.Lock()
+	defer hdl.Unlock()
+	if !hdl.health.checking && time.Since(hdl.health.checked) > HandlerHealthInterval {
+		hdl.health.checking = true
+		go b.checkHandlerHealth(id, hdl)
+	}
+	return hdl.health.checked.IsZero() || hdl.health.healthy
+}
+
+func (b *broker) checkHandlerHealth(id string, hdl *handler) {
+	var healthy bool
+	conn, err := b.getHandlerConn(id)
+	if err == nil {
+		healthy, err = health.Check(conn)
+	}
+	if err != nil {
+		b.Ctx.WithField("HandlerID", id).WithError(err).Debug("Could not check Handler health")
+	}
+	hdl.Lock()
+	defer hdl.Unlock()
+	hdl.health = handlerHealth{healthy: healthy, checked: time.Now()}
+}
+
+// selectHandlerUplink selects the Handler that should handle the uplink of a device. If the preferred Handler of the
+// group is unhealthy or not connected to this Broker, the next Handler in the group is selected. If none of the
+// connected Handlers is healthy, the preferred connected Handler is selected.
+func (b *broker) selectHandlerUplink(appID, devID string, announcements []*pb_discovery.Announcement) (*pb_discovery.Announcement, chan<- *pb.DeduplicatedUplinkMessage, error) {
+	var fallback *pb_discovery.Announcement
+	var fallbackHandler chan<- *pb.DeduplicatedUplinkMessage
+	for _, announcement := range rankHandlers(devID, announcements) {
+		handler, err := b.getHandlerUplink(announcement.Id)
+		if err != nil {
+			continue
+		}
+		// There is nothing to choose from if the Handler is not part of a group
+		if len(announcements) > 1 && !b.isHandlerHealthy(announcement.Id) {
+			if fallback == nil {
+				fallback, fallbackHandler = announcement, handler
+			}
+			continue
+		}
+		return announcement, handler, nil
+	}
+	if fallback != nil {
+		return fallback, fallbackHandler, nil
+	}
+	return nil, nil, errors.NewErrInternal(fmt.Sprintf("No active Handler for AppID %s", appID))
+}
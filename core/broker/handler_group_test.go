@@ -0,0 +1,88 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRankHandlers(t *testing.T) {
+	a := New(t)
+
+	handler1 := &pb_discovery.Announcement{Id: "handler1"}
+	handler2 := &pb_discovery.Announcement{Id: "handler2"}
+	handler3 := &pb_discovery.Announcement{Id: "handler3"}
+
+	a.So(rankHandlers("dev", nil), ShouldBeEmpty)
+	a.So(rankHandlers("dev", []*pb_discovery.Announcement{handler1}), ShouldResemble, []*pb_discovery.Announcement{handler1})
+
+	preferred := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		devID := fmt.Sprintf("dev-%d", i)
+		ranked := rankHandlers(devID, []*pb_discovery.Announcement{handler1, handler2, handler3})
+		a.So(ranked, ShouldHaveLength, 3)
+
+		// The order of the announcements doesn't matter
+		a.So(rankHandlers(devID, []*pb_discovery.Announcement{handler3, handler1, handler2}), ShouldResemble, ranked)
+
+		// Removing a Handler only moves its own devices
+		if ranked[0] != handler2 {
+			a.So(rankHandlers(devID, []*pb_discovery.Announcement{handler1, handler3})[0], ShouldEqual, ranked[0])
+		}
+
+		preferred[ranked[0].Id]++
+	}
+
+	// Devices are spread over the Handlers
+	a.So(preferred, ShouldHaveLength, 3)
+	for _, count := range preferred {
+		a.So(count, ShouldBeGreaterThan, 50)
+	}
+}
+
+func TestSelectHandlerUplink(t *testing.T) {
+	a := New(t)
+
+	b := getTestBroker(t)
+	announcements := []*pb_discovery.Announcement{
+		&pb_discovery.Announcement{Id: "handler1"},
+		&pb_discovery.Announcement{Id: "handler2"},
+	}
+	ranked := rankHandlers("dev", announcements)
+	healthy := handlerHealth{healthy: true, checked: time.Now()}
+
+	// No active Handlers
+	_, _, err := b.selectHandlerUplink("app", "dev", announcements)
+	a.So(err, ShouldNotBeNil)
+
+	// Only the second Handler is active
+	b.handlers[ranked[1].Id] = &handler{uplink: make(chan *pb.DeduplicatedUplinkMessage, 1), health: healthy}
+	selected, _, err := b.selectHandlerUplink("app", "dev", announcements)
+	a.So(err, ShouldBeNil)
+	a.So(selected.Id, ShouldEqual, ranked[1].Id)
+
+	// The preferred Handler is active
+	b.handlers[ranked[0].Id] = &handler{uplink: make(chan *pb.DeduplicatedUplinkMessage, 1), health: healthy}
+	selected, _, err = b.selectHandlerUplink("app", "dev", announcements)
+	a.So(err, ShouldBeNil)
+	a.So(selected.Id, ShouldEqual, ranked[0].Id)
+
+	// The preferred Handler is unhealthy
+	b.handlers[ranked[0].Id].health = handlerHealth{healthy: false, checked: time.Now()}
+	selected, _, err = b.selectHandlerUplink("app", "dev", announcements)
+	a.So(err, ShouldBeNil)
+	a.So(selected.Id, ShouldEqual, ranked[1].Id)
+
+	// All Handlers are unhealthy
+	b.handlers[ranked[1].Id].health = handlerHealth{healthy: false, checked: time.Now()}
+	selected, _, err = b.selectHandlerUplink("app", "dev", announcements)
+	a.So(err, ShouldBeNil)
+	a.So(selected.Id, ShouldEqual, ranked[0].Id)
+}
@@ -15,6 +15,7 @@ import (
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/random"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
@@ -72,6 +73,39 @@ func (b *brokerManager) DeleteDevice(ctx context.Context, in *lorawan.DeviceIden
 	return res, nil
 }
 
+func (b *brokerManager) GetMACState(ctx context.Context, in *lorawan.DeviceIdentifier) (*lorawan.MACState, error) {
+	if _, err := b.validateClient(ctx); err != nil {
+		return nil, err
+	}
+	res, err := b.deviceManager.GetMACState(ctx, in)
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not return MAC state")
+	}
+	return res, nil
+}
+
+func (b *brokerManager) ResetMACState(ctx context.Context, in *lorawan.DeviceIdentifier) (*empty.Empty, error) {
+	if _, err := b.validateClient(ctx); err != nil {
+		return nil, err
+	}
+	res, err := b.deviceManager.ResetMACState(ctx, in)
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not reset MAC state")
+	}
+	return res, nil
+}
+
+func (b *brokerManager) RestoreMACState(ctx context.Context, in *lorawan.MACState) (*empty.Empty, error) {
+	if _, err := b.validateClient(ctx); err != nil {
+		return nil, err
+	}
+	res, err := b.deviceManager.RestoreMACState(ctx, in)
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not restore MAC state")
+	}
+	return res, nil
+}
+
 func (b *brokerManager) RegisterApplicationHandler(ctx context.Context, in *pb.ApplicationHandlerRegistration) (*empty.Empty, error) {
 	claims, err := b.broker.Component.ValidateTTNAuthContext(ctx)
 	if err != nil {
@@ -127,6 +161,40 @@ func (b *brokerManager) GetStatus(ctx context.Context, in *pb.StatusRequest) (*p
 	return status, nil
 }
 
+func (b *brokerManager) Traffic(in *pb.TrafficRequest, stream pb.BrokerManager_TrafficServer) error {
+	claims, err := b.validateClient(stream.Context())
+	if err != nil {
+		return err
+	}
+	if !claims.ComponentAccess(b.broker.Identity.Id) {
+		if in.AppId == "" {
+			return errors.NewErrPermissionDenied(fmt.Sprintf("Claims do not grant access to %s", b.broker.Identity.Id))
+		}
+		if !claims.AppRight(in.AppId, rights.Devices) {
+			return errors.NewErrPermissionDenied("No access to this application")
+		}
+	}
+	subscriptionID := random.String(10)
+	ch, err := b.broker.SubscribeTraffic(subscriptionID, in)
+	if err != nil {
+		return err
+	}
+	defer b.broker.UnsubscribeTraffic(subscriptionID)
+	ctx := b.broker.Ctx.WithField("SubscriptionID", subscriptionID).WithField("AppID", in.AppId)
+	ctx.Debug("Subscribed to traffic")
+	defer ctx.Debug("Unsubscribed from traffic")
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case message := <-ch:
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (b *broker) RegisterManager(s *grpc.Server) {
 	server := &brokerManager{
 		broker:         b,
@@ -135,6 +203,7 @@ func (b *broker) RegisterManager(s *grpc.Server) {
 	}
 
 	server.clientRate = ratelimit.NewRegistry(5000, time.Hour)
+	b.ConfigureRate("broker.client-rate", server.clientRate, time.Hour)
 
 	pb.RegisterBrokerManagerServer(s, server)
 	lorawan.RegisterDeviceManagerServer(s, server)
@@ -0,0 +1,73 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exposes the status of the broker as Prometheus metrics
+type collector struct {
+	broker *broker
+
+	uplink            *prometheus.Desc
+	uplinkUnique      *prometheus.Desc
+	downlink          *prometheus.Desc
+	activations       *prometheus.Desc
+	activationsUnique *prometheus.Desc
+	deduplication     *prometheus.Desc
+	micChecks         *prometheus.Desc
+	quotaExceeded     *prometheus.Desc
+	connectedRouters  *prometheus.Desc
+	connectedHandlers *prometheus.Desc
+}
+
+func newCollector(b *broker) *collector {
+	return &collector{
+		broker:            b,
+		uplink:            b.Component.NewMetricDesc("uplink_total", "Total number of received uplink messages"),
+		uplinkUnique:      b.Component.NewMetricDesc("uplink_unique_total", "Total number of deduplicated uplink messages"),
+		downlink:          b.Component.NewMetricDesc("downlink_total", "Total number of forwarded downlink messages"),
+		activations:       b.Component.NewMetricDesc("activations_total", "Total number of received activation requests"),
+		activationsUnique: b.Component.NewMetricDesc("activations_unique_total", "Total number of deduplicated activation requests"),
+		deduplication:     b.Component.NewMetricDesc("deduplication", "Number of devices that match the DevAddr of deduplicated uplink messages"),
+		micChecks:         b.Component.NewMetricDesc("mic_checks_total", "Total number of MIC checks on uplink messages"),
+		quotaExceeded:     b.Component.NewMetricDesc("quota_exceeded_total", "Total number of messages that were dropped because a quota was exceeded"),
+		connectedRouters:  b.Component.NewMetricDesc("connected_routers", "Number of connected routers"),
+		connectedHandlers: b.Component.NewMetricDesc("connected_handlers", "Number of connected handlers"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.uplink
+	ch <- c.uplinkUnique
+	ch <- c.downlink
+	ch <- c.activations
+	ch <- c.activationsUnique
+	ch <- c.deduplication
+	ch <- c.micChecks
+	ch <- c.quotaExceeded
+	ch <- c.connectedRouters
+	ch <- c.connectedHandlers
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	status := c.broker.status
+	if status == nil {
+		return
+	}
+	ch <- component.MeterMetric(c.uplink, status.uplink)
+	ch <- component.MeterMetric(c.uplinkUnique, status.uplinkUnique)
+	ch <- component.MeterMetric(c.downlink, status.downlink)
+	ch <- component.MeterMetric(c.activations, status.activations)
+	ch <- component.MeterMetric(c.activationsUnique, status.activationsUnique)
+	ch <- component.HistogramMetric(c.deduplication, status.deduplication, 1)
+	ch <- component.MeterMetric(c.micChecks, status.micChecks)
+	ch <- component.MeterMetric(c.quotaExceeded, status.quotaExceeded)
+	ch <- component.GaugeMetric(c.connectedRouters, status.connectedRouters)
+	ch <- component.GaugeMetric(c.connectedHandlers, status.connectedHandlers)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"testing"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestMetrics(t *testing.T) {
+	a := New(t)
+	b := &broker{
+		Component: &component.Component{
+			Identity: &pb_discovery.Announcement{Id: "test", ServiceName: "broker"},
+		},
+	}
+	b.InitStatus()
+	b.status.uplink.Mark(2)
+	b.status.micChecks.Mark(5)
+
+	registry := prometheus.NewRegistry()
+	a.So(registry.Register(newCollector(b)), ShouldBeNil)
+
+	families, err := registry.Gather()
+	a.So(err, ShouldBeNil)
+	values := make(map[string]float64)
+	for _, family := range families {
+		if metric := family.GetMetric()[0]; metric.GetCounter() != nil {
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		}
+	}
+	a.So(values["ttn_broker_uplink_total"], ShouldEqual, 2)
+	a.So(values["ttn_broker_mic_checks_total"], ShouldEqual, 5)
+}
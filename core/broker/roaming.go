@@ -0,0 +1,534 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/band"
+	"github.com/TheThingsNetwork/ttn/core/roaming"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/random"
+	"github.com/brocaar/lorawan"
+)
+
+// With passive roaming, the Broker forwards the uplink messages of devices of partner networks to the network of the
+// device, and sends the downlink messages that the partner network returns through its own Routers. The partners
+// forward the uplink messages of our devices to us, and we send the downlink messages for those devices back through
+// the partner.
+//
+// Join requests are not forwarded, because the network of a device can not be derived from a join request.
+
+// roamingIdentifierPrefix is the prefix of the identifiers of DownlinkOptions through roaming partners
+const roamingIdentifierPrefix = "roaming:"
+
+// roamingDownlinkScore is the score of DownlinkOptions through roaming partners. It is higher than the score of most
+// DownlinkOptions of our own gateways, because we have no control over the gateways of the partner.
+const roamingDownlinkScore = 100
+
+type roamingState struct {
+	netID    types.NetID
+	client   *roaming.Client
+	partners map[types.NetID]*roaming.Partner
+
+	mu           sync.Mutex
+	homePrefixes []types.DevAddrPrefix             // DevAddr prefixes of our Network Server
+	sessions     map[types.DevAddr]time.Time       // Roaming sessions that partners started with a PRStartAns
+	uplinks      map[string][]*pb.DownlinkOption   // DownlinkOptions of our gateways by FNSULToken
+	downlinks    map[string]*roamingDownlinkOption // DownlinkOptions through partners by identifier
+}
+
+type roamingDownlinkOption struct {
+	partner    *roaming.Partner
+	dlMetaData roaming.DLMetaData
+}
+
+// UseRoaming makes the Broker forward the uplink messages of devices of the partner networks to those networks, and
+// accept the uplink messages of its own devices that the partners forward. The partners send their requests to the
+// RoamingHandler.
+func (b *broker) UseRoaming(netID types.NetID, partners ...*roaming.Partner) {
+	r := &roamingState{
+		netID:     netID,
+		client:    roaming.NewClient(netID),
+		partners:  make(map[types.NetID]*roaming.Partner),
+		sessions:  make(map[types.DevAddr]time.Time),
+		uplinks:   make(map[string][]*pb.DownlinkOption),
+		downlinks: make(map[string]*roamingDownlinkOption),
+	}
+	for _, partner := range partners {
+		r.partners[partner.NetID] = partner
+	}
+	b.roaming = r
+}
+
+// RoamingHandler returns the http.Handler for the requests of the roaming partners, or nil if roaming is not used
+func (b *broker) RoamingHandler() http.Handler {
+	if b.roaming == nil {
+		return nil
+	}
+	partners := make([]*roaming.Partner, 0, len(b.roaming.partners))
+	for _, partner := range b.roaming.partners {
+		partners = append(partners, partner)
+	}
+	return roaming.NewServer(b.roaming.netID, partners, &roamingHandler{b})
+}
+
+// isHome returns true if the DevAddr belongs to our network, or to one of the prefixes of our Network Server
+func (r *roamingState) isHome(devAddr types.DevAddr) bool {
+	if devAddr.HasPrefix(r.netID.DevAddrPrefix()) {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, prefix := range r.homePrefixes {
+		if devAddr.HasPrefix(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// setHomePrefixes sets the DevAddr prefixes of our Network Server
+func (r *roamingState) setHomePrefixes(prefixes []types.DevAddrPrefix) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.homePrefixes = prefixes
+}
+
+// partnerFor returns the roaming partner that the DevAddr belongs to
+func (r *roamingState) partnerFor(devAddr types.DevAddr) *roaming.Partner {
+	for netID, partner := range r.partners {
+		if devAddr.HasPrefix(netID.DevAddrPrefix()) {
+			return partner
+		}
+	}
+	return nil
+}
+
+func (r *roamingState) hasSession(devAddr types.DevAddr) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	expires, ok := r.sessions[devAddr]
+	if ok && time.Now().After(expires) {
+		delete(r.sessions, devAddr)
+		return false
+	}
+	return ok
+}
+
+func (r *roamingState) setSession(devAddr types.DevAddr, lifetime time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lifetime > 0 {
+		r.sessions[devAddr] = time.Now().Add(lifetime)
+	} else {
+		delete(r.sessions, devAddr)
+	}
+}
+
+func (r *roamingState) setUplink(token string, options []*pb.DownlinkOption) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uplinks[token] = options
+	time.AfterFunc(DownlinkOptionsTTL, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.uplinks, token)
+	})
+}
+
+// takeUplink returns the DownlinkOptions for the FNSULToken. Only one downlink can be sent for an uplink message.
+func (r *roamingState) takeUplink(token string) []*pb.DownlinkOption {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	options := r.uplinks[token]
+	delete(r.uplinks, token)
+	return options
+}
+
+func (r *roamingState) setDownlink(identifier string, option *roamingDownlinkOption) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.downlinks[identifier] = option
+	time.AfterFunc(DownlinkOptionsTTL, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.downlinks, identifier)
+	})
+}
+
+func (r *roamingState) takeDownlink(identifier string) *roamingDownlinkOption {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	option := r.downlinks[identifier]
+	delete(r.downlinks, identifier)
+	return option
+}
+
+// buildULMetaData builds the ULMetaData of an uplink message for a roaming partner. The DownlinkOptions of the
+// uplink message are kept for the downlink that the partner may send.
+func (r *roamingState) buildULMetaData(devAddr types.DevAddr, uplink *pb.DeduplicatedUplinkMessage, duplicates []*pb.UplinkMessage) (*roaming.ULMetaData, error) {
+	lorawanMetadata := uplink.ProtocolMetadata.GetLorawan()
+	fp, err := band.Get(lorawanMetadata.Region.String())
+	if err != nil {
+		return nil, err
+	}
+	dataRate, err := fp.GetDataRateIndexFor(lorawanMetadata.DataRate)
+	if err != nil {
+		return nil, err
+	}
+	rfRegion := roaming.RFRegion(lorawanMetadata.Region)
+
+	ulMetaData := &roaming.ULMetaData{
+		DevAddr:  &devAddr,
+		DataRate: &dataRate,
+		RecvTime: time.Unix(0, uplink.ServerTime).UTC().Format(time.RFC3339Nano),
+		RFRegion: rfRegion,
+	}
+	var options []*pb.DownlinkOption
+	for _, duplicate := range duplicates {
+		gateway := duplicate.GatewayMetadata
+		if gateway == nil {
+			continue
+		}
+		if ulMetaData.ULFreq == nil {
+			freq := float64(gateway.Frequency) / 1e6
+			ulMetaData.ULFreq = &freq
+		}
+		rssi, snr := int(gateway.Rssi), float64(gateway.Snr)
+		gwInfo := roaming.GWInfo{
+			ID:        roaming.HEXBytes(gateway.GatewayId),
+			RFRegion:  rfRegion,
+			ULToken:   roaming.HEXBytes(gateway.GatewayId),
+			RSSI:      &rssi,
+			SNR:       &snr,
+			DLAllowed: len(duplicate.DownlinkOptions) > 0,
+		}
+		if gps := gateway.Gps; gps != nil && (gps.Latitude != 0 || gps.Longitude != 0) {
+			lat, lon := float64(gps.Latitude), float64(gps.Longitude)
+			gwInfo.Lat, gwInfo.Lon = &lat, &lon
+		}
+		ulMetaData.GWInfo = append(ulMetaData.GWInfo, gwInfo)
+		options = append(options, duplicate.DownlinkOptions...)
+	}
+	ulMetaData.GWCnt = len(ulMetaData.GWInfo)
+
+	if len(options) > 0 {
+		token := random.String(16)
+		r.setUplink(token, options)
+		ulMetaData.FNSULToken = roaming.HEXBytes(token)
+	}
+
+	return ulMetaData, nil
+}
+
+// forwardRoamingUplink forwards the uplink message of a device of another network to the roaming partner
+func (b *broker) forwardRoamingUplink(uplink *pb.DeduplicatedUplinkMessage, duplicates []*pb.UplinkMessage, devAddr types.DevAddr) error {
+	partner := b.roaming.partnerFor(devAddr)
+	if partner == nil {
+		return errors.NewErrNotFound(fmt.Sprintf("Roaming partner for DevAddr %s (NetID %s)", devAddr, devAddr.NetID()))
+	}
+	ulMetaData, err := b.roaming.buildULMetaData(devAddr, uplink, duplicates)
+	if err != nil {
+		return err
+	}
+
+	uplink.Trace = uplink.Trace.WithEvent(b.TraceService(), trace.ForwardEvent, "roaming partner", partner.NetID.String())
+
+	if b.roaming.hasSession(devAddr) {
+		_, err = b.roaming.client.XmitData(partner, &roaming.XmitDataReq{
+			PHYPayload: uplink.Payload,
+			ULMetaData: ulMetaData,
+		})
+		if err != nil {
+			// The next uplink message starts a new session
+			b.roaming.setSession(devAddr, 0)
+		}
+		return err
+	}
+
+	ans, err := b.roaming.client.PRStart(partner, &roaming.PRStartReq{
+		PHYPayload: uplink.Payload,
+		ULMetaData: *ulMetaData,
+	})
+	if err != nil {
+		return err
+	}
+	if ans.Lifetime != nil {
+		b.roaming.setSession(devAddr, time.Duration(*ans.Lifetime)*time.Second)
+	}
+	return nil
+}
+
+// sendRoamingDownlink sends a downlink message to the roaming partner that forwarded the uplink message
+func (b *broker) sendRoamingDownlink(downlink *pb.DownlinkMessage) error {
+	if b.roaming == nil {
+		return errors.NewErrInternal("Roaming not enabled")
+	}
+	option := b.roaming.takeDownlink(downlink.DownlinkOption.Identifier)
+	if option == nil {
+		return errors.NewErrNotFound(fmt.Sprintf("DownlinkOption %s", downlink.DownlinkOption.Identifier))
+	}
+	dlMetaData := option.dlMetaData
+	dlMetaData.DevEUI = downlink.DevEui
+
+	downlink.Trace = downlink.Trace.WithEvent(b.TraceService(), trace.ForwardEvent, "roaming partner", option.partner.NetID.String())
+
+	_, err := b.roaming.client.XmitData(option.partner, &roaming.XmitDataReq{
+		PHYPayload: downlink.Payload,
+		DLMetaData: &dlMetaData,
+	})
+	return err
+}
+
+// roamingHandler handles the requests of roaming partners
+type roamingHandler struct {
+	*broker
+}
+
+// HandleUplink handles an uplink message of one of our devices that was received by the gateways of a partner
+func (h *roamingHandler) HandleUplink(partner *roaming.Partner, phyPayload []byte, ulMetaData *roaming.ULMetaData) roaming.Result {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(phyPayload); err != nil {
+		return roaming.Result{ResultCode: roaming.ResultMalformedRequest, Description: err.Error()}
+	}
+	macPayload, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return roaming.Result{ResultCode: roaming.ResultMalformedRequest, Description: "PHYPayload does not contain a MAC payload"}
+	}
+	if devAddr := types.DevAddr(macPayload.FHDR.DevAddr); !h.roaming.isHome(devAddr) {
+		return roaming.Result{ResultCode: roaming.ResultUnknownDevAddr, Description: devAddr.String()}
+	}
+
+	uplinks, err := h.roaming.buildUplinks(partner, phyPayload, ulMetaData)
+	if err != nil {
+		return roaming.Result{ResultCode: roaming.ResultMalformedRequest, Description: err.Error()}
+	}
+
+	// The uplink messages are deduplicated like the uplink messages of Routers
+	errs := make(chan error, len(uplinks))
+	for _, uplink := range uplinks {
+		go func(uplink *pb.UplinkMessage) {
+			errs <- h.broker.HandleUplink(uplink)
+		}(uplink)
+	}
+	for range uplinks {
+		if uplinkErr := <-errs; uplinkErr != nil && err == nil {
+			err = uplinkErr
+		}
+	}
+
+	switch {
+	case err == nil:
+		return roaming.Result{ResultCode: roaming.ResultSuccess}
+	case errors.GetErrType(err) == errors.NotFound:
+		return roaming.Result{ResultCode: roaming.ResultUnknownDevAddr, Description: err.Error()}
+	default:
+		return roaming.Result{ResultCode: roaming.ResultOther, Description: err.Error()}
+	}
+}
+
+// HandleDownlink sends a downlink message of a partner through the gateways that received the uplink message
+func (h *roamingHandler) HandleDownlink(partner *roaming.Partner, phyPayload []byte, dlMetaData *roaming.DLMetaData) roaming.Result {
+	ctx := h.Ctx.WithField("RoamingPartner", partner.NetID)
+
+	options := filterRoamingDownlinkOptions(h.roaming.takeUplink(string(dlMetaData.FNSULToken)), dlMetaData)
+	if len(options) == 0 {
+		return roaming.Result{ResultCode: roaming.ResultXmitFailed, Description: "No DownlinkOption for FNSULToken"}
+	}
+	sort.Sort(ByScore(options))
+	h.downlinkOptions.Set(options)
+
+	downlink := &pb.DownlinkMessage{
+		Payload:        phyPayload,
+		DownlinkOption: options[0],
+	}
+	downlink.Trace = downlink.Trace.WithEvent(h.TraceService(), trace.ReceiveEvent, "roaming partner", partner.NetID.String())
+	defer trace.Export(h.TraceService(), downlink.Trace)
+
+	if err := h.sendDownlink(ctx, downlink); err != nil {
+		ctx.WithError(err).Warn("Could not handle roaming downlink")
+		return roaming.Result{ResultCode: roaming.ResultXmitFailed, Description: err.Error()}
+	}
+	ctx.Info("Handled roaming downlink")
+	return roaming.Result{ResultCode: roaming.ResultSuccess}
+}
+
+// filterRoamingDownlinkOptions returns the DownlinkOptions that match the gateways and frequencies that the partner
+// selected
+func filterRoamingDownlinkOptions(options []*pb.DownlinkOption, dlMetaData *roaming.DLMetaData) (res []*pb.DownlinkOption) {
+	gateways := make(map[string]bool)
+	for _, gwInfo := range dlMetaData.GWInfo {
+		gateways[string(gwInfo.ULToken)] = true
+	}
+	var frequencies []uint64
+	for _, freq := range []*float64{dlMetaData.DLFreq1, dlMetaData.DLFreq2} {
+		if freq != nil {
+			frequencies = append(frequencies, uint64(math.Floor(*freq*1e6+0.5)))
+		}
+	}
+	for _, option := range options {
+		if len(gateways) > 0 && !gateways[option.GatewayId] {
+			continue
+		}
+		if len(frequencies) > 0 {
+			var match bool
+			for _, freq := range frequencies {
+				if option.GatewayConfig.GetFrequency() == freq {
+					match = true
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+		res = append(res, option)
+	}
+	return
+}
+
+// buildUplinks builds the uplink messages for an uplink message that a partner forwarded, one for each gateway
+func (r *roamingState) buildUplinks(partner *roaming.Partner, phyPayload []byte, ulMetaData *roaming.ULMetaData) ([]*pb.UplinkMessage, error) {
+	region, ok := roaming.Region(ulMetaData.RFRegion)
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("RFRegion", fmt.Sprintf("unknown region %s", ulMetaData.RFRegion))
+	}
+	fp, err := band.Get(region.String())
+	if err != nil {
+		return nil, err
+	}
+	if ulMetaData.DataRate == nil || *ulMetaData.DataRate < 0 || *ulMetaData.DataRate >= len(fp.DataRates) {
+		return nil, errors.NewErrInvalidArgument("DataRate", "invalid data rate")
+	}
+	dataRate, err := fp.GetDataRateStringForIndex(*ulMetaData.DataRate)
+	if err != nil {
+		return nil, err
+	}
+	var frequency uint64
+	if ulMetaData.ULFreq != nil {
+		frequency = uint64(math.Floor(*ulMetaData.ULFreq*1e6 + 0.5))
+	}
+	var recvTime int64
+	if t, err := time.Parse(time.RFC3339Nano, ulMetaData.RecvTime); err == nil {
+		recvTime = t.UnixNano()
+	}
+
+	gateways := ulMetaData.GWInfo
+	if len(gateways) == 0 {
+		gateways = []roaming.GWInfo{{}}
+	}
+	uplinks := make([]*pb.UplinkMessage, 0, len(gateways))
+	var dlGateways []roaming.GWInfo
+	var dlGatewayID string
+	for _, gwInfo := range gateways {
+		gatewayMetadata := &pb_gateway.RxMetadata{
+			GatewayId: roamingGatewayID(partner.NetID, gwInfo.ID),
+			Time:      recvTime,
+			Frequency: frequency,
+		}
+		if gwInfo.RSSI != nil {
+			gatewayMetadata.Rssi = float32(*gwInfo.RSSI)
+		}
+		if gwInfo.SNR != nil {
+			gatewayMetadata.Snr = float32(*gwInfo.SNR)
+		}
+		if gwInfo.Lat != nil && gwInfo.Lon != nil {
+			gatewayMetadata.Gps = &pb_gateway.GPSMetadata{Latitude: float32(*gwInfo.Lat), Longitude: float32(*gwInfo.Lon)}
+		}
+		if gwInfo.DLAllowed {
+			dlGateways = append(dlGateways, roaming.GWInfo{ID: gwInfo.ID, ULToken: gwInfo.ULToken})
+			if dlGatewayID == "" {
+				dlGatewayID = gatewayMetadata.GatewayId
+			}
+		}
+		uplinks = append(uplinks, &pb.UplinkMessage{
+			Payload: phyPayload,
+			ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{Lorawan: &pb_lorawan.Metadata{
+				Modulation: pb_lorawan.Modulation_LORA,
+				DataRate:   dataRate,
+				CodingRate: "4/5",
+				Region:     region,
+			}}},
+			GatewayMetadata: gatewayMetadata,
+		})
+	}
+
+	// The partner selects the gateway for the downlink, so we only give it the RX1 and RX2 parameters
+	if len(dlGateways) > 0 && frequency != 0 {
+		option, err := r.buildDownlinkOption(partner, fp, *ulMetaData.DataRate, frequency, dlGatewayID, ulMetaData.FNSULToken, dlGateways)
+		if err != nil {
+			return nil, err
+		}
+		uplinks[0].DownlinkOptions = []*pb.DownlinkOption{option}
+	}
+
+	return uplinks, nil
+}
+
+// buildDownlinkOption builds the DownlinkOption for a downlink through a partner
+func (r *roamingState) buildDownlinkOption(partner *roaming.Partner, fp band.FrequencyPlan, upDataRate int, frequency uint64, gatewayID string, fnsULToken roaming.HEXBytes, gateways []roaming.GWInfo) (*pb.DownlinkOption, error) {
+	rx1Frequency, err := fp.GetRX1Frequency(int(frequency))
+	if err != nil {
+		return nil, err
+	}
+	rx1DataRate, err := fp.GetRX1DataRate(upDataRate, 0)
+	if err != nil {
+		return nil, err
+	}
+	rx2DataRate := fp.RX2DataRate
+	rxDelay1 := int(fp.ReceiveDelay1 / time.Second)
+	dlFreq1, dlFreq2 := float64(rx1Frequency)/1e6, float64(fp.RX2Frequency)/1e6
+
+	option := &pb.DownlinkOption{
+		Identifier: roamingIdentifierPrefix + random.String(16),
+		GatewayId:  gatewayID,
+		Score:      roamingDownlinkScore,
+		ProtocolConfig: &pb_protocol.TxConfiguration{Protocol: &pb_protocol.TxConfiguration_Lorawan{Lorawan: &pb_lorawan.TxConfiguration{
+			Modulation: pb_lorawan.Modulation_LORA,
+			CodingRate: "4/5",
+		}}},
+		GatewayConfig: &pb_gateway.TxConfiguration{
+			Frequency:             uint64(rx1Frequency),
+			Power:                 int32(fp.DefaultTXPower),
+			PolarizationInversion: true,
+		},
+	}
+	if err := option.ProtocolConfig.GetLorawan().SetDataRate(fp.DataRates[rx1DataRate]); err != nil {
+		return nil, err
+	}
+
+	r.setDownlink(option.Identifier, &roamingDownlinkOption{
+		partner: partner,
+		dlMetaData: roaming.DLMetaData{
+			DLFreq1:    &dlFreq1,
+			DLFreq2:    &dlFreq2,
+			RXDelay1:   &rxDelay1,
+			ClassMode:  "A",
+			DataRate1:  &rx1DataRate,
+			DataRate2:  &rx2DataRate,
+			FNSULToken: fnsULToken,
+			GWInfo:     gateways,
+		},
+	})
+
+	return option, nil
+}
+
+// roamingGatewayID returns the ID that we use for a gateway of a roaming partner
+func roamingGatewayID(netID types.NetID, id []byte) string {
+	return fmt.Sprintf("netid-%s-%s", strings.ToLower(hex.EncodeToString(netID[:])), hex.EncodeToString(id))
+}
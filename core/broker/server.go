@@ -6,9 +6,11 @@ package broker
 import (
 	"time"
 
+	"github.com/TheThingsNetwork/ttn/api"
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -122,6 +124,27 @@ func (b *brokerRPC) Activate(ctx context.Context, req *pb.DeviceActivationReques
 	return
 }
 
+func (b *brokerRPC) DownlinkFailed(ctx context.Context, downlink *pb.DownlinkMessage) (*empty.Empty, error) {
+	_, err := b.broker.ValidateNetworkContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The downlink of an activation does not contain the IDs of the device
+	if downlink.AppId == "" && downlink.DevId == "" {
+		err = api.NotNilAndValid(downlink.DownlinkOption, "DownlinkOption")
+	} else {
+		err = downlink.Validate()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid Downlink")
+	}
+	err = b.broker.HandleDownlinkFailure(downlink)
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 func (b *broker) RegisterRPC(s *grpc.Server) {
 	server := &brokerRPC{broker: b}
 	server.SetLogger(b.Ctx)
@@ -17,6 +17,8 @@ type status struct {
 	activations       metrics.Meter
 	activationsUnique metrics.Meter
 	deduplication     metrics.Histogram
+	micChecks         metrics.Meter
+	quotaExceeded     metrics.Meter
 	connectedRouters  metrics.Gauge
 	connectedHandlers metrics.Gauge
 }
@@ -29,6 +31,8 @@ func (b *broker) InitStatus() {
 		activations:       metrics.NewMeter(),
 		activationsUnique: metrics.NewMeter(),
 		deduplication:     metrics.NewHistogram(metrics.NewUniformSample(512)),
+		micChecks:         metrics.NewMeter(),
+		quotaExceeded:     metrics.NewMeter(),
 		connectedRouters: metrics.NewFunctionalGauge(func() int64 {
 			b.routersLock.RLock()
 			defer b.routersLock.RUnlock()
@@ -91,6 +95,12 @@ func (b *broker) GetStatus() *pb.Status {
 		Percentile95: float32(deduplication[7]),
 		Percentile99: float32(deduplication[8]),
 	}
+	quotaExceeded := b.status.quotaExceeded.Snapshot()
+	status.QuotaExceeded = &api.Rates{
+		Rate1:  float32(quotaExceeded.Rate1()),
+		Rate5:  float32(quotaExceeded.Rate5()),
+		Rate15: float32(quotaExceeded.Rate15()),
+	}
 	status.ConnectedRouters = uint32(b.status.connectedRouters.Snapshot().Value())
 	status.ConnectedHandlers = uint32(b.status.connectedHandlers.Snapshot().Value())
 	return status
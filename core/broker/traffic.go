@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"fmt"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// TrafficBufferSize indicates the number of traffic messages that are buffered for each subscription. Messages are
+// dropped for subscribers that do not keep up.
+var TrafficBufferSize = 100
+
+type trafficSubscription struct {
+	filter *pb.TrafficRequest
+	ch     chan *pb.TrafficMessage
+}
+
+func (b *broker) SubscribeTraffic(subscriptionID string, filter *pb.TrafficRequest) (<-chan *pb.TrafficMessage, error) {
+	b.trafficLock.Lock()
+	defer b.trafficLock.Unlock()
+	if b.traffic == nil {
+		b.traffic = make(map[string]*trafficSubscription)
+	}
+	if _, ok := b.traffic[subscriptionID]; ok {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Already subscribed to traffic with %s", subscriptionID))
+	}
+	sub := &trafficSubscription{
+		filter: filter,
+		ch:     make(chan *pb.TrafficMessage, TrafficBufferSize),
+	}
+	b.traffic[subscriptionID] = sub
+	return sub.ch, nil
+}
+
+func (b *broker) UnsubscribeTraffic(subscriptionID string) error {
+	b.trafficLock.Lock()
+	defer b.trafficLock.Unlock()
+	if sub, ok := b.traffic[subscriptionID]; ok {
+		close(sub.ch)
+		delete(b.traffic, subscriptionID)
+	}
+	return nil
+}
+
+// handleTraffic sends an uplink or downlink message of a device to the matching traffic subscriptions
+func (b *broker) handleTraffic(message *pb.TrafficMessage) {
+	b.trafficLock.RLock()
+	defer b.trafficLock.RUnlock()
+	for subscriptionID, sub := range b.traffic {
+		if !sub.filter.Matches(message) {
+			continue
+		}
+		select {
+		case sub.ch <- message:
+		default:
+			b.Ctx.WithField("SubscriptionID", subscriptionID).Debug("Not sending traffic message, buffer full")
+		}
+	}
+}
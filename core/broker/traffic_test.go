@@ -0,0 +1,33 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package broker
+
+import (
+	"testing"
+
+	pb "github.com/TheThingsNetwork/ttn/api/broker"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestTraffic(t *testing.T) {
+	a := New(t)
+	b := getTestBroker(t)
+
+	all, err := b.SubscribeTraffic("all", &pb.TrafficRequest{})
+	a.So(err, ShouldBeNil)
+	app, err := b.SubscribeTraffic("app", &pb.TrafficRequest{AppId: "app"})
+	a.So(err, ShouldBeNil)
+
+	b.handleTraffic(&pb.TrafficMessage{Uplink: &pb.DeduplicatedUplinkMessage{AppId: "app"}})
+	b.handleTraffic(&pb.TrafficMessage{Downlink: &pb.DownlinkMessage{AppId: "other-app"}})
+
+	a.So(all, ShouldHaveLength, 2)
+	a.So(app, ShouldHaveLength, 1)
+	a.So((<-app).Uplink.AppId, ShouldEqual, "app")
+
+	a.So(b.UnsubscribeTraffic("app"), ShouldBeNil)
+	_, open := <-app
+	a.So(open, ShouldBeFalse)
+	a.So(b.UnsubscribeTraffic("all"), ShouldBeNil)
+}
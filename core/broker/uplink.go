@@ -14,9 +14,9 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
 	"github.com/TheThingsNetwork/ttn/api/fields"
-	"github.com/TheThingsNetwork/ttn/api/networkserver"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/quota"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/TheThingsNetwork/ttn/utils/fcnt"
@@ -33,23 +33,32 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 	defer func() {
 		if err != nil {
 			if deduplicatedUplink != nil {
-				deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(trace.DropEvent, "reason", err)
+				deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(b.TraceService(), trace.DropEvent, "reason", err)
 			}
 			ctx.WithError(err).Warn("Could not handle uplink")
 		} else {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled uplink")
 		}
 		if deduplicatedUplink != nil {
+			trace.Export(b.TraceService(), deduplicatedUplink.Trace)
 			for _, monitor := range b.Monitors.BrokerClients() {
 				ctx.Debug("Sending uplink to monitor")
 				go monitor.SendUplink(deduplicatedUplink)
 			}
+			if deduplicatedUplink.Payload != nil {
+				b.handleTraffic(&pb.TrafficMessage{Time: time.Now().UnixNano(), Uplink: deduplicatedUplink})
+			}
 		}
 	}()
 
 	b.status.uplink.Mark(1)
 
-	uplink.Trace = uplink.Trace.WithEvent(trace.ReceiveEvent)
+	if !b.inFlight.Add() {
+		return errors.NewErrInternal("Broker is shutting down")
+	}
+	defer b.inFlight.Done()
+
+	uplink.Trace = uplink.Trace.WithEvent(b.TraceService(), trace.ReceiveEvent)
 
 	// De-duplicate uplink messages
 	duplicates := b.deduplicateUplink(uplink)
@@ -62,7 +71,7 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 
 	deduplicatedUplink.Payload = duplicates[0].Payload
 	deduplicatedUplink.ProtocolMetadata = duplicates[0].ProtocolMetadata
-	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(trace.DeduplicateEvent,
+	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(b.TraceService(), trace.DeduplicateEvent,
 		"duplicates", len(duplicates),
 	)
 	for _, duplicate := range duplicates {
@@ -86,67 +95,59 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 		return errors.NewErrInvalidArgument("Uplink", "does not contain a MAC payload")
 	}
 
-	// Request devices from NS
 	devAddr := types.DevAddr(macPayload.FHDR.DevAddr)
 	ctx = ctx.WithFields(ttnlog.Fields{
 		"DevAddr": devAddr,
 		"FCnt":    macPayload.FHDR.FCnt,
 	})
-	var getDevicesResp *networkserver.DevicesResponse
-	getDevicesResp, err = b.ns.GetDevices(b.Component.GetContext(b.nsToken), &networkserver.DevicesRequest{
-		DevAddr: &devAddr,
-		FCnt:    macPayload.FHDR.FCnt,
-	})
-	if err != nil {
-		return errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not return devices")
-	}
-	b.status.deduplication.Update(int64(len(getDevicesResp.Results)))
-	if len(getDevicesResp.Results) == 0 {
-		return errors.NewErrNotFound(fmt.Sprintf("Device with DevAddr %s and FCnt <= %d", devAddr, macPayload.FHDR.FCnt))
+
+	// Uplink messages of devices of other networks are forwarded to their network
+	if b.roaming != nil && !b.roaming.isHome(devAddr) {
+		return b.forwardRoamingUplink(deduplicatedUplink, duplicates, devAddr)
 	}
-	ctx = ctx.WithField("DevAddrResults", len(getDevicesResp.Results))
-	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent("got devices from networkserver",
-		"devices", len(getDevicesResp.Results),
-	)
 
-	// Sort by FCntUp to optimize the number of MIC checks
-	sort.Sort(ByFCntUp(getDevicesResp.Results))
+	// Request devices from NS
+	var candidates []*pb_lorawan.Device
+	var cached bool
+	candidates, cached, err = b.getDevices(devAddr, macPayload.FHDR.FCnt)
+	if err != nil {
+		return err
+	}
+	originalFCnt := macPayload.FHDR.FCnt
 
 	// Find AppEUI/DevEUI through MIC check
 	var device *pb_lorawan.Device
 	var micChecks int
-	originalFCnt := macPayload.FHDR.FCnt
-	for _, candidate := range getDevicesResp.Results {
-		nwkSKey := lorawan.AES128Key(*candidate.NwkSKey)
-
-		// First check with the 16 bit counter
-		micChecks++
-		ok, err = phyPayload.ValidateMIC(nwkSKey)
+	device, micChecks, err = findDevice(&phyPayload, macPayload, candidates)
+	if err != nil {
+		return err
+	}
+	if device == nil && cached {
+		// The device may have been activated after the devices were cached
+		b.devicesCache.invalidate(devAddr)
+		macPayload.FHDR.FCnt = originalFCnt
+		candidates, _, err = b.getDevices(devAddr, originalFCnt)
 		if err != nil {
 			return err
 		}
-		if ok {
-			device = candidate
-			break
+		var retryMICChecks int
+		device, retryMICChecks, err = findDevice(&phyPayload, macPayload, candidates)
+		micChecks += retryMICChecks
+		if err != nil {
+			return err
 		}
+	}
 
-		if candidate.Uses32BitFCnt {
-			macPayload.FHDR.FCnt = fcnt.GetFull(candidate.FCntUp, uint16(originalFCnt))
-
-			// If 32 bit counter has different value, perform another MIC check
-			if macPayload.FHDR.FCnt != originalFCnt {
-				micChecks++
-				ok, err = phyPayload.ValidateMIC(nwkSKey)
-				if err != nil {
-					return err
-				}
-				if ok {
-					device = candidate
-					break
-				}
-			}
-		}
+	b.status.deduplication.Update(int64(len(candidates)))
+	if len(candidates) == 0 {
+		return errors.NewErrNotFound(fmt.Sprintf("Device with DevAddr %s and FCnt <= %d", devAddr, originalFCnt))
 	}
+	ctx = ctx.WithField("DevAddrResults", len(candidates))
+	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(b.TraceService(), "got devices from networkserver",
+		"devices", len(candidates),
+	)
+
+	b.status.micChecks.Mark(int64(micChecks))
 	if device == nil {
 		return errors.NewErrNotFound("device that validates MIC")
 	}
@@ -163,7 +164,7 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 	deduplicatedUplink.AppEui = device.AppEui
 	deduplicatedUplink.AppId = device.AppId
 	deduplicatedUplink.DevId = device.DevId
-	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(trace.CheckMICEvent, "mic checks", micChecks)
+	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(b.TraceService(), trace.CheckMICEvent, "mic checks", micChecks)
 	if macPayload.FHDR.FCnt != originalFCnt {
 		ctx = ctx.WithField("RealFCnt", macPayload.FHDR.FCnt)
 	}
@@ -189,6 +190,20 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 		return errors.NewErrInternal("FCnt check failed")
 	}
 
+	if b.quota != nil {
+		var airtime time.Duration
+		airtime, err = quota.Airtime(deduplicatedUplink.Payload, deduplicatedUplink.ProtocolMetadata.GetLorawan())
+		if err != nil {
+			return err
+		}
+		if _, err = b.quota.Uplink(device.AppId, device.DevId, airtime); err != nil {
+			if errors.GetErrType(err) == errors.ResourceExhausted {
+				b.status.quotaExceeded.Mark(1)
+			}
+			return err
+		}
+	}
+
 	// Add FCnt to Metadata (because it's not marshaled in lorawan payload)
 	deduplicatedUplink.ProtocolMetadata.GetLorawan().FCnt = macPayload.FHDR.FCnt
 
@@ -199,7 +214,7 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 		downlinkOptions = append(downlinkOptions, duplicate.DownlinkOptions...)
 	}
 
-	// Select best DownlinkOption
+	// Select best DownlinkOption and keep the others for failover
 	if len(downlinkOptions) > 0 {
 		deduplicatedUplink.ResponseTemplate = &pb.DownlinkMessage{
 			DevEui:         device.DevEui,
@@ -208,14 +223,23 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 			DevId:          device.DevId,
 			DownlinkOption: selectBestDownlink(downlinkOptions),
 		}
+		b.downlinkOptions.Set(downlinkOptions)
 	}
 
 	// Pass Uplink through NS
 	deduplicatedUplink, err = b.ns.Uplink(b.Component.GetContext(b.nsToken), deduplicatedUplink)
 	if err != nil {
+		if b.devicesCache != nil {
+			// The cached device may be out of date, for example if another Broker handled newer uplink messages
+			b.devicesCache.invalidate(devAddr)
+		}
 		return errors.Wrap(errors.FromGRPCError(err), "NetworkServer did not handle uplink")
 	}
 
+	if b.devicesCache != nil {
+		b.devicesCache.updateFCntUp(devAddr, device, macPayload.FHDR.FCnt)
+	}
+
 	var announcements []*pb_discovery.Announcement
 	announcements, err = b.Discovery.GetAllHandlersForAppID(device.AppId)
 	if err != nil {
@@ -224,18 +248,16 @@ func (b *broker) HandleUplink(uplink *pb.UplinkMessage) (err error) {
 	if len(announcements) == 0 {
 		return errors.NewErrNotFound(fmt.Sprintf("Handler for AppID %s", device.AppId))
 	}
-	if len(announcements) > 1 {
-		return errors.NewErrInternal(fmt.Sprintf("Multiple Handlers for AppID %s", device.AppId))
-	}
 
+	var announcement *pb_discovery.Announcement
 	var handler chan<- *pb.DeduplicatedUplinkMessage
-	handler, err = b.getHandlerUplink(announcements[0].Id)
+	announcement, handler, err = b.selectHandlerUplink(device.AppId, device.DevId, announcements)
 	if err != nil {
 		return err
 	}
 
-	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(trace.ForwardEvent,
-		"handler", announcements[0].Id,
+	deduplicatedUplink.Trace = deduplicatedUplink.Trace.WithEvent(b.TraceService(), trace.ForwardEvent,
+		"handler", announcement.Id,
 	)
 
 	handler <- deduplicatedUplink
@@ -273,3 +295,42 @@ func (a ByFCntUp) Less(i, j int) bool {
 	}
 	return int(a[i].FCntUp) < int(a[j].FCntUp)
 }
+
+// findDevice finds the candidate that validates the MIC of the uplink message. If the candidate uses 32 bit frame
+// counters, the FCnt of the MAC payload is updated to the full frame counter.
+func findDevice(phyPayload *lorawan.PHYPayload, macPayload *lorawan.MACPayload, candidates []*pb_lorawan.Device) (device *pb_lorawan.Device, micChecks int, err error) {
+	// Sort by FCntUp to optimize the number of MIC checks
+	sort.Sort(ByFCntUp(candidates))
+
+	originalFCnt := macPayload.FHDR.FCnt
+	for _, candidate := range candidates {
+		nwkSKey := lorawan.AES128Key(*candidate.NwkSKey)
+
+		// First check with the 16 bit counter
+		micChecks++
+		ok, err := phyPayload.ValidateMIC(nwkSKey)
+		if err != nil {
+			return nil, micChecks, err
+		}
+		if ok {
+			return candidate, micChecks, nil
+		}
+
+		if candidate.Uses32BitFCnt {
+			macPayload.FHDR.FCnt = fcnt.GetFull(candidate.FCntUp, uint16(originalFCnt))
+
+			// If 32 bit counter has different value, perform another MIC check
+			if macPayload.FHDR.FCnt != originalFCnt {
+				micChecks++
+				ok, err = phyPayload.ValidateMIC(nwkSKey)
+				if err != nil {
+					return nil, micChecks, err
+				}
+				if ok {
+					return candidate, micChecks, nil
+				}
+			}
+		}
+	}
+	return nil, micChecks, nil
+}
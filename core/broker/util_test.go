@@ -37,6 +37,7 @@ func getTestBroker(t *testing.T) *testBroker {
 			handlers:               make(map[string]*handler),
 			activationDeduplicator: NewDeduplicator(10 * time.Millisecond),
 			uplinkDeduplicator:     NewDeduplicator(10 * time.Millisecond),
+			downlinkOptions:        NewDownlinkOptionsStore(),
 			ns:                     ns,
 		},
 		ns:        ns,
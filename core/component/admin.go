@@ -0,0 +1,98 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/security"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
+	"google.golang.org/grpc"
+)
+
+// AdminServer is the server API for the administration of a component
+type AdminServer interface {
+	// Reload re-reads the configuration and applies the settings that can be changed at runtime
+	Reload(context.Context, *empty.Empty) (*empty.Empty, error)
+}
+
+var adminServiceDesc = grpc.ServiceDesc{
+	ServiceName: "component.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reload",
+			Handler:    adminReloadHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func adminReloadHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Reload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/component.Admin/Reload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Reload(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminReload asks the component at the other end of the connection to reload its configuration. The token must
+// be signed with the private key of the component.
+func AdminReload(conn *grpc.ClientConn, token string) error {
+	ctx := api.ContextWithToken(context.Background(), token)
+	return grpc.Invoke(ctx, "/component.Admin/Reload", new(empty.Empty), new(empty.Empty), conn)
+}
+
+type adminServer struct {
+	*Component
+}
+
+// RegisterAdminServer registers the administration API of the component to the gRPC server. Only callers with a
+// token that is signed with the private key of the component are allowed to use it.
+func (c *Component) RegisterAdminServer(srv *grpc.Server) {
+	srv.RegisterService(&adminServiceDesc, &adminServer{c})
+}
+
+func (a *adminServer) validateAdminContext(ctx context.Context) error {
+	token, err := api.TokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if a.privateKey == nil {
+		return errors.NewErrInternal("No private key to validate admin token")
+	}
+	pubPEM, err := security.PublicPEM(a.privateKey)
+	if err != nil {
+		return err
+	}
+	claims, err := security.ValidateJWT(token, pubPEM)
+	if err != nil {
+		return errors.NewErrPermissionDenied(err.Error())
+	}
+	if claims.Subject != a.Identity.Id {
+		return errors.NewErrPermissionDenied("Token is not for this component")
+	}
+	return nil
+}
+
+func (a *adminServer) Reload(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	if err := a.validateAdminContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := Reload(a.Ctx); err != nil {
+		return nil, errors.NewErrInvalidArgument("Configuration", err.Error())
+	}
+	return new(empty.Empty), nil
+}
@@ -0,0 +1,60 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"sync"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	"github.com/TheThingsNetwork/ttn/core/audit"
+	"github.com/spf13/viper"
+)
+
+var auditSinkOnce sync.Once
+
+// initAuditSink sets up the sink of the audit log of this process. The audit log is shared by all components in
+// the process, so the sink is only set up by the first component.
+func (c *Component) initAuditSink() (err error) {
+	auditSinkOnce.Do(func() {
+		err = c.setAuditSink()
+	})
+	return
+}
+
+func (c *Component) setAuditSink() error {
+	var sinks []audit.Sink
+	if file := viper.GetString("audit-file"); file != "" {
+		sink, err := audit.NewFileSink(file)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+	if url := viper.GetString("audit-elasticsearch"); url != "" {
+		sinks = append(sinks, audit.NewElasticsearchSink(url))
+	}
+	switch len(sinks) {
+	case 0:
+		return nil
+	case 1:
+		audit.SetSink(sinks[0])
+	default:
+		audit.SetSink(audit.NewMultiSink(sinks...))
+	}
+	c.Ctx.Info("Recording audit log")
+	return nil
+}
+
+// RecordAudit records a change that was made by the holder of a token with the given claims in the audit log, with
+// the service name and ID of this component
+func (c *Component) RecordAudit(claims *claims.Claims, entry *audit.Entry) {
+	entry.SetActor(claims)
+	if c.Identity != nil {
+		entry.ServiceName = c.Identity.ServiceName
+		entry.ServiceID = c.Identity.Id
+	}
+	if err := audit.Record(entry); err != nil && c.Ctx != nil {
+		c.Ctx.WithError(err).WithField("Action", entry.Action).Warn("Could not record audit log entry")
+	}
+}
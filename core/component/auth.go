@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/TheThingsNetwork/go-account-lib/account"
@@ -24,6 +25,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/TheThingsNetwork/ttn/utils/security"
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/spf13/viper"
 	"golang.org/x/net/context"
 )
 
@@ -67,16 +69,41 @@ func parseAuthServer(str string) (srv authServer, err error) {
 }
 
 func (c *Component) initAuthServers() error {
+	provider, err := c.newTokenKeyProvider(c.Config.AuthServers)
+	if err != nil {
+		return err
+	}
+	c.authServers = new(tokenKeyProvider)
+	c.authServers.set(provider)
+	c.TokenKeyProvider = c.authServers
+	return nil
+}
+
+// reloadAuthServers validates the auth servers in the configuration and returns a function that makes the
+// component use them
+func (c *Component) reloadAuthServers() (func(), error) {
+	authServers := viper.GetStringMapString("auth-servers")
+	provider, err := c.newTokenKeyProvider(authServers)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		c.Config.AuthServers = authServers
+		c.authServers.set(provider)
+	}, nil
+}
+
+func (c *Component) newTokenKeyProvider(authServers map[string]string) (tokenkey.Provider, error) {
 	urlMap := make(map[string]string)
 	funcMap := make(map[string]tokenkey.TokenFunc)
 	var httpProvider tokenkey.Provider
-	for id, url := range c.Config.AuthServers {
+	for id, url := range authServers {
 		id, url := id, url // deliberately shadow these
 		if strings.HasPrefix(url, "file://") {
 			file := strings.TrimPrefix(url, "file://")
 			contents, err := ioutil.ReadFile(path.Clean(file))
 			if err != nil {
-				return err
+				return nil, err
 			}
 			funcMap[id] = func(renew bool) (*tokenkey.TokenKey, error) {
 				return &tokenkey.TokenKey{Algorithm: "ES256", Key: string(contents)}, nil
@@ -85,7 +112,7 @@ func (c *Component) initAuthServers() error {
 		}
 		srv, err := parseAuthServer(url)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		urlMap[id] = srv.url
 		funcMap[id] = func(renew bool) (*tokenkey.TokenKey, error) {
@@ -96,8 +123,24 @@ func (c *Component) initAuthServers() error {
 		urlMap,
 		cache.WriteTroughCacheWithFormat(c.Config.KeyDir, "auth-%s.pub"),
 	)
-	c.TokenKeyProvider = tokenkey.FuncProvider(funcMap)
-	return nil
+	return tokenkey.FuncProvider(funcMap), nil
+}
+
+// tokenKeyProvider is a tokenkey.Provider of which the auth servers can be changed while the component is running
+type tokenKeyProvider struct {
+	provider atomic.Value
+}
+
+func (p *tokenKeyProvider) set(provider tokenkey.Provider) {
+	p.provider.Store(provider)
+}
+
+func (p *tokenKeyProvider) Get(server string, renew bool) (*tokenkey.TokenKey, error) {
+	return p.provider.Load().(tokenkey.Provider).Get(server, renew)
+}
+
+func (p *tokenKeyProvider) Update() error {
+	return p.provider.Load().(tokenkey.Provider).Update()
 }
 
 // UpdateTokenKey updates the OAuth Bearer token key
@@ -184,6 +227,15 @@ func (c *Component) BuildJWT() (string, error) {
 	return security.BuildJWT(c.Identity.Id, 20*time.Second, privPEM)
 }
 
+// Sign signs data with the private key of this component, so that other components can verify it with the public key
+// in its announcement
+func (c *Component) Sign(data []byte) (string, error) {
+	if c.privateKey == nil {
+		return "", errors.NewErrInternal("No private key to sign with")
+	}
+	return security.Sign(data, c.privateKey)
+}
+
 // GetContext returns a context for outgoing RPC request. If token is "", this function will generate a short lived token from the component
 func (c *Component) GetContext(token string) context.Context {
 	if c.bgCtx == nil {
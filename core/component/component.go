@@ -8,7 +8,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/tls"
 	"fmt"
-	"net/http"
 	"runtime"
 	"time"
 
@@ -17,7 +16,6 @@ import (
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
 	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
 	pb_monitor "github.com/TheThingsNetwork/ttn/api/monitor"
-	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/spf13/viper"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
@@ -36,8 +34,10 @@ type Component struct {
 	privateKey       *ecdsa.PrivateKey
 	tlsConfig        *tls.Config
 	TokenKeyProvider tokenkey.Provider
+	authServers      *tokenKeyProvider
 	status           int64
 	healthServer     *health.Server
+	ShutdownTimeout  time.Duration
 }
 
 type Interface interface {
@@ -80,14 +80,22 @@ func New(ctx ttnlog.Interface, serviceName string, announcedAddress string) (*Co
 			NetAddress:     announcedAddress,
 			Public:         viper.GetBool("public"),
 		},
-		AccessToken: viper.GetString("auth-token"),
+		AccessToken:     viper.GetString("auth-token"),
+		ShutdownTimeout: viper.GetDuration("shutdown-timeout"),
 	}
 
-	trace.SetComponent(component.Identity.ServiceName, component.Identity.Id)
+	if err := component.initTraceExporter(); err != nil {
+		return nil, err
+	}
+
+	if err := component.initAuditSink(); err != nil {
+		return nil, err
+	}
 
 	if err := component.InitAuth(); err != nil {
 		return nil, err
 	}
+	component.OnReload([]string{"auth-servers"}, component.reloadAuthServers)
 
 	if serviceName != "discovery" && serviceName != "networkserver" {
 		var err error
@@ -105,19 +113,7 @@ func New(ctx ttnlog.Interface, serviceName string, announcedAddress string) (*Co
 	}
 
 	if healthPort := viper.GetInt("health-port"); healthPort > 0 {
-		http.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
-			switch component.GetStatus() {
-			case StatusHealthy:
-				w.WriteHeader(200)
-				w.Write([]byte("Status is HEALTHY"))
-				return
-			case StatusUnhealthy:
-				w.WriteHeader(503)
-				w.Write([]byte("Status is UNHEALTHY"))
-				return
-			}
-		})
-		go http.ListenAndServe(fmt.Sprintf(":%d", healthPort), nil)
+		component.startHealthServer(healthPort)
 	}
 
 	component.Monitors = pb_monitor.NewRegistry(ctx)
@@ -0,0 +1,46 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	healthServerOnce     sync.Once
+	healthComponentsLock sync.RWMutex
+	healthComponents     []*Component
+)
+
+// startHealthServer adds the component to the HTTP health server of this process, and starts the server if it
+// is not running yet. The server reports the status and metrics of all components in the process.
+func (c *Component) startHealthServer(port int) {
+	healthComponentsLock.Lock()
+	healthComponents = append(healthComponents, c)
+	healthComponentsLock.Unlock()
+
+	healthServerOnce.Do(func() {
+		http.HandleFunc("/healthz", healthz)
+		http.Handle("/metrics", promhttp.Handler())
+		go http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	})
+}
+
+func healthz(w http.ResponseWriter, req *http.Request) {
+	healthComponentsLock.RLock()
+	defer healthComponentsLock.RUnlock()
+	for _, component := range healthComponents {
+		if component.GetStatus() != StatusHealthy {
+			w.WriteHeader(503)
+			w.Write([]byte("Status is UNHEALTHY"))
+			return
+		}
+	}
+	w.WriteHeader(200)
+	w.Write([]byte("Status is HEALTHY"))
+}
@@ -0,0 +1,70 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rcrowley/go-metrics"
+)
+
+// MetricsNamespace is the namespace of the Prometheus metrics of all components
+const MetricsNamespace = "ttn"
+
+// metricQuantiles are the quantiles that are exposed for histograms
+var metricQuantiles = []float64{0.01, 0.05, 0.10, 0.25, 0.50, 0.75, 0.90, 0.95, 0.99}
+
+// MetricLabels returns the labels that identify the component in its metrics
+func (c *Component) MetricLabels() prometheus.Labels {
+	return prometheus.Labels{
+		"service": c.Identity.ServiceName,
+		"id":      c.Identity.Id,
+	}
+}
+
+// NewMetricDesc returns the description of a metric of the component. The name of the metric is prefixed with the
+// namespace and the service name of the component.
+func (c *Component) NewMetricDesc(name, help string, variableLabels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName(MetricsNamespace, c.Identity.ServiceName, name),
+		help,
+		variableLabels,
+		c.MetricLabels(),
+	)
+}
+
+// RegisterMetrics registers a collector for the metrics of the component. The metrics are exposed on the /metrics
+// endpoint of the health server.
+func (c *Component) RegisterMetrics(collector prometheus.Collector) {
+	if err := prometheus.Register(collector); err != nil {
+		c.Ctx.WithError(err).Warn("Could not register metrics")
+	}
+}
+
+// UnregisterMetrics unregisters a collector that was registered with RegisterMetrics, so that its metrics are no
+// longer exposed
+func (c *Component) UnregisterMetrics(collector prometheus.Collector) {
+	prometheus.Unregister(collector)
+}
+
+// MeterMetric returns a Prometheus counter with the total count of a meter
+func MeterMetric(desc *prometheus.Desc, meter metrics.Meter, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(meter.Count()), labelValues...)
+}
+
+// GaugeMetric returns a Prometheus gauge with the value of a gauge
+func GaugeMetric(desc *prometheus.Desc, gauge metrics.Gauge, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(gauge.Value()), labelValues...)
+}
+
+// HistogramMetric returns a Prometheus summary with the quantiles of a histogram. The values of the histogram are
+// multiplied by scale.
+func HistogramMetric(desc *prometheus.Desc, histogram metrics.Histogram, scale float64, labelValues ...string) prometheus.Metric {
+	snapshot := histogram.Snapshot()
+	percentiles := snapshot.Percentiles(metricQuantiles)
+	quantiles := make(map[float64]float64, len(metricQuantiles))
+	for i, quantile := range metricQuantiles {
+		quantiles[quantile] = percentiles[i] * scale
+	}
+	return prometheus.MustNewConstSummary(desc, uint64(snapshot.Count()), float64(snapshot.Sum())*scale, quantiles, labelValues...)
+}
@@ -0,0 +1,74 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"testing"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rcrowley/go-metrics"
+	. "github.com/smartystreets/assertions"
+)
+
+// testCollector collects the metrics that are passed to it
+type testCollector []prometheus.Metric
+
+func (c testCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, metric := range c {
+		ch <- metric.Desc()
+	}
+}
+
+func (c testCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, metric := range c {
+		ch <- metric
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	a := New(t)
+
+	c := &Component{
+		Identity: &pb_discovery.Announcement{
+			Id:          "test",
+			ServiceName: "broker",
+		},
+	}
+	a.So(c.MetricLabels(), ShouldResemble, prometheus.Labels{"service": "broker", "id": "test"})
+
+	meter := metrics.NewMeter()
+	meter.Mark(3)
+	gauge := metrics.NewGauge()
+	gauge.Update(2)
+	histogram := metrics.NewHistogram(metrics.NewUniformSample(512))
+	histogram.Update(1000)
+	histogram.Update(3000)
+
+	registry := prometheus.NewRegistry()
+	err := registry.Register(testCollector{
+		MeterMetric(c.NewMetricDesc("meter_total", "A meter"), meter),
+		GaugeMetric(c.NewMetricDesc("gauge", "A gauge"), gauge),
+		HistogramMetric(c.NewMetricDesc("histogram_seconds", "A histogram", "app_id"), histogram, 1e-3, "app"),
+	})
+	a.So(err, ShouldBeNil)
+
+	families, err := registry.Gather()
+	a.So(err, ShouldBeNil)
+	a.So(families, ShouldHaveLength, 3)
+
+	a.So(families[0].GetName(), ShouldEqual, "ttn_broker_gauge")
+	a.So(families[0].GetMetric()[0].GetGauge().GetValue(), ShouldEqual, 2)
+	a.So(families[0].GetMetric()[0].GetLabel(), ShouldHaveLength, 2)
+
+	a.So(families[1].GetName(), ShouldEqual, "ttn_broker_histogram_seconds")
+	summary := families[1].GetMetric()[0].GetSummary()
+	a.So(summary.GetSampleCount(), ShouldEqual, 2)
+	a.So(summary.GetSampleSum(), ShouldEqual, 4)
+	a.So(summary.GetQuantile(), ShouldHaveLength, len(metricQuantiles))
+	a.So(families[1].GetMetric()[0].GetLabel(), ShouldHaveLength, 3)
+
+	a.So(families[2].GetName(), ShouldEqual, "ttn_broker_meter_total")
+	a.So(families[2].GetMetric()[0].GetCounter().GetValue(), ShouldEqual, 3)
+}
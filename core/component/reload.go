@@ -0,0 +1,174 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/viper"
+)
+
+// ReloadFunc validates the settings of a component when the configuration is reloaded. It returns a function that
+// applies the settings, or an error if they are invalid.
+type ReloadFunc func() (apply func(), err error)
+
+type reloader struct {
+	keys   []string
+	reload ReloadFunc
+}
+
+var (
+	reloadLock     sync.Mutex
+	reloaders      []reloader
+	reloadSettings map[string]interface{}
+)
+
+// OnReload registers a function that applies the settings with the given configuration keys when the
+// configuration is reloaded. Changes to settings that are not registered are only applied after a restart.
+func (c *Component) OnReload(keys []string, reload ReloadFunc) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	if reloadSettings == nil {
+		reloadSettings = configSettings()
+	}
+	reloaders = append(reloaders, reloader{keys: keys, reload: reload})
+}
+
+// Reload re-reads the configuration file and applies the settings that can be changed at runtime to all
+// components in this process. The settings are only applied if all of them are valid. The changed settings are
+// logged.
+func Reload(ctx ttnlog.Interface) error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			return errors.Wrap(err, "Could not read configuration")
+		}
+	}
+
+	applies := make([]func(), 0, len(reloaders))
+	var applied []string
+	for _, reloader := range reloaders {
+		apply, err := reloader.reload()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Invalid configuration of %s", strings.Join(reloader.keys, ", ")))
+		}
+		applies = append(applies, apply)
+		applied = append(applied, reloader.keys...)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+
+	settings := configSettings()
+	changed := configChanges(reloadSettings, settings)
+	for _, key := range changed {
+		ctx := ctx.WithFields(ttnlog.Fields{
+			"Setting": key,
+			"Old":     configValue(key, reloadSettings[key]),
+			"New":     configValue(key, settings[key]),
+		})
+		if configKeyIn(key, applied) {
+			ctx.Info("Applied configuration change")
+		} else {
+			ctx.Warn("Configuration change requires a restart")
+		}
+	}
+	reloadSettings = settings
+
+	ctx.WithField("Changes", len(changed)).Info("Reloaded configuration")
+	return nil
+}
+
+// configSettings returns the current configuration, with nested keys joined by dots
+func configSettings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	for _, key := range viper.AllKeys() {
+		settings[key] = viper.Get(key)
+	}
+	return settings
+}
+
+// configChanges returns the sorted keys of the settings that are different in old and new
+func configChanges(old, new map[string]interface{}) (changed []string) {
+	for key, value := range new {
+		if !reflect.DeepEqual(old[key], value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return
+}
+
+// configKeyIn returns true if the key, or the setting that contains it, is one of the keys
+func configKeyIn(key string, keys []string) bool {
+	for _, k := range keys {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	return false
+}
+
+var credentialsInURL = regexp.MustCompile(`://[^/@\s]+@`)
+
+// configValue returns the value of a setting for logging, hiding secrets
+func configValue(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	for _, secret := range []string{"token", "password", "secret"} {
+		if strings.Contains(key, secret) {
+			return "<hidden>"
+		}
+	}
+	return credentialsInURL.ReplaceAllString(fmt.Sprint(value), "://<hidden>@")
+}
+
+// Configure applies the settings with the given configuration keys, and registers the function to apply them again
+// when the configuration is reloaded
+func (c *Component) Configure(keys []string, reload ReloadFunc) error {
+	apply, err := reload()
+	if err != nil {
+		return err
+	}
+	apply()
+	c.OnReload(keys, reload)
+	return nil
+}
+
+// ConfigureRate applies the rate limit with the given configuration key to the registry, and applies it again when
+// the configuration is reloaded. The rate limit is the number of requests per the given duration.
+func (c *Component) ConfigureRate(key string, registry *ratelimit.Registry, per time.Duration) {
+	err := c.Configure([]string{key}, func() (func(), error) {
+		if !viper.IsSet(key) {
+			return func() {}, nil
+		}
+		rate := viper.GetInt(key)
+		if rate <= 0 {
+			return nil, errors.NewErrInvalidArgument(key, "must be positive")
+		}
+		return func() {
+			registry.SetRate(rate, per)
+		}, nil
+	})
+	if err != nil {
+		c.Ctx.WithError(err).Warn("Invalid rate limit, using the default")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"sync"
+	"time"
+)
+
+// DrainInterval is the interval at which Drain checks whether there is still pending work
+var DrainInterval = 10 * time.Millisecond
+
+// ShutdownDeadline returns the time until which the component waits for in-flight messages when it shuts down
+func (c *Component) ShutdownDeadline() time.Time {
+	return time.Now().Add(c.ShutdownTimeout)
+}
+
+// Drain waits until pending returns zero, or until the deadline has passed. It returns the number of items that
+// were still pending, and will therefore be dropped.
+func Drain(deadline time.Time, pending func() int) int {
+	for {
+		n := pending()
+		if n == 0 || !time.Now().Before(deadline) {
+			return n
+		}
+		<-time.After(DrainInterval)
+	}
+}
+
+// InFlight keeps track of the messages that are being handled by a component, so that it can wait for them when
+// it shuts down. The zero value is ready to use.
+type InFlight struct {
+	mu     sync.Mutex
+	count  int
+	closed bool
+	idle   chan struct{}
+}
+
+// Add adds a message. It returns false if the component is shutting down and should not accept the message.
+func (f *InFlight) Add() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return false
+	}
+	f.count++
+	return true
+}
+
+// Done marks a message that was added as handled
+func (f *InFlight) Done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count--
+	if f.count == 0 && f.idle != nil {
+		close(f.idle)
+		f.idle = nil
+	}
+}
+
+// Count returns the number of messages that are being handled
+func (f *InFlight) Count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count
+}
+
+// Close stops accepting messages and waits until the messages that are being handled are done, or until the
+// deadline has passed. It returns the number of messages that were still being handled.
+func (f *InFlight) Close(deadline time.Time) int {
+	f.mu.Lock()
+	f.closed = true
+	if f.count == 0 {
+		f.mu.Unlock()
+		return 0
+	}
+	if f.idle == nil {
+		f.idle = make(chan struct{})
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+	case <-time.After(deadline.Sub(time.Now())):
+	}
+	return f.Count()
+}
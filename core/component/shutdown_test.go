@@ -0,0 +1,51 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+)
+
+func TestInFlight(t *testing.T) {
+	a := New(t)
+
+	var f InFlight
+	a.So(f.Close(time.Now().Add(10*time.Millisecond)), ShouldEqual, 0)
+	a.So(f.Add(), ShouldBeFalse)
+
+	f = InFlight{}
+	a.So(f.Add(), ShouldBeTrue)
+	a.So(f.Add(), ShouldBeTrue)
+	a.So(f.Count(), ShouldEqual, 2)
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		f.Done()
+		f.Done()
+	}()
+	a.So(f.Close(time.Now().Add(time.Second)), ShouldEqual, 0)
+
+	f = InFlight{}
+	a.So(f.Add(), ShouldBeTrue)
+	start := time.Now()
+	a.So(f.Close(time.Now().Add(20*time.Millisecond)), ShouldEqual, 1)
+	a.So(time.Now().Sub(start), ShouldBeGreaterThanOrEqualTo, 20*time.Millisecond)
+	f.Done()
+}
+
+func TestDrain(t *testing.T) {
+	a := New(t)
+
+	pending := 3
+	a.So(Drain(time.Now().Add(time.Second), func() int {
+		pending--
+		return pending
+	}), ShouldEqual, 0)
+
+	a.So(Drain(time.Now().Add(20*time.Millisecond), func() int {
+		return 1
+	}), ShouldEqual, 1)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"os"
+	"sync"
+
+	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/spf13/viper"
+)
+
+var traceExporterOnce sync.Once
+
+// TraceService returns the identity of the component in the events that it adds to traces
+func (c *Component) TraceService() trace.Service {
+	if c == nil || c.Identity == nil {
+		return trace.Service{}
+	}
+	return trace.Service{Name: c.Identity.ServiceName, ID: c.Identity.Id}
+}
+
+// initTraceExporter sets up the exporter for the traces of this process. Traces are exported for the whole
+// process, so the exporter is only set up by the first component.
+func (c *Component) initTraceExporter() (err error) {
+	traceExporterOnce.Do(func() {
+		err = c.setTraceExporter()
+	})
+	return
+}
+
+func (c *Component) setTraceExporter() error {
+	var exporters []trace.Exporter
+	switch file := viper.GetString("trace-file"); file {
+	case "":
+	case "-":
+		exporters = append(exporters, trace.NewWriterExporter(os.Stdout))
+	default:
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return err
+		}
+		exporters = append(exporters, trace.NewWriterExporter(f))
+	}
+	if collector := viper.GetString("trace-collector"); collector != "" {
+		exporters = append(exporters, trace.NewZipkinExporter(collector))
+	}
+	switch len(exporters) {
+	case 0:
+		return nil
+	case 1:
+		trace.SetExporter(c.Ctx, exporters[0])
+	default:
+		trace.SetExporter(c.Ctx, trace.NewMultiExporter(exporters...))
+	}
+	c.Ctx.Info("Exporting traces")
+	return nil
+}
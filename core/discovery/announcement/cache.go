@@ -103,6 +103,11 @@ func (s *cachedAnnouncementStore) GetForAppID(appID string) (*Announcement, erro
 	return s.backingStore.GetForAppID(appID)
 }
 
+func (s *cachedAnnouncementStore) GetAllForAppID(appID string) ([]*Announcement, error) {
+	// TODO: We're not using this function. Implement cache when we start using it.
+	return s.backingStore.GetAllForAppID(appID)
+}
+
 func (s *cachedAnnouncementStore) GetForAppEUI(appEUI types.AppEUI) (*Announcement, error) {
 	// TODO: We're not using this function. Implement cache when we start using it.
 	return s.backingStore.GetForAppEUI(appEUI)
@@ -118,9 +123,29 @@ func (s *cachedAnnouncementStore) Set(new *Announcement) error {
 }
 
 func (s *cachedAnnouncementStore) AddMetadata(serviceName, serviceID string, metadata ...Metadata) error {
+	// The AppIDs are moved from the services that announced them before, so their cache is invalidated as well
+	var affected []*Announcement
+	for _, meta := range metadata {
+		if meta, ok := meta.(AppIDMetadata); ok {
+			group, _ := s.backingStore.GetAllForAppID(meta.AppID)
+			affected = append(affected, group...)
+		}
+	}
 	if err := s.backingStore.AddMetadata(serviceName, serviceID, metadata...); err != nil {
 		return err
 	}
+	for _, announcement := range affected {
+		s.serviceCache.Remove(serviceCacheKey(announcement.ServiceName, announcement.ID))
+	}
+	s.serviceCache.Remove(serviceCacheKey(serviceName, serviceID))
+	s.listCache.Remove(&serviceName)
+	return nil
+}
+
+func (s *cachedAnnouncementStore) JoinMetadata(serviceName, serviceID string, metadata ...Metadata) error {
+	if err := s.backingStore.JoinMetadata(serviceName, serviceID, metadata...); err != nil {
+		return err
+	}
 	s.serviceCache.Remove(serviceCacheKey(serviceName, serviceID))
 	s.listCache.Remove(&serviceName)
 	return nil
@@ -22,9 +22,11 @@ type Store interface {
 	Get(serviceName, serviceID string) (*Announcement, error)
 	GetMetadata(serviceName, serviceID string) ([]Metadata, error)
 	GetForAppID(appID string) (*Announcement, error)
+	GetAllForAppID(appID string) ([]*Announcement, error)
 	GetForAppEUI(appEUI types.AppEUI) (*Announcement, error)
 	Set(new *Announcement) error
 	AddMetadata(serviceName, serviceID string, metadata ...Metadata) error
+	JoinMetadata(serviceName, serviceID string, metadata ...Metadata) error
 	RemoveMetadata(serviceName, serviceID string, metadata ...Metadata) error
 	Delete(serviceName, serviceID string) error
 }
@@ -33,8 +35,13 @@ const defaultRedisPrefix = "discovery"
 
 const redisAnnouncementPrefix = "announcement"
 const redisMetadataPrefix = "metadata"
-const redisAppIDPrefix = "app_id"
-const redisAppEUIPrefix = "app_eui"
+const redisAppIDPrefix = "app_id_group"
+const redisAppEUIPrefix = "app_eui_group"
+
+// Before Handler groups, AppIDs and AppEUIs were indexed with key/value pairs under these prefixes. They are migrated
+// to a Set when they are used.
+const redisLegacyAppIDPrefix = "app_id"
+const redisLegacyAppEUIPrefix = "app_eui"
 
 // NewRedisAnnouncementStore creates a new Redis-based Announcement store
 func NewRedisAnnouncementStore(client *redis.Client, prefix string) Store {
@@ -47,22 +54,64 @@ func NewRedisAnnouncementStore(client *redis.Client, prefix string) Store {
 		store.AddMigration(v, f)
 	}
 	return &RedisAnnouncementStore{
+		client:   client,
+		prefix:   prefix,
 		store:    store,
 		metadata: storage.NewRedisSetStore(client, prefix+":"+redisMetadataPrefix),
-		byAppID:  storage.NewRedisKVStore(client, prefix+":"+redisAppIDPrefix),
-		byAppEUI: storage.NewRedisKVStore(client, prefix+":"+redisAppEUIPrefix),
+		byAppID:  newIndex(client, prefix+":"+redisAppIDPrefix, prefix+":"+redisLegacyAppIDPrefix),
+		byAppEUI: newIndex(client, prefix+":"+redisAppEUIPrefix, prefix+":"+redisLegacyAppEUIPrefix),
 	}
 }
 
 // RedisAnnouncementStore stores Announcements in Redis.
 // - Announcements are stored as a Hash
 // - Metadata is stored in a Set
-// - AppIDs and AppEUIs are indexed with key/value pairs
+// - AppIDs and AppEUIs are indexed with a Set of the services that announce them
+//
+// An AppID or AppEUI is announced by one service, unless services explicitly join the group of services that
+// announce it with JoinMetadata.
 type RedisAnnouncementStore struct {
+	client   *redis.Client
+	prefix   string
 	store    *storage.RedisMapStore
 	metadata *storage.RedisSetStore
-	byAppID  *storage.RedisKVStore
-	byAppEUI *storage.RedisKVStore
+	byAppID  *index
+	byAppEUI *index
+}
+
+// index of the services that announce an AppID or AppEUI
+type index struct {
+	prefix string
+	set    *storage.RedisSetStore
+	legacy *storage.RedisKVStore
+}
+
+func newIndex(client *redis.Client, prefix, legacyPrefix string) *index {
+	return &index{
+		prefix: prefix + ":",
+		set:    storage.NewRedisSetStore(client, prefix),
+		legacy: storage.NewRedisKVStore(client, legacyPrefix),
+	}
+}
+
+// Get the services that announce the given AppID or AppEUI. An index that was written as a key/value pair is migrated
+// to a Set.
+func (i *index) Get(key string) ([]string, error) {
+	members, err := i.set.Get(key)
+	if errors.GetErrType(err) != errors.NotFound {
+		return members, err
+	}
+	member, legacyErr := i.legacy.Get(key)
+	if legacyErr != nil {
+		return nil, err
+	}
+	if err := i.set.Add(key, member); err != nil {
+		return nil, err
+	}
+	if err := i.legacy.Delete(key); err != nil {
+		return nil, err
+	}
+	return []string{member}, nil
 }
 
 // List all Announcements
@@ -137,23 +186,44 @@ func (s *RedisAnnouncementStore) GetMetadata(serviceName, serviceID string) ([]M
 	return out, nil
 }
 
-// GetForAppID returns the last Announcement that contains metadata for the given AppID
+// GetForAppID returns the first Announcement (ordered by ID) of the group that contains metadata for the given AppID
 func (s *RedisAnnouncementStore) GetForAppID(appID string) (*Announcement, error) {
-	key, err := s.byAppID.Get(appID)
+	keys, err := s.byAppID.Get(appID)
 	if err != nil {
 		return nil, err
 	}
-	service := strings.Split(key, ":")
+	service := strings.Split(keys[0], ":")
 	return s.Get(service[0], service[1])
 }
 
-// GetForAppEUI returns the last Announcement that contains metadata for the given AppEUI
+// GetAllForAppID returns the Announcements (ordered by ID) of the group that contains metadata for the given AppID
+func (s *RedisAnnouncementStore) GetAllForAppID(appID string) ([]*Announcement, error) {
+	keys, err := s.byAppID.Get(appID)
+	if err != nil {
+		return nil, err
+	}
+	announcements := make([]*Announcement, 0, len(keys))
+	for _, key := range keys {
+		service := strings.Split(key, ":")
+		announcement, err := s.Get(service[0], service[1])
+		if errors.GetErrType(err) == errors.NotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, announcement)
+	}
+	return announcements, nil
+}
+
+// GetForAppEUI returns the first Announcement (ordered by ID) of the group that contains metadata for the given AppEUI
 func (s *RedisAnnouncementStore) GetForAppEUI(appEUI types.AppEUI) (*Announcement, error) {
-	key, err := s.byAppEUI.Get(appEUI.String())
+	keys, err := s.byAppEUI.Get(appEUI.String())
 	if err != nil {
 		return nil, err
 	}
-	service := strings.Split(key, ":")
+	service := strings.Split(keys[0], ":")
 	return s.Get(service[0], service[1])
 }
 
@@ -174,77 +244,96 @@ func (s *RedisAnnouncementStore) Set(new *Announcement) error {
 	return nil
 }
 
-// AddMetadata adds metadata to the announcement of the specified service
+// AddMetadata adds metadata to the announcement of the specified service. AppIDs and AppEUIs are moved from the
+// services that announced them before in one transaction, so that they are never announced by two services.
 func (s *RedisAnnouncementStore) AddMetadata(serviceName, serviceID string, metadata ...Metadata) error {
-	key := fmt.Sprintf("%s:%s", serviceName, serviceID)
+	return s.addMetadata(serviceName, serviceID, true, metadata...)
+}
 
-	metadataStrings := make([]string, 0, len(metadata))
+// JoinMetadata adds metadata to the announcement of the specified service. Services that already announce the same
+// AppIDs or AppEUIs keep announcing them, and together form a group that handles the application.
+func (s *RedisAnnouncementStore) JoinMetadata(serviceName, serviceID string, metadata ...Metadata) error {
+	return s.addMetadata(serviceName, serviceID, false, metadata...)
+}
+
+func (s *RedisAnnouncementStore) addMetadata(serviceName, serviceID string, move bool, metadata ...Metadata) error {
+	key := fmt.Sprintf("%s:%s", serviceName, serviceID)
 	for _, meta := range metadata {
 		txt, err := meta.MarshalText()
 		if err != nil {
 			return err
 		}
-		metadataStrings = append(metadataStrings, string(txt))
 
+		var idx *index
+		var idxKey string
 		switch meta := meta.(type) {
 		case AppIDMetadata:
-			existing, err := s.byAppID.Get(meta.AppID)
-			switch {
-			case errors.GetErrType(err) == errors.NotFound:
-				if err := s.byAppID.Create(meta.AppID, key); err != nil {
-					return err
-				}
-			case err != nil:
+			idx, idxKey = s.byAppID, meta.AppID
+		case AppEUIMetadata:
+			idx, idxKey = s.byAppEUI, meta.AppEUI.String()
+		default:
+			if err := s.metadata.Add(key, string(txt)); err != nil {
 				return err
-			case existing == key:
-				continue
-			default:
-				go s.metadata.Remove(existing, string(txt))
-				if err := s.byAppID.Update(meta.AppID, key); err != nil {
-					return err
-				}
 			}
-		case AppEUIMetadata:
-			existing, err := s.byAppEUI.Get(meta.AppEUI.String())
-			switch {
-			case errors.GetErrType(err) == errors.NotFound:
-				if err := s.byAppEUI.Create(meta.AppEUI.String(), key); err != nil {
-					return err
-				}
-			case err != nil:
+			continue
+		}
+
+		// Migrate the index if it was written as a key/value pair
+		if _, err := idx.Get(idxKey); err != nil && errors.GetErrType(err) != errors.NotFound {
+			return err
+		}
+
+		// Watch the index, so that the AppID or AppEUI is not moved to another service at the same time
+		idxRedisKey := idx.prefix + idxKey
+		err = s.client.Watch(func(tx *redis.Tx) error {
+			members, err := tx.SMembers(idxRedisKey).Result()
+			if err != nil && err != redis.Nil {
 				return err
-			case existing == key:
-				continue
-			default:
-				go s.metadata.Remove(existing, string(txt))
-				if err := s.byAppEUI.Update(meta.AppEUI.String(), key); err != nil {
-					return err
-				}
 			}
+			_, err = tx.Pipelined(func(pipe *redis.Pipeline) error {
+				for _, member := range members {
+					if move && member != key {
+						pipe.SRem(s.metadataKey(member), string(txt))
+						pipe.SRem(idxRedisKey, member)
+					}
+				}
+				pipe.SAdd(s.metadataKey(key), string(txt))
+				pipe.SAdd(idxRedisKey, key)
+				return nil
+			})
+			return err
+		}, idxRedisKey)
+		if err != nil {
+			return err
 		}
 	}
-	err := s.metadata.Add(key, metadataStrings...)
-	if err != nil {
-		return err
-	}
 	return nil
 }
 
-// RemoveMetadata removes metadata from the announcement of the specified service
+func (s *RedisAnnouncementStore) metadataKey(key string) string {
+	return fmt.Sprintf("%s:%s:%s", s.prefix, redisMetadataPrefix, key)
+}
+
+// RemoveMetadata removes metadata from the announcement of the specified service. The service leaves the group of
+// the AppIDs and AppEUIs, the other services of the group keep announcing them.
 func (s *RedisAnnouncementStore) RemoveMetadata(serviceName, serviceID string, metadata ...Metadata) error {
+	key := fmt.Sprintf("%s:%s", serviceName, serviceID)
 	metadataStrings := make([]string, 0, len(metadata))
 	for _, meta := range metadata {
 		if txt, err := meta.MarshalText(); err == nil {
 			metadataStrings = append(metadataStrings, string(txt))
 		}
+		// Getting the index migrates it if it was written as a key/value pair
 		switch meta := meta.(type) {
 		case AppIDMetadata:
-			s.byAppID.Delete(meta.AppID)
+			s.byAppID.Get(meta.AppID)
+			s.byAppID.set.Remove(meta.AppID, key)
 		case AppEUIMetadata:
-			s.byAppEUI.Delete(meta.AppEUI.String())
+			s.byAppEUI.Get(meta.AppEUI.String())
+			s.byAppEUI.set.Remove(meta.AppEUI.String(), key)
 		}
 	}
-	err := s.metadata.Remove(fmt.Sprintf("%s:%s", serviceName, serviceID), metadataStrings...)
+	err := s.metadata.Remove(key, metadataStrings...)
 	if err != nil {
 		return err
 	}
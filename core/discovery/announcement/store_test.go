@@ -87,25 +87,35 @@ func TestRedisAnnouncementStore(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(metadata, ShouldHaveLength, 3)
 
-	err = s.AddMetadata("handler", "handler2",
+	// The AppEUI and AppID moved from handler1 to handler2
+	metadata, err = s.GetMetadata("handler", "handler1")
+	a.So(err, ShouldBeNil)
+	a.So(metadata, ShouldBeEmpty)
+
+	handler, err = s.GetForAppID("AppID")
+	a.So(err, ShouldBeNil)
+	a.So(handler.ID, ShouldEqual, "handler2")
+
+	// handler1 joins the group of handler2
+	err = s.JoinMetadata("handler", "handler1",
 		AppEUIMetadata{AppEUI: appEUI},
 		AppIDMetadata{AppID: "AppID"},
 	)
 	a.So(err, ShouldBeNil)
 
-	metadata, err = s.GetMetadata("handler", "handler2")
+	metadata, err = s.GetMetadata("handler", "handler1")
 	a.So(err, ShouldBeNil)
-	a.So(metadata, ShouldHaveLength, 3)
+	a.So(metadata, ShouldHaveLength, 2)
 
-	handler, err = s.GetForAppEUI(appEUI)
+	group, err := s.GetAllForAppID("AppID")
 	a.So(err, ShouldBeNil)
-	a.So(handler, ShouldNotBeNil)
-	a.So(handler.ID, ShouldEqual, "handler2")
+	a.So(group, ShouldHaveLength, 2)
+	a.So(group[0].ID, ShouldEqual, "handler1")
+	a.So(group[1].ID, ShouldEqual, "handler2")
 
-	handler, err = s.GetForAppID("AppID")
+	handler, err = s.GetForAppEUI(appEUI)
 	a.So(err, ShouldBeNil)
-	a.So(handler, ShouldNotBeNil)
-	a.So(handler.ID, ShouldEqual, "handler2")
+	a.So(handler.ID, ShouldEqual, "handler1")
 
 	err = s.RemoveMetadata("handler", "handler1",
 		AppEUIMetadata{AppEUI: appEUI},
@@ -113,12 +123,25 @@ func TestRedisAnnouncementStore(t *testing.T) {
 	)
 	a.So(err, ShouldBeNil)
 
+	// handler1 left the group, handler2 still announces the AppEUI and AppID
+	handler, err = s.GetForAppEUI(appEUI)
+	a.So(err, ShouldBeNil)
+	a.So(handler.ID, ShouldEqual, "handler2")
+
+	group, err = s.GetAllForAppID("AppID")
+	a.So(err, ShouldBeNil)
+	a.So(group, ShouldHaveLength, 1)
+	a.So(group[0].ID, ShouldEqual, "handler2")
+
 	err = s.RemoveMetadata("handler", "handler2",
 		AppEUIMetadata{AppEUI: appEUI},
 		AppIDMetadata{AppID: "AppID"},
 	)
 	a.So(err, ShouldBeNil)
 
+	_, err = s.GetForAppID("AppID")
+	a.So(err, ShouldNotBeNil)
+
 	// List
 	announcements, err := s.List(nil)
 	a.So(err, ShouldBeNil)
@@ -142,3 +165,38 @@ func TestRedisAnnouncementStore(t *testing.T) {
 	err = s.Delete("handler", "handler2")
 	a.So(err, ShouldBeNil)
 }
+
+func TestRedisAnnouncementStoreLegacyIndex(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	s := NewRedisAnnouncementStore(client, "discovery-test-legacy-index")
+
+	err := s.Set(&Announcement{
+		ServiceName: "handler",
+		ID:          "handler1",
+	})
+	a.So(err, ShouldBeNil)
+
+	defer func() {
+		s.Delete("handler", "handler1")
+		client.Del("discovery-test-legacy-index:app_id_group:LegacyAppID")
+	}()
+
+	// An AppID that was indexed before Handler groups
+	client.Set("discovery-test-legacy-index:app_id:LegacyAppID", "handler:handler1", 0)
+	defer client.Del("discovery-test-legacy-index:app_id:LegacyAppID")
+
+	handler, err := s.GetForAppID("LegacyAppID")
+	a.So(err, ShouldBeNil)
+	a.So(handler.ID, ShouldEqual, "handler1")
+
+	// The index was migrated to a Set
+	exists, err := client.Exists("discovery-test-legacy-index:app_id:LegacyAppID").Result()
+	a.So(err, ShouldBeNil)
+	a.So(exists, ShouldBeFalse)
+
+	members, err := client.SMembers("discovery-test-legacy-index:app_id_group:LegacyAppID").Result()
+	a.So(err, ShouldBeNil)
+	a.So(members, ShouldResemble, []string{"handler:handler1"})
+}
@@ -23,6 +23,7 @@ type Discovery interface {
 	Get(serviceName string, id string) (*pb.Announcement, error)
 	AddMetadata(serviceName string, id string, metadata *pb.Metadata) error
 	DeleteMetadata(serviceName string, id string, metadata *pb.Metadata) error
+	JoinMetadata(serviceName string, id string, metadata *pb.Metadata) error
 }
 
 // discovery is a reference implementation for a TTN Service Discovery component.
@@ -30,6 +31,9 @@ type discovery struct {
 	*component.Component
 	services          announcement.Store
 	masterAuthServers map[string]struct{}
+	status            *status
+	collector         *collector
+	inFlight          component.InFlight
 }
 
 func (d *discovery) WithCache(options announcement.CacheOptions) {
@@ -49,6 +53,9 @@ func (d *discovery) IsMasterAuthServer(serverID string) bool {
 
 func (d *discovery) Init(c *component.Component) error {
 	d.Component = c
+	d.InitStatus()
+	d.collector = newCollector(d)
+	d.Component.RegisterMetrics(d.collector)
 	err := d.Component.UpdateTokenKey()
 	if err != nil {
 		return err
@@ -57,9 +64,32 @@ func (d *discovery) Init(c *component.Component) error {
 	return nil
 }
 
-func (d *discovery) Shutdown() {}
+// Shutdown stops accepting announcements and changes to metadata, waits for the ones that are being stored, and
+// unregisters the metrics of the discovery server
+func (d *discovery) Shutdown() {
+	if dropped := d.inFlight.Close(d.ShutdownDeadline()); dropped > 0 {
+		d.Ctx.WithField("Changes", dropped).Warn("Dropped announcements or metadata changes that were still being stored")
+	}
+	if d.collector != nil {
+		d.Component.UnregisterMetrics(d.collector)
+	}
+}
+
+// startChange adds an announcement or metadata change to the in-flight changes. It returns an error if the
+// discovery server is shutting down.
+func (d *discovery) startChange() error {
+	if !d.inFlight.Add() {
+		return errors.NewErrInternal("Discovery server is shutting down")
+	}
+	return nil
+}
 
 func (d *discovery) Announce(in *pb.Announcement) error {
+	d.status.announcement()
+	if err := d.startChange(); err != nil {
+		return err
+	}
+	defer d.inFlight.Done()
 	service, err := d.services.Get(in.ServiceName, in.Id)
 	if err != nil && errors.GetErrType(err) != errors.NotFound {
 		return err
@@ -88,6 +118,7 @@ func (d *discovery) Announce(in *pb.Announcement) error {
 
 func (d *discovery) Get(serviceName string, id string) (*pb.Announcement, error) {
 	service, err := d.services.Get(serviceName, id)
+	d.status.lookup(err)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +130,7 @@ func (d *discovery) GetAll(serviceName string, limit, offset uint64) ([]*pb.Anno
 		Limit:  limit,
 		Offset: offset,
 	})
+	d.status.lookup(err)
 	if err != nil {
 		return nil, err
 	}
@@ -113,15 +145,35 @@ func (d *discovery) GetAll(serviceName string, limit, offset uint64) ([]*pb.Anno
 }
 
 func (d *discovery) AddMetadata(serviceName string, id string, in *pb.Metadata) error {
+	d.status.metadataChange()
+	if err := d.startChange(); err != nil {
+		return err
+	}
+	defer d.inFlight.Done()
 	meta := announcement.MetadataFromProto(in)
 	return d.services.AddMetadata(serviceName, id, meta)
 }
 
 func (d *discovery) DeleteMetadata(serviceName string, id string, in *pb.Metadata) error {
+	d.status.metadataChange()
+	if err := d.startChange(); err != nil {
+		return err
+	}
+	defer d.inFlight.Done()
 	meta := announcement.MetadataFromProto(in)
 	return d.services.RemoveMetadata(serviceName, id, meta)
 }
 
+func (d *discovery) JoinMetadata(serviceName string, id string, in *pb.Metadata) error {
+	d.status.metadataChange()
+	if err := d.startChange(); err != nil {
+		return err
+	}
+	defer d.inFlight.Done()
+	meta := announcement.MetadataFromProto(in)
+	return d.services.JoinMetadata(serviceName, id, meta)
+}
+
 // NewRedisDiscovery creates a new Redis-based discovery service
 func NewRedisDiscovery(client *redis.Client) Discovery {
 	return &discovery{
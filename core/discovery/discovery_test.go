@@ -171,3 +171,54 @@ func TestDiscoveryMetadata(t *testing.T) {
 	a.So(service.Metadata, ShouldHaveLength, 0)
 
 }
+
+func TestDiscoveryHandlerGroup(t *testing.T) {
+	a := New(t)
+
+	client := getRedisClient(1)
+	d := NewRedisDiscovery(client)
+	defer func() {
+		client.Del("discovery:announcement:handler:handler-group-1")
+		client.Del("discovery:announcement:handler:handler-group-2")
+		client.Del("discovery:metadata:handler:handler-group-1")
+		client.Del("discovery:metadata:handler:handler-group-2")
+		client.Del("discovery:app_id_group:app-id-group")
+	}()
+
+	d.Announce(&pb.Announcement{ServiceName: "handler", Id: "handler-group-1"})
+	d.Announce(&pb.Announcement{ServiceName: "handler", Id: "handler-group-2"})
+
+	appID := &pb.Metadata{Metadata: &pb.Metadata_AppId{AppId: "app-id-group"}}
+
+	// The second Handler joins the group of the first
+	err := d.AddMetadata("handler", "handler-group-1", appID)
+	a.So(err, ShouldBeNil)
+	err = d.JoinMetadata("handler", "handler-group-2", appID)
+	a.So(err, ShouldBeNil)
+
+	handlers, err := d.GetAll("handler", 0, 0)
+	a.So(err, ShouldBeNil)
+	var group []string
+	for _, handler := range handlers {
+		for _, handlerAppID := range handler.AppIDs() {
+			if handlerAppID == "app-id-group" {
+				group = append(group, handler.Id)
+			}
+		}
+	}
+	a.So(group, ShouldHaveLength, 2)
+	a.So(group, ShouldContain, "handler-group-1")
+	a.So(group, ShouldContain, "handler-group-2")
+
+	// One Handler leaves the group
+	err = d.DeleteMetadata("handler", "handler-group-1", appID)
+	a.So(err, ShouldBeNil)
+
+	service, err := d.Get("handler", "handler-group-1")
+	a.So(err, ShouldBeNil)
+	a.So(service.Metadata, ShouldBeEmpty)
+
+	service, err = d.Get("handler", "handler-group-2")
+	a.So(err, ShouldBeNil)
+	a.So(service.AppIDs(), ShouldResemble, []string{"app-id-group"})
+}
@@ -0,0 +1,49 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package discovery
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exposes the status of the discovery server as Prometheus metrics
+type collector struct {
+	discovery *discovery
+
+	announcements   *prometheus.Desc
+	metadataChanges *prometheus.Desc
+	lookups         *prometheus.Desc
+	lookupErrors    *prometheus.Desc
+}
+
+func newCollector(d *discovery) *collector {
+	return &collector{
+		discovery:       d,
+		announcements:   d.Component.NewMetricDesc("announcements_total", "Total number of announcements of services"),
+		metadataChanges: d.Component.NewMetricDesc("metadata_changes_total", "Total number of added, joined and deleted metadata of services"),
+		lookups:         d.Component.NewMetricDesc("lookups_total", "Total number of lookups of announcements"),
+		lookupErrors:    d.Component.NewMetricDesc("lookup_errors_total", "Total number of lookups of announcements that failed or found nothing"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.announcements
+	ch <- c.metadataChanges
+	ch <- c.lookups
+	ch <- c.lookupErrors
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	status := c.discovery.status
+	if status == nil {
+		return
+	}
+	ch <- component.MeterMetric(c.announcements, status.announcements)
+	ch <- component.MeterMetric(c.metadataChanges, status.metadataChanges)
+	ch <- component.MeterMetric(c.lookups, status.lookups)
+	ch <- component.MeterMetric(c.lookupErrors, status.lookupErrors)
+}
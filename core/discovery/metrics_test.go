@@ -0,0 +1,67 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package discovery
+
+import (
+	"testing"
+
+	pb "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestMetrics(t *testing.T) {
+	a := New(t)
+	d := &discovery{
+		Component: &component.Component{
+			Identity: &pb.Announcement{Id: "test", ServiceName: "discovery"},
+		},
+	}
+	d.InitStatus()
+	d.status.announcement()
+	d.status.metadataChange()
+	d.status.lookup(nil)
+	d.status.lookup(errors.NewErrNotFound("test"))
+
+	registry := prometheus.NewRegistry()
+	a.So(registry.Register(newCollector(d)), ShouldBeNil)
+
+	families, err := registry.Gather()
+	a.So(err, ShouldBeNil)
+	values := make(map[string]float64)
+	for _, family := range families {
+		if metric := family.GetMetric()[0]; metric.GetCounter() != nil {
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		}
+	}
+	a.So(values["ttn_discovery_announcements_total"], ShouldEqual, 1)
+	a.So(values["ttn_discovery_metadata_changes_total"], ShouldEqual, 1)
+	a.So(values["ttn_discovery_lookups_total"], ShouldEqual, 2)
+	a.So(values["ttn_discovery_lookup_errors_total"], ShouldEqual, 1)
+}
+
+func TestShutdown(t *testing.T) {
+	a := New(t)
+	d := &discovery{
+		Component: &component.Component{
+			Identity: &pb.Announcement{Id: "test", ServiceName: "discovery"},
+		},
+	}
+	d.InitStatus()
+	d.collector = newCollector(d)
+	d.Component.RegisterMetrics(d.collector)
+
+	d.Shutdown()
+
+	// The discovery server no longer accepts changes
+	a.So(d.Announce(&pb.Announcement{ServiceName: "broker", Id: "broker1"}), ShouldNotBeNil)
+	a.So(d.AddMetadata("broker", "broker1", &pb.Metadata{}), ShouldNotBeNil)
+
+	// The metrics were unregistered, so they can be registered again
+	collector := newCollector(d)
+	a.So(prometheus.Register(collector), ShouldBeNil)
+	prometheus.Unregister(collector)
+}
@@ -6,9 +6,12 @@ package discovery
 import (
 	"fmt"
 
+	"github.com/TheThingsNetwork/go-account-lib/claims"
 	"github.com/TheThingsNetwork/go-account-lib/rights"
 	"github.com/TheThingsNetwork/ttn/api"
 	pb "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/core/audit"
+	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
@@ -23,10 +26,10 @@ func errPermissionDeniedf(format string, args ...interface{}) error {
 	return errors.NewErrPermissionDenied(fmt.Sprintf("Discovery:"+format, args...))
 }
 
-func (d *discoveryServer) checkMetadataEditRights(ctx context.Context, in *pb.MetadataRequest) error {
+func (d *discoveryServer) checkMetadataEditRights(ctx context.Context, in *pb.MetadataRequest) (*claims.Claims, error) {
 	claims, err := d.discovery.ValidateTTNAuthContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	appEUI := in.Metadata.GetAppEui()
@@ -34,17 +37,17 @@ func (d *discoveryServer) checkMetadataEditRights(ctx context.Context, in *pb.Me
 	prefix := in.Metadata.GetDevAddrPrefix()
 
 	if appEUI == nil && appID == "" && prefix == nil {
-		return errPermissionDeniedf("Unknown Metadata type")
+		return nil, errPermissionDeniedf("Unknown Metadata type")
 	}
 
 	// AppEUI and AppID can only be added to Handlers
 	if (appEUI != nil || appID != "") && in.ServiceName != "handler" {
-		return errPermissionDeniedf("Announcement service type should be \"handler\"")
+		return nil, errPermissionDeniedf("Announcement service type should be \"handler\"")
 	}
 
 	// DevAddrPrefix can only be added to Brokers
 	if prefix != nil && in.ServiceName != "broker" {
-		return errPermissionDeniedf("Announcement service type should be \"broker\"")
+		return nil, errPermissionDeniedf("Announcement service type should be \"broker\"")
 	}
 
 	// DevAddrPrefix and AppEUI are network level changes
@@ -55,33 +58,33 @@ func (d *discoveryServer) checkMetadataEditRights(ctx context.Context, in *pb.Me
 
 			// We require a signature from a master auth server
 			if !d.discovery.IsMasterAuthServer(claims.Issuer) {
-				return errPermissionDeniedf("Token issuer \"%s\" is not allowed to make changes to the network settings", claims.Issuer)
+				return nil, errPermissionDeniedf("Token issuer \"%s\" is not allowed to make changes to the network settings", claims.Issuer)
 			}
 
 			// TODO: Check if claims allow DevAddrPrefix to be announced
 
 			// AppEUI can not be announced yet
 			if appEUI != nil {
-				return errPermissionDeniedf("Can not announce AppEUIs at this time")
+				return nil, errPermissionDeniedf("Can not announce AppEUIs at this time")
 			}
 		}
 
 		// Can only be announced to "self"
 		if claims.Type != in.ServiceName {
-			return errPermissionDeniedf("Token type %s does not correspond with announcement service type %s", claims.Type, in.ServiceName)
+			return nil, errPermissionDeniedf("Token type %s does not correspond with announcement service type %s", claims.Type, in.ServiceName)
 		}
 		if claims.Subject != in.Id {
-			return errPermissionDeniedf("Token subject %s does not correspond with announcement id %s", claims.Subject, in.Id)
+			return nil, errPermissionDeniedf("Token subject %s does not correspond with announcement id %s", claims.Subject, in.Id)
 		}
 	}
 
 	// Check claims for AppID
 	if appID != "" {
 		if !claims.AppRight(appID, rights.AppDelete) {
-			return errPermissionDeniedf(`No "%s" rights to Application "%s"`, rights.AppDelete, appID)
+			return nil, errPermissionDeniedf(`No "%s" rights to Application "%s"`, rights.AppDelete, appID)
 		}
 	}
-	return nil
+	return claims, nil
 }
 
 func (d *discoveryServer) Announce(ctx context.Context, announcement *pb.Announcement) (*empty.Empty, error) {
@@ -117,8 +120,34 @@ func (d *discoveryServer) Announce(ctx context.Context, announcement *pb.Announc
 	return &empty.Empty{}, nil
 }
 
+// auditMetadata returns the audit log entry for a change to the metadata of a component
+func auditMetadata(action audit.Action, in *pb.MetadataRequest) *audit.Entry {
+	entry := &audit.Entry{
+		Action:  action,
+		Target:  fmt.Sprintf("%s/%s", in.ServiceName, in.Id),
+		Changed: make(map[string]string),
+	}
+	if appID := in.Metadata.GetAppId(); appID != "" {
+		entry.AppID = appID
+		entry.Changed["AppID"] = appID
+	}
+	if euiBytes := in.Metadata.GetAppEui(); euiBytes != nil {
+		var eui types.AppEUI
+		if err := eui.Unmarshal(euiBytes); err == nil {
+			entry.Changed["AppEUI"] = eui.String()
+		}
+	}
+	if prefixBytes := in.Metadata.GetDevAddrPrefix(); prefixBytes != nil {
+		var prefix types.DevAddrPrefix
+		if err := prefix.Unmarshal(prefixBytes); err == nil {
+			entry.Changed["DevAddrPrefix"] = prefix.String()
+		}
+	}
+	return entry
+}
+
 func (d *discoveryServer) AddMetadata(ctx context.Context, in *pb.MetadataRequest) (*empty.Empty, error) {
-	err := d.checkMetadataEditRights(ctx, in)
+	claims, err := d.checkMetadataEditRights(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -126,11 +155,12 @@ func (d *discoveryServer) AddMetadata(ctx context.Context, in *pb.MetadataReques
 	if err != nil {
 		return nil, err
 	}
+	d.discovery.RecordAudit(claims, auditMetadata(audit.AddMetadata, in))
 	return &empty.Empty{}, nil
 }
 
 func (d *discoveryServer) DeleteMetadata(ctx context.Context, in *pb.MetadataRequest) (*empty.Empty, error) {
-	err := d.checkMetadataEditRights(ctx, in)
+	claims, err := d.checkMetadataEditRights(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +168,24 @@ func (d *discoveryServer) DeleteMetadata(ctx context.Context, in *pb.MetadataReq
 	if err != nil {
 		return nil, err
 	}
+	d.discovery.RecordAudit(claims, auditMetadata(audit.DeleteMetadata, in))
+	return &empty.Empty{}, nil
+}
+
+func (d *discoveryServer) JoinMetadata(ctx context.Context, in *pb.MetadataRequest) (*empty.Empty, error) {
+	claims, err := d.checkMetadataEditRights(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	// Handlers only form groups for AppIDs
+	if in.Metadata.GetAppId() == "" {
+		return nil, errPermissionDeniedf("Only AppID Metadata can be joined")
+	}
+	err = d.discovery.JoinMetadata(in.ServiceName, in.Id, in.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	d.discovery.RecordAudit(claims, auditMetadata(audit.JoinMetadata, in))
 	return &empty.Empty{}, nil
 }
 
@@ -77,3 +77,7 @@ func (d *mockDiscoveryServer) DeleteMetadata(ctx context.Context, in *pb.Metadat
 	<-time.After(5 * time.Millisecond)
 	return &empty.Empty{}, nil
 }
+func (d *mockDiscoveryServer) JoinMetadata(ctx context.Context, in *pb.MetadataRequest) (*empty.Empty, error) {
+	<-time.After(5 * time.Millisecond)
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,49 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package discovery
+
+import "github.com/rcrowley/go-metrics"
+
+type status struct {
+	announcements   metrics.Meter
+	metadataChanges metrics.Meter
+	lookups         metrics.Meter
+	lookupErrors    metrics.Meter
+}
+
+func (d *discovery) InitStatus() {
+	d.status = &status{
+		announcements:   metrics.NewMeter(),
+		metadataChanges: metrics.NewMeter(),
+		lookups:         metrics.NewMeter(),
+		lookupErrors:    metrics.NewMeter(),
+	}
+}
+
+// announcement records an announcement of a service
+func (s *status) announcement() {
+	if s == nil {
+		return
+	}
+	s.announcements.Mark(1)
+}
+
+// metadataChange records a change of the metadata of a service
+func (s *status) metadataChange() {
+	if s == nil {
+		return
+	}
+	s.metadataChanges.Mark(1)
+}
+
+// lookup records a lookup of one or more announcements
+func (s *status) lookup(err error) {
+	if s == nil {
+		return
+	}
+	s.lookups.Mark(1)
+	if err != nil {
+		s.lookupErrors.Mark(1)
+	}
+}
@@ -74,26 +74,42 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	appID, devID := activation.AppId, activation.DevId
 	ctx := h.Ctx.WithFields(fields.Get(activation))
 	start := time.Now()
+	var reason string
+	var retryAfter time.Time
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			data := types.ActivationEventData{
+				AppEUI:         *activation.AppEui,
+				DevEUI:         *activation.DevEui,
+				ErrorEventData: types.ErrorEventData{Error: err.Error()},
+				Reason:         reason,
+			}
+			if !retryAfter.IsZero() {
+				data.RetryAfter = &retryAfter
+			}
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.ActivationErrorEvent,
-				Data: types.ActivationEventData{
-					AppEUI:         *activation.AppEui,
-					DevEUI:         *activation.DevEui,
-					ErrorEventData: types.ErrorEventData{Error: err.Error()},
-				},
+				Data:  data,
+			})
+			if reason != "" {
+				ctx = ctx.WithField("Reason", reason)
 			}
 			ctx.WithError(err).Warn("Could not handle activation")
 		} else {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled activation")
 		}
+		trace.Export(h.TraceService(), activation.Trace)
 	}()
 	h.status.activations.Mark(1)
 
-	activation.Trace = activation.Trace.WithEvent(trace.ReceiveEvent)
+	if !h.inFlight.Add() {
+		return nil, errors.NewErrInternal("Handler is shutting down")
+	}
+	defer h.inFlight.Done()
+
+	activation.Trace = activation.Trace.WithEvent(h.TraceService(), trace.ReceiveEvent)
 
 	if activation.ResponseTemplate == nil {
 		err = errors.NewErrInvalidArgument("Activation", "No gateways available for downlink")
@@ -107,6 +123,11 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 		return nil, err
 	}
 
+	// Devices of an application that is exported to another Handler join there
+	if err = h.checkApplicationFrozen(appID); err != nil {
+		return nil, err
+	}
+
 	if dev.AppKey.IsEmpty() {
 		err = errors.NewErrNotFound(fmt.Sprintf("AppKey for device %s", devID))
 		return nil, err
@@ -130,27 +151,25 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	}
 
 	// Validate MIC
-	activation.Trace = activation.Trace.WithEvent(trace.CheckMICEvent)
+	activation.Trace = activation.Trace.WithEvent(h.TraceService(), trace.CheckMICEvent)
 	if ok, err = reqPHY.ValidateMIC(lorawan.AES128Key(dev.AppKey)); err != nil || !ok {
 		err = errors.NewErrNotFound("MIC does not match device")
 		return nil, err
 	}
 
 	// Validate DevNonce
-	var alreadyUsed bool
-	for _, usedNonce := range dev.UsedDevNonces {
-		if usedNonce == device.DevNonce(reqMAC.DevNonce) {
-			alreadyUsed = true
-			break
-		}
+	if reason, err = h.joinLimits.checkDevNonce(dev, device.DevNonce(reqMAC.DevNonce)); err != nil {
+		return nil, err
 	}
-	if alreadyUsed {
-		err = errors.NewErrInvalidArgument("Activation DevNonce", "already used")
+
+	// Check join rate
+	if retryAfter, err = h.joinLimits.checkJoinRate(dev, start); err != nil {
+		reason = JoinRejectedRateLimited
 		return nil, err
 	}
 
 	ctx.Debug("Accepting Join Request")
-	activation.Trace = activation.Trace.WithEvent(trace.AcceptEvent)
+	activation.Trace = activation.Trace.WithEvent(h.TraceService(), trace.AcceptEvent)
 
 	// Prepare Device Activation Response
 	var resPHY lorawan.PHYPayload
@@ -170,7 +189,7 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 
 	// Publish Activation
 	mqttMetadata, _ := h.getActivationMetadata(ctx, activation, dev)
-	h.mqttEvent <- &types.DeviceEvent{
+	h.publishEvent(&types.DeviceEvent{
 		AppID: appID,
 		DevID: devID,
 		Event: types.ActivationEvent,
@@ -180,14 +199,14 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 			DevAddr:  types.DevAddr(joinAccept.DevAddr),
 			Metadata: mqttMetadata,
 		},
-	}
+	})
 
 	// Generate random AppNonce
 	var appNonce device.AppNonce
 	for {
 		// NOTE: As DevNonces are only 2 bytes, we will start rejecting those before we run out of AppNonces.
 		// It might just take some time to get one we didn't use yet...
-		alreadyUsed = false
+		alreadyUsed := false
 		random.FillBytes(appNonce[:])
 		for _, usedNonce := range dev.UsedAppNonces {
 			if usedNonce == appNonce {
@@ -212,8 +231,9 @@ func (h *handler) HandleActivation(activation *pb_broker.DeduplicatedDeviceActiv
 	dev.DevAddr = types.DevAddr(joinAccept.DevAddr)
 	dev.AppSKey = appSKey
 	dev.NwkSKey = nwkSKey
-	dev.UsedAppNonces = append(dev.UsedAppNonces, appNonce)
-	dev.UsedDevNonces = append(dev.UsedDevNonces, reqMAC.DevNonce)
+	h.joinLimits.useAppNonce(dev, appNonce)
+	h.joinLimits.useDevNonce(dev, reqMAC.DevNonce)
+	h.joinLimits.join(dev, start)
 	err = h.devices.Set(dev)
 	if err != nil {
 		return nil, err
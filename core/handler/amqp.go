@@ -9,6 +9,9 @@ import (
 	"github.com/TheThingsNetwork/ttn/core/types"
 )
 
+// AMQPBufferSize indicates the size for the event channel buffer
+var AMQPBufferSize = 10
+
 func (h *handler) assertAMQPExchange() error {
 	ch, err := h.amqpClient.(*amqp.DefaultClient).GetChannel()
 	if err != nil {
@@ -49,6 +52,7 @@ func (h *handler) HandleAMQP(username, password, host, exchange, downlinkQueue s
 	}
 
 	h.amqpUp = make(chan *types.UplinkMessage)
+	h.amqpEvent = make(chan *types.DeviceEvent, AMQPBufferSize)
 
 	subscriber := h.amqpClient.NewSubscriber(h.amqpExchange, downlinkQueue, downlinkQueue != "", downlinkQueue == "")
 	err = subscriber.Open()
@@ -61,6 +65,10 @@ func (h *handler) HandleAMQP(username, password, host, exchange, downlinkQueue s
 		}
 	}()
 	err = subscriber.SubscribeDownlink(func(_ amqp.Subscriber, _, _ string, req types.DownlinkMessage) {
+		// Without a shared downlink queue, all Handlers of a group receive the downlink
+		if !h.claimDownlink(&req) {
+			return
+		}
 		h.EnqueueDownlink(&req)
 	})
 	if err != nil {
@@ -90,5 +98,32 @@ func (h *handler) HandleAMQP(username, password, host, exchange, downlinkQueue s
 		}
 	}()
 
+	go func() {
+		publisher := h.amqpClient.NewPublisher(h.amqpExchange)
+		err := publisher.Open()
+		if err != nil {
+			ctx.WithError(err).Error("Could not open publisher channel")
+			return
+		}
+		defer publisher.Close()
+
+		for event := range h.amqpEvent {
+			ctx.WithFields(ttnlog.Fields{
+				"DevID": event.DevID,
+				"AppID": event.AppID,
+				"Event": event.Event,
+			}).Debug("Publish Event")
+			var err error
+			if event.DevID == "" {
+				err = publisher.PublishAppEvent(event.AppID, event.Event, event.Data)
+			} else {
+				err = publisher.PublishDeviceEvent(event.AppID, event.DevID, event.Event, event.Data)
+			}
+			if err != nil {
+				ctx.WithError(err).Warn("Could not publish Event")
+			}
+		}
+	}()
+
 	return nil
 }
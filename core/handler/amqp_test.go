@@ -10,6 +10,7 @@ import (
 
 	"github.com/TheThingsNetwork/ttn/amqp"
 	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
@@ -32,8 +33,9 @@ func TestHandleAMQP(t *testing.T) {
 	appID := "handler-amqp-app1"
 	devID := "handler-amqp-dev1"
 	h := &handler{
-		Component: &component.Component{Ctx: GetLogger(t, "TestHandleAMQP")},
-		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-amqp"),
+		Component:    &component.Component{Ctx: GetLogger(t, "TestHandleAMQP")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-handle-amqp"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-handle-amqp"),
 	}
 	h.WithAMQP("guest", "guest", host, "amq.topic")
 	h.devices.Set(&device.Device{
@@ -84,4 +86,21 @@ func TestHandleAMQP(t *testing.T) {
 	}
 
 	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
+
+	wg.Add(1)
+	err = s.SubscribeDeviceEvents(appID, devID, "", func(_ amqp.Subscriber, r_appID string, r_devID string, eventType types.EventType, payload []byte) {
+		a.So(r_appID, ShouldEqual, appID)
+		a.So(r_devID, ShouldEqual, devID)
+		a.So(eventType, ShouldEqual, types.ActivationEvent)
+		wg.Done()
+	})
+	a.So(err, ShouldBeNil)
+
+	h.amqpEvent <- &types.DeviceEvent{
+		AppID: appID,
+		DevID: devID,
+		Event: types.ActivationEvent,
+	}
+
+	a.So(wg.WaitFor(200*time.Millisecond), ShouldBeNil)
 }
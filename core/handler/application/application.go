@@ -29,6 +29,14 @@ type Application struct {
 	// Encoder is a JavaScript function that encode the data send on Downlink messages
 	// Returns an object containing the converted values in []byte
 	Encoder string `redis:"encoder"`
+	// Engine is the engine that runs the payload functions of the application
+	Engine string `redis:"engine"`
+	// MigratedTo is the ID of the Handler that confirmed the import of the application
+	MigratedTo string `redis:"migrated_to"`
+	// ExportedTo is the ID of the Handler that the application is being exported to
+	ExportedTo string `redis:"exported_to"`
+	// ExportedAt is the time of the export to ExportedTo
+	ExportedAt time.Time `redis:"exported_at"`
 
 	CreatedAt time.Time `redis:"created_at"`
 	UpdatedAt time.Time `redis:"updated_at"`
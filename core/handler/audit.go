@@ -0,0 +1,71 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/rights"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/audit"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"golang.org/x/net/context"
+)
+
+func (h *handlerManager) GetAuditLog(ctx context.Context, in *pb.AuditLogRequest) (*pb.AuditLog, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Audit Log Request")
+	}
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAppRights(claims, in.AppId, rights.AppSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := audit.Filter{
+		AppID: in.AppId,
+		DevID: in.DevId,
+		Limit: int(in.Limit),
+	}
+	if in.Since > 0 {
+		filter.Since = time.Unix(0, in.Since)
+	}
+	entries, err := audit.Query(filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not query audit log")
+	}
+
+	res := &pb.AuditLog{Entries: make([]*pb.AuditLogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, auditLogEntry(entry))
+	}
+	return res, nil
+}
+
+func auditLogEntry(entry *audit.Entry) *pb.AuditLogEntry {
+	res := &pb.AuditLogEntry{
+		Time:        entry.Time.UnixNano(),
+		ServiceName: entry.ServiceName,
+		ServiceId:   entry.ServiceID,
+		Actor:       entry.Actor,
+		Issuer:      entry.Issuer,
+		Action:      string(entry.Action),
+		AppId:       entry.AppID,
+		DevId:       entry.DevID,
+		Target:      entry.Target,
+	}
+	names := make([]string, 0, len(entry.Changed))
+	for name := range entry.Changed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		res.Changed = append(res.Changed, &pb.AuditLogField{Name: name, Value: entry.Changed[name]})
+	}
+	return res
+}
@@ -0,0 +1,174 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"io"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	"github.com/TheThingsNetwork/go-account-lib/rights"
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"golang.org/x/net/context"
+)
+
+// exportBatchSize is about the number of devices that ExportDevices reads from the store at once
+const exportBatchSize = 100
+
+type deviceEUIs struct {
+	AppEUI types.AppEUI
+	DevEUI types.DevEUI
+}
+
+// bulkApplication is the state of an application in a BulkSetDevices stream. The caller is only authenticated and
+// rate limited once per application, and the EUIs of the devices of the application are only listed once.
+type bulkApplication struct {
+	ctx    context.Context
+	claims *claims.Claims
+	err    error
+
+	devIDs map[deviceEUIs]string // The DevID of the device that uses the EUIs
+	euis   map[string]deviceEUIs // The EUIs of each DevID
+}
+
+func (h *handlerManager) getBulkApplication(ctx context.Context, appID string) *bulkApplication {
+	app := &bulkApplication{
+		devIDs: make(map[deviceEUIs]string),
+		euis:   make(map[string]deviceEUIs),
+	}
+	app.ctx, app.claims, app.err = h.validateTTNAuthAppContext(ctx, appID)
+	if app.err != nil {
+		return app
+	}
+	if app.err = checkAppRights(app.claims, appID, rights.Devices); app.err != nil {
+		return app
+	}
+	if _, err := h.handler.applications.Get(appID); err != nil {
+		app.err = errors.Wrap(err, "Application not registered to this Handler")
+		return app
+	}
+	devices, err := h.handler.devices.ListForApp(appID, nil)
+	if err != nil {
+		app.err = err
+		return app
+	}
+	for _, dev := range devices {
+		if dev == nil {
+			continue
+		}
+		app.set(dev.DevID, deviceEUIs{dev.AppEUI, dev.DevEUI})
+	}
+	return app
+}
+
+func (app *bulkApplication) set(devID string, euis deviceEUIs) {
+	if old, ok := app.euis[devID]; ok {
+		delete(app.devIDs, old)
+	}
+	app.devIDs[euis] = devID
+	app.euis[devID] = euis
+}
+
+func (app *bulkApplication) euiInUse(appEUI types.AppEUI, devEUI types.DevEUI) (bool, error) {
+	_, inUse := app.devIDs[deviceEUIs{appEUI, devEUI}]
+	return inUse, nil
+}
+
+// BulkSetDevices sets the devices of the stream one by one. Every existing device costs a GetDevice round trip to the
+// NetworkServer to keep its frame counters and to detect drift, and every created or changed device costs a SetDevice
+// round trip. The duration of the stream is logged, so that the cost of large imports can be measured.
+func (h *handlerManager) BulkSetDevices(stream pb.ApplicationManager_BulkSetDevicesServer) error {
+	apps := make(map[string]*bulkApplication)
+	start := time.Now()
+	var created, updated, unchanged, failed int
+	for index := uint32(0); ; index++ {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			h.handler.Ctx.WithFields(ttnlog.Fields{
+				"Created":   created,
+				"Updated":   updated,
+				"Unchanged": unchanged,
+				"Failed":    failed,
+				"Duration":  time.Now().Sub(start),
+			}).Info("Bulk set devices")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res := &pb.BulkSetDevicesResult{Index: index, AppId: in.AppId, DevId: in.DevId}
+		eventType, err := h.bulkSetDevice(stream.Context(), apps, in)
+		switch {
+		case err != nil:
+			res.Error = err.Error()
+			failed++
+		case eventType == types.CreateEvent:
+			res.Created = true
+			created++
+		case eventType == "":
+			res.Unchanged = true
+			unchanged++
+		default:
+			updated++
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *handlerManager) bulkSetDevice(ctx context.Context, apps map[string]*bulkApplication, in *pb.Device) (types.EventType, error) {
+	if err := in.Validate(); err != nil {
+		return "", errors.Wrap(err, "Invalid Device")
+	}
+	app, ok := apps[in.AppId]
+	if !ok {
+		app = h.getBulkApplication(ctx, in.AppId)
+		apps[in.AppId] = app
+	}
+	if app.err != nil {
+		return "", app.err
+	}
+	eventType, err := h.setDevice(app.ctx, app.claims, in, app.euiInUse, true)
+	if err != nil {
+		return "", err
+	}
+	lorawan := in.GetLorawanDevice()
+	app.set(in.DevId, deviceEUIs{*lorawan.AppEui, *lorawan.DevEui})
+	return eventType, nil
+}
+
+func (h *handlerManager) ExportDevices(in *pb.ApplicationIdentifier, stream pb.ApplicationManager_ExportDevicesServer) error {
+	if err := in.Validate(); err != nil {
+		return errors.Wrap(err, "Invalid Application Identifier")
+	}
+	_, claims, err := h.validateTTNAuthAppContext(stream.Context(), in.AppId)
+	if err != nil {
+		return err
+	}
+	err = checkAppRights(claims, in.AppId, rights.Devices)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.handler.applications.Get(in.AppId); err != nil {
+		return errors.Wrap(err, "Application not registered to this Handler")
+	}
+
+	return h.handler.devices.RangeForApp(in.AppId, exportBatchSize, func(devices []*device.Device) error {
+		for _, dev := range devices {
+			if dev == nil {
+				continue
+			}
+			if err := stream.Send(deviceToProto(dev)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
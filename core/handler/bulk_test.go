@@ -0,0 +1,134 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	"github.com/golang/protobuf/ptypes/empty"
+	. "github.com/smartystreets/assertions"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// mockDeviceManager is a NetworkServer with a single device
+type mockDeviceManager struct {
+	pb_lorawan.DeviceManagerClient
+	dev  *pb_lorawan.Device
+	sets int
+}
+
+func (m *mockDeviceManager) GetDevice(ctx context.Context, in *pb_lorawan.DeviceIdentifier, opts ...grpc.CallOption) (*pb_lorawan.Device, error) {
+	if m.dev == nil {
+		return nil, errors.NewErrNotFound("Device")
+	}
+	dev := *m.dev
+	return &dev, nil
+}
+
+func (m *mockDeviceManager) SetDevice(ctx context.Context, in *pb_lorawan.Device, opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.sets++
+	dev := *in
+	m.dev = &dev
+	return &empty.Empty{}, nil
+}
+
+func TestBulkApplicationEUIs(t *testing.T) {
+	a := New(t)
+
+	app := &bulkApplication{
+		devIDs: make(map[deviceEUIs]string),
+		euis:   make(map[string]deviceEUIs),
+	}
+
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	devEUI := types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	otherDevEUI := types.DevEUI{8, 7, 6, 5, 4, 3, 2, 1}
+
+	inUse, _ := app.euiInUse(appEUI, devEUI)
+	a.So(inUse, ShouldBeFalse)
+
+	app.set("dev-1", deviceEUIs{appEUI, devEUI})
+	inUse, _ = app.euiInUse(appEUI, devEUI)
+	a.So(inUse, ShouldBeTrue)
+
+	// The EUIs of the device are changed
+	app.set("dev-1", deviceEUIs{appEUI, otherDevEUI})
+	inUse, _ = app.euiInUse(appEUI, devEUI)
+	a.So(inUse, ShouldBeFalse)
+	inUse, _ = app.euiInUse(appEUI, otherDevEUI)
+	a.So(inUse, ShouldBeTrue)
+}
+
+func TestBulkSetDeviceFCnt(t *testing.T) {
+	a := New(t)
+
+	ns := &mockDeviceManager{}
+	h := &handlerManager{
+		handler: &handler{
+			Component: &component.Component{Ctx: GetLogger(t, "TestBulkSetDeviceFCnt")},
+			devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-bulk-set-device-fcnt"),
+			mqttEvent: make(chan *types.DeviceEvent, 10),
+		},
+		deviceManager: ns,
+	}
+	defer h.handler.devices.Delete("app", "dev")
+
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	devEUI := types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	devAddr := types.DevAddr{1, 2, 3, 4}
+	row := func() *pb.Device {
+		return &pb.Device{AppId: "app", DevId: "dev", Device: &pb.Device_LorawanDevice{LorawanDevice: &pb_lorawan.Device{
+			AppId: "app", DevId: "dev", AppEui: &appEUI, DevEui: &devEUI, DevAddr: &devAddr,
+		}}}
+	}
+	notInUse := func(types.AppEUI, types.DevEUI) (bool, error) { return false, nil }
+
+	eventType, err := h.setDevice(context.Background(), nil, row(), notInUse, true)
+	a.So(err, ShouldBeNil)
+	a.So(eventType, ShouldEqual, types.CreateEvent)
+	a.So(ns.sets, ShouldEqual, 1)
+
+	// The device sends some frames
+	ns.dev.FCntUp, ns.dev.FCntDown = 42, 24
+
+	// The same row keeps the frame counters of the NetworkServer
+	eventType, err = h.setDevice(context.Background(), nil, row(), notInUse, true)
+	a.So(err, ShouldBeNil)
+	a.So(eventType, ShouldBeEmpty)
+	a.So(ns.sets, ShouldEqual, 1)
+
+	// A changed row also keeps them
+	changed := row()
+	changed.Description = "changed"
+	eventType, err = h.setDevice(context.Background(), nil, changed, notInUse, true)
+	a.So(err, ShouldBeNil)
+	a.So(eventType, ShouldEqual, types.UpdateEvent)
+	a.So(ns.dev.FCntUp, ShouldEqual, 42)
+	a.So(ns.dev.FCntDown, ShouldEqual, 24)
+
+	// The NetworkServer drifted from the Handler, an unchanged row repairs it
+	otherDevAddr := types.DevAddr{1, 2, 3, 5}
+	ns.dev.DevAddr = &otherDevAddr
+	eventType, err = h.setDevice(context.Background(), nil, changed, notInUse, true)
+	a.So(err, ShouldBeNil)
+	a.So(eventType, ShouldEqual, types.UpdateEvent)
+	a.So(*ns.dev.DevAddr, ShouldEqual, devAddr)
+	a.So(ns.dev.FCntUp, ShouldEqual, 42)
+
+	// A row that sets the frame counters resets them
+	reset := row()
+	reset.GetLorawanDevice().FCntUp = 1
+	eventType, err = h.setDevice(context.Background(), nil, reset, notInUse, true)
+	a.So(err, ShouldBeNil)
+	a.So(ns.dev.FCntUp, ShouldEqual, 1)
+	a.So(ns.dev.FCntDown, ShouldEqual, 0)
+}
@@ -4,7 +4,6 @@
 package handler
 
 import (
-	"fmt"
 	"reflect"
 	"time"
 
@@ -25,22 +24,27 @@ func (h *handler) ConvertFieldsUp(ctx ttnlog.Interface, _ *pb_broker.Deduplicate
 	}
 
 	functions := &UplinkFunctions{
+		AppID:     app.AppID,
+		Pool:      h.payloadFunctions,
+		Engine:    app.Engine,
 		Decoder:   app.Decoder,
 		Converter: app.Converter,
 		Validator: app.Validator,
 		Logger:    functions.Ignore,
 	}
 
+	start := time.Now()
 	fields, valid, err := functions.Process(appUp.PayloadRaw, appUp.FPort)
+	h.status.payloadFunction(app.AppID, time.Since(start), err)
 	if err != nil {
 
 		// Emit the error
-		h.mqttEvent <- &types.DeviceEvent{
+		h.publishEvent(&types.DeviceEvent{
 			AppID: appUp.AppID,
 			DevID: appUp.DevID,
 			Event: types.UplinkErrorEvent,
 			Data:  types.ErrorEventData{Error: err.Error()},
-		}
+		})
 
 		// Do not set fields if processing failed, but allow the handler to continue processing
 		// without payload functions
@@ -58,6 +62,9 @@ func (h *handler) ConvertFieldsUp(ctx ttnlog.Interface, _ *pb_broker.Deduplicate
 
 // UplinkFunctions decodes, converts and validates payload using JavaScript functions
 type UplinkFunctions struct {
+	// Engine is the engine that runs the functions. If it is empty, the
+	// functions.DefaultEngine is used
+	Engine string
 	// Decoder is a JavaScript function that accepts the payload as byte array and
 	// returns an object containing the decoded values
 	Decoder string
@@ -70,11 +77,27 @@ type UplinkFunctions struct {
 
 	// Logger is the logger that will be used to store logs
 	Logger functions.Logger
+
+	// AppID is the application that the functions belong to
+	AppID string
+	// Pool runs the compiled functions. If it is nil, the functions are run in a new VM
+	Pool *functions.Pool
 }
 
 // timeOut is the maximum allowed time a payload function is allowed to run
 var timeOut = 100 * time.Millisecond
 
+// runFunction runs the source of a payload function, followed by the call
+// expression, and returns the exported value
+func runFunction(pool *functions.Pool, engine, appID, name, source, call string, env map[string]interface{}, logger functions.Logger) (interface{}, error) {
+	if pool == nil {
+		limits := functions.DefaultLimits
+		limits.Timeout = timeOut
+		return functions.Run(engine, name, source, call, env, limits, logger)
+	}
+	return pool.Run(engine, appID, name, source, call, env, logger)
+}
+
 // Decode decodes the payload using the Decoder function into a map
 func (f *UplinkFunctions) Decode(payload []byte, port uint8) (map[string]interface{}, error) {
 	if f.Decoder == "" {
@@ -85,22 +108,12 @@ func (f *UplinkFunctions) Decode(payload []byte, port uint8) (map[string]interfa
 		"payload": payload,
 		"port":    port,
 	}
-	code := fmt.Sprintf(`
-		%s;
-		Decoder(payload.slice(0), port);
-	`, f.Decoder)
-
-	value, err := functions.RunCode("Decoder", code, env, timeOut, f.Logger)
+	value, err := runFunction(f.Pool, f.Engine, f.AppID, "Decoder", f.Decoder, "Decoder(payload.slice(0), port)", env, f.Logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if !value.IsObject() {
-		return nil, errors.NewErrInvalidArgument("Decoder", "does not return an object")
-	}
-
-	v, _ := value.Export()
-	m, ok := v.(map[string]interface{})
+	m, ok := value.(map[string]interface{})
 	if !ok {
 		return nil, errors.NewErrInvalidArgument("Decoder", "does not return an object")
 	}
@@ -120,22 +133,12 @@ func (f *UplinkFunctions) Convert(fields map[string]interface{}, port uint8) (ma
 		"port":   port,
 	}
 
-	code := fmt.Sprintf(`
-		%s;
-		Converter(fields, port)
-	`, f.Converter)
-
-	value, err := functions.RunCode("Converter", code, env, timeOut, f.Logger)
+	value, err := runFunction(f.Pool, f.Engine, f.AppID, "Converter", f.Converter, "Converter(fields, port)", env, f.Logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if !value.IsObject() {
-		return nil, errors.NewErrInvalidArgument("Converter", "does not return an object")
-	}
-
-	v, _ := value.Export()
-	m, ok := v.(map[string]interface{})
+	m, ok := value.(map[string]interface{})
 	if !ok {
 		return nil, errors.NewErrInvalidArgument("Converter", "does not return an object")
 	}
@@ -154,21 +157,17 @@ func (f *UplinkFunctions) Validate(fields map[string]interface{}, port uint8) (b
 		"fields": fields,
 		"port":   port,
 	}
-	code := fmt.Sprintf(`
-		%s;
-		Validator(fields, port)
-	`, f.Validator)
-
-	value, err := functions.RunCode("Validator", code, env, timeOut, f.Logger)
+	value, err := runFunction(f.Pool, f.Engine, f.AppID, "Validator", f.Validator, "Validator(fields, port)", env, f.Logger)
 	if err != nil {
 		return false, err
 	}
 
-	if !value.IsBoolean() {
+	valid, ok := value.(bool)
+	if !ok {
 		return false, errors.NewErrInvalidArgument("Validator", "does not return a boolean")
 	}
 
-	return value.ToBoolean()
+	return valid, nil
 }
 
 // Process decodes the specified payload, converts it and test the validity
@@ -189,12 +188,20 @@ func (f *UplinkFunctions) Process(payload []byte, port uint8) (map[string]interf
 
 // DownlinkFunctions encodes payload using JavaScript functions
 type DownlinkFunctions struct {
+	// Engine is the engine that runs the functions. If it is empty, the
+	// functions.DefaultEngine is used
+	Engine string
 	// Encoder is a JavaScript function that accepts the payload as JSON and
 	// returns an array of bytes
 	Encoder string
 
 	// Logger is the logger that will be used to store logs
 	Logger functions.Logger
+
+	// AppID is the application that the functions belong to
+	AppID string
+	// Pool runs the compiled functions. If it is nil, the functions are run in a new VM
+	Pool *functions.Pool
 }
 
 // Encode encodes the map into a byte slice using the encoder payload function
@@ -208,30 +215,24 @@ func (f *DownlinkFunctions) Encode(payload map[string]interface{}, port uint8) (
 		"payload": payload,
 		"port":    port,
 	}
-	code := fmt.Sprintf(`
-		%s;
-		Encoder(payload, port)
-	`, f.Encoder)
-
-	value, err := functions.RunCode("Encoder", code, env, timeOut, f.Logger)
+	value, err := runFunction(f.Pool, f.Engine, f.AppID, "Encoder", f.Encoder, "Encoder(payload, port)", env, f.Logger)
 	if err != nil {
 		return nil, err
 	}
 
-	if !value.IsObject() {
+	if value == nil {
 		return nil, errors.NewErrInvalidArgument("Encoder", "does not return an object")
 	}
 
-	v, err := value.Export()
-	if err != nil {
-		return nil, err
-	}
-
-	if reflect.TypeOf(v).Kind() != reflect.Slice {
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Slice:
+	case reflect.Map, reflect.Struct, reflect.Ptr:
 		return nil, errors.NewErrInvalidArgument("Encoder", "does not return an Array")
+	default:
+		return nil, errors.NewErrInvalidArgument("Encoder", "does not return an object")
 	}
 
-	s := reflect.ValueOf(v)
+	s := reflect.ValueOf(value)
 	l := s.Len()
 
 	res := make([]byte, l)
@@ -311,11 +312,16 @@ func (h *handler) ConvertFieldsDown(ctx ttnlog.Interface, appDown *types.Downlin
 	}
 
 	functions := &DownlinkFunctions{
+		AppID:   app.AppID,
+		Pool:    h.payloadFunctions,
+		Engine:  app.Engine,
 		Encoder: app.Encoder,
 		Logger:  functions.Ignore,
 	}
 
+	start := time.Now()
 	message, _, err := functions.Process(appDown.PayloadFields, appDown.FPort)
+	h.status.payloadFunction(app.AppID, time.Since(start), err)
 	if err != nil {
 		return err
 	}
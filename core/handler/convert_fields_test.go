@@ -9,8 +9,10 @@ import (
 	"time"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
@@ -469,3 +471,64 @@ func TestEncodeCharCode(t *testing.T) {
 
 	fmt.Println("VALUE", val)
 }
+
+func TestProcessGoja(t *testing.T) {
+	a := New(t)
+
+	uplink := &UplinkFunctions{
+		Engine: functions.Goja,
+		Decoder: `const Decoder = (bytes, port) => {
+	const [temperature, humidity] = new Uint8Array(bytes);
+	return { temperature, humidity, port };
+};`,
+		Converter: `const Converter = (data) => ({ ...data, temperature: data.temperature / 2 });`,
+		Validator: `const Validator = ({ humidity }) => humidity >= 0 && humidity <= 100;`,
+	}
+
+	data, valid, err := uplink.Process([]byte{40, 110}, 1)
+	a.So(err, ShouldBeNil)
+	a.So(valid, ShouldBeFalse)
+	a.So(data["temperature"], ShouldEqual, 20)
+	a.So(data["humidity"], ShouldEqual, 110)
+	a.So(data["port"], ShouldEqual, 1)
+
+	downlink := &DownlinkFunctions{
+		Engine:  functions.Goja,
+		Encoder: "const Encoder = (object) => Array.from(`${object.text}`, (char) => char.charCodeAt(0));",
+	}
+
+	payload, _, err := downlink.Process(map[string]interface{}{"text": "Hi"}, 1)
+	a.So(err, ShouldBeNil)
+	a.So(payload, ShouldResemble, []byte{72, 105})
+}
+
+func TestCompilePayloadFunctions(t *testing.T) {
+	a := New(t)
+
+	a.So(compilePayloadFunctions(&pb_handler.Application{
+		AppId:   "app",
+		Decoder: `function Decoder(bytes, port) { return {}; }`,
+	}), ShouldBeNil)
+
+	a.So(compilePayloadFunctions(&pb_handler.Application{
+		AppId:   "app",
+		Decoder: `const Decoder = (bytes, port) => ({});`,
+	}), ShouldNotBeNil)
+
+	a.So(compilePayloadFunctions(&pb_handler.Application{
+		AppId:   "app",
+		Engine:  functions.Goja,
+		Decoder: `const Decoder = (bytes, port) => ({});`,
+	}), ShouldBeNil)
+
+	a.So(compilePayloadFunctions(&pb_handler.Application{
+		AppId:   "app",
+		Engine:  functions.Goja,
+		Encoder: `this is not valid JavaScript`,
+	}), ShouldNotBeNil)
+
+	a.So(compilePayloadFunctions(&pb_handler.Application{
+		AppId:  "app",
+		Engine: "v8",
+	}), ShouldNotBeNil)
+}
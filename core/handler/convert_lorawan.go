@@ -33,7 +33,7 @@ func (h *handler) ConvertFromLoRaWAN(ctx ttnlog.Interface, ttnUp *pb_broker.Dedu
 
 	// LoRaWAN: Validate MIC
 	macPayload.FHDR.FCnt = ttnUp.ProtocolMetadata.GetLorawan().FCnt
-	ttnUp.Trace = ttnUp.Trace.WithEvent(trace.CheckMICEvent)
+	ttnUp.Trace = ttnUp.Trace.WithEvent(h.TraceService(), trace.CheckMICEvent)
 	ok, err = phyPayload.ValidateMIC(lorawan.AES128Key(dev.NwkSKey))
 	if err != nil {
 		return err
@@ -75,14 +75,14 @@ func (h *handler) ConvertFromLoRaWAN(ctx ttnlog.Interface, ttnUp *pb_broker.Dedu
 			// If it's confirmed, we can only unset it if we receive an ack.
 			if macPayload.FHDR.FCtrl.ACK {
 				// Send event over MQTT
-				h.mqttEvent <- &types.DeviceEvent{
+				h.publishEvent(&types.DeviceEvent{
 					AppID: appUp.AppID,
 					DevID: appUp.DevID,
 					Event: types.DownlinkAckEvent,
 					Data: types.DownlinkEventData{
 						Message: dev.CurrentDownlink,
 					},
-				}
+				})
 				dev.CurrentDownlink = nil
 			}
 		} else {
@@ -131,13 +131,13 @@ func (h *handler) ConvertToLoRaWAN(ctx ttnlog.Interface, appDown *types.Downlink
 
 	// Set Payload
 	if len(appDown.PayloadRaw) > 0 {
-		ttnDown.Trace = ttnDown.Trace.WithEvent("set payload")
+		ttnDown.Trace = ttnDown.Trace.WithEvent(h.TraceService(), "set payload")
 		macPayload.FRMPayload = []lorawan.Payload{&lorawan.DataPayload{Bytes: appDown.PayloadRaw}}
 		if macPayload.FPort == nil || *macPayload.FPort == 0 {
 			macPayload.FPort = pointer.Uint8(1)
 		}
 	} else {
-		ttnDown.Trace = ttnDown.Trace.WithEvent("set empty payload")
+		ttnDown.Trace = ttnDown.Trace.WithEvent(h.TraceService(), "set empty payload")
 		macPayload.FRMPayload = []lorawan.Payload{}
 	}
 
@@ -12,7 +12,7 @@ import (
 	"github.com/fatih/structs"
 )
 
-const currentDBVersion = "2.4.1"
+const currentDBVersion = "2.5.0"
 
 type DevNonce [2]byte
 type AppNonce [3]byte
@@ -45,6 +45,9 @@ type Device struct {
 	UsedDevNonces []DevNonce   `redis:"used_dev_nonces"`
 	UsedAppNonces []AppNonce   `redis:"used_app_nonces"`
 
+	LastJoin    time.Time `redis:"last_join"`
+	RecentJoins int       `redis:"recent_joins"` // Number of joins that count towards the join rate limit
+
 	DevAddr types.DevAddr `redis:"dev_addr"`
 	NwkSKey types.NwkSKey `redis:"nwk_s_key"`
 	AppSKey types.AppSKey `redis:"app_s_key"`
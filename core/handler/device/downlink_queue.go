@@ -14,10 +14,12 @@ import (
 // DownlinkQueue stores the Downlink queue
 type DownlinkQueue interface {
 	Length() (int, error)
+	All() ([]*types.DownlinkMessage, error)
 	Next() (*types.DownlinkMessage, error)
 	Replace(msg *types.DownlinkMessage) error
 	PushFirst(msg *types.DownlinkMessage) error
 	PushLast(msg *types.DownlinkMessage) error
+	Clear() error
 }
 
 // RedisDownlinkQueue implements the downlink queue in Redis
@@ -36,6 +38,23 @@ func (s *RedisDownlinkQueue) Length() (int, error) {
 	return s.queues.Length(s.key())
 }
 
+// All items in the downlink queue, without removing them
+func (s *RedisDownlinkQueue) All() ([]*types.DownlinkMessage, error) {
+	qd, err := s.queues.Get(s.key())
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]*types.DownlinkMessage, 0, len(qd))
+	for _, item := range qd {
+		msg := new(types.DownlinkMessage)
+		if err := json.Unmarshal([]byte(item), msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
 // Next item in the downlink queue
 func (s *RedisDownlinkQueue) Next() (*types.DownlinkMessage, error) {
 	qd, err := s.queues.Next(s.key())
@@ -77,3 +96,8 @@ func (s *RedisDownlinkQueue) PushLast(msg *types.DownlinkMessage) error {
 	}
 	return s.queues.AddEnd(s.key(), string(qd))
 }
+
+// Clear the downlink queue
+func (s *RedisDownlinkQueue) Clear() error {
+	return s.queues.Delete(s.key())
+}
@@ -53,6 +53,14 @@ func TestDownlinkQueue(t *testing.T) {
 		a.So(length, ShouldEqual, 2)
 	}
 
+	{
+		all, err := s.All()
+		a.So(err, ShouldBeNil)
+		a.So(all, ShouldHaveLength, 2)
+		a.So(all[0].PayloadRaw, ShouldResemble, []byte{0xab, 0xcd})
+		a.So(all[1].PayloadRaw, ShouldResemble, []byte{0x12, 0x34})
+	}
+
 	{
 		next, err := s.Next()
 		a.So(err, ShouldBeNil)
@@ -80,4 +88,13 @@ func TestDownlinkQueue(t *testing.T) {
 		a.So(next.PayloadRaw, ShouldResemble, []byte{0xaa, 0xbc})
 	}
 
+	{
+		s.PushLast(&types.DownlinkMessage{PayloadRaw: []byte{0x12, 0x34}})
+		err := s.Clear()
+		a.So(err, ShouldBeNil)
+		length, err := s.Length()
+		a.So(err, ShouldBeNil)
+		a.So(length, ShouldEqual, 0)
+	}
+
 }
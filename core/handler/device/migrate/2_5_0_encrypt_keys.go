@@ -0,0 +1,18 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	redis "gopkg.in/redis.v5"
+)
+
+// EncryptKeys migration from 2.4.2 to 2.5.0
+// This migration is only used if a KEK is configured, as it encrypts the keys of the device with the KeyRing
+func EncryptKeys(keys *storage.KeyRing, fields ...string) storage.MigrateFunction {
+	return func(client *redis.Client, key string, obj map[string]string) (string, map[string]string, error) {
+		err := keys.EncryptFields(key, obj, fields...)
+		return "2.5.0", obj, err
+	}
+}
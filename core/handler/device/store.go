@@ -17,6 +17,7 @@ import (
 type Store interface {
 	List(opts *storage.ListOptions) ([]*Device, error)
 	ListForApp(appID string, opts *storage.ListOptions) ([]*Device, error)
+	RangeForApp(appID string, batchSize int64, fn func([]*Device) error) error
 	Get(appID, devID string) (*Device, error)
 	DownlinkQueue(appID, devID string) (DownlinkQueue, error)
 	Set(new *Device, properties ...string) (err error)
@@ -27,6 +28,9 @@ const defaultRedisPrefix = "handler"
 const redisDevicePrefix = "device"
 const redisDownlinkQueuePrefix = "downlink"
 
+// encryptedFields are the fields of a Device that are encrypted if a KeyRing is set
+var encryptedFields = []string{"app_key", "nwk_s_key", "app_s_key"}
+
 // NewRedisDeviceStore creates a new Redis-based Device store
 func NewRedisDeviceStore(client *redis.Client, prefix string) *RedisDeviceStore {
 	if prefix == "" {
@@ -34,6 +38,7 @@ func NewRedisDeviceStore(client *redis.Client, prefix string) *RedisDeviceStore
 	}
 	store := storage.NewRedisMapStore(client, prefix+":"+redisDevicePrefix)
 	store.SetBase(Device{}, "")
+	store.SetEncryptedFields(encryptedFields...)
 	for v, f := range migrate.DeviceMigrations(prefix) {
 		store.AddMigration(v, f)
 	}
@@ -51,6 +56,21 @@ type RedisDeviceStore struct {
 	queues *storage.RedisQueueStore
 }
 
+// SetKeyRing enables encryption of the keys of Devices with the KeyRing. The keys of Devices that were stored before
+// version 2.5.0 are encrypted when they are migrated; the keys of newer Devices are encrypted when they are written.
+// Keys that were written without a KeyRing are encrypted when their Device is updated. Use RewrapKeys to encrypt
+// them all at once.
+func (s *RedisDeviceStore) SetKeyRing(keys *storage.KeyRing) {
+	s.store.SetKeyRing(keys)
+	s.store.AddMigration("2.4.2", migrate.EncryptKeys(keys, encryptedFields...))
+}
+
+// RewrapKeys wraps the keys of all Devices with the current KEK of the KeyRing, and returns the number of Devices
+// that changed
+func (s *RedisDeviceStore) RewrapKeys() (int, error) {
+	return s.store.Rewrap("")
+}
+
 // List all Devices
 func (s *RedisDeviceStore) List(opts *storage.ListOptions) ([]*Device, error) {
 	devicesI, err := s.store.List("", opts)
@@ -81,6 +101,20 @@ func (s *RedisDeviceStore) ListForApp(appID string, opts *storage.ListOptions) (
 	return devices, nil
 }
 
+// RangeForApp calls fn with batches of about batchSize devices of a specific Application, until all devices are
+// listed or fn returns an error
+func (s *RedisDeviceStore) RangeForApp(appID string, batchSize int64, fn func([]*Device) error) error {
+	return s.store.Range(fmt.Sprintf("%s:*", appID), batchSize, func(devicesI []interface{}) error {
+		devices := make([]*Device, len(devicesI))
+		for i, deviceI := range devicesI {
+			if device, ok := deviceI.(Device); ok {
+				devices[i] = &device
+			}
+		}
+		return fn(devices)
+	})
+}
+
 // Get a specific Device
 func (s *RedisDeviceStore) Get(appID, devID string) (*Device, error) {
 	deviceI, err := s.store.Get(fmt.Sprintf("%s:%s", appID, devID))
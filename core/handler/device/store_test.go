@@ -6,6 +6,7 @@ package device
 import (
 	"testing"
 
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	. "github.com/TheThingsNetwork/ttn/utils/testing"
 	. "github.com/smartystreets/assertions"
@@ -99,3 +100,62 @@ func TestDeviceStore(t *testing.T) {
 	a.So(devs, ShouldHaveLength, 1)
 
 }
+
+func TestDeviceStoreKeyEncryption(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	s := NewRedisDeviceStore(client, "handler-test-device-store-encryption")
+
+	appKey := types.AppKey([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8})
+	nwkSKey := types.NwkSKey([16]byte{2, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8})
+
+	// Device that was stored before keys were encrypted
+	err := s.Set(&Device{
+		AppID:   "AppID-1",
+		DevID:   "DevID-1",
+		AppKey:  appKey,
+		NwkSKey: nwkSKey,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete("AppID-1", "DevID-1")
+	}()
+	a.So(storage.IsEncrypted(client.HGet("handler-test-device-store-encryption:device:AppID-1:DevID-1", "app_key").Val()), ShouldBeFalse)
+	client.HSet("handler-test-device-store-encryption:device:AppID-1:DevID-1", storage.VersionKey, "2.4.2")
+
+	keys, _ := storage.ParseKeyRing("1:01020304050607080102030405060708")
+	s.SetKeyRing(keys)
+
+	// Keys are encrypted when the device is migrated
+	dev, err := s.Get("AppID-1", "DevID-1")
+	a.So(err, ShouldBeNil)
+	a.So(dev.AppKey, ShouldEqual, appKey)
+	a.So(dev.NwkSKey, ShouldEqual, nwkSKey)
+	a.So(storage.IsEncrypted(client.HGet("handler-test-device-store-encryption:device:AppID-1:DevID-1", "app_key").Val()), ShouldBeTrue)
+	a.So(storage.IsEncrypted(client.HGet("handler-test-device-store-encryption:device:AppID-1:DevID-1", "nwk_s_key").Val()), ShouldBeTrue)
+	a.So(client.HGet("handler-test-device-store-encryption:device:AppID-1:DevID-1", storage.VersionKey).Val(), ShouldEqual, "2.5.0")
+
+	// Keys of new devices are encrypted
+	err = s.Set(&Device{
+		AppID:  "AppID-1",
+		DevID:  "DevID-2",
+		AppKey: appKey,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete("AppID-1", "DevID-2")
+	}()
+	a.So(storage.IsEncrypted(client.HGet("handler-test-device-store-encryption:device:AppID-1:DevID-2", "app_key").Val()), ShouldBeTrue)
+
+	// Rotate the KEK
+	keys, _ = storage.ParseKeyRing("2:08070605040302010807060504030201,1:01020304050607080102030405060708")
+	s.SetKeyRing(keys)
+	changed, err := s.RewrapKeys()
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldEqual, 2)
+
+	dev, err = s.Get("AppID-1", "DevID-2")
+	a.So(err, ShouldBeNil)
+	a.So(dev.AppKey, ShouldEqual, appKey)
+}
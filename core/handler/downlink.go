@@ -38,7 +38,7 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.DownlinkErrorEvent,
@@ -46,10 +46,14 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 					ErrorEventData: types.ErrorEventData{Error: err.Error()},
 					Message:        appDownlink,
 				},
-			}
+			})
 		}
 	}()
 
+	if err = h.checkApplicationFrozen(appID); err != nil {
+		return err
+	}
+
 	// Clear redundant fields
 	appDownlink.AppID = ""
 	appDownlink.DevID = ""
@@ -82,14 +86,14 @@ func (h *handler) EnqueueDownlink(appDownlink *types.DownlinkMessage) (err error
 		return err
 	}
 
-	h.mqttEvent <- &types.DeviceEvent{
+	h.publishEvent(&types.DeviceEvent{
 		AppID: appID,
 		DevID: devID,
 		Event: types.DownlinkScheduledEvent,
 		Data: types.DownlinkEventData{
 			Message: appDownlink,
 		},
-	}
+	})
 
 	return nil
 }
@@ -106,7 +110,7 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.DownlinkErrorEvent,
@@ -114,9 +118,10 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 					ErrorEventData: types.ErrorEventData{Error: err.Error()},
 					Message:        appDownlink,
 				},
-			}
+			})
 			ctx.WithError(err).Warn("Could not handle downlink")
 		}
+		trace.Export(h.TraceService(), downlink.Trace)
 	}()
 
 	dev, err := h.devices.Get(appID, devID)
@@ -138,7 +143,7 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 	}
 
 	ctx.WithField("NumProcessors", len(processors)).Debug("Running Downlink Processors")
-	downlink.Trace = downlink.Trace.WithEvent("process downlink")
+	downlink.Trace = downlink.Trace.WithEvent(h.TraceService(), "process downlink")
 
 	// Run Processors
 	for _, processor := range processors {
@@ -154,11 +159,16 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 	downlink.Message = nil
 	downlink.UnmarshalPayload()
 
+	err = h.checkDownlinkQuota(appID, devID)
+	if err != nil {
+		return err
+	}
+
 	h.status.downlink.Mark(1)
 
 	ctx.Debug("Send Downlink")
 
-	downlink.Trace = downlink.Trace.WithEvent(trace.ForwardEvent, "broker", h.ttnBrokerID)
+	downlink.Trace = downlink.Trace.WithEvent(h.TraceService(), trace.ForwardEvent, "broker", h.ttnBrokerID)
 
 	h.downlink <- downlink
 
@@ -177,7 +187,7 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 		downlinkConfig.Power = int(downlink.DownlinkOption.GatewayConfig.Power)
 	}
 
-	h.mqttEvent <- &types.DeviceEvent{
+	h.publishEvent(&types.DeviceEvent{
 		AppID: appDownlink.AppID,
 		DevID: appDownlink.DevID,
 		Event: types.DownlinkSentEvent,
@@ -187,7 +197,7 @@ func (h *handler) HandleDownlink(appDownlink *types.DownlinkMessage, downlink *p
 			GatewayID: downlink.DownlinkOption.GatewayId,
 			Config:    downlinkConfig,
 		},
-	}
+	})
 
 	return nil
 }
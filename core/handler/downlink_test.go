@@ -21,9 +21,10 @@ func TestEnqueueDownlink(t *testing.T) {
 	appID := "app1"
 	devID := "dev1"
 	h := &handler{
-		Component: &component.Component{Ctx: GetLogger(t, "TestEnqueueDownlink")},
-		devices:   device.NewRedisDeviceStore(GetRedisClient(), "handler-test-enqueue-downlink"),
-		mqttEvent: make(chan *types.DeviceEvent, 10),
+		Component:    &component.Component{Ctx: GetLogger(t, "TestEnqueueDownlink")},
+		devices:      device.NewRedisDeviceStore(GetRedisClient(), "handler-test-enqueue-downlink"),
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-enqueue-downlink"),
+		mqttEvent:    make(chan *types.DeviceEvent, 10),
 	}
 	err := h.EnqueueDownlink(&types.DownlinkMessage{
 		AppID: appID,
@@ -24,6 +24,7 @@ func (h *handlerManager) DryUplink(ctx context.Context, in *pb.DryUplinkMessage)
 	valid := true
 	if app != nil && app.Decoder != "" {
 		functions := &UplinkFunctions{
+			Engine:    app.Engine,
 			Decoder:   app.Decoder,
 			Converter: app.Converter,
 			Validator: app.Validator,
@@ -79,6 +80,7 @@ func (h *handlerManager) DryDownlink(ctx context.Context, in *pb.DryDownlinkMess
 	logger := functions.NewEntryLogger()
 
 	functions := &DownlinkFunctions{
+		Engine:  app.Engine,
 		Encoder: app.Encoder,
 		Logger:  logger,
 	}
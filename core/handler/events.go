@@ -0,0 +1,36 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// publishEvent publishes a device or application event to all enabled integrations. An integration that can not
+// keep up (for example because its publisher could not be opened) does not block the Handler: the event is dropped
+// for that integration when its buffer is full.
+func (h *handler) publishEvent(event *types.DeviceEvent) {
+	select {
+	case h.mqttEvent <- event:
+	default:
+		h.dropEvent("MQTT", event)
+	}
+	if h.amqpEnabled {
+		select {
+		case h.amqpEvent <- event:
+		default:
+			h.dropEvent("AMQP", event)
+		}
+	}
+}
+
+func (h *handler) dropEvent(protocol string, event *types.DeviceEvent) {
+	h.Ctx.WithFields(ttnlog.Fields{
+		"Protocol": protocol,
+		"AppID":    event.AppID,
+		"DevID":    event.DevID,
+		"Event":    event.Event,
+	}).Warn("Event buffer full, dropping event")
+}
@@ -0,0 +1,31 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestPublishEvent(t *testing.T) {
+	a := New(t)
+
+	h := &handler{
+		Component:   &component.Component{Ctx: GetLogger(t, "TestPublishEvent")},
+		mqttEvent:   make(chan *types.DeviceEvent, 1),
+		amqpEvent:   make(chan *types.DeviceEvent, 1),
+		amqpEnabled: true,
+	}
+
+	// The second event does not fit in the buffers and is dropped instead of blocking
+	for i := 0; i < 2; i++ {
+		h.publishEvent(&types.DeviceEvent{AppID: "app", DevID: "dev", Event: types.ActivationEvent})
+	}
+	a.So(len(h.mqttEvent), ShouldEqual, 1)
+	a.So(len(h.amqpEvent), ShouldEqual, 1)
+}
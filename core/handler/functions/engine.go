@@ -0,0 +1,89 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"fmt"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// Engines that can run payload functions
+const (
+	// Otto runs ECMAScript 5.1
+	Otto = "otto"
+	// Goja runs ECMAScript 2015+, including let/const, arrow functions,
+	// template strings and typed arrays
+	Goja = "goja"
+)
+
+// DefaultEngine is used for applications that do not select an engine
+const DefaultEngine = Otto
+
+// engine compiles code and creates the VMs that run it
+type engine interface {
+	// compile compiles the source code into a program that can be run by the VMs of the engine
+	compile(name, source string) (interface{}, error)
+	// newVM returns a new VM with a clean global scope
+	newVM() vm
+}
+
+// vm runs programs of its engine
+type vm interface {
+	// run runs the programs and returns the exported value of the last one
+	run(name string, env map[string]interface{}, limits Limits, logger Logger, programs ...interface{}) (interface{}, error)
+}
+
+var engines = map[string]engine{
+	Otto: newOttoEngine(),
+	Goja: gojaEngine{},
+}
+
+func getEngine(name string) (engine, error) {
+	if name == "" {
+		name = DefaultEngine
+	}
+	engine, ok := engines[name]
+	if !ok {
+		return nil, errors.NewErrInvalidArgument("Engine", fmt.Sprintf("%s is not supported", name))
+	}
+	return engine, nil
+}
+
+// Compile compiles the source code of a function with the given engine and
+// returns an error if the engine is not supported or if the code is invalid
+func Compile(engineName, name, source string) error {
+	engine, err := getEngine(engineName)
+	if err != nil {
+		return err
+	}
+	if err := DefaultLimits.checkCodeSize(name, source); err != nil {
+		return err
+	}
+	if _, err := engine.compile(name, source); err != nil {
+		return errors.NewErrInvalidArgument(name, err.Error())
+	}
+	return nil
+}
+
+// Run runs the source code of a function in a new VM of the given engine,
+// followed by the call expression that invokes it
+func Run(engineName, name, source, call string, env map[string]interface{}, limits Limits, logger Logger) (interface{}, error) {
+	engine, err := getEngine(engineName)
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.checkCodeSize(name, source); err != nil {
+		return nil, err
+	}
+	function, err := engine.compile(name, source)
+	if err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
+	}
+	invocation, err := engine.compile("call", call)
+	if err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
+	}
+	return engine.newVM().run(name, env, limits, logger, function, invocation)
+}
@@ -0,0 +1,93 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"testing"
+	"time"
+
+	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
+	. "github.com/smartystreets/assertions"
+)
+
+const es2015Decoder = `
+const Decoder = (bytes, port) => {
+  let buf = new Uint8Array(bytes);
+  const value = (buf[0] << 8) | buf[1];
+  console.log(` + "`port ${port}`" + `);
+  return { value, port };
+};
+`
+
+func TestCompile(t *testing.T) {
+	a := New(t)
+
+	a.So(Compile("", "Decoder", `function Decoder(bytes, port) { return {}; }`), ShouldBeNil)
+	a.So(Compile(Otto, "Decoder", `function Decoder(bytes, port) { return {}; }`), ShouldBeNil)
+	a.So(Compile(Goja, "Decoder", `function Decoder(bytes, port) { return {}; }`), ShouldBeNil)
+
+	a.So(Compile(Otto, "Decoder", es2015Decoder), ShouldNotBeNil)
+	a.So(Compile(Goja, "Decoder", es2015Decoder), ShouldBeNil)
+
+	a.So(Compile(Goja, "Decoder", `this is not valid JavaScript`), ShouldNotBeNil)
+	a.So(Compile("v8", "Decoder", `function Decoder(bytes, port) { return {}; }`), ShouldNotBeNil)
+}
+
+func TestRunGoja(t *testing.T) {
+	a := New(t)
+
+	logger := NewEntryLogger()
+	env := map[string]interface{}{
+		"payload": []byte{0x48, 0x65},
+		"port":    12,
+	}
+
+	val, err := Run(Goja, "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, DefaultLimits, logger)
+	a.So(err, ShouldBeNil)
+	m, ok := val.(map[string]interface{})
+	a.So(ok, ShouldBeTrue)
+	a.So(m["value"], ShouldEqual, 18533)
+	a.So(m["port"], ShouldEqual, 12)
+	a.So(logger.Logs, ShouldResemble, []*pb_handler.LogEntry{
+		&pb_handler.LogEntry{
+			Function: "Decoder",
+			Fields:   []string{`"port 12"`},
+		},
+	})
+
+	_, err = Run(Goja, "Decoder", `const Decoder = () => { throw new Error("This is an error"); };`, "Decoder()", nil, DefaultLimits, nil)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestRunGojaTimeout(t *testing.T) {
+	a := New(t)
+
+	limits := DefaultLimits
+	limits.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := Run(Goja, "Decoder", `const Decoder = () => { while (true) {} };`, "Decoder()", nil, limits, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(time.Since(start), ShouldBeLessThan, time.Second)
+}
+
+func TestPoolEngines(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, DefaultLimits)
+	env := map[string]interface{}{
+		"payload": []byte{0x48, 0x65},
+		"port":    12,
+	}
+
+	_, err := p.Run(Otto, "app", "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, nil)
+	a.So(err, ShouldNotBeNil)
+
+	val, err := p.Run(Goja, "app", "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, nil)
+	a.So(err, ShouldBeNil)
+	a.So(val.(map[string]interface{})["value"], ShouldEqual, 18533)
+
+	_, err = p.Run("v8", "app", "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, nil)
+	a.So(err, ShouldNotBeNil)
+}
@@ -5,17 +5,127 @@ package functions
 
 import (
 	"fmt"
+	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 	"github.com/robertkrimen/otto"
 )
 
-var errTimeOutExceeded = errors.NewErrInternal("Code has been running to long")
+var (
+	errTimeOutExceeded      = errors.NewErrInternal("Code has been running to long")
+	errInstructionsExceeded = errors.NewErrInternal("Code has executed too many instructions")
+	errMemoryExceeded       = errors.NewErrInternal("Code has allocated too much memory")
+)
+
+// memoryCheckInterval is the interval at which the memory limit is checked
+var memoryCheckInterval = 10 * time.Millisecond
+
+// Limits restrict the resources that JavaScript code is allowed to use
+type Limits struct {
+	// Timeout is the maximum time the code is allowed to run
+	Timeout time.Duration
+	// Instructions is the maximum number of statements and expressions the code is allowed to evaluate.
+	// This limit is not enforced by the Goja engine
+	Instructions uint64
+	// StackDepth is the maximum depth of the JavaScript call stack, which bounds the memory used by recursion
+	StackDepth int
+	// Memory is the maximum number of bytes that the heap may grow while the code runs. The heap is shared by the
+	// process, so this is a coarse limit that stops code that allocates without bounds
+	Memory uint64
+	// CodeSize is the maximum size of the code in bytes
+	CodeSize int
+}
+
+// DefaultLimits are the limits that are used for payload functions. A zero
+// value for any of the limits means that there is no limit
+var DefaultLimits = Limits{
+	Timeout:      100 * time.Millisecond,
+	Instructions: 1000000,
+	StackDepth:   256,
+	Memory:       64 * 1024 * 1024,
+	CodeSize:     64 * 1024,
+}
 
+// RunCode runs the code in a new VM with the DefaultLimits and the given timeout
 func RunCode(name, code string, env map[string]interface{}, timeout time.Duration, logger Logger) (val otto.Value, err error) {
-	vm := otto.New()
+	limits := DefaultLimits
+	limits.Timeout = timeout
+	if err := limits.checkCodeSize(name, code); err != nil {
+		return otto.Value{}, err
+	}
+	return run(otto.New(), name, env, limits, logger, code)
+}
+
+func (l Limits) checkCodeSize(name, code string) error {
+	if l.CodeSize > 0 && len(code) > l.CodeSize {
+		return errors.NewErrInvalidArgument(name, fmt.Sprintf("code is larger than %d bytes", l.CodeSize))
+	}
+	return nil
+}
+
+// watchMemory calls exceeded once the heap has grown more than the memory limit since the watch started. The
+// returned function stops the watch.
+func watchMemory(limits Limits, exceeded func()) (stop func()) {
+	if limits.Memory == 0 {
+		return func() {}
+	}
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	start := stats.HeapAlloc
+	ticker := time.NewTicker(memoryCheckInterval)
+	done := make(chan struct{})
+	go func() {
+		var stats runtime.MemStats
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				runtime.ReadMemStats(&stats)
+				if stats.HeapAlloc > start && stats.HeapAlloc-start > limits.Memory {
+					exceeded()
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
 
+// limit makes the interrupt handler of the VM enforce the timeout, instruction count and memory limit. The returned
+// function stops enforcing the memory limit.
+func limit(vm *otto.Otto, limits Limits) (stop func()) {
+	deadline := time.Now().Add(limits.Timeout)
+	var instructions uint64
+	var memoryExceeded int32
+	var check func()
+	check = func() {
+		instructions++
+		if limits.Instructions > 0 && instructions > limits.Instructions {
+			panic(errInstructionsExceeded)
+		}
+		if limits.Timeout > 0 && time.Now().After(deadline) {
+			panic(errTimeOutExceeded)
+		}
+		if atomic.LoadInt32(&memoryExceeded) == 1 {
+			panic(errMemoryExceeded)
+		}
+		vm.Interrupt <- check
+	}
+	vm.Interrupt = make(chan func(), 1)
+	vm.Interrupt <- check
+	return watchMemory(limits, func() {
+		atomic.StoreInt32(&memoryExceeded, 1)
+	})
+}
+
+// run runs the code (strings or compiled scripts) in the given VM and returns the value of the last one
+func run(vm *otto.Otto, name string, env map[string]interface{}, limits Limits, logger Logger, code ...interface{}) (val otto.Value, err error) {
 	// load the environment
 	for key, val := range env {
 		vm.Set(key, val)
@@ -26,39 +136,53 @@ func RunCode(name, code string, env map[string]interface{}, timeout time.Duratio
 	}
 	logger.Enter(name)
 
-	vm.Set("__log", func(call otto.FunctionCall) otto.Value {
-		logger.Log(call)
+	console, err := vm.Get("console")
+	if err != nil || !console.IsObject() {
+		return otto.Value{}, errors.NewErrInternal(fmt.Sprintf("Could not set up console for %s", name))
+	}
+	console.Object().Set("log", func(call otto.FunctionCall) otto.Value {
+		if logger != Ignore {
+			fields := make([]string, 0, len(call.ArgumentList))
+			for _, arg := range call.ArgumentList {
+				fields = append(fields, JSON(arg))
+			}
+			logger.Log(fields)
+		}
 		return otto.UndefinedValue()
 	})
-	vm.Run("console.log = __log")
+
+	if limits.StackDepth > 0 {
+		vm.SetStackDepthLimit(limits.StackDepth)
+	}
 
 	start := time.Now()
 
 	defer func() {
+		vm.Interrupt = nil
 		duration := time.Since(start)
 		if caught := recover(); caught != nil {
 			val = otto.Value{}
-			if caught == errTimeOutExceeded {
+			switch caught {
+			case errTimeOutExceeded:
 				err = errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after %v", name, duration))
-				return
-			} else {
+			case errInstructionsExceeded:
+				err = errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after %d instructions", name, limits.Instructions))
+			case errMemoryExceeded:
+				err = errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after allocating more than %d bytes", name, limits.Memory))
+			default:
 				err = errors.NewErrInternal(fmt.Sprintf("Fatal error in %s: %s", name, caught))
 			}
 		}
 	}()
 
-	vm.Interrupt = make(chan func(), 1)
+	stop := limit(vm, limits)
+	defer stop()
 
-	go func() {
-		time.Sleep(timeout)
-		vm.Interrupt <- func() {
-			panic(errTimeOutExceeded)
+	for _, code := range code {
+		val, err = vm.Run(code)
+		if err != nil {
+			return val, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
 		}
-	}()
-
-	val, err = vm.Run(code)
-	if err != nil {
-		return val, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
 	}
 
 	return val, nil
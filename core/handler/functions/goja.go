@@ -0,0 +1,97 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/dop251/goja"
+)
+
+// gojaEngine compiles code into programs that can be run by any goja runtime
+type gojaEngine struct{}
+
+func (gojaEngine) compile(name, source string) (interface{}, error) {
+	return goja.Compile(name, source, false)
+}
+
+func (gojaEngine) newVM() vm {
+	return &gojaVM{goja.New()}
+}
+
+type gojaVM struct {
+	runtime *goja.Runtime
+}
+
+func (v *gojaVM) run(name string, env map[string]interface{}, limits Limits, logger Logger, programs ...interface{}) (interface{}, error) {
+	runtime := v.runtime
+
+	// load the environment
+	for key, val := range env {
+		runtime.Set(key, val)
+	}
+
+	if logger == nil {
+		logger = Ignore
+	}
+	logger.Enter(name)
+
+	stringify, ok := goja.AssertFunction(runtime.Get("JSON").ToObject(runtime).Get("stringify"))
+	if !ok {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Could not set up console for %s", name))
+	}
+	console := runtime.NewObject()
+	console.Set("log", func(call goja.FunctionCall) goja.Value {
+		if logger != Ignore {
+			fields := make([]string, 0, len(call.Arguments))
+			for _, arg := range call.Arguments {
+				field, err := stringify(goja.Undefined(), arg)
+				if err != nil {
+					fields = append(fields, arg.String())
+					continue
+				}
+				fields = append(fields, field.String())
+			}
+			logger.Log(fields)
+		}
+		return goja.Undefined()
+	})
+	runtime.Set("console", console)
+
+	if limits.StackDepth > 0 {
+		runtime.SetMaxCallStackSize(limits.StackDepth)
+	}
+
+	start := time.Now()
+	defer runtime.ClearInterrupt()
+	if limits.Timeout > 0 {
+		timer := time.AfterFunc(limits.Timeout, func() {
+			runtime.Interrupt(errTimeOutExceeded)
+		})
+		defer timer.Stop()
+	}
+	stop := watchMemory(limits, func() {
+		runtime.Interrupt(errMemoryExceeded)
+	})
+	defer stop()
+
+	var val goja.Value
+	for _, program := range programs {
+		var err error
+		val, err = runtime.RunProgram(program.(*goja.Program))
+		if interrupted, ok := err.(*goja.InterruptedError); ok {
+			if interrupted.Value() == errMemoryExceeded {
+				return nil, errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after allocating more than %d bytes", name, limits.Memory))
+			}
+			return nil, errors.NewErrInternal(fmt.Sprintf("Interrupted javascript execution for %s after %v", name, time.Since(start)))
+		}
+		if err != nil {
+			return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, err))
+		}
+	}
+
+	return val.Export(), nil
+}
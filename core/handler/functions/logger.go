@@ -10,8 +10,8 @@ import (
 
 // Logger is something that can be logged to, saving the logs for later use
 type Logger interface {
-	// Log passes the console.log function call to the logger
-	Log(call otto.FunctionCall)
+	// Log passes the JSON encoded arguments of a console.log function call to the logger
+	Log(fields []string)
 
 	// Enter tells the Logger what function it is currently in
 	Enter(function string)
@@ -40,12 +40,7 @@ func JSON(val otto.Value) string {
 	return res.String()
 }
 
-func (c *EntryLogger) Log(call otto.FunctionCall) {
-	fields := []string{}
-	for _, field := range call.ArgumentList {
-		fields = append(fields, JSON(field))
-	}
-
+func (c *EntryLogger) Log(fields []string) {
 	c.Logs = append(c.Logs, &pb_handler.LogEntry{
 		Function: c.function,
 		Fields:   fields,
@@ -60,5 +55,5 @@ type IgnoreLogger struct{}
 
 var Ignore = &IgnoreLogger{}
 
-func (c *IgnoreLogger) Log(call otto.FunctionCall) {}
-func (c *IgnoreLogger) Enter(function string)      {}
+func (c *IgnoreLogger) Log(fields []string)   {}
+func (c *IgnoreLogger) Enter(function string) {}
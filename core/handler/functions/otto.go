@@ -0,0 +1,46 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"sync"
+
+	"github.com/robertkrimen/otto"
+)
+
+// ottoEngine compiles code with, and copies new VMs from a template VM
+type ottoEngine struct {
+	mu       sync.Mutex
+	template *otto.Otto
+}
+
+func newOttoEngine() *ottoEngine {
+	return &ottoEngine{
+		template: otto.New(),
+	}
+}
+
+func (e *ottoEngine) compile(name, source string) (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.template.Compile(name, source)
+}
+
+func (e *ottoEngine) newVM() vm {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return &ottoVM{e.template.Copy()}
+}
+
+type ottoVM struct {
+	vm *otto.Otto
+}
+
+func (v *ottoVM) run(name string, env map[string]interface{}, limits Limits, logger Logger, programs ...interface{}) (interface{}, error) {
+	val, err := run(v.vm, name, env, limits, logger, programs...)
+	if err != nil {
+		return nil, err
+	}
+	return val.Export()
+}
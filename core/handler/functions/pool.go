@@ -0,0 +1,149 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// Pool runs the payload functions of applications in a bounded number of VMs.
+// The functions are compiled once and cached per application. Every run gets a
+// new VM with a clean global scope, so that state of one run, such as global
+// variables, never leaks into the next run of the same or another application.
+type Pool struct {
+	limits Limits
+
+	slots chan struct{}
+
+	mu           sync.RWMutex
+	applications map[string]*poolApplication
+	calls        map[string]*compiled
+}
+
+type poolApplication struct {
+	functions map[string]*compiled
+}
+
+type compiled struct {
+	engine  string
+	source  string
+	program interface{}
+	err     error
+}
+
+// NewPool returns a new Pool that runs at most size VMs at a time, within the given limits
+func NewPool(size int, limits Limits) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	return &Pool{
+		limits:       limits,
+		slots:        make(chan struct{}, size),
+		applications: make(map[string]*poolApplication),
+		calls:        make(map[string]*compiled),
+	}
+}
+
+// Run runs the function with the given name and source code of an application
+// with the given engine, followed by the call expression that invokes it. It
+// blocks while the maximum number of VMs is running.
+func (p *Pool) Run(engineName, appID, name, source, call string, env map[string]interface{}, logger Logger) (interface{}, error) {
+	if engineName == "" {
+		engineName = DefaultEngine
+	}
+	engine, err := getEngine(engineName)
+	if err != nil {
+		return nil, err
+	}
+	limits := p.Limits()
+	if err := limits.checkCodeSize(name, source); err != nil {
+		return nil, err
+	}
+
+	function := p.function(engineName, engine, appID, name, source)
+	if function.err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, function.err))
+	}
+	invocation := p.call(engineName, engine, call)
+	if invocation.err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("%s threw error: %s", name, invocation.err))
+	}
+
+	p.slots <- struct{}{}
+	defer func() {
+		<-p.slots
+	}()
+
+	return engine.newVM().run(name, env, limits, logger, function.program, invocation.program)
+}
+
+// Limits returns the limits of the code that runs in the pool
+func (p *Pool) Limits() Limits {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.limits
+}
+
+// SetLimits changes the limits of the code that runs in the pool
+func (p *Pool) SetLimits(limits Limits) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limits = limits
+}
+
+// Invalidate removes the compiled functions of an application from the cache
+func (p *Pool) Invalidate(appID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.applications, appID)
+}
+
+func (p *Pool) function(engineName string, engine engine, appID, name, source string) *compiled {
+	p.mu.RLock()
+	app, ok := p.applications[appID]
+	if ok {
+		if function, ok := app.functions[name]; ok && function.engine == engineName && function.source == source {
+			p.mu.RUnlock()
+			return function
+		}
+	}
+	p.mu.RUnlock()
+
+	function := compile(engineName, engine, name, source)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	app, ok = p.applications[appID]
+	if !ok {
+		app = &poolApplication{
+			functions: make(map[string]*compiled),
+		}
+		p.applications[appID] = app
+	}
+	app.functions[name] = function
+	return function
+}
+
+func (p *Pool) call(engineName string, engine engine, source string) *compiled {
+	key := engineName + ":" + source
+	p.mu.RLock()
+	call, ok := p.calls[key]
+	p.mu.RUnlock()
+	if ok {
+		return call
+	}
+	call = compile(engineName, engine, "call", source)
+	p.mu.Lock()
+	p.calls[key] = call
+	p.mu.Unlock()
+	return call
+}
+
+func compile(engineName string, engine engine, name, source string) *compiled {
+	program, err := engine.compile(name, source)
+	return &compiled{engine: engineName, source: source, program: program, err: err}
+}
@@ -0,0 +1,179 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package functions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestPoolRun(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, DefaultLimits)
+	logger := NewEntryLogger()
+
+	source := `function Decoder(bytes, port) { console.log("port", port); return { first: bytes[0] }; }`
+	val, err := p.Run(Otto, "app", "Decoder", source, "Decoder(payload, port)", map[string]interface{}{
+		"payload": []byte{42},
+		"port":    1,
+	}, logger)
+	a.So(err, ShouldBeNil)
+	a.So(val.(map[string]interface{})["first"], ShouldEqual, 42)
+	a.So(logger.Logs, ShouldResemble, []*pb_handler.LogEntry{
+		&pb_handler.LogEntry{
+			Function: "Decoder",
+			Fields:   []string{`"port"`, "1"},
+		},
+	})
+
+	// The compiled function is cached
+	cached := p.function(Otto, engines[Otto], "app", "Decoder", source)
+	again := p.function(Otto, engines[Otto], "app", "Decoder", source)
+	a.So(again, ShouldEqual, cached)
+
+	// A changed function is compiled again
+	changed := p.function(Otto, engines[Otto], "app", "Decoder", `function Decoder() { return {}; }`)
+	a.So(changed, ShouldNotEqual, cached)
+
+	// Invalidation removes the cached functions
+	p.Invalidate("app")
+	invalidated := p.function(Otto, engines[Otto], "app", "Decoder", source)
+	a.So(invalidated, ShouldNotEqual, cached)
+}
+
+func TestPoolSyntaxError(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, DefaultLimits)
+	_, err := p.Run(Otto, "app", "Decoder", `function Decoder( {`, "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(err.Error(), ShouldContainSubstring, "Decoder threw error")
+}
+
+func TestPoolIsolation(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, DefaultLimits)
+
+	_, err := p.Run(Otto, "app-1", "Decoder", `var secret = "app-1"; function Decoder() { return {}; }`, "Decoder()", nil, nil)
+	a.So(err, ShouldBeNil)
+
+	// Another application gets a clean VM
+	val, err := p.Run(Otto, "app-2", "Check", `function Check() { return typeof secret; }`, "Check()", nil, nil)
+	a.So(err, ShouldBeNil)
+	a.So(val, ShouldEqual, "undefined")
+
+	// The same application gets a clean VM as well, so one device can not see the globals of another
+	val, err = p.Run(Otto, "app-1", "Check", `function Check() { return typeof secret; }`, "Check()", nil, nil)
+	a.So(err, ShouldBeNil)
+	a.So(val, ShouldEqual, "undefined")
+
+	counter := `var count = 0; function Decoder() { count++; return { count: count }; }`
+	for _, engine := range []string{Otto, Goja} {
+		for i := 0; i < 2; i++ {
+			val, err = p.Run(engine, "app-1", "Decoder", counter, "Decoder()", nil, nil)
+			a.So(err, ShouldBeNil)
+			a.So(val.(map[string]interface{})["count"], ShouldEqual, 1)
+		}
+	}
+}
+
+func TestPoolReuse(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, DefaultLimits)
+	env := map[string]interface{}{
+		"payload": []byte{0x48, 0x65},
+		"port":    12,
+	}
+
+	// Top-level const declarations run again for every run
+	for i := 0; i < 2; i++ {
+		val, err := p.Run(Goja, "app", "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, nil)
+		a.So(err, ShouldBeNil)
+		a.So(val.(map[string]interface{})["value"], ShouldEqual, 18533)
+	}
+
+	// An invalidated application compiles its functions again
+	p.Invalidate("app")
+	val, err := p.Run(Goja, "app", "Decoder", es2015Decoder, "Decoder(payload.slice(0), port)", env, nil)
+	a.So(err, ShouldBeNil)
+	a.So(val.(map[string]interface{})["value"], ShouldEqual, 18533)
+}
+
+func TestPoolLimits(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, Limits{
+		Timeout:      100 * time.Millisecond,
+		Instructions: 10000,
+		StackDepth:   64,
+		CodeSize:     1024,
+	})
+
+	_, err := p.Run(Otto, "app", "Decoder", `function Decoder() { for (var i = 0; i < 100000; i++) {} return {}; }`, "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(err.Error(), ShouldContainSubstring, "instructions")
+
+	_, err = p.Run(Otto, "app", "Decoder", `function Decoder() { return Decoder(); }`, "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+
+	_, err = p.Run(Otto, "app", "Decoder", `function Decoder() { return {}; }`+strings.Repeat(" ", 1024), "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+
+	// The VM can be used again after it was interrupted
+	_, err = p.Run(Otto, "app", "Decoder", `function Decoder() { return {}; }`, "Decoder()", nil, nil)
+	a.So(err, ShouldBeNil)
+
+	p = NewPool(1, Limits{Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err = p.Run(Otto, "app", "Decoder", `function Decoder() { while (true) {} }`, "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+	a.So(time.Since(start), ShouldBeLessThan, time.Second)
+}
+
+func TestPoolMemoryLimit(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, Limits{Timeout: 10 * time.Second, Memory: 1024 * 1024})
+	source := `function Decoder() { var a = []; while (true) { a.push(new Array(1024).join("x") + a.length); } }`
+	for _, engine := range []string{Otto, Goja} {
+		_, err := p.Run(engine, "app", "Decoder", source, "Decoder()", nil, nil)
+		a.So(err, ShouldNotBeNil)
+		a.So(err.Error(), ShouldContainSubstring, "allocating")
+	}
+}
+
+func BenchmarkPoolRun(b *testing.B) {
+	p := NewPool(1, DefaultLimits)
+	source := `function Decoder(bytes, port) { return { value: (bytes[0] << 8) | bytes[1] }; }`
+	env := map[string]interface{}{
+		"payload": []byte{1, 2},
+		"port":    1,
+	}
+	for n := 0; n < b.N; n++ {
+		p.Run(Otto, "app", "Decoder", source, "Decoder(payload, port)", env, Ignore)
+	}
+}
+
+func TestPoolSetLimits(t *testing.T) {
+	a := New(t)
+
+	p := NewPool(1, Limits{Timeout: time.Second, CodeSize: 1024})
+	a.So(p.Limits().CodeSize, ShouldEqual, 1024)
+
+	source := `function Decoder() { return {}; }` + strings.Repeat(" ", 512)
+	_, err := p.Run(Otto, "app", "Decoder", source, "Decoder()", nil, nil)
+	a.So(err, ShouldBeNil)
+
+	p.SetLimits(Limits{Timeout: time.Second, CodeSize: 256})
+	a.So(p.Limits().CodeSize, ShouldEqual, 256)
+	_, err = p.Run(Otto, "app", "Decoder", source, "Decoder()", nil, nil)
+	a.So(err, ShouldNotBeNil)
+}
@@ -0,0 +1,58 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"gopkg.in/redis.v5"
+)
+
+// Handlers that announce the same AppIDs and share their Redis store form a Handler group. A Handler only joins the
+// group of an application that was registered by another Handler if it is started with --join-group. The Broker sends
+// the uplink of a device to one of the Handlers in the group, but downlink that is published on MQTT is received by all
+// of them. The first Handler that claims the message_id of a downlink enqueues it, the others ignore it. Downlink
+// without a message_id can not be told apart from a repeated downlink, so it is enqueued by all Handlers.
+
+// DownlinkClaimTTL indicates how long the message_id of a downlink that is received over MQTT is claimed by the
+// Handler that enqueues it
+var DownlinkClaimTTL = 2 * time.Second
+
+// messageClaims allows Handlers in a group to claim messages that all of them receive
+type messageClaims interface {
+	// Claim claims the key, and returns false if another Handler already claimed it
+	Claim(key string, ttl time.Duration) (bool, error)
+}
+
+type redisClaims struct {
+	prefix string
+	client *redis.Client
+}
+
+// newRedisClaims creates claims that are shared by all Handlers that use the same Redis store
+func newRedisClaims(client *redis.Client, prefix string) messageClaims {
+	return &redisClaims{
+		prefix: prefix + ":claim:",
+		client: client,
+	}
+}
+
+func (c *redisClaims) Claim(key string, ttl time.Duration) (bool, error) {
+	return c.client.SetNX(c.prefix+key, 1, ttl).Result()
+}
+
+// claimDownlink returns true if this Handler should enqueue the downlink
+func (h *handler) claimDownlink(downlink *types.DownlinkMessage) bool {
+	if h.claims == nil || downlink.MessageID == "" {
+		return true
+	}
+	claimed, err := h.claims.Claim("downlink:"+downlink.AppID+":"+downlink.DevID+":"+downlink.MessageID, DownlinkClaimTTL)
+	if err != nil {
+		// Better to enqueue the downlink twice than not at all
+		h.Ctx.WithError(err).Warn("Could not claim downlink")
+		return true
+	}
+	return claimed
+}
@@ -0,0 +1,55 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestClaimDownlink(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	defer func() {
+		keys, _ := client.Keys("handler-test-claim-downlink:*").Result()
+		for _, key := range keys {
+			client.Del(key).Result()
+		}
+	}()
+
+	claims := newRedisClaims(client, "handler-test-claim-downlink")
+	h1 := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestClaimDownlink")},
+		claims:    claims,
+	}
+	h2 := &handler{
+		Component: &component.Component{Ctx: GetLogger(t, "TestClaimDownlink")},
+		claims:    claims,
+	}
+
+	downlink := &types.DownlinkMessage{AppID: "app", DevID: "dev", MessageID: "1", PayloadRaw: []byte{0xAA, 0xBC}}
+
+	// Only one Handler of the group enqueues the downlink
+	a.So(h1.claimDownlink(downlink), ShouldBeTrue)
+	a.So(h2.claimDownlink(downlink), ShouldBeFalse)
+	a.So(h1.claimDownlink(downlink), ShouldBeFalse)
+
+	// An identical downlink with a different message ID can be claimed
+	a.So(h2.claimDownlink(&types.DownlinkMessage{AppID: "app", DevID: "dev", MessageID: "2", PayloadRaw: []byte{0xAA, 0xBC}}), ShouldBeTrue)
+
+	// Downlink without a message ID is enqueued by all Handlers
+	withoutID := &types.DownlinkMessage{AppID: "app", DevID: "dev", PayloadRaw: []byte{0xAA, 0xBC}}
+	a.So(h1.claimDownlink(withoutID), ShouldBeTrue)
+	a.So(h2.claimDownlink(withoutID), ShouldBeTrue)
+
+	// Without claims, all downlink is enqueued
+	h3 := &handler{Component: &component.Component{Ctx: GetLogger(t, "TestClaimDownlink")}}
+	a.So(h3.claimDownlink(downlink), ShouldBeTrue)
+	a.So(h3.claimDownlink(downlink), ShouldBeTrue)
+}
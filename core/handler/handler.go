@@ -5,6 +5,9 @@ package handler
 
 import (
 	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
 
 	"github.com/TheThingsNetwork/ttn/amqp"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
@@ -12,8 +15,13 @@ import (
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/mqtt"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/viper"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"gopkg.in/redis.v5"
@@ -26,6 +34,11 @@ type Handler interface {
 
 	WithMQTT(username, password string, brokers ...string) Handler
 	WithAMQP(username, password, host, exchange string) Handler
+	WithQuotas(application, device quota.Limits) Handler
+	WithGroup() Handler
+	WithKeyEncryption(keys *storage.KeyRing) Handler
+	WithJoinLimits(limits JoinLimits) Handler
+	SetQuotaLimits(application, device quota.Limits)
 
 	HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) error
 	HandleActivationChallenge(challenge *pb_broker.ActivationChallengeRequest) (*pb_broker.ActivationChallengeResponse, error)
@@ -38,7 +51,11 @@ func NewRedisHandler(client *redis.Client, ttnBrokerID string) Handler {
 	return &handler{
 		devices:      device.NewRedisDeviceStore(client, "handler"),
 		applications: application.NewRedisApplicationStore(client, "handler"),
+		quotaStore:   quota.NewRedisStore(client, "handler"),
+		claims:       newRedisClaims(client, "handler"),
 		ttnBrokerID:  ttnBrokerID,
+
+		payloadFunctions: functions.NewPool(runtime.NumCPU(), functions.DefaultLimits),
 	}
 }
 
@@ -47,6 +64,17 @@ type handler struct {
 
 	devices      device.Store
 	applications application.Store
+	keys         *storage.KeyRing
+
+	quotaStore quota.Store
+	quota      *quota.Enforcer
+
+	claims     messageClaims
+	joinGroups bool
+
+	joinLimits JoinLimits
+
+	payloadFunctions *functions.Pool
 
 	ttnBrokerID      string
 	ttnBrokerConn    *grpc.ClientConn
@@ -55,13 +83,14 @@ type handler struct {
 
 	downlink chan *pb_broker.DownlinkMessage
 
-	mqttClient   mqtt.Client
-	mqttUsername string
-	mqttPassword string
-	mqttBrokers  []string
-	mqttEnabled  bool
-	mqttUp       chan *types.UplinkMessage
-	mqttEvent    chan *types.DeviceEvent
+	mqttClient     mqtt.Client
+	mqttUsername   string
+	mqttPassword   string
+	mqttBrokers    []string
+	mqttEnabled    bool
+	mqttUp         chan *types.UplinkMessage
+	mqttEvent      chan *types.DeviceEvent
+	mqttPublishing int32
 
 	amqpClient   amqp.Client
 	amqpUsername string
@@ -70,8 +99,10 @@ type handler struct {
 	amqpExchange string
 	amqpEnabled  bool
 	amqpUp       chan *types.UplinkMessage
+	amqpEvent    chan *types.DeviceEvent
 
-	status *status
+	status   *status
+	inFlight component.InFlight
 }
 
 var (
@@ -96,9 +127,46 @@ func (h *handler) WithAMQP(username, password, host, exchange string) Handler {
 	return h
 }
 
+func (h *handler) WithQuotas(application, device quota.Limits) Handler {
+	h.quota = quota.NewEnforcer(h.quotaStore, application, device)
+	return h
+}
+
+// WithGroup makes the Handler join the Handler group of applications that were already registered by another Handler
+// that shares its store, instead of refusing to register them
+func (h *handler) WithGroup() Handler {
+	h.joinGroups = true
+	return h
+}
+
+// keyEncrypter is implemented by stores that can encrypt keys at rest
+type keyEncrypter interface {
+	SetKeyRing(keys *storage.KeyRing)
+}
+
+func (h *handler) WithKeyEncryption(keys *storage.KeyRing) Handler {
+	h.keys = keys
+	if devices, ok := h.devices.(keyEncrypter); ok {
+		devices.SetKeyRing(keys)
+	}
+	return h
+}
+
+func (h *handler) SetQuotaLimits(application, device quota.Limits) {
+	if h.quota != nil {
+		h.quota.SetLimits(application, device)
+	}
+}
+
 func (h *handler) Init(c *component.Component) error {
 	h.Component = c
 	h.InitStatus()
+	h.Component.RegisterMetrics(newCollector(h))
+	go func(status *status) {
+		for range time.Tick(5 * time.Second) {
+			status.tickPayloadFunctions()
+		}
+	}(h.status)
 	err := h.Component.UpdateTokenKey()
 	if err != nil {
 		return err
@@ -132,12 +200,51 @@ func (h *handler) Init(c *component.Component) error {
 		return err
 	}
 
+	if err := h.Configure([]string{"handler.response-deadline", "handler.function-timeout"}, h.reloadSettings); err != nil {
+		h.Ctx.WithError(err).Warn("Invalid settings, using the defaults")
+	}
+
 	h.Component.SetStatus(component.StatusHealthy)
 
 	return nil
 }
 
+// reloadSettings validates the configured response deadline and payload function timeout, and returns a function
+// that applies them
+func (h *handler) reloadSettings() (func(), error) {
+	var deadline, timeout time.Duration
+	if viper.IsSet("handler.response-deadline") {
+		deadline = viper.GetDuration("handler.response-deadline")
+		if deadline <= 0 {
+			return nil, errors.NewErrInvalidArgument("Response deadline", "must be positive")
+		}
+	}
+	if viper.IsSet("handler.function-timeout") {
+		timeout = viper.GetDuration("handler.function-timeout")
+		if timeout <= 0 {
+			return nil, errors.NewErrInvalidArgument("Function timeout", "must be positive")
+		}
+	}
+	return func() {
+		if deadline != 0 {
+			SetResponseDeadline(deadline)
+		}
+		if timeout != 0 && h.payloadFunctions != nil {
+			limits := h.payloadFunctions.Limits()
+			limits.Timeout = timeout
+			h.payloadFunctions.SetLimits(limits)
+		}
+	}, nil
+}
+
 func (h *handler) Shutdown() {
+	deadline := h.ShutdownDeadline()
+	if dropped := h.inFlight.Close(deadline); dropped > 0 {
+		h.Ctx.WithField("Messages", dropped).Warn("Dropped messages that were still being handled")
+	}
+	if dropped := component.Drain(deadline, h.pendingPublishes); dropped > 0 {
+		h.Ctx.WithField("Messages", dropped).Warn("Dropped messages that were not yet published to the integrations")
+	}
 	if h.mqttEnabled {
 		h.mqttClient.Disconnect()
 	}
@@ -146,6 +253,11 @@ func (h *handler) Shutdown() {
 	}
 }
 
+// pendingPublishes returns the number of messages that are waiting to be published to the integrations
+func (h *handler) pendingPublishes() int {
+	return len(h.mqttUp) + len(h.mqttEvent) + int(atomic.LoadInt32(&h.mqttPublishing)) + len(h.amqpEvent)
+}
+
 func (h *handler) associateBroker() error {
 	broker, err := h.Discover("broker", h.ttnBrokerID)
 	if err != nil {
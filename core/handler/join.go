@@ -0,0 +1,141 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/utils/backoff"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// JoinLimits limit the join requests that the Handler accepts. The zero value keeps all used DevNonces and doesn't
+// limit the rate of joins.
+type JoinLimits struct {
+	// DevNonceRetention is the number of used DevNonces and AppNonces that are kept for each device. Older nonces can
+	// be used again. A zero value keeps all nonces.
+	DevNonceRetention int
+	// MonotonicDevNonce requires the DevNonce of each join request to be higher than the previous one, for devices
+	// that use a counter for the DevNonce. The counter wraps around: after 0xFFFF, the next DevNonce is 0x0000. A
+	// DevNonce is higher if it is less than 0x8000 ahead of the previous one. Only the last DevNonce and AppNonce are
+	// kept.
+	MonotonicDevNonce bool
+	// JoinInterval is the minimum time between two accepted joins of a device. It is doubled for every join that
+	// happens before the device has been quiet for twice its current interval, up to MaxJoinInterval. A zero value
+	// doesn't limit the rate of joins.
+	JoinInterval    time.Duration
+	MaxJoinInterval time.Duration
+}
+
+// Reasons for rejected join requests, used in the activations/errors events
+const (
+	JoinRejectedDevNonceUsed = "devnonce_already_used"
+	JoinRejectedDevNonceLow  = "devnonce_too_low"
+	JoinRejectedRateLimited  = "rate_limited"
+)
+
+func (h *handler) WithJoinLimits(limits JoinLimits) Handler {
+	h.joinLimits = limits
+	return h
+}
+
+// devNonceCounter returns the value of a DevNonce that is used as a counter
+func devNonceCounter(devNonce device.DevNonce) uint16 {
+	return binary.BigEndian.Uint16(devNonce[:])
+}
+
+// devNonceAfter returns true if the counter of the DevNonce is higher than the counter of the previous DevNonce,
+// taking into account that the counter wraps around after 0xFFFF
+func devNonceAfter(devNonce, previous device.DevNonce) bool {
+	diff := devNonceCounter(devNonce) - devNonceCounter(previous)
+	return diff != 0 && diff < 0x8000
+}
+
+// checkDevNonce returns the reason and error if the DevNonce can not be used by the device
+func (l JoinLimits) checkDevNonce(dev *device.Device, devNonce device.DevNonce) (reason string, err error) {
+	if l.MonotonicDevNonce {
+		if n := len(dev.UsedDevNonces); n > 0 && !devNonceAfter(devNonce, dev.UsedDevNonces[n-1]) {
+			return JoinRejectedDevNonceLow, errors.NewErrInvalidArgument("Activation DevNonce", "must be higher than the previous DevNonce")
+		}
+		return "", nil
+	}
+	for _, usedNonce := range dev.UsedDevNonces {
+		if usedNonce == devNonce {
+			return JoinRejectedDevNonceUsed, errors.NewErrInvalidArgument("Activation DevNonce", "already used")
+		}
+	}
+	return "", nil
+}
+
+// nonceRetention returns the number of used nonces that are kept for each device, or zero to keep all
+func (l JoinLimits) nonceRetention() int {
+	if l.MonotonicDevNonce {
+		return 1
+	}
+	return l.DevNonceRetention
+}
+
+// useDevNonce adds the DevNonce to the used DevNonces of the device, and removes the DevNonces that are no longer
+// retained
+func (l JoinLimits) useDevNonce(dev *device.Device, devNonce device.DevNonce) {
+	retention := l.nonceRetention()
+	used := append(dev.UsedDevNonces, devNonce)
+	if retention > 0 && len(used) > retention {
+		used = append([]device.DevNonce{}, used[len(used)-retention:]...)
+	}
+	dev.UsedDevNonces = used
+}
+
+// useAppNonce adds the AppNonce to the used AppNonces of the device, and removes the AppNonces that are no longer
+// retained
+func (l JoinLimits) useAppNonce(dev *device.Device, appNonce device.AppNonce) {
+	retention := l.nonceRetention()
+	used := append(dev.UsedAppNonces, appNonce)
+	if retention > 0 && len(used) > retention {
+		used = append([]device.AppNonce{}, used[len(used)-retention:]...)
+	}
+	dev.UsedAppNonces = used
+}
+
+// joinInterval returns the minimum time between the last join of the device and the next join
+func (l JoinLimits) joinInterval(recentJoins int) time.Duration {
+	maxInterval := l.MaxJoinInterval
+	if maxInterval < l.JoinInterval {
+		maxInterval = l.JoinInterval
+	}
+	return backoff.Config{
+		BaseDelay: l.JoinInterval,
+		MaxDelay:  maxInterval,
+		Factor:    2,
+	}.Backoff(recentJoins)
+}
+
+// checkJoinRate returns the time after which the device can join again, and an error if it joins before that time
+func (l JoinLimits) checkJoinRate(dev *device.Device, now time.Time) (retryAfter time.Time, err error) {
+	if l.JoinInterval <= 0 || dev.LastJoin.IsZero() {
+		return time.Time{}, nil
+	}
+	retryAfter = dev.LastJoin.Add(l.joinInterval(dev.RecentJoins))
+	if now.Before(retryAfter) {
+		return retryAfter, errors.NewErrResourceExhausted(fmt.Sprintf("Joins of device %s, retry after %s", dev.DevID, retryAfter.UTC().Format(time.RFC3339)))
+	}
+	return time.Time{}, nil
+}
+
+// join updates the join state of the device. Joins only count as recent if the device hasn't been quiet for twice
+// its current interval.
+func (l JoinLimits) join(dev *device.Device, now time.Time) {
+	if l.JoinInterval <= 0 {
+		return
+	}
+	if !dev.LastJoin.IsZero() && now.Sub(dev.LastJoin) < 2*l.joinInterval(dev.RecentJoins) {
+		dev.RecentJoins++
+	} else {
+		dev.RecentJoins = 0
+	}
+	dev.LastJoin = now
+}
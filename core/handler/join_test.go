@@ -0,0 +1,152 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDevNonceRetention(t *testing.T) {
+	a := New(t)
+
+	dev := &device.Device{}
+
+	// Keep all
+	var limits JoinLimits
+	for i := 0; i < 5; i++ {
+		nonce := device.DevNonce{0, byte(i)}
+		reason, err := limits.checkDevNonce(dev, nonce)
+		a.So(err, ShouldBeNil)
+		a.So(reason, ShouldBeEmpty)
+		limits.useDevNonce(dev, nonce)
+	}
+	a.So(dev.UsedDevNonces, ShouldHaveLength, 5)
+	reason, err := limits.checkDevNonce(dev, device.DevNonce{0, 0})
+	a.So(err, ShouldNotBeNil)
+	a.So(reason, ShouldEqual, JoinRejectedDevNonceUsed)
+
+	// Keep the last 3
+	limits.DevNonceRetention = 3
+	limits.useDevNonce(dev, device.DevNonce{0, 5})
+	a.So(dev.UsedDevNonces, ShouldResemble, []device.DevNonce{{0, 3}, {0, 4}, {0, 5}})
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0, 0})
+	a.So(err, ShouldBeNil)
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0, 4})
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestAppNonceRetention(t *testing.T) {
+	a := New(t)
+
+	dev := &device.Device{}
+
+	// Keep all
+	var limits JoinLimits
+	for i := 0; i < 5; i++ {
+		limits.useAppNonce(dev, device.AppNonce{0, 0, byte(i)})
+	}
+	a.So(dev.UsedAppNonces, ShouldHaveLength, 5)
+
+	// Keep the last 3
+	limits.DevNonceRetention = 3
+	limits.useAppNonce(dev, device.AppNonce{0, 0, 5})
+	a.So(dev.UsedAppNonces, ShouldResemble, []device.AppNonce{{0, 0, 3}, {0, 0, 4}, {0, 0, 5}})
+
+	// Keep the last one
+	limits.MonotonicDevNonce = true
+	limits.useAppNonce(dev, device.AppNonce{0, 0, 6})
+	a.So(dev.UsedAppNonces, ShouldResemble, []device.AppNonce{{0, 0, 6}})
+}
+
+func TestMonotonicDevNonce(t *testing.T) {
+	a := New(t)
+
+	limits := JoinLimits{MonotonicDevNonce: true}
+	dev := &device.Device{}
+
+	_, err := limits.checkDevNonce(dev, device.DevNonce{0x01, 0x00})
+	a.So(err, ShouldBeNil)
+	limits.useDevNonce(dev, device.DevNonce{0x01, 0x00})
+	a.So(dev.UsedDevNonces, ShouldResemble, []device.DevNonce{{0x01, 0x00}})
+
+	reason, err := limits.checkDevNonce(dev, device.DevNonce{0x01, 0x00})
+	a.So(err, ShouldNotBeNil)
+	a.So(reason, ShouldEqual, JoinRejectedDevNonceLow)
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0x00, 0xff})
+	a.So(err, ShouldNotBeNil)
+
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0x01, 0x01})
+	a.So(err, ShouldBeNil)
+	limits.useDevNonce(dev, device.DevNonce{0x01, 0x01})
+	a.So(dev.UsedDevNonces, ShouldResemble, []device.DevNonce{{0x01, 0x01}})
+
+	// Too far ahead to be the next DevNonce
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0x81, 0x01})
+	a.So(err, ShouldNotBeNil)
+
+	// The counter wraps around after 0xFFFF
+	limits.useDevNonce(dev, device.DevNonce{0xff, 0xff})
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0x00, 0x00})
+	a.So(err, ShouldBeNil)
+	limits.useDevNonce(dev, device.DevNonce{0x00, 0x00})
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0x00, 0x01})
+	a.So(err, ShouldBeNil)
+	_, err = limits.checkDevNonce(dev, device.DevNonce{0xff, 0xff})
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestJoinRate(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	dev := &device.Device{}
+
+	// Not limited
+	var limits JoinLimits
+	limits.join(dev, now)
+	_, err := limits.checkJoinRate(dev, now)
+	a.So(err, ShouldBeNil)
+	a.So(dev.LastJoin.IsZero(), ShouldBeTrue)
+
+	limits = JoinLimits{JoinInterval: time.Minute, MaxJoinInterval: 10 * time.Minute}
+
+	// First join
+	_, err = limits.checkJoinRate(dev, now)
+	a.So(err, ShouldBeNil)
+	limits.join(dev, now)
+	a.So(dev.RecentJoins, ShouldEqual, 0)
+
+	// Too soon
+	retryAfter, err := limits.checkJoinRate(dev, now.Add(30*time.Second))
+	a.So(err, ShouldNotBeNil)
+	a.So(errors.GetErrType(err), ShouldEqual, errors.ResourceExhausted)
+	a.So(retryAfter, ShouldResemble, now.Add(time.Minute))
+
+	// The interval is doubled for every join in quick succession
+	now = now.Add(time.Minute)
+	_, err = limits.checkJoinRate(dev, now)
+	a.So(err, ShouldBeNil)
+	limits.join(dev, now)
+	a.So(dev.RecentJoins, ShouldEqual, 1)
+	retryAfter, err = limits.checkJoinRate(dev, now.Add(time.Minute))
+	a.So(err, ShouldNotBeNil)
+	a.So(retryAfter, ShouldResemble, now.Add(2*time.Minute))
+
+	// Up to the maximum
+	dev.RecentJoins = 10
+	retryAfter, _ = limits.checkJoinRate(dev, now)
+	a.So(retryAfter, ShouldResemble, now.Add(10*time.Minute))
+
+	// The device was quiet
+	now = now.Add(time.Hour)
+	_, err = limits.checkJoinRate(dev, now)
+	a.So(err, ShouldBeNil)
+	limits.join(dev, now)
+	a.So(dev.RecentJoins, ShouldEqual, 0)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"github.com/TheThingsNetwork/go-account-lib/rights"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+)
+
+func (h *handlerManager) getDeviceForMACState(ctx context.Context, in *pb.DeviceIdentifier) (context.Context, *device.Device, error) {
+	if err := in.Validate(); err != nil {
+		return ctx, nil, errors.Wrap(err, "Invalid Device Identifier")
+	}
+
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return ctx, nil, err
+	}
+	err = checkAppRights(claims, in.AppId, rights.Devices)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	if _, err := h.handler.applications.Get(in.AppId); err != nil {
+		return ctx, nil, errors.Wrap(err, "Application not registered to this Handler")
+	}
+
+	dev, err := h.handler.devices.Get(in.AppId, in.DevId)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return ctx, dev, nil
+}
+
+func (h *handlerManager) GetDeviceMACState(ctx context.Context, in *pb.DeviceIdentifier) (*pb_lorawan.MACState, error) {
+	ctx, dev, err := h.getDeviceForMACState(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	state, err := h.deviceManager.GetMACState(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &dev.AppEUI, DevEui: &dev.DevEUI})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return MAC state")
+	}
+	state.AppId = dev.AppID
+	state.DevId = dev.DevID
+	return state, nil
+}
+
+func (h *handlerManager) ResetDeviceMACState(ctx context.Context, in *pb.DeviceIdentifier) (*empty.Empty, error) {
+	ctx, dev, err := h.getDeviceForMACState(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	_, err = h.deviceManager.ResetMACState(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &dev.AppEUI, DevEui: &dev.DevEUI})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not reset MAC state")
+	}
+	return &empty.Empty{}, nil
+}
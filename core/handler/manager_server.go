@@ -16,8 +16,10 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	"github.com/TheThingsNetwork/ttn/core/audit"
 	"github.com/TheThingsNetwork/ttn/core/handler/application"
 	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/handler/functions"
 	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
@@ -73,6 +75,31 @@ func (h *handlerManager) validateTTNAuthAppContext(ctx context.Context, appID st
 	return ctx, claims, nil
 }
 
+// deviceToProto returns the settings and keys of the device
+func deviceToProto(dev *device.Device) *pb.Device {
+	return &pb.Device{
+		AppId:       dev.AppID,
+		DevId:       dev.DevID,
+		Description: dev.Description,
+		Device: &pb.Device_LorawanDevice{LorawanDevice: &pb_lorawan.Device{
+			AppId:                 dev.AppID,
+			AppEui:                &dev.AppEUI,
+			DevId:                 dev.DevID,
+			DevEui:                &dev.DevEUI,
+			DevAddr:               &dev.DevAddr,
+			NwkSKey:               &dev.NwkSKey,
+			AppSKey:               &dev.AppSKey,
+			AppKey:                &dev.AppKey,
+			DisableFCntCheck:      dev.Options.DisableFCntCheck,
+			Uses32BitFCnt:         dev.Options.Uses32BitFCnt,
+			ActivationConstraints: dev.Options.ActivationConstraints,
+		}},
+		Latitude:  dev.Latitude,
+		Longitude: dev.Longitude,
+		Altitude:  dev.Altitude,
+	}
+}
+
 func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier) (*pb.Device, error) {
 	if err := in.Validate(); err != nil {
 		return nil, errors.Wrap(err, "Invalid Device Identifier")
@@ -96,27 +123,7 @@ func (h *handlerManager) GetDevice(ctx context.Context, in *pb.DeviceIdentifier)
 		return nil, err
 	}
 
-	pbDev := &pb.Device{
-		AppId:       dev.AppID,
-		DevId:       dev.DevID,
-		Description: dev.Description,
-		Device: &pb.Device_LorawanDevice{LorawanDevice: &pb_lorawan.Device{
-			AppId:                 dev.AppID,
-			AppEui:                &dev.AppEUI,
-			DevId:                 dev.DevID,
-			DevEui:                &dev.DevEUI,
-			DevAddr:               &dev.DevAddr,
-			NwkSKey:               &dev.NwkSKey,
-			AppSKey:               &dev.AppSKey,
-			AppKey:                &dev.AppKey,
-			DisableFCntCheck:      dev.Options.DisableFCntCheck,
-			Uses32BitFCnt:         dev.Options.Uses32BitFCnt,
-			ActivationConstraints: dev.Options.ActivationConstraints,
-		}},
-		Latitude:  dev.Latitude,
-		Longitude: dev.Longitude,
-		Altitude:  dev.Altitude,
-	}
+	pbDev := deviceToProto(dev)
 
 	nsDev, err := h.deviceManager.GetDevice(ctx, &pb_lorawan.DeviceIdentifier{
 		AppEui: &dev.AppEUI,
@@ -164,43 +171,93 @@ func (h *handlerManager) SetDevice(ctx context.Context, in *pb.Device) (*empty.E
 		return nil, errors.Wrap(err, "Application not registered to this Handler")
 	}
 
+	_, err = h.setDevice(ctx, claims, in, h.euiInUse(in.AppId), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// euiInUse returns a function for setDevice that checks the EUIs of the devices of the application in the store
+func (h *handlerManager) euiInUse(appID string) func(types.AppEUI, types.DevEUI) (bool, error) {
+	return func(appEUI types.AppEUI, devEUI types.DevEUI) (bool, error) {
+		existingDevices, err := h.handler.devices.ListForApp(appID, nil)
+		if err != nil {
+			return false, err
+		}
+		for _, existingDevice := range existingDevices {
+			if existingDevice.AppEUI == appEUI && existingDevice.DevEUI == devEUI {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// nsDeviceInSync returns whether the device in the NetworkServer has the settings of the device in the Handler
+func nsDeviceInSync(nsDev, dev *pb_lorawan.Device) bool {
+	return nsDev.AppId == dev.AppId && nsDev.DevId == dev.DevId &&
+		nsDev.AppEui != nil && *nsDev.AppEui == *dev.AppEui &&
+		nsDev.DevEui != nil && *nsDev.DevEui == *dev.DevEui &&
+		sameSession(dev, nsDev) &&
+		nsDev.DisableFCntCheck == dev.DisableFCntCheck &&
+		nsDev.Uses32BitFCnt == dev.Uses32BitFCnt
+}
+
+// sameSession returns whether the device in the NetworkServer still has the session of the exported device
+func sameSession(exported, existing *pb_lorawan.Device) bool {
+	var exportedAddr, existingAddr types.DevAddr
+	if exported.DevAddr != nil {
+		exportedAddr = *exported.DevAddr
+	}
+	if existing.DevAddr != nil {
+		existingAddr = *existing.DevAddr
+	}
+	var exportedKey, existingKey types.NwkSKey
+	if exported.NwkSKey != nil {
+		exportedKey = *exported.NwkSKey
+	}
+	if existing.NwkSKey != nil {
+		existingKey = *existing.NwkSKey
+	}
+	return exportedAddr == existingAddr && exportedKey == existingKey
+}
+
+// setDevice creates or updates a device of an application that the claims have rights to. When a device is created,
+// euiInUse is called to check if the AppEUI and DevEUI are already used by another device of the application. If
+// skipUnchanged is true, an existing device that would not change is not written, unless frame counters are given.
+//
+// The returned event type is empty if the device was not written.
+func (h *handlerManager) setDevice(ctx context.Context, claims *claims.Claims, in *pb.Device, euiInUse func(types.AppEUI, types.DevEUI) (bool, error), skipUnchanged bool) (types.EventType, error) {
 	dev, err := h.handler.devices.Get(in.AppId, in.DevId)
 	if err != nil && errors.GetErrType(err) != errors.NotFound {
-		return nil, err
+		return "", err
 	}
 
 	lorawan := in.GetLorawanDevice()
 	if lorawan == nil {
-		return nil, errors.NewErrInvalidArgument("Device", "No LoRaWAN Device")
+		return "", errors.NewErrInvalidArgument("Device", "No LoRaWAN Device")
 	}
 
 	var eventType types.EventType
+	var euiChanged bool
 	if dev != nil {
 		eventType = types.UpdateEvent
-		if dev.AppEUI != *lorawan.AppEui || dev.DevEUI != *lorawan.DevEui {
-			// If the AppEUI or DevEUI is changed, we should remove the device from the NetworkServer and re-add it later
-			_, err = h.deviceManager.DeleteDevice(ctx, &pb_lorawan.DeviceIdentifier{
-				AppEui: &dev.AppEUI,
-				DevEui: &dev.DevEUI,
-			})
-			if err != nil {
-				return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not delete device")
-			}
-		}
+		euiChanged = dev.AppEUI != *lorawan.AppEui || dev.DevEUI != *lorawan.DevEui
 		dev.StartUpdate()
 	} else {
 		eventType = types.CreateEvent
-		existingDevices, err := h.handler.devices.ListForApp(in.AppId, nil)
+		inUse, err := euiInUse(*lorawan.AppEui, *lorawan.DevEui)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		for _, existingDevice := range existingDevices {
-			if existingDevice.AppEUI == *lorawan.AppEui && existingDevice.DevEUI == *lorawan.DevEui {
-				return nil, errors.NewErrAlreadyExists("Device with AppEUI and DevEUI")
-			}
+		if inUse {
+			return "", errors.NewErrAlreadyExists("Device with AppEUI and DevEUI")
 		}
 		dev = new(device.Device)
 	}
+	oldAppEUI, oldDevEUI := dev.AppEUI, dev.DevEUI
 
 	dev.AppID = in.AppId
 	dev.AppEUI = *lorawan.AppEui
@@ -240,29 +297,64 @@ func (h *handlerManager) SetDevice(ctx context.Context, in *pb.Device) (*empty.E
 	dev.Longitude = in.Longitude
 	dev.Altitude = in.Altitude
 
-	// Update the device in the Broker (NetworkServer)
 	nsUpdated := dev.GetLoRaWAN()
 	nsUpdated.FCntUp = lorawan.FCntUp
 	nsUpdated.FCntDown = lorawan.FCntDown
 
+	// Rows of a bulk set only set the frame counters when they are not zero. Otherwise, the frame counters of the
+	// NetworkServer are kept, and the device is skipped if it did not change in the Handler or the NetworkServer.
+	if skipUnchanged && eventType == types.UpdateEvent && lorawan.FCntUp == 0 && lorawan.FCntDown == 0 {
+		nsDev, err := h.deviceManager.GetDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &oldAppEUI, DevEui: &oldDevEUI})
+		switch {
+		case errors.GetErrType(errors.FromGRPCError(err)) == errors.NotFound:
+			// The device is added to the NetworkServer again
+		case err != nil:
+			return "", errors.Wrap(errors.FromGRPCError(err), "Broker did not return device")
+		default:
+			nsUpdated.FCntUp, nsUpdated.FCntDown = nsDev.FCntUp, nsDev.FCntDown
+			if len(dev.ChangedFields()) == 0 && nsDeviceInSync(nsDev, nsUpdated) {
+				return "", nil
+			}
+		}
+	}
+
+	if euiChanged {
+		// If the AppEUI or DevEUI is changed, we should remove the device from the NetworkServer and re-add it later
+		_, err = h.deviceManager.DeleteDevice(ctx, &pb_lorawan.DeviceIdentifier{
+			AppEui: &oldAppEUI,
+			DevEui: &oldDevEUI,
+		})
+		if err != nil {
+			return "", errors.Wrap(errors.FromGRPCError(err), "Broker did not delete device")
+		}
+	}
+
+	// Update the device in the Broker (NetworkServer)
 	_, err = h.deviceManager.SetDevice(ctx, nsUpdated)
 	if err != nil {
-		return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not set device")
+		return "", errors.Wrap(errors.FromGRPCError(err), "Broker did not set device")
 	}
 
 	err = h.handler.devices.Set(dev)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	h.handler.mqttEvent <- &types.DeviceEvent{
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action:  audit.SetDevice,
+		AppID:   dev.AppID,
+		DevID:   dev.DevID,
+		Changed: audit.Changes(dev),
+	})
+
+	h.handler.publishEvent(&types.DeviceEvent{
 		AppID: dev.AppID,
 		DevID: dev.DevID,
 		Event: eventType,
 		Data:  nil, // Don't send potentially sensitive details over MQTT
-	}
+	})
 
-	return &empty.Empty{}, nil
+	return eventType, nil
 }
 
 func (h *handlerManager) DeleteDevice(ctx context.Context, in *pb.DeviceIdentifier) (*empty.Empty, error) {
@@ -294,11 +386,16 @@ func (h *handlerManager) DeleteDevice(ctx context.Context, in *pb.DeviceIdentifi
 	if err != nil {
 		return nil, err
 	}
-	h.handler.mqttEvent <- &types.DeviceEvent{
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action: audit.DeleteDevice,
+		AppID:  in.AppId,
+		DevID:  in.DevId,
+	})
+	h.handler.publishEvent(&types.DeviceEvent{
 		AppID: in.AppId,
 		DevID: in.DevId,
 		Event: types.DeleteEvent,
-	}
+	})
 	return &empty.Empty{}, nil
 }
 
@@ -387,6 +484,7 @@ func (h *handlerManager) GetApplication(ctx context.Context, in *pb.ApplicationI
 		Converter: app.Converter,
 		Validator: app.Validator,
 		Encoder:   app.Encoder,
+		Engine:    app.Engine,
 	}, nil
 }
 
@@ -406,19 +504,34 @@ func (h *handlerManager) RegisterApplication(ctx context.Context, in *pb.Applica
 	if err != nil && errors.GetErrType(err) != errors.NotFound {
 		return nil, err
 	}
+	var joinGroup bool
 	if app != nil {
-		return nil, errors.NewErrAlreadyExists("Application")
-	}
+		// The Handlers of a group share their store, so another Handler of the group may have registered the
+		// Application. If this Handler is configured to join groups, it joins the group of that Handler.
+		if !h.handler.joinGroups || h.announcesAppID(in.AppId) {
+			return nil, errors.NewErrAlreadyExists("Application")
+		}
+		joinGroup = true
+	} else {
+		err = h.handler.applications.Set(&application.Application{
+			AppID: in.AppId,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	err = h.handler.applications.Set(&application.Application{
-		AppID: in.AppId,
-	})
-	if err != nil {
-		return nil, err
+		h.handler.RecordAudit(claims, &audit.Entry{
+			Action: audit.RegisterApplication,
+			AppID:  in.AppId,
+		})
 	}
 
 	token, _ := api.TokenFromContext(ctx)
-	err = h.handler.Discovery.AddAppID(in.AppId, token)
+	if joinGroup {
+		err = h.handler.Discovery.JoinAppID(in.AppId, token)
+	} else {
+		err = h.handler.Discovery.AddAppID(in.AppId, token)
+	}
 	if err != nil {
 		h.handler.Ctx.WithField("AppID", in.AppId).WithError(err).Warn("Could not register Application with Discovery")
 	}
@@ -435,6 +548,40 @@ func (h *handlerManager) RegisterApplication(ctx context.Context, in *pb.Applica
 
 }
 
+// announcesAppID returns true if this Handler announces the AppID in Discovery
+func (h *handlerManager) announcesAppID(appID string) bool {
+	announcement, err := h.handler.Discovery.Get("handler", h.handler.Identity.Id)
+	if err != nil {
+		return false
+	}
+	for _, announcedAppID := range announcement.AppIDs() {
+		if announcedAppID == appID {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePayloadFunctions compiles the payload functions of an application,
+// so that syntax errors are reported before the first message is processed
+func compilePayloadFunctions(in *pb.Application) error {
+	payloadFunctions := []struct {
+		name   string
+		source string
+	}{
+		{"Decoder", in.Decoder},
+		{"Converter", in.Converter},
+		{"Validator", in.Validator},
+		{"Encoder", in.Encoder},
+	}
+	for _, function := range payloadFunctions {
+		if err := functions.Compile(in.Engine, function.name, function.source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (h *handlerManager) SetApplication(ctx context.Context, in *pb.Application) (*empty.Empty, error) {
 	if err := in.Validate(); err != nil {
 		return nil, errors.Wrap(err, "Invalid Application")
@@ -447,6 +594,9 @@ func (h *handlerManager) SetApplication(ctx context.Context, in *pb.Application)
 	if err != nil {
 		return nil, err
 	}
+	if err := compilePayloadFunctions(in); err != nil {
+		return nil, errors.Wrap(err, "Invalid Application")
+	}
 	app, err := h.handler.applications.Get(in.AppId)
 	if err != nil {
 		return nil, err
@@ -458,12 +608,23 @@ func (h *handlerManager) SetApplication(ctx context.Context, in *pb.Application)
 	app.Converter = in.Converter
 	app.Validator = in.Validator
 	app.Encoder = in.Encoder
+	app.Engine = in.Engine
 
 	err = h.handler.applications.Set(app)
 	if err != nil {
 		return nil, err
 	}
 
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action:  audit.SetApplication,
+		AppID:   app.AppID,
+		Changed: audit.Changes(app),
+	})
+
+	if h.handler.payloadFunctions != nil {
+		h.handler.payloadFunctions.Invalidate(in.AppId)
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -480,7 +641,7 @@ func (h *handlerManager) DeleteApplication(ctx context.Context, in *pb.Applicati
 		return nil, err
 	}
 
-	_, err = h.handler.applications.Get(in.AppId)
+	app, err := h.handler.applications.Get(in.AppId)
 	if err != nil {
 		return nil, err
 	}
@@ -491,9 +652,12 @@ func (h *handlerManager) DeleteApplication(ctx context.Context, in *pb.Applicati
 		return nil, err
 	}
 	for _, dev := range devices {
-		_, err = h.deviceManager.DeleteDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &dev.AppEUI, DevEui: &dev.DevEUI})
-		if err != nil {
-			return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not delete device")
+		// The devices of a migrated application are still used by the other Handler
+		if app.MigratedTo == "" {
+			_, err = h.deviceManager.DeleteDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &dev.AppEUI, DevEui: &dev.DevEUI})
+			if err != nil {
+				return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not delete device")
+			}
 		}
 		err = h.handler.devices.Delete(dev.AppID, dev.DevID)
 		if err != nil {
@@ -507,6 +671,16 @@ func (h *handlerManager) DeleteApplication(ctx context.Context, in *pb.Applicati
 		return nil, err
 	}
 
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action: audit.DeleteApplication,
+		AppID:  in.AppId,
+	})
+
+	if h.handler.payloadFunctions != nil {
+		h.handler.payloadFunctions.Invalidate(in.AppId)
+	}
+	h.handler.status.removePayloadFunction(in.AppId)
+
 	token, _ := api.TokenFromContext(ctx)
 	err = h.handler.Discovery.RemoveAppID(in.AppId, token)
 	if err != nil {
@@ -558,6 +732,8 @@ func (h *handler) RegisterManager(s *grpc.Server) {
 
 	server.applicationRate = ratelimit.NewRegistry(5000, time.Hour)
 	server.clientRate = ratelimit.NewRegistry(5000, time.Hour)
+	h.ConfigureRate("handler.application-rate", server.applicationRate, time.Hour)
+	h.ConfigureRate("handler.client-rate", server.clientRate, time.Hour)
 
 	pb.RegisterHandlerManagerServer(s, server)
 	pb.RegisterApplicationManagerServer(s, server)
@@ -0,0 +1,67 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exposes the status of the handler as Prometheus metrics
+type collector struct {
+	handler *handler
+
+	uplink                  *prometheus.Desc
+	downlink                *prometheus.Desc
+	activations             *prometheus.Desc
+	quotaExceeded           *prometheus.Desc
+	payloadFunctionDuration *prometheus.Desc
+	payloadFunctionRuns     *prometheus.Desc
+	payloadFunctionErrors   *prometheus.Desc
+}
+
+func newCollector(h *handler) *collector {
+	return &collector{
+		handler:                 h,
+		uplink:                  h.Component.NewMetricDesc("uplink_total", "Total number of handled uplink messages"),
+		downlink:                h.Component.NewMetricDesc("downlink_total", "Total number of handled downlink messages"),
+		activations:             h.Component.NewMetricDesc("activations_total", "Total number of accepted activations"),
+		quotaExceeded:           h.Component.NewMetricDesc("quota_exceeded_total", "Total number of messages that were dropped because a quota was exceeded"),
+		payloadFunctionDuration: h.Component.NewMetricDesc("payload_function_duration_seconds", "Duration of the payload functions of an application", "app_id"),
+		payloadFunctionRuns:     h.Component.NewMetricDesc("payload_function_runs_total", "Total number of runs of the payload functions of an application", "app_id"),
+		payloadFunctionErrors:   h.Component.NewMetricDesc("payload_function_errors_total", "Total number of failed runs of the payload functions of an application", "app_id"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.uplink
+	ch <- c.downlink
+	ch <- c.activations
+	ch <- c.quotaExceeded
+	ch <- c.payloadFunctionDuration
+	ch <- c.payloadFunctionRuns
+	ch <- c.payloadFunctionErrors
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	status := c.handler.status
+	if status == nil {
+		return
+	}
+	ch <- component.MeterMetric(c.uplink, status.uplink)
+	ch <- component.MeterMetric(c.downlink, status.downlink)
+	ch <- component.MeterMetric(c.activations, status.activations)
+	ch <- component.MeterMetric(c.quotaExceeded, status.quotaExceeded)
+
+	status.payloadFunctionsLock.Lock()
+	defer status.payloadFunctionsLock.Unlock()
+	for appID, function := range status.payloadFunctions {
+		// The durations are recorded in microseconds
+		ch <- component.HistogramMetric(c.payloadFunctionDuration, function.duration, 1e-6, appID)
+		ch <- component.MeterMetric(c.payloadFunctionRuns, function.runs, appID)
+		ch <- component.MeterMetric(c.payloadFunctionErrors, function.errors, appID)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestMetrics(t *testing.T) {
+	a := New(t)
+	h := &handler{
+		Component: &component.Component{
+			Identity: &pb_discovery.Announcement{Id: "test", ServiceName: "handler"},
+		},
+	}
+	h.InitStatus()
+	h.status.payloadFunction("app", 2*time.Millisecond, nil)
+	h.status.payloadFunction("app", 4*time.Millisecond, errors.New("Error"))
+
+	registry := prometheus.NewRegistry()
+	a.So(registry.Register(newCollector(h)), ShouldBeNil)
+
+	families, err := registry.Gather()
+	a.So(err, ShouldBeNil)
+	var found int
+	for _, family := range families {
+		switch family.GetName() {
+		case "ttn_handler_payload_function_duration_seconds":
+			found++
+			a.So(family.GetMetric()[0].GetSummary().GetSampleCount(), ShouldEqual, 2)
+			a.So(family.GetMetric()[0].GetSummary().GetSampleSum(), ShouldAlmostEqual, 0.006)
+		case "ttn_handler_payload_function_runs_total":
+			found++
+			a.So(family.GetMetric()[0].GetCounter().GetValue(), ShouldEqual, 2)
+		case "ttn_handler_payload_function_errors_total":
+			found++
+			a.So(family.GetMetric()[0].GetCounter().GetValue(), ShouldEqual, 1)
+		}
+	}
+	a.So(found, ShouldEqual, 3)
+}
@@ -0,0 +1,455 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/rights"
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api"
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/audit"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/security"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+)
+
+// ApplicationExportTTL is how long an exported application can be imported into another Handler
+var ApplicationExportTTL = 10 * time.Minute
+
+// applicationState is the state of an application that is exported from one Handler and imported into another
+type applicationState struct {
+	AppID       string                   `json:"app_id"`
+	From        string                   `json:"from"`
+	To          string                   `json:"to"`
+	ExportedAt  time.Time                `json:"exported_at"`
+	Application *application.Application `json:"application"`
+	Devices     []*deviceState           `json:"devices"`
+}
+
+// deviceState is the state of a device in an applicationState, including the frame counters of the NetworkServer
+type deviceState struct {
+	Device        *device.Device           `json:"device"`
+	DownlinkQueue []*types.DownlinkMessage `json:"downlink_queue,omitempty"`
+	FCntUp        uint32                   `json:"f_cnt_up"`
+	FCntDown      uint32                   `json:"f_cnt_down"`
+}
+
+// check that the state is an export of the application from one Handler to another that has not expired yet
+func (s *applicationState) check(appID, from, to string, now time.Time) error {
+	if s.AppID != appID || s.Application == nil || s.Application.AppID != appID {
+		return errors.NewErrInvalidArgument("State", fmt.Sprintf("not an export of application %s", appID))
+	}
+	if s.From != from {
+		return errors.NewErrInvalidArgument("State", fmt.Sprintf("not exported by Handler %s", from))
+	}
+	if s.To != to {
+		return errors.NewErrInvalidArgument("State", fmt.Sprintf("not exported to Handler %s", to))
+	}
+	if now.Sub(s.ExportedAt) > ApplicationExportTTL {
+		return errors.NewErrInvalidArgument("State", "export has expired")
+	}
+	for _, dev := range s.Devices {
+		if dev == nil || dev.Device == nil || dev.Device.AppID != appID {
+			return errors.NewErrInvalidArgument("State", fmt.Sprintf("device not in application %s", appID))
+		}
+	}
+	return nil
+}
+
+// checkApplicationFrozen returns an error if the application was migrated to another Handler, or is being exported to
+// one, so that devices do not join and downlink is not sent by both Handlers
+func (h *handler) checkApplicationFrozen(appID string) error {
+	app, err := h.applications.Get(appID)
+	if errors.GetErrType(err) == errors.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if app.MigratedTo != "" {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("Application %s was migrated to Handler %s", appID, app.MigratedTo))
+	}
+	if app.ExportedTo != "" && time.Now().Sub(app.ExportedAt) <= ApplicationExportTTL {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("Application %s is being exported to Handler %s", appID, app.ExportedTo))
+	}
+	return nil
+}
+
+// confirms returns whether the state is the last export of the application by one Handler to the other
+func (s *applicationState) confirms(app *application.Application, from, to string) bool {
+	return s.AppID == app.AppID && s.From == from && s.To == to && app.ExportedTo == to && s.ExportedAt.Equal(app.ExportedAt)
+}
+
+// exportApplicationState returns the state of the application and its devices in the stores of the Handler
+func (h *handler) exportApplicationState(appID string) (*applicationState, error) {
+	app, err := h.applications.Get(appID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := h.devices.ListForApp(appID, nil)
+	if err != nil {
+		return nil, err
+	}
+	state := &applicationState{
+		AppID:       appID,
+		From:        h.Identity.Id,
+		ExportedAt:  time.Now().UTC(),
+		Application: app,
+		Devices:     make([]*deviceState, 0, len(devices)),
+	}
+	for _, dev := range devices {
+		if dev == nil {
+			continue
+		}
+		queue, err := h.devices.DownlinkQueue(dev.AppID, dev.DevID)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := queue.All()
+		if err != nil {
+			return nil, err
+		}
+		state.Devices = append(state.Devices, &deviceState{Device: dev, DownlinkQueue: msgs})
+	}
+	return state, nil
+}
+
+// importApplicationState writes the application and its devices to the stores of the Handler
+func (h *handler) importApplicationState(state *applicationState) error {
+	app := *state.Application
+	app.MigratedTo = ""
+	app.ExportedTo = ""
+	app.ExportedAt = time.Time{}
+	if err := h.applications.Set(&app); err != nil {
+		return err
+	}
+	for _, dev := range state.Devices {
+		if err := h.devices.Set(dev.Device); err != nil {
+			return err
+		}
+		queue, err := h.devices.DownlinkQueue(dev.Device.AppID, dev.Device.DevID)
+		if err != nil {
+			return err
+		}
+		for _, msg := range dev.DownlinkQueue {
+			if err := queue.PushLast(msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteApplicationState deletes the application and its devices from the stores of the Handler
+func (h *handler) deleteApplicationState(state *applicationState) {
+	for _, dev := range state.Devices {
+		h.devices.Delete(dev.Device.AppID, dev.Device.DevID)
+	}
+	h.applications.Delete(state.AppID)
+}
+
+func (h *handlerManager) ExportApplication(ctx context.Context, in *pb.ApplicationExportRequest) (*pb.ApplicationExport, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Application Export Request")
+	}
+	if in.HandlerId == h.handler.Identity.Id {
+		return nil, errors.NewErrInvalidArgument("HandlerId", "can not export to the same Handler")
+	}
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	for _, right := range []types.Right{rights.AppSettings, rights.Devices} {
+		if err := checkAppRights(claims, in.AppId, right); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.handler.checkApplicationFrozen(in.AppId); err != nil {
+		return nil, err
+	}
+
+	// Freeze the application until the other Handler confirms the import or the export expires
+	app, err := h.handler.applications.Get(in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	app.StartUpdate()
+	app.ExportedTo = in.HandlerId
+	app.ExportedAt = time.Now().UTC()
+	if err := h.handler.applications.Set(app); err != nil {
+		return nil, err
+	}
+	unfreeze := func() {
+		app.StartUpdate()
+		app.ExportedTo = ""
+		app.ExportedAt = time.Time{}
+		h.handler.applications.Set(app)
+	}
+
+	state, err := h.handler.exportApplicationState(in.AppId)
+	if err != nil {
+		unfreeze()
+		return nil, err
+	}
+	state.To = in.HandlerId
+	state.ExportedAt = app.ExportedAt
+
+	for _, dev := range state.Devices {
+		nsDev, err := h.deviceManager.GetDevice(ctx, &pb_lorawan.DeviceIdentifier{
+			AppEui: &dev.Device.AppEUI,
+			DevEui: &dev.Device.DevEUI,
+		})
+		if errors.GetErrType(errors.FromGRPCError(err)) == errors.NotFound {
+			continue
+		}
+		if err != nil {
+			unfreeze()
+			return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return device")
+		}
+		dev.FCntUp, dev.FCntDown = nsDev.FCntUp, nsDev.FCntDown
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		unfreeze()
+		return nil, err
+	}
+	signature, err := h.handler.Sign(data)
+	if err != nil {
+		unfreeze()
+		return nil, err
+	}
+
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action: audit.ExportApplication,
+		AppID:  in.AppId,
+		Target: in.HandlerId,
+	})
+
+	return &pb.ApplicationExport{
+		AppId:     in.AppId,
+		HandlerId: h.handler.Identity.Id,
+		State:     data,
+		Signature: signature,
+	}, nil
+}
+
+func (h *handlerManager) ImportApplication(ctx context.Context, in *pb.ApplicationExport) (*pb.ApplicationExport, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Application Export")
+	}
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	for _, right := range []types.Right{rights.AppSettings, rights.Devices} {
+		if err := checkAppRights(claims, in.AppId, right); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := h.handler.Discover("handler", in.HandlerId)
+	if err != nil {
+		return nil, err
+	}
+	if source.PublicKey == "" {
+		return nil, errors.NewErrInvalidArgument("HandlerId", "Handler has no public key to verify the export with")
+	}
+	if err := security.VerifySignature(in.State, in.Signature, []byte(source.PublicKey)); err != nil {
+		return nil, errors.NewErrPermissionDenied(err.Error())
+	}
+
+	state := new(applicationState)
+	if err := json.Unmarshal(in.State, state); err != nil {
+		return nil, errors.NewErrInvalidArgument("State", err.Error())
+	}
+	if err := state.check(in.AppId, in.HandlerId, h.handler.Identity.Id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The state signed by this Handler confirms the import to the source Handler
+	receipt, err := h.handler.Sign(in.State)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := h.handler.applications.Get(in.AppId)
+	if err != nil && errors.GetErrType(err) != errors.NotFound {
+		return nil, err
+	}
+	if app != nil {
+		return nil, errors.NewErrAlreadyExists("Application")
+	}
+
+	if err := h.handler.importApplicationState(state); err != nil {
+		h.handler.deleteApplicationState(state)
+		return nil, err
+	}
+
+	// If the import fails, devices that were not in the NetworkServer yet are deleted from it again, and devices that
+	// were overwritten get their previous state back
+	var created []*device.Device
+	var overwritten []*pb_lorawan.Device
+	rollback := func() {
+		for _, dev := range created {
+			_, err := h.deviceManager.DeleteDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: &dev.AppEUI, DevEui: &dev.DevEUI})
+			if err != nil {
+				h.handler.Ctx.WithFields(ttnlog.Fields{"AppID": dev.AppID, "DevID": dev.DevID}).WithError(err).Warn("Could not delete imported device from NetworkServer")
+			}
+		}
+		for _, nsDev := range overwritten {
+			if _, err := h.deviceManager.SetDevice(ctx, nsDev); err != nil {
+				h.handler.Ctx.WithFields(ttnlog.Fields{"AppID": nsDev.AppId, "DevID": nsDev.DevId}).WithError(err).Warn("Could not restore device in NetworkServer")
+			}
+		}
+		h.handler.deleteApplicationState(state)
+	}
+
+	for _, dev := range state.Devices {
+		nsDev := dev.Device.GetLoRaWAN()
+		nsDev.FCntUp, nsDev.FCntDown = dev.FCntUp, dev.FCntDown
+		existing, err := h.deviceManager.GetDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: nsDev.AppEui, DevEui: nsDev.DevEui})
+		switch {
+		case errors.GetErrType(errors.FromGRPCError(err)) == errors.NotFound:
+			created = append(created, dev.Device)
+		case err != nil:
+			rollback()
+			return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return device")
+		default:
+			// The source Handler does not accept joins after the export, so the session must still be the same
+			if !sameSession(nsDev, existing) {
+				rollback()
+				return nil, errors.NewErrInvalidArgument("State", fmt.Sprintf("session of device %s changed since the export", dev.Device.DevID))
+			}
+			// The NetworkServer may have seen frames since the export
+			if existing.FCntUp > nsDev.FCntUp {
+				nsDev.FCntUp = existing.FCntUp
+			}
+			if existing.FCntDown > nsDev.FCntDown {
+				nsDev.FCntDown = existing.FCntDown
+			}
+			overwritten = append(overwritten, existing)
+		}
+		if _, err := h.deviceManager.SetDevice(ctx, nsDev); err != nil {
+			rollback()
+			return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not set device")
+		}
+	}
+
+	// Discovery moves the AppID from the announcement of the source Handler to ours in one transaction
+	token, _ := api.TokenFromContext(ctx)
+	if err := h.handler.Discovery.AddAppID(in.AppId, token); err != nil {
+		rollback()
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Could not register Application with Discovery")
+	}
+
+	_, err = h.handler.ttnBrokerManager.RegisterApplicationHandler(ctx, &pb_broker.ApplicationHandlerRegistration{
+		AppId:     in.AppId,
+		HandlerId: h.handler.Identity.Id,
+	})
+	if err != nil {
+		h.handler.Ctx.WithField("AppID", in.AppId).WithError(err).Warn("Could not register Application with Broker")
+	}
+
+	if h.handler.payloadFunctions != nil {
+		h.handler.payloadFunctions.Invalidate(in.AppId)
+	}
+
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action: audit.ImportApplication,
+		AppID:  in.AppId,
+		Target: in.HandlerId,
+	})
+
+	h.handler.Ctx.WithFields(ttnlog.Fields{
+		"AppID":   in.AppId,
+		"From":    in.HandlerId,
+		"Devices": len(state.Devices),
+	}).Info("Imported application")
+
+	return &pb.ApplicationExport{
+		AppId:     in.AppId,
+		HandlerId: h.handler.Identity.Id,
+		State:     in.State,
+		Signature: receipt,
+	}, nil
+}
+
+func (h *handlerManager) ConfirmApplicationExport(ctx context.Context, in *pb.ApplicationExport) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Application Export")
+	}
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	for _, right := range []types.Right{rights.AppSettings, rights.Devices} {
+		if err := checkAppRights(claims, in.AppId, right); err != nil {
+			return nil, err
+		}
+	}
+
+	target, err := h.handler.Discover("handler", in.HandlerId)
+	if err != nil {
+		return nil, err
+	}
+	if target.PublicKey == "" {
+		return nil, errors.NewErrInvalidArgument("HandlerId", "Handler has no public key to verify the import with")
+	}
+	if err := security.VerifySignature(in.State, in.Signature, []byte(target.PublicKey)); err != nil {
+		return nil, errors.NewErrPermissionDenied(err.Error())
+	}
+
+	state := new(applicationState)
+	if err := json.Unmarshal(in.State, state); err != nil {
+		return nil, errors.NewErrInvalidArgument("State", err.Error())
+	}
+
+	app, err := h.handler.applications.Get(in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	if !state.confirms(app, h.handler.Identity.Id, in.HandlerId) {
+		return nil, errors.NewErrInvalidArgument("State", "not the last export of the application")
+	}
+
+	// The other Handler sends the queued downlink from now on
+	for _, dev := range state.Devices {
+		queue, err := h.handler.devices.DownlinkQueue(dev.Device.AppID, dev.Device.DevID)
+		if err != nil {
+			return nil, err
+		}
+		if err := queue.Clear(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remember where the application went, so that deleting it here does not delete it from the NetworkServer
+	app.StartUpdate()
+	app.MigratedTo = in.HandlerId
+	app.ExportedTo = ""
+	app.ExportedAt = time.Time{}
+	if err := h.handler.applications.Set(app); err != nil {
+		return nil, err
+	}
+
+	h.handler.RecordAudit(claims, &audit.Entry{
+		Action: audit.MigrateApplication,
+		AppID:  in.AppId,
+		Target: in.HandlerId,
+	})
+
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/handler/application"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestApplicationStateCheck(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	state := &applicationState{
+		AppID:       "app",
+		From:        "handler1",
+		To:          "handler2",
+		ExportedAt:  now,
+		Application: &application.Application{AppID: "app"},
+		Devices:     []*deviceState{{Device: &device.Device{AppID: "app", DevID: "dev"}}},
+	}
+	a.So(state.check("app", "handler1", "handler2", now), ShouldBeNil)
+
+	a.So(state.check("other-app", "handler1", "handler2", now), ShouldNotBeNil)
+	a.So(state.check("app", "handler3", "handler2", now), ShouldNotBeNil)
+	a.So(state.check("app", "handler1", "handler3", now), ShouldNotBeNil)
+	a.So(state.check("app", "handler1", "handler2", now.Add(ApplicationExportTTL+time.Second)), ShouldNotBeNil)
+
+	state.Devices = append(state.Devices, &deviceState{Device: &device.Device{AppID: "other-app", DevID: "dev"}})
+	a.So(state.check("app", "handler1", "handler2", now), ShouldNotBeNil)
+}
+
+func TestApplicationStateConfirms(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	app := &application.Application{AppID: "app", ExportedTo: "handler2", ExportedAt: now}
+	state := &applicationState{AppID: "app", From: "handler1", To: "handler2", ExportedAt: now}
+	a.So(state.confirms(app, "handler1", "handler2"), ShouldBeTrue)
+	a.So(state.confirms(app, "handler1", "handler3"), ShouldBeFalse)
+	a.So(state.confirms(app, "handler3", "handler2"), ShouldBeFalse)
+
+	// A later export to the same Handler replaces this one
+	app.ExportedAt = now.Add(time.Second)
+	a.So(state.confirms(app, "handler1", "handler2"), ShouldBeFalse)
+}
+
+func TestSameSession(t *testing.T) {
+	a := New(t)
+
+	devAddr, otherDevAddr := types.DevAddr{1, 2, 3, 4}, types.DevAddr{1, 2, 3, 5}
+	nwkSKey, otherNwkSKey := types.NwkSKey{1, 2, 3, 4}, types.NwkSKey{1, 2, 3, 5}
+
+	exported := &pb_lorawan.Device{DevAddr: &devAddr, NwkSKey: &nwkSKey}
+	a.So(sameSession(exported, &pb_lorawan.Device{DevAddr: &devAddr, NwkSKey: &nwkSKey, FCntUp: 42}), ShouldBeTrue)
+	a.So(sameSession(exported, &pb_lorawan.Device{DevAddr: &otherDevAddr, NwkSKey: &nwkSKey}), ShouldBeFalse)
+	a.So(sameSession(exported, &pb_lorawan.Device{DevAddr: &devAddr, NwkSKey: &otherNwkSKey}), ShouldBeFalse)
+	a.So(sameSession(exported, &pb_lorawan.Device{}), ShouldBeFalse)
+}
+
+func TestCheckApplicationFrozen(t *testing.T) {
+	a := New(t)
+
+	h := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestCheckApplicationFrozen")},
+		applications: application.NewRedisApplicationStore(GetRedisClient(), "handler-test-check-application-frozen"),
+	}
+	defer h.applications.Delete("app")
+
+	a.So(h.checkApplicationFrozen("app"), ShouldBeNil)
+
+	app := &application.Application{AppID: "app"}
+	h.applications.Set(app)
+	a.So(h.checkApplicationFrozen("app"), ShouldBeNil)
+
+	app.StartUpdate()
+	app.ExportedTo, app.ExportedAt = "handler2", time.Now()
+	h.applications.Set(app)
+	a.So(h.checkApplicationFrozen("app"), ShouldNotBeNil)
+
+	// The export expired without being confirmed
+	app.StartUpdate()
+	app.ExportedAt = time.Now().Add(-1 * (ApplicationExportTTL + time.Second))
+	h.applications.Set(app)
+	a.So(h.checkApplicationFrozen("app"), ShouldBeNil)
+
+	app.StartUpdate()
+	app.ExportedTo, app.ExportedAt = "", time.Time{}
+	app.MigratedTo = "handler2"
+	h.applications.Set(app)
+	a.So(h.checkApplicationFrozen("app"), ShouldNotBeNil)
+}
+
+func TestExportImportApplicationState(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	h1 := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestExportImportApplicationState"), Identity: &pb_discovery.Announcement{Id: "handler1"}},
+		applications: application.NewRedisApplicationStore(client, "handler-test-export-application-1"),
+		devices:      device.NewRedisDeviceStore(client, "handler-test-export-application-1"),
+	}
+	h2 := &handler{
+		Component:    &component.Component{Ctx: GetLogger(t, "TestExportImportApplicationState"), Identity: &pb_discovery.Announcement{Id: "handler2"}},
+		applications: application.NewRedisApplicationStore(client, "handler-test-export-application-2"),
+		devices:      device.NewRedisDeviceStore(client, "handler-test-export-application-2"),
+	}
+	defer func() {
+		for _, h := range []*handler{h1, h2} {
+			h.devices.Delete("app", "dev")
+			h.applications.Delete("app")
+		}
+	}()
+
+	h1.applications.Set(&application.Application{AppID: "app", Decoder: "function Decoder(bytes) { return {}; }"})
+	h1.devices.Set(&device.Device{AppID: "app", DevID: "dev", DevAddr: types.DevAddr{1, 2, 3, 4}})
+	queue, _ := h1.devices.DownlinkQueue("app", "dev")
+	queue.PushLast(&types.DownlinkMessage{PayloadRaw: []byte{0x01}})
+	queue.PushLast(&types.DownlinkMessage{PayloadRaw: []byte{0x02}})
+
+	state, err := h1.exportApplicationState("app")
+	a.So(err, ShouldBeNil)
+	a.So(state.From, ShouldEqual, "handler1")
+	a.So(state.Devices, ShouldHaveLength, 1)
+	a.So(state.Devices[0].DownlinkQueue, ShouldHaveLength, 2)
+
+	// The state is sent as JSON
+	data, err := json.Marshal(state)
+	a.So(err, ShouldBeNil)
+	imported := new(applicationState)
+	err = json.Unmarshal(data, imported)
+	a.So(err, ShouldBeNil)
+
+	imported.Application.ExportedTo = "handler2"
+	imported.Application.ExportedAt = time.Now()
+	err = h2.importApplicationState(imported)
+	a.So(err, ShouldBeNil)
+
+	app, err := h2.applications.Get("app")
+	a.So(err, ShouldBeNil)
+	a.So(app.Decoder, ShouldEqual, "function Decoder(bytes) { return {}; }")
+	a.So(app.ExportedTo, ShouldBeEmpty)
+
+	dev, err := h2.devices.Get("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(dev.DevAddr, ShouldEqual, types.DevAddr{1, 2, 3, 4})
+
+	queue, _ = h2.devices.DownlinkQueue("app", "dev")
+	msgs, err := queue.All()
+	a.So(err, ShouldBeNil)
+	a.So(msgs, ShouldHaveLength, 2)
+	a.So(msgs[0].PayloadRaw, ShouldResemble, []byte{0x01})
+
+	h2.deleteApplicationState(imported)
+	_, err = h2.applications.Get("app")
+	a.So(err, ShouldNotBeNil)
+	_, err = h2.devices.Get("app", "dev")
+	a.So(err, ShouldNotBeNil)
+}
@@ -4,6 +4,7 @@
 package handler
 
 import (
+	"sync/atomic"
 	"time"
 
 	ttnlog "github.com/TheThingsNetwork/go-utils/log"
@@ -32,6 +33,9 @@ func (h *handler) HandleMQTT(username, password string, mqttBrokers ...string) e
 		down := &msg
 		down.DevID = devID
 		down.AppID = appID
+		if !h.claimDownlink(down) {
+			return
+		}
 		go h.EnqueueDownlink(down)
 	})
 	token.Wait()
@@ -47,34 +51,16 @@ func (h *handler) HandleMQTT(username, password string, mqttBrokers ...string) e
 				"DevID": up.DevID,
 				"AppID": up.AppID,
 			}).Debug("Publish Uplink")
-			upToken := h.mqttClient.PublishUplink(*up)
-			go func() {
-				if upToken.WaitTimeout(MQTTTimeout) {
-					if upToken.Error() != nil {
-						ctx.WithError(upToken.Error()).Warn("Could not publish Uplink")
-					}
-				} else {
-					ctx.Warn("Uplink publish timeout")
-				}
-			}()
+			h.waitForMQTTPublish(ctx, "Uplink", h.mqttClient.PublishUplink(*up))
 			if len(up.PayloadFields) > 0 {
-				fieldsToken := h.mqttClient.PublishUplinkFields(up.AppID, up.DevID, up.PayloadFields)
-				go func() {
-					if fieldsToken.WaitTimeout(MQTTTimeout) {
-						if fieldsToken.Error() != nil {
-							ctx.WithError(fieldsToken.Error()).Warn("Could not publish Uplink Fields")
-						}
-					} else {
-						ctx.Warn("Uplink Fields publish timeout")
-					}
-				}()
+				h.waitForMQTTPublish(ctx, "Uplink Fields", h.mqttClient.PublishUplinkFields(up.AppID, up.DevID, up.PayloadFields))
 			}
 		}
 	}()
 
 	go func() {
 		for event := range h.mqttEvent {
-			h.Ctx.WithFields(ttnlog.Fields{
+			ctx.WithFields(ttnlog.Fields{
 				"DevID": event.DevID,
 				"AppID": event.AppID,
 				"Event": event.Event,
@@ -85,17 +71,25 @@ func (h *handler) HandleMQTT(username, password string, mqttBrokers ...string) e
 			} else {
 				token = h.mqttClient.PublishDeviceEvent(event.AppID, event.DevID, event.Event, event.Data)
 			}
-			go func() {
-				if token.WaitTimeout(MQTTTimeout) {
-					if token.Error() != nil {
-						h.Ctx.WithError(token.Error()).Warn("Could not publish Event")
-					}
-				} else {
-					h.Ctx.Warn("Event publish timeout")
-				}
-			}()
+			h.waitForMQTTPublish(ctx, "Event", token)
 		}
 	}()
 
 	return nil
 }
+
+// waitForMQTTPublish waits for a publish to the MQTT broker in the background. The publish is counted as pending
+// until it completes, so that the handler can wait for it when it shuts down.
+func (h *handler) waitForMQTTPublish(ctx ttnlog.Interface, what string, token mqtt.Token) {
+	atomic.AddInt32(&h.mqttPublishing, 1)
+	go func() {
+		defer atomic.AddInt32(&h.mqttPublishing, -1)
+		if token.WaitTimeout(MQTTTimeout) {
+			if token.Error() != nil {
+				ctx.WithError(token.Error()).Warnf("Could not publish %s", what)
+			}
+		} else {
+			ctx.Warnf("%s publish timeout", what)
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+func (h *handler) checkUplinkQuota(uplink *pb_broker.DeduplicatedUplinkMessage) error {
+	if h.quota == nil {
+		return nil
+	}
+	airtime, err := quota.Airtime(uplink.Payload, uplink.GetProtocolMetadata().GetLorawan())
+	if err != nil {
+		return err
+	}
+	exhausted, err := h.quota.Uplink(uplink.AppId, uplink.DevId, airtime)
+	h.handleQuota(uplink.AppId, uplink.DevId, exhausted, err)
+	return err
+}
+
+func (h *handler) checkDownlinkQuota(appID, devID string) error {
+	if h.quota == nil {
+		return nil
+	}
+	exhausted, err := h.quota.Downlink(appID, devID)
+	h.handleQuota(appID, devID, exhausted, err)
+	return err
+}
+
+// handleQuota publishes an event if a quota was exhausted
+func (h *handler) handleQuota(appID, devID string, exhausted *quota.Exhausted, err error) {
+	if exhausted == nil {
+		return
+	}
+	data := types.QuotaEventData{
+		Scope: exhausted.Scope,
+		Quota: exhausted.Quota,
+		Reset: exhausted.Reset,
+	}
+	if err != nil {
+		h.status.quotaExceeded.Mark(1)
+		data.Error = err.Error()
+	}
+	h.publishEvent(&types.DeviceEvent{
+		AppID: appID,
+		DevID: devID,
+		Event: types.QuotaExceededEvent,
+		Data:  data,
+	})
+}
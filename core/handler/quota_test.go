@@ -0,0 +1,78 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/TheThingsNetwork/ttn/core/quota"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestCheckUplinkQuota(t *testing.T) {
+	a := New(t)
+	h := &handler{
+		Component:  &component.Component{Ctx: GetLogger(t, "TestCheckUplinkQuota")},
+		quotaStore: quota.NewMemoryStore(),
+		mqttEvent:  make(chan *types.DeviceEvent, 10),
+	}
+	h.InitStatus()
+
+	uplink := &pb_broker.DeduplicatedUplinkMessage{
+		AppId:   "app1",
+		DevId:   "dev1",
+		Payload: make([]byte, 20),
+		ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{
+			Lorawan: &pb_lorawan.Metadata{
+				Modulation: pb_lorawan.Modulation_LORA,
+				DataRate:   "SF12BW125",
+				CodingRate: "4/5",
+			},
+		}},
+	}
+
+	// Without quotas
+	a.So(h.checkUplinkQuota(uplink), ShouldBeNil)
+	a.So(h.mqttEvent, ShouldBeEmpty)
+
+	// A single SF12 uplink exhausts the quota
+	h.WithQuotas(quota.Limits{}, quota.Limits{UplinkAirtime: time.Second})
+	a.So(h.checkUplinkQuota(uplink), ShouldBeNil)
+	a.So(h.mqttEvent, ShouldHaveLength, 1)
+	event := <-h.mqttEvent
+	a.So(event.Event, ShouldEqual, types.QuotaExceededEvent)
+	a.So(event.Data.(types.QuotaEventData).Error, ShouldBeEmpty)
+
+	// Next uplink is rejected
+	a.So(h.checkUplinkQuota(uplink), ShouldNotBeNil)
+	a.So(h.mqttEvent, ShouldHaveLength, 1)
+	event = <-h.mqttEvent
+	a.So(event.Data.(types.QuotaEventData).Scope, ShouldEqual, quota.DeviceScope)
+	a.So(event.Data.(types.QuotaEventData).Error, ShouldNotBeEmpty)
+}
+
+func TestCheckDownlinkQuota(t *testing.T) {
+	a := New(t)
+	h := &handler{
+		Component:  &component.Component{Ctx: GetLogger(t, "TestCheckDownlinkQuota")},
+		quotaStore: quota.NewMemoryStore(),
+		mqttEvent:  make(chan *types.DeviceEvent, 10),
+	}
+	h.InitStatus()
+	h.WithQuotas(quota.Limits{Downlinks: 1}, quota.Limits{})
+
+	a.So(h.checkDownlinkQuota("app1", "dev1"), ShouldBeNil)
+	<-h.mqttEvent
+	a.So(h.checkDownlinkQuota("app1", "dev2"), ShouldNotBeNil)
+	event := <-h.mqttEvent
+	a.So(event.DevID, ShouldEqual, "dev2")
+	a.So(event.Data.(types.QuotaEventData).Scope, ShouldEqual, quota.ApplicationScope)
+}
@@ -0,0 +1,323 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/rights"
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb "github.com/TheThingsNetwork/ttn/api/handler"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+)
+
+// DeviceSnapshotVersion is the version of the device snapshots that this Handler creates and restores
+const DeviceSnapshotVersion = 1
+
+// newDeviceSnapshot returns a snapshot of the device in the Handler and its MAC state in the NetworkServer
+func newDeviceSnapshot(dev *device.Device, state *pb_lorawan.MACState, now time.Time) *pb.DeviceSnapshot {
+	pbDev := deviceToProto(dev)
+	lorawan := pbDev.GetLorawanDevice()
+	lorawan.FCntUp = state.FCntUp
+	lorawan.FCntDown = state.FCntDown
+	lorawan.LastSeen = state.LastSeen
+	snapshot := &pb.DeviceSnapshot{
+		Version:  DeviceSnapshotVersion,
+		Time:     now.UnixNano(),
+		Device:   pbDev,
+		MacState: state,
+	}
+	for _, nonce := range dev.UsedDevNonces {
+		snapshot.UsedDevNonces = append(snapshot.UsedDevNonces, append([]byte{}, nonce[:]...))
+	}
+	for _, nonce := range dev.UsedAppNonces {
+		snapshot.UsedAppNonces = append(snapshot.UsedAppNonces, append([]byte{}, nonce[:]...))
+	}
+	return snapshot
+}
+
+// snapshotKeys are the keys of the device in a snapshot, before they are encrypted
+type snapshotKeys struct {
+	AppKey  *types.AppKey  `json:"app_key,omitempty"`
+	NwkSKey *types.NwkSKey `json:"nwk_s_key,omitempty"`
+	AppSKey *types.AppSKey `json:"app_s_key,omitempty"`
+}
+
+// snapshotKeysField is the field that the encrypted keys of a snapshot are bound to, together with the device
+const snapshotKeysField = "keys"
+
+func snapshotKeysLocation(dev *pb_lorawan.Device) string {
+	return fmt.Sprintf("snapshot:%s:%s", dev.AppId, dev.DevId)
+}
+
+// encryptSnapshotKeys moves the keys of the device in the snapshot to its encrypted keys, so that the snapshot does
+// not contain the keys in plaintext. The encrypted keys can only be restored for the same device.
+func encryptSnapshotKeys(keys *storage.KeyRing, snapshot *pb.DeviceSnapshot) error {
+	dev := snapshot.Device.GetLorawanDevice()
+	plaintext, err := json.Marshal(snapshotKeys{AppKey: dev.AppKey, NwkSKey: dev.NwkSKey, AppSKey: dev.AppSKey})
+	if err != nil {
+		return err
+	}
+	encrypted, err := keys.Encrypt(string(plaintext), snapshotKeysLocation(dev), snapshotKeysField)
+	if err != nil {
+		return err
+	}
+	snapshot.EncryptedKeys = encrypted
+	dev.AppKey, dev.NwkSKey, dev.AppSKey = nil, nil, nil
+	return nil
+}
+
+// decryptSnapshotKeys moves the encrypted keys of the snapshot back to its device. Snapshots without encrypted keys
+// are left as they are.
+func decryptSnapshotKeys(keys *storage.KeyRing, snapshot *pb.DeviceSnapshot) error {
+	if snapshot.EncryptedKeys == "" {
+		return nil
+	}
+	if keys == nil {
+		return errors.NewErrInvalidArgument("EncryptedKeys", "can not be decrypted, as this Handler does not encrypt keys")
+	}
+	if !storage.IsEncrypted(snapshot.EncryptedKeys) {
+		return errors.NewErrInvalidArgument("EncryptedKeys", "are not encrypted")
+	}
+	dev := snapshot.Device.GetLorawanDevice()
+	plaintext, err := keys.Decrypt(snapshot.EncryptedKeys, snapshotKeysLocation(dev), snapshotKeysField)
+	if err != nil {
+		return err
+	}
+	var decrypted snapshotKeys
+	if err := json.Unmarshal([]byte(plaintext), &decrypted); err != nil {
+		return errors.NewErrInvalidArgument("EncryptedKeys", "do not contain valid keys")
+	}
+	if decrypted.NwkSKey == nil || decrypted.AppSKey == nil {
+		return errors.NewErrInvalidArgument("EncryptedKeys", "do not contain session keys")
+	}
+	dev.AppKey, dev.NwkSKey, dev.AppSKey = decrypted.AppKey, decrypted.NwkSKey, decrypted.AppSKey
+	snapshot.EncryptedKeys = ""
+	return nil
+}
+
+// checkDeviceSnapshot checks that the snapshot can be restored over the current device in the NetworkServer, which
+// is nil if the NetworkServer does not know the device. Restoring older frame counters of the same session would
+// allow replay attacks. Restoring another session replaces the session of the device, which is only done with force.
+func checkDeviceSnapshot(snapshot *pb.DeviceSnapshot, current *pb_lorawan.Device) error {
+	if snapshot.Version != DeviceSnapshotVersion {
+		return errors.NewErrInvalidArgument("Version", fmt.Sprintf("version %d is not supported", snapshot.Version))
+	}
+	if current == nil || current.DevAddr == nil || current.NwkSKey == nil {
+		return nil
+	}
+	if current.DevAddr.IsEmpty() && current.NwkSKey.IsEmpty() {
+		return nil
+	}
+	dev := snapshot.Device.GetLorawanDevice()
+	if !sameSession(dev, current) {
+		if snapshot.Force {
+			return nil
+		}
+		return errors.NewErrInvalidArgument("Snapshot", "is of another session than the current session of the device")
+	}
+	if current.FCntUp > dev.FCntUp || current.FCntDown > dev.FCntDown {
+		return errors.NewErrInvalidArgument("Snapshot", "is older than the current session of the device")
+	}
+	return nil
+}
+
+// snapshotNonces returns the used DevNonces and AppNonces in the snapshot
+func snapshotNonces(snapshot *pb.DeviceSnapshot) (devNonces []device.DevNonce, appNonces []device.AppNonce, err error) {
+	devNonces = make([]device.DevNonce, 0, len(snapshot.UsedDevNonces))
+	for _, nonce := range snapshot.UsedDevNonces {
+		var devNonce device.DevNonce
+		if len(nonce) != len(devNonce) {
+			return nil, nil, errors.NewErrInvalidArgument("UsedDevNonces", fmt.Sprintf("DevNonce should be %d bytes", len(devNonce)))
+		}
+		copy(devNonce[:], nonce)
+		devNonces = append(devNonces, devNonce)
+	}
+	appNonces = make([]device.AppNonce, 0, len(snapshot.UsedAppNonces))
+	for _, nonce := range snapshot.UsedAppNonces {
+		var appNonce device.AppNonce
+		if len(nonce) != len(appNonce) {
+			return nil, nil, errors.NewErrInvalidArgument("UsedAppNonces", fmt.Sprintf("AppNonce should be %d bytes", len(appNonce)))
+		}
+		copy(appNonce[:], nonce)
+		appNonces = append(appNonces, appNonce)
+	}
+	return devNonces, appNonces, nil
+}
+
+func (h *handlerManager) GetDeviceSnapshot(ctx context.Context, in *pb.DeviceIdentifier) (*pb.DeviceSnapshot, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Device Identifier")
+	}
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, in.AppId)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAppRights(claims, in.AppId, rights.Devices)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.handler.applications.Get(in.AppId); err != nil {
+		return nil, errors.Wrap(err, "Application not registered to this Handler")
+	}
+
+	dev, err := h.handler.devices.Get(in.AppId, in.DevId)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := h.deviceManager.GetMACState(ctx, &pb_lorawan.DeviceIdentifier{
+		AppEui: &dev.AppEUI,
+		DevEui: &dev.DevEUI,
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return MAC state")
+	}
+	if state.DevAddr == nil || *state.DevAddr != dev.DevAddr {
+		return nil, errors.NewErrInternal("Handler and NetworkServer have a different session for the device")
+	}
+
+	snapshot := newDeviceSnapshot(dev, state, time.Now())
+	if h.handler.keys != nil {
+		if err := encryptSnapshotKeys(h.handler.keys, snapshot); err != nil {
+			return nil, errors.Wrap(err, "Could not encrypt keys of device snapshot")
+		}
+	}
+	return snapshot, nil
+}
+
+func (h *handlerManager) RestoreDeviceSnapshot(ctx context.Context, in *pb.DeviceSnapshot) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid Device Snapshot")
+	}
+	devNonces, appNonces, err := snapshotNonces(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid Device Snapshot")
+	}
+	appID := in.Device.AppId
+
+	ctx, claims, err := h.validateTTNAuthAppContext(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAppRights(claims, appID, rights.Devices)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.handler.applications.Get(appID); err != nil {
+		return nil, errors.Wrap(err, "Application not registered to this Handler")
+	}
+
+	if err := decryptSnapshotKeys(h.handler.keys, in); err != nil {
+		return nil, errors.Wrap(err, "Could not decrypt keys of device snapshot")
+	}
+
+	lorawan := in.Device.GetLorawanDevice()
+	identifier := &pb_lorawan.DeviceIdentifier{AppEui: lorawan.AppEui, DevEui: lorawan.DevEui}
+
+	// Check everything before changing anything
+	existing, err := h.handler.devices.Get(appID, in.Device.DevId)
+	if err != nil && errors.GetErrType(err) != errors.NotFound {
+		return nil, err
+	}
+	if existing != nil && (existing.AppEUI != *lorawan.AppEui || existing.DevEUI != *lorawan.DevEui) {
+		return nil, errors.NewErrInvalidArgument("Snapshot", "is of a device with other EUIs")
+	}
+	if existing == nil {
+		inUse, err := h.euiInUse(appID)(*lorawan.AppEui, *lorawan.DevEui)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			return nil, errors.NewErrAlreadyExists("Device with AppEUI and DevEUI")
+		}
+	}
+	current, err := h.deviceManager.GetDevice(ctx, identifier)
+	if err != nil && errors.GetErrType(errors.FromGRPCError(err)) != errors.NotFound {
+		return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return device")
+	}
+	if err := checkDeviceSnapshot(in, current); err != nil {
+		return nil, err
+	}
+	var currentState *pb_lorawan.MACState
+	if current != nil {
+		currentState, err = h.deviceManager.GetMACState(ctx, identifier)
+		if err != nil {
+			return nil, errors.Wrap(errors.FromGRPCError(err), "Broker did not return MAC state")
+		}
+	}
+
+	// Put the device back in the Handler and NetworkServer if the restore fails halfway. The rollback returns the
+	// cause of the failure, or an error that says that the rollback was incomplete.
+	var existingFields []string
+	if existing != nil {
+		existingFields = existing.ChangedFields() // All fields, as nothing was changed yet
+		existing.StartUpdate()
+	}
+	rollback := func(cause error) error {
+		logger := h.handler.Ctx.WithFields(ttnlog.Fields{"AppID": appID, "DevID": in.Device.DevId})
+		var failed []string
+		fail := func(step string, err error) {
+			if err != nil {
+				logger.WithError(err).Errorf("Could not %s after failed restore of device snapshot", step)
+				failed = append(failed, step)
+			}
+		}
+		if existing != nil {
+			fail("restore device in Handler", h.handler.devices.Set(existing, existingFields...))
+		} else {
+			fail("delete device from Handler", h.handler.devices.Delete(appID, in.Device.DevId))
+		}
+		if current != nil {
+			_, err := h.deviceManager.SetDevice(ctx, current)
+			fail("restore device in NetworkServer", errors.FromGRPCError(err))
+			_, err = h.deviceManager.RestoreMACState(ctx, currentState)
+			fail("restore MAC state in NetworkServer", errors.FromGRPCError(err))
+		} else {
+			_, err := h.deviceManager.DeleteDevice(ctx, identifier)
+			fail("delete device from NetworkServer", errors.FromGRPCError(err))
+		}
+		if len(failed) > 0 {
+			return errors.Wrapf(cause, "Restore of device snapshot failed and the rollback was incomplete (could not %s)", strings.Join(failed, ", "))
+		}
+		return cause
+	}
+
+	// Sets the keys, options and frame counters in the Handler and NetworkServer
+	_, err = h.setDevice(ctx, claims, in.Device, h.euiInUse(appID), false)
+	if err != nil {
+		return nil, rollback(err)
+	}
+
+	dev, err := h.handler.devices.Get(appID, in.Device.DevId)
+	if err != nil {
+		return nil, rollback(err)
+	}
+	dev.StartUpdate()
+	dev.UsedDevNonces = devNonces
+	dev.UsedAppNonces = appNonces
+	dev.FCntUp = lorawan.FCntUp
+	err = h.handler.devices.Set(dev)
+	if err != nil {
+		return nil, rollback(err)
+	}
+
+	_, err = h.deviceManager.RestoreMACState(ctx, in.MacState)
+	if err != nil {
+		return nil, rollback(errors.Wrap(errors.FromGRPCError(err), "Broker did not restore MAC state"))
+	}
+
+	return &empty.Empty{}, nil
+}
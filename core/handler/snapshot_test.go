@@ -0,0 +1,129 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/handler/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDeviceSnapshot(t *testing.T) {
+	a := New(t)
+
+	dev := &device.Device{
+		AppID:         "app",
+		DevID:         "dev",
+		AppEUI:        types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevEUI:        types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:       types.DevAddr{1, 2, 3, 4},
+		NwkSKey:       types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		UsedDevNonces: []device.DevNonce{{1, 2}, {3, 4}},
+		UsedAppNonces: []device.AppNonce{{1, 2, 3}},
+	}
+	state := &pb_lorawan.MACState{
+		AppId:    "app",
+		DevId:    "dev",
+		AppEui:   &dev.AppEUI,
+		DevEui:   &dev.DevEUI,
+		DevAddr:  &dev.DevAddr,
+		FCntUp:   42,
+		FCntDown: 12,
+		Adr:      &pb_lorawan.ADRSettings{Band: "EU_863_870", DataRate: "SF7BW125"},
+	}
+
+	snapshot := newDeviceSnapshot(dev, state, time.Now())
+	a.So(snapshot.Validate(), ShouldBeNil)
+	a.So(snapshot.Version, ShouldEqual, DeviceSnapshotVersion)
+	a.So(snapshot.Device.GetLorawanDevice().FCntUp, ShouldEqual, 42)
+	a.So(snapshot.UsedDevNonces, ShouldResemble, [][]byte{{1, 2}, {3, 4}})
+
+	devNonces, appNonces, err := snapshotNonces(snapshot)
+	a.So(err, ShouldBeNil)
+	a.So(devNonces, ShouldResemble, dev.UsedDevNonces)
+	a.So(appNonces, ShouldResemble, dev.UsedAppNonces)
+
+	// Restoring over an unknown device or a device without session
+	a.So(checkDeviceSnapshot(snapshot, nil), ShouldBeNil)
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &types.DevAddr{}, NwkSKey: &types.NwkSKey{}}), ShouldBeNil)
+
+	// Restoring over another session is only done with force
+	otherDevAddr := types.DevAddr{4, 3, 2, 1}
+	otherNwkSKey := types.NwkSKey{4, 3, 2, 1}
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &otherDevAddr, NwkSKey: &dev.NwkSKey, FCntUp: 100}), ShouldNotBeNil)
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &dev.DevAddr, NwkSKey: &otherNwkSKey}), ShouldNotBeNil)
+	snapshot.Force = true
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &otherDevAddr, NwkSKey: &dev.NwkSKey, FCntUp: 100}), ShouldBeNil)
+	snapshot.Force = false
+
+	// Restoring over the same session
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &dev.DevAddr, NwkSKey: &dev.NwkSKey, FCntUp: 40, FCntDown: 12}), ShouldBeNil)
+	a.So(checkDeviceSnapshot(snapshot, &pb_lorawan.Device{DevAddr: &dev.DevAddr, NwkSKey: &dev.NwkSKey, FCntUp: 43}), ShouldNotBeNil)
+
+	snapshot.Version = DeviceSnapshotVersion + 1
+	a.So(checkDeviceSnapshot(snapshot, nil), ShouldNotBeNil)
+	snapshot.Version = DeviceSnapshotVersion
+
+	// The MAC state must be of the same session
+	state.DevAddr = &otherDevAddr
+	a.So(snapshot.Validate(), ShouldNotBeNil)
+	state.DevAddr = &dev.DevAddr
+
+	snapshot.UsedDevNonces = append(snapshot.UsedDevNonces, []byte{1, 2, 3})
+	_, _, err = snapshotNonces(snapshot)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestDeviceSnapshotKeys(t *testing.T) {
+	a := New(t)
+
+	dev := &device.Device{
+		AppID:   "app",
+		DevID:   "dev",
+		AppEUI:  types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevEUI:  types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr: types.DevAddr{1, 2, 3, 4},
+		NwkSKey: types.NwkSKey{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		AppSKey: types.AppSKey{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+	}
+	state := &pb_lorawan.MACState{
+		AppId:   "app",
+		DevId:   "dev",
+		AppEui:  &dev.AppEUI,
+		DevEui:  &dev.DevEUI,
+		DevAddr: &dev.DevAddr,
+	}
+	keys, _ := storage.ParseKeyRing("1:01020304050607080102030405060708")
+
+	snapshot := newDeviceSnapshot(dev, state, time.Now())
+	a.So(encryptSnapshotKeys(keys, snapshot), ShouldBeNil)
+	a.So(snapshot.EncryptedKeys, ShouldNotBeEmpty)
+	a.So(snapshot.Device.GetLorawanDevice().NwkSKey, ShouldBeNil)
+	a.So(snapshot.Device.GetLorawanDevice().AppSKey, ShouldBeNil)
+	a.So(snapshot.Validate(), ShouldBeNil)
+
+	// Only a Handler with the key-encryption key can restore the keys
+	a.So(decryptSnapshotKeys(nil, snapshot), ShouldNotBeNil)
+	otherKeys, _ := storage.ParseKeyRing("2:08070605040302010807060504030201")
+	a.So(decryptSnapshotKeys(otherKeys, snapshot), ShouldNotBeNil)
+
+	// The keys can only be restored for the same device
+	snapshot.Device.GetLorawanDevice().DevId = "other"
+	a.So(decryptSnapshotKeys(keys, snapshot), ShouldNotBeNil)
+	snapshot.Device.GetLorawanDevice().DevId = "dev"
+
+	a.So(decryptSnapshotKeys(keys, snapshot), ShouldBeNil)
+	a.So(snapshot.EncryptedKeys, ShouldBeEmpty)
+	a.So(*snapshot.Device.GetLorawanDevice().NwkSKey, ShouldEqual, dev.NwkSKey)
+	a.So(*snapshot.Device.GetLorawanDevice().AppSKey, ShouldEqual, dev.AppSKey)
+
+	// Plaintext in the encrypted keys is not accepted
+	snapshot.EncryptedKeys = `{"nwk_s_key":"01020304050607080102030405060708"}`
+	a.So(decryptSnapshotKeys(keys, snapshot), ShouldNotBeNil)
+}
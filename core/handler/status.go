@@ -4,6 +4,11 @@
 package handler
 
 import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/TheThingsNetwork/ttn/api"
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/api/stats"
@@ -11,16 +16,123 @@ import (
 )
 
 type status struct {
-	uplink      metrics.Meter
-	downlink    metrics.Meter
-	activations metrics.Meter
+	uplink        metrics.Meter
+	downlink      metrics.Meter
+	activations   metrics.Meter
+	quotaExceeded metrics.Meter
+
+	payloadFunctionsLock sync.Mutex
+	payloadFunctions     map[string]*payloadFunctionStatus
+}
+
+type payloadFunctionStatus struct {
+	duration metrics.Histogram
+	runs     *meter
+	errors   *meter
+}
+
+// meter is a metrics.Meter that is ticked by the status instead of by go-metrics. Meters of go-metrics are
+// registered globally and are never released, which leaks the meters of deleted applications.
+type meter struct {
+	count       int64
+	start       time.Time
+	a1, a5, a15 metrics.EWMA
+}
+
+func newMeter() *meter {
+	return &meter{
+		start: time.Now(),
+		a1:    metrics.NewEWMA1(),
+		a5:    metrics.NewEWMA5(),
+		a15:   metrics.NewEWMA15(),
+	}
+}
+
+func (m *meter) Count() int64      { return atomic.LoadInt64(&m.count) }
+func (m *meter) Rate1() float64    { return m.a1.Rate() }
+func (m *meter) Rate5() float64    { return m.a5.Rate() }
+func (m *meter) Rate15() float64   { return m.a15.Rate() }
+func (m *meter) RateMean() float64 { return float64(m.Count()) / time.Since(m.start).Seconds() }
+func (m *meter) Stop()             {}
+
+func (m *meter) Mark(n int64) {
+	atomic.AddInt64(&m.count, n)
+	m.a1.Update(n)
+	m.a5.Update(n)
+	m.a15.Update(n)
+}
+
+func (m *meter) Snapshot() metrics.Meter {
+	return &meter{
+		count: m.Count(),
+		start: m.start,
+		a1:    m.a1.Snapshot(),
+		a5:    m.a5.Snapshot(),
+		a15:   m.a15.Snapshot(),
+	}
+}
+
+// tick updates the moving averages. It should be called every 5 seconds
+func (m *meter) tick() {
+	m.a1.Tick()
+	m.a5.Tick()
+	m.a15.Tick()
+}
+
+// payloadFunction records a run of the payload functions of an application
+func (s *status) payloadFunction(appID string, duration time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	s.payloadFunctionsLock.Lock()
+	defer s.payloadFunctionsLock.Unlock()
+	function, ok := s.payloadFunctions[appID]
+	if !ok {
+		function = &payloadFunctionStatus{
+			duration: metrics.NewHistogram(metrics.NewUniformSample(512)),
+			runs:     newMeter(),
+			errors:   newMeter(),
+		}
+		s.payloadFunctions[appID] = function
+	}
+	function.duration.Update(int64(duration / time.Microsecond))
+	function.runs.Mark(1)
+	if err != nil {
+		function.errors.Mark(1)
+	}
+}
+
+// tickPayloadFunctions updates the rates of the payload function statistics. It should be called every 5 seconds
+func (s *status) tickPayloadFunctions() {
+	if s == nil {
+		return
+	}
+	s.payloadFunctionsLock.Lock()
+	defer s.payloadFunctionsLock.Unlock()
+	for _, function := range s.payloadFunctions {
+		function.runs.tick()
+		function.errors.tick()
+	}
+}
+
+// removePayloadFunction removes the payload function statistics of an application
+func (s *status) removePayloadFunction(appID string) {
+	if s == nil {
+		return
+	}
+	s.payloadFunctionsLock.Lock()
+	defer s.payloadFunctionsLock.Unlock()
+	delete(s.payloadFunctions, appID)
 }
 
 func (h *handler) InitStatus() {
 	h.status = &status{
-		uplink:      metrics.NewMeter(),
-		downlink:    metrics.NewMeter(),
-		activations: metrics.NewMeter(),
+		uplink:        metrics.NewMeter(),
+		downlink:      metrics.NewMeter(),
+		activations:   metrics.NewMeter(),
+		quotaExceeded: metrics.NewMeter(),
+
+		payloadFunctions: make(map[string]*payloadFunctionStatus),
 	}
 }
 
@@ -49,5 +161,54 @@ func (h *handler) GetStatus() *pb.Status {
 		Rate5:  float32(activations.Rate5()),
 		Rate15: float32(activations.Rate15()),
 	}
+	quotaExceeded := h.status.quotaExceeded.Snapshot()
+	status.QuotaExceeded = &api.Rates{
+		Rate1:  float32(quotaExceeded.Rate1()),
+		Rate5:  float32(quotaExceeded.Rate5()),
+		Rate15: float32(quotaExceeded.Rate15()),
+	}
+	status.PayloadFunctions = h.status.getPayloadFunctions()
 	return status
 }
+
+func (s *status) getPayloadFunctions() []*pb.PayloadFunctionStatus {
+	s.payloadFunctionsLock.Lock()
+	defer s.payloadFunctionsLock.Unlock()
+	appIDs := make([]string, 0, len(s.payloadFunctions))
+	for appID := range s.payloadFunctions {
+		appIDs = append(appIDs, appID)
+	}
+	sort.Strings(appIDs)
+	res := make([]*pb.PayloadFunctionStatus, 0, len(appIDs))
+	for _, appID := range appIDs {
+		function := s.payloadFunctions[appID]
+		duration := function.duration.Snapshot().Percentiles([]float64{0.01, 0.05, 0.10, 0.25, 0.50, 0.75, 0.90, 0.95, 0.99})
+		runs := function.runs.Snapshot()
+		errors := function.errors.Snapshot()
+		res = append(res, &pb.PayloadFunctionStatus{
+			AppId: appID,
+			Duration: &api.Percentiles{
+				Percentile1:  float32(duration[0] / 1000),
+				Percentile5:  float32(duration[1] / 1000),
+				Percentile10: float32(duration[2] / 1000),
+				Percentile25: float32(duration[3] / 1000),
+				Percentile50: float32(duration[4] / 1000),
+				Percentile75: float32(duration[5] / 1000),
+				Percentile90: float32(duration[6] / 1000),
+				Percentile95: float32(duration[7] / 1000),
+				Percentile99: float32(duration[8] / 1000),
+			},
+			Runs: &api.Rates{
+				Rate1:  float32(runs.Rate1()),
+				Rate5:  float32(runs.Rate5()),
+				Rate15: float32(runs.Rate15()),
+			},
+			Errors: &api.Rates{
+				Rate1:  float32(errors.Rate1()),
+				Rate5:  float32(errors.Rate5()),
+				Rate15: float32(errors.Rate15()),
+			},
+		})
+	}
+	return res
+}
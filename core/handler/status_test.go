@@ -5,6 +5,7 @@ package handler
 
 import (
 	"testing"
+	"time"
 
 	pb "github.com/TheThingsNetwork/ttn/api/handler"
 	. "github.com/smartystreets/assertions"
@@ -18,4 +19,18 @@ func TestStatus(t *testing.T) {
 	a.So(h.status, ShouldNotBeNil)
 	status := h.GetStatus()
 	a.So(status.Uplink.Rate1, ShouldEqual, 0)
+	a.So(status.PayloadFunctions, ShouldBeEmpty)
+
+	h.status.payloadFunction("test", 2*time.Millisecond, nil)
+	status = h.GetStatus()
+	a.So(status.PayloadFunctions, ShouldHaveLength, 1)
+	a.So(status.PayloadFunctions[0].AppId, ShouldEqual, "test")
+	a.So(status.PayloadFunctions[0].Duration.Percentile50, ShouldEqual, 2)
+	a.So(status.PayloadFunctions[0].Runs.Rate1, ShouldEqual, 0)
+
+	h.status.tickPayloadFunctions()
+	a.So(h.GetStatus().PayloadFunctions[0].Runs.Rate1, ShouldBeGreaterThan, 0)
+
+	h.status.removePayloadFunction("test")
+	a.So(h.GetStatus().PayloadFunctions, ShouldBeEmpty)
 }
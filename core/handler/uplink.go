@@ -4,37 +4,56 @@
 package handler
 
 import (
+	"sync/atomic"
 	"time"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	"github.com/TheThingsNetwork/ttn/api/fields"
 	"github.com/TheThingsNetwork/ttn/api/trace"
 	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
-// ResponseDeadline indicates how long
+// ResponseDeadline indicates how long the handler waits for a downlink after an uplink. Use SetResponseDeadline to
+// change it while the handler is running.
 var ResponseDeadline = 100 * time.Millisecond
 
+// SetResponseDeadline changes the ResponseDeadline
+func SetResponseDeadline(deadline time.Duration) {
+	atomic.StoreInt64((*int64)(&ResponseDeadline), int64(deadline))
+}
+
+// GetResponseDeadline returns the ResponseDeadline
+func GetResponseDeadline() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&ResponseDeadline)))
+}
+
 func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err error) {
 	appID, devID := uplink.AppId, uplink.DevId
 	ctx := h.Ctx.WithFields(fields.Get(uplink))
 	start := time.Now()
 	defer func() {
 		if err != nil {
-			h.mqttEvent <- &types.DeviceEvent{
+			h.publishEvent(&types.DeviceEvent{
 				AppID: appID,
 				DevID: devID,
 				Event: types.UplinkErrorEvent,
 				Data:  types.ErrorEventData{Error: err.Error()},
-			}
+			})
 			ctx.WithError(err).Warn("Could not handle uplink")
 		} else {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled uplink")
 		}
+		trace.Export(h.TraceService(), uplink.Trace)
 	}()
 	h.status.uplink.Mark(1)
 
-	uplink.Trace = uplink.Trace.WithEvent(trace.ReceiveEvent)
+	if !h.inFlight.Add() {
+		return errors.NewErrInternal("Handler is shutting down")
+	}
+	defer h.inFlight.Done()
+
+	uplink.Trace = uplink.Trace.WithEvent(h.TraceService(), trace.ReceiveEvent)
 
 	dev, err := h.devices.Get(appID, devID)
 	if err != nil {
@@ -42,6 +61,11 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 	}
 	dev.StartUpdate()
 
+	err = h.checkUplinkQuota(uplink)
+	if err != nil {
+		return err
+	}
+
 	// Build AppUplink
 	appUplink := &types.UplinkMessage{
 		AppID: appID,
@@ -56,7 +80,7 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 	}
 
 	ctx.WithField("NumProcessors", len(processors)).Debug("Running Uplink Processors")
-	uplink.Trace = uplink.Trace.WithEvent("process uplink")
+	uplink.Trace = uplink.Trace.WithEvent(h.TraceService(), "process uplink")
 
 	// Run Uplink Processors
 	for _, processor := range processors {
@@ -88,8 +112,11 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 		Data:  types.ErrorEventData{Error: "No gateways available for downlink"},
 	}
 
-	if dev.CurrentDownlink == nil {
-		<-time.After(ResponseDeadline)
+	// The downlink of an application that is exported to another Handler is sent by that Handler
+	frozen := h.checkApplicationFrozen(appID) != nil
+
+	if dev.CurrentDownlink == nil && !frozen {
+		<-time.After(GetResponseDeadline())
 
 		queue, err := h.devices.DownlinkQueue(appID, devID)
 		if err != nil {
@@ -104,15 +131,15 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 				}
 				dev.CurrentDownlink = next
 			} else {
-				h.mqttEvent <- noDownlinkErrEvent
+				h.publishEvent(noDownlinkErrEvent)
 				return nil
 			}
 		}
 	}
 
 	if uplink.ResponseTemplate == nil {
-		if dev.CurrentDownlink != nil {
-			h.mqttEvent <- noDownlinkErrEvent
+		if dev.CurrentDownlink != nil && !frozen {
+			h.publishEvent(noDownlinkErrEvent)
 		}
 		return nil
 	}
@@ -125,13 +152,13 @@ func (h *handler) HandleUplink(uplink *pb_broker.DeduplicatedUplinkMessage) (err
 
 	// Prepare Downlink
 	var appDownlink types.DownlinkMessage
-	if dev.CurrentDownlink != nil {
+	if dev.CurrentDownlink != nil && !frozen {
 		appDownlink = *dev.CurrentDownlink
 	}
 	appDownlink.AppID = uplink.AppId
 	appDownlink.DevID = uplink.DevId
 	downlink := uplink.ResponseTemplate
-	downlink.Trace = uplink.Trace.WithEvent("prepare downlink")
+	downlink.Trace = uplink.Trace.WithEvent(h.TraceService(), "prepare downlink")
 
 	// Handle Downlink
 	err = h.HandleDownlink(&appDownlink, downlink)
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/TheThingsNetwork/go-utils/pseudorandom"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 	"github.com/TheThingsNetwork/ttn/api/trace"
@@ -19,23 +18,17 @@ import (
 )
 
 func (n *networkServer) getDevAddr(constraints ...string) (types.DevAddr, error) {
-	// Generate random DevAddr bytes
-	var devAddr types.DevAddr
-	pseudorandom.FillBytes(devAddr[:])
-
-	// Get a random prefix that matches the constraints
+	// Get the prefixes that match the constraints
 	prefixes := n.GetPrefixesFor(constraints...)
 	if len(prefixes) == 0 {
 		return types.DevAddr{}, errors.NewErrNotFound(fmt.Sprintf("DevAddr prefix with constraints %v", constraints))
 	}
 
-	// Select a prefix
-	prefix := prefixes[pseudorandom.Intn(len(prefixes))]
-
-	// Apply the prefix
-	devAddr = devAddr.WithPrefix(prefix)
-
-	return devAddr, nil
+	allocator := n.devAddrAllocator
+	if allocator == nil {
+		allocator = NewRandomDevAddrAllocator()
+	}
+	return allocator.Allocate(prefixes)
 }
 
 func (n *networkServer) HandlePrepareActivation(activation *pb_broker.DeduplicatedDeviceActivationRequest) (*pb_broker.DeduplicatedDeviceActivationRequest, error) {
@@ -68,7 +61,11 @@ func (n *networkServer) HandlePrepareActivation(activation *pb_broker.Deduplicat
 	}
 
 	// Allocate a  device address
-	activation.Trace = activation.Trace.WithEvent("allocate devaddr")
+	activation.Trace = activation.Trace.WithEvent(n.TraceService(), "allocate devaddr")
+	defer func() {
+		trace.Export(n.TraceService(), activation.Trace)
+	}()
+
 	devAddr, err := n.getDevAddr(activationConstraints...)
 	if err != nil {
 		return nil, err
@@ -124,7 +121,11 @@ func (n *networkServer) HandleActivate(activation *pb_handler.DeviceActivationRe
 		return nil, err
 	}
 
-	activation.Trace = activation.Trace.WithEvent(trace.UpdateStateEvent)
+	activation.Trace = activation.Trace.WithEvent(n.TraceService(), trace.UpdateStateEvent)
+	defer func() {
+		trace.Export(n.TraceService(), activation.Trace)
+	}()
+
 	dev.StartUpdate()
 
 	dev.LastSeen = time.Now()
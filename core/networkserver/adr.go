@@ -5,6 +5,7 @@ package networkserver
 
 import (
 	"math"
+	"sync"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
@@ -13,9 +14,26 @@ import (
 	"github.com/brocaar/lorawan"
 )
 
-// DefaultADRMargin is the default SNR margin for ADR
+// DefaultADRMargin is the default SNR margin for ADR. Use SetDefaultADRMargin to change it while the network server
+// is running.
 var DefaultADRMargin = 15
 
+var defaultADRMarginLock sync.RWMutex
+
+// SetDefaultADRMargin changes the DefaultADRMargin
+func SetDefaultADRMargin(margin int) {
+	defaultADRMarginLock.Lock()
+	defer defaultADRMarginLock.Unlock()
+	DefaultADRMargin = margin
+}
+
+// GetDefaultADRMargin returns the DefaultADRMargin
+func GetDefaultADRMargin() int {
+	defaultADRMarginLock.RLock()
+	defer defaultADRMarginLock.RUnlock()
+	return DefaultADRMargin
+}
+
 func maxSNR(frames []*device.Frame) float32 {
 	if len(frames) == 0 {
 		return 0
@@ -107,7 +125,7 @@ func (n *networkServer) handleDownlinkADR(message *pb_broker.DownlinkMessage, de
 		return nil
 	}
 	if dev.ADR.Margin == 0 {
-		dev.ADR.Margin = DefaultADRMargin
+		dev.ADR.Margin = GetDefaultADRMargin()
 	}
 	if dev.ADR.Band == "" {
 		return nil
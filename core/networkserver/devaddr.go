@@ -0,0 +1,159 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+
+	"github.com/TheThingsNetwork/go-utils/pseudorandom"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// DevAddrAllocator allocates the DevAddrs of devices that activate
+type DevAddrAllocator interface {
+	// Allocate a DevAddr in one of the prefixes
+	Allocate(prefixes []types.DevAddrPrefix) (types.DevAddr, error)
+	// Stats returns the allocation statistics
+	Stats() DevAddrAllocatorStats
+}
+
+// DevAddrAllocatorStats are the allocation statistics of a DevAddrAllocator
+type DevAddrAllocatorStats struct {
+	Allocations uint64 // Number of allocated DevAddrs
+	Collisions  uint64 // Number of allocated DevAddrs that were already used by other devices
+	Lookups     uint64 // Number of lookups of the devices that use a DevAddr
+}
+
+// DefaultDevAddrCandidates is the default number of candidate DevAddrs that are compared by the least-loaded allocator
+const DefaultDevAddrCandidates = 8
+
+// NewRandomDevAddrAllocator returns a DevAddrAllocator that allocates a random DevAddr in a random prefix. It doesn't
+// know about collisions.
+func NewRandomDevAddrAllocator() DevAddrAllocator {
+	return &randomDevAddrAllocator{}
+}
+
+type randomDevAddrAllocator struct {
+	allocations uint64 // Accessed atomically
+}
+
+func (a *randomDevAddrAllocator) Allocate(prefixes []types.DevAddrPrefix) (types.DevAddr, error) {
+	if len(prefixes) == 0 {
+		return types.DevAddr{}, errors.NewErrNotFound("DevAddr prefix")
+	}
+	var devAddr types.DevAddr
+	pseudorandom.FillBytes(devAddr[:])
+	devAddr = devAddr.WithPrefix(prefixes[pseudorandom.Intn(len(prefixes))])
+	atomic.AddUint64(&a.allocations, 1)
+	return devAddr, nil
+}
+
+func (a *randomDevAddrAllocator) Stats() DevAddrAllocatorStats {
+	return DevAddrAllocatorStats{Allocations: atomic.LoadUint64(&a.allocations)}
+}
+
+// NewLeastLoadedDevAddrAllocator returns a DevAddrAllocator that compares a number of random candidate DevAddrs, and
+// allocates the one that is used by the least devices. Every device that shares a DevAddr costs an extra MIC check for
+// each uplink message of those devices.
+//
+// The occupancy func returns the number of devices that use a DevAddr. If the prefixes contain fewer DevAddrs than
+// the number of candidates, all DevAddrs in the prefixes are compared.
+func NewLeastLoadedDevAddrAllocator(occupancy func(types.DevAddr) (int, error), candidates int) DevAddrAllocator {
+	if candidates < 1 {
+		candidates = 1
+	}
+	return &leastLoadedDevAddrAllocator{
+		occupancy:  occupancy,
+		candidates: candidates,
+	}
+}
+
+type leastLoadedDevAddrAllocator struct {
+	allocations uint64 // Accessed atomically, keep 64-bit aligned
+	collisions  uint64
+	lookups     uint64
+
+	occupancy  func(types.DevAddr) (int, error)
+	candidates int
+}
+
+// prefixSize returns the number of DevAddrs in the prefix
+func prefixSize(prefix types.DevAddrPrefix) uint64 {
+	return 1 << uint(32-prefix.Length)
+}
+
+// devAddrAt returns the DevAddr at the offset in the prefixes, as if the prefixes were one range of DevAddrs
+func devAddrAt(prefixes []types.DevAddrPrefix, offset uint64) types.DevAddr {
+	for _, prefix := range prefixes {
+		size := prefixSize(prefix)
+		if offset < size {
+			var devAddr types.DevAddr
+			binary.BigEndian.PutUint32(devAddr[:], uint32(offset))
+			return devAddr.WithPrefix(prefix)
+		}
+		offset -= size
+	}
+	return types.DevAddr{}
+}
+
+// randomOffset returns a random offset smaller than max
+func randomOffset(max uint64) uint64 {
+	var b [8]byte
+	pseudorandom.FillBytes(b[:])
+	return binary.BigEndian.Uint64(b[:]) % max
+}
+
+func (a *leastLoadedDevAddrAllocator) Allocate(prefixes []types.DevAddrPrefix) (types.DevAddr, error) {
+	if len(prefixes) == 0 {
+		return types.DevAddr{}, errors.NewErrNotFound("DevAddr prefix")
+	}
+
+	// Bigger prefixes get more candidates, so that DevAddrs are spread evenly over all prefixes
+	var total uint64
+	for _, prefix := range prefixes {
+		total += prefixSize(prefix)
+	}
+	candidates := uint64(a.candidates)
+	exhaustive := total <= candidates
+	if exhaustive {
+		candidates = total
+	}
+
+	var best types.DevAddr
+	bestOccupancy := -1
+	for i := uint64(0); i < candidates; i++ {
+		offset := i
+		if !exhaustive {
+			offset = randomOffset(total)
+		}
+		devAddr := devAddrAt(prefixes, offset)
+		occupancy, err := a.occupancy(devAddr)
+		atomic.AddUint64(&a.lookups, 1)
+		if err != nil {
+			return types.DevAddr{}, err
+		}
+		if bestOccupancy == -1 || occupancy < bestOccupancy {
+			best, bestOccupancy = devAddr, occupancy
+		}
+		if occupancy == 0 {
+			break
+		}
+	}
+
+	atomic.AddUint64(&a.allocations, 1)
+	if bestOccupancy > 0 {
+		atomic.AddUint64(&a.collisions, 1)
+	}
+	return best, nil
+}
+
+func (a *leastLoadedDevAddrAllocator) Stats() DevAddrAllocatorStats {
+	return DevAddrAllocatorStats{
+		Allocations: atomic.LoadUint64(&a.allocations),
+		Collisions:  atomic.LoadUint64(&a.collisions),
+		Lookups:     atomic.LoadUint64(&a.lookups),
+	}
+}
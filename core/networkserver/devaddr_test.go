@@ -0,0 +1,72 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestRandomDevAddrAllocator(t *testing.T) {
+	a := New(t)
+
+	allocator := NewRandomDevAddrAllocator()
+
+	_, err := allocator.Allocate(nil)
+	a.So(err, ShouldNotBeNil)
+
+	prefix := types.DevAddrPrefix{DevAddr: types.DevAddr{0x26, 0x00, 0x00, 0x00}, Length: 7}
+	devAddr, err := allocator.Allocate([]types.DevAddrPrefix{prefix})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr.HasPrefix(prefix), ShouldBeTrue)
+	a.So(allocator.Stats().Allocations, ShouldEqual, 1)
+}
+
+func TestLeastLoadedDevAddrAllocator(t *testing.T) {
+	a := New(t)
+
+	occupancy := make(map[types.DevAddr]int)
+	var lookupErr error
+	allocator := NewLeastLoadedDevAddrAllocator(func(devAddr types.DevAddr) (int, error) {
+		return occupancy[devAddr], lookupErr
+	}, 8)
+
+	_, err := allocator.Allocate(nil)
+	a.So(err, ShouldNotBeNil)
+
+	// A prefix with 4 DevAddrs is searched exhaustively
+	small := types.DevAddrPrefix{DevAddr: types.DevAddr{0x26, 0x01, 0x00, 0x00}, Length: 30}
+	for i := 0; i < 8; i++ {
+		devAddr, err := allocator.Allocate([]types.DevAddrPrefix{small})
+		a.So(err, ShouldBeNil)
+		a.So(devAddr.HasPrefix(small), ShouldBeTrue)
+		a.So(occupancy[devAddr], ShouldEqual, i/4)
+		occupancy[devAddr]++
+	}
+	stats := allocator.Stats()
+	a.So(stats.Allocations, ShouldEqual, 8)
+	a.So(stats.Collisions, ShouldEqual, 4)
+	a.So(stats.Lookups, ShouldEqual, 1+2+3+4+4*4)
+
+	// The least loaded candidate in multiple prefixes
+	other := types.DevAddrPrefix{DevAddr: types.DevAddr{0x26, 0x02, 0x00, 0x00}, Length: 31}
+	occupancy[types.DevAddr{0x26, 0x02, 0x00, 0x00}] = 1
+	devAddr, err := allocator.Allocate([]types.DevAddrPrefix{small, other})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr, ShouldEqual, types.DevAddr{0x26, 0x02, 0x00, 0x01})
+
+	// A big prefix is sampled
+	big := types.DevAddrPrefix{DevAddr: types.DevAddr{0x26, 0x00, 0x00, 0x00}, Length: 7}
+	devAddr, err = allocator.Allocate([]types.DevAddrPrefix{big})
+	a.So(err, ShouldBeNil)
+	a.So(devAddr.HasPrefix(big), ShouldBeTrue)
+
+	// Lookup errors are returned
+	lookupErr = errors.New("database unavailable")
+	_, err = allocator.Allocate([]types.DevAddrPrefix{big})
+	a.So(err, ShouldNotBeNil)
+}
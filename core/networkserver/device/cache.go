@@ -0,0 +1,323 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/random"
+	"gopkg.in/redis.v5"
+)
+
+// CachedStore is a Store that keeps an in-memory index of the devices per DevAddr, so that ListForAddress doesn't
+// have to go to the database for every uplink message.
+//
+// The index is written through by Set and Delete. NetworkServers that share a database let each other know about
+// their changes with ShareInvalidations. Entries expire after the TTL, in case an invalidation is missed.
+type CachedStore struct {
+	hits   uint64 // Accessed atomically, keep 64-bit aligned
+	misses uint64
+
+	Store
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[types.DevAddr]*cacheEntry
+	nextSweep time.Time
+
+	id      string
+	publish func(devAddr types.DevAddr)
+}
+
+type cacheEntry struct {
+	loaded  bool
+	devices map[string]Device
+	expires time.Time
+}
+
+// NewCachedStore wraps the store with an in-memory index of the devices per DevAddr
+func NewCachedStore(store Store, ttl time.Duration) *CachedStore {
+	return &CachedStore{
+		Store:   store,
+		ttl:     ttl,
+		entries: make(map[types.DevAddr]*cacheEntry),
+	}
+}
+
+func deviceKey(appEUI types.AppEUI, devEUI types.DevEUI) string {
+	return fmt.Sprintf("%s:%s", appEUI, devEUI)
+}
+
+// Stats returns the number of lookups that were served from the index, and the number of lookups that went to the
+// database
+func (s *CachedStore) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&s.hits), atomic.LoadUint64(&s.misses)
+}
+
+// ListForAddress lists all devices for a specific DevAddr
+func (s *CachedStore) ListForAddress(devAddr types.DevAddr) ([]*Device, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.sweep(now)
+	entry, ok := s.entries[devAddr]
+	if ok && entry.loaded && now.Before(entry.expires) {
+		devices := make([]*Device, 0, len(entry.devices))
+		for _, device := range entry.devices {
+			device := device
+			devices = append(devices, &device)
+		}
+		s.mu.Unlock()
+		atomic.AddUint64(&s.hits, 1)
+		return devices, nil
+	}
+
+	// Set and Delete remove the placeholder if they change this DevAddr while we're loading it
+	entry = &cacheEntry{}
+	s.entries[devAddr] = entry
+	s.mu.Unlock()
+	atomic.AddUint64(&s.misses, 1)
+
+	devices, err := s.Store.ListForAddress(devAddr)
+	if err != nil {
+		s.invalidate(devAddr)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries[devAddr] == entry {
+		entry.devices = make(map[string]Device, len(devices))
+		for _, device := range devices {
+			if device == nil {
+				continue
+			}
+			entry.devices[deviceKey(device.AppEUI, device.DevEUI)] = *device
+		}
+		entry.loaded = true
+		entry.expires = now.Add(s.ttl)
+	}
+
+	return devices, nil
+}
+
+// CountForAddress returns the number of devices for a specific DevAddr. The index is used if it has the DevAddr, but
+// the DevAddr is not loaded into the index if it doesn't.
+func (s *CachedStore) CountForAddress(devAddr types.DevAddr) (int, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[devAddr]
+	if ok && entry.loaded && time.Now().Before(entry.expires) {
+		count := len(entry.devices)
+		s.mu.Unlock()
+		return count, nil
+	}
+	s.mu.Unlock()
+	return s.Store.CountForAddress(devAddr)
+}
+
+// sweep removes expired entries from the index. It should be called with the lock held.
+func (s *CachedStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for devAddr, entry := range s.entries {
+		if entry.loaded && !now.Before(entry.expires) {
+			delete(s.entries, devAddr)
+		}
+	}
+	s.nextSweep = now.Add(s.ttl)
+}
+
+// Set a new Device or update an existing one
+func (s *CachedStore) Set(new *Device, properties ...string) error {
+	old := new.old
+	err := s.Store.Set(new, properties...)
+
+	changed := []types.DevAddr{new.DevAddr}
+	if old != nil && old.DevAddr != new.DevAddr {
+		changed = append(changed, old.DevAddr)
+	}
+
+	if err != nil {
+		// We don't know what was written, so we let the index reload
+		s.invalidate(changed...)
+		s.publishInvalidation(changed...)
+		return err
+	}
+
+	s.mu.Lock()
+	if old != nil && (old.DevAddr != new.DevAddr || old.AppEUI != new.AppEUI || old.DevEUI != new.DevEUI) {
+		s.remove(old.DevAddr, deviceKey(old.AppEUI, old.DevEUI))
+	}
+	if !new.DevAddr.IsEmpty() {
+		if entry, ok := s.entries[new.DevAddr]; ok {
+			if entry.loaded {
+				device := *new
+				device.old = nil
+				entry.devices[deviceKey(new.AppEUI, new.DevEUI)] = device
+			} else {
+				delete(s.entries, new.DevAddr)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if indexChanged(old, new) {
+		s.publishInvalidation(changed...)
+	}
+
+	return nil
+}
+
+// indexChanged returns true if the change of the device is relevant for the indexes of other NetworkServers. An
+// increasing FCntUp is not published, so the index of another NetworkServer may return the device with an old FCntUp
+// until the entry expires. The index is therefore only used to find candidate devices: HandleUplink checks the FCnt
+// against the device in the database before it accepts an uplink message.
+func indexChanged(old, new *Device) bool {
+	if old == nil {
+		return true
+	}
+	return old.DevAddr != new.DevAddr ||
+		old.AppEUI != new.AppEUI ||
+		old.DevEUI != new.DevEUI ||
+		old.AppID != new.AppID ||
+		old.DevID != new.DevID ||
+		old.NwkSKey != new.NwkSKey ||
+		old.Options != new.Options ||
+		new.FCntUp < old.FCntUp
+}
+
+// Delete a Device
+func (s *CachedStore) Delete(appEUI types.AppEUI, devEUI types.DevEUI) error {
+	device, err := s.Store.Get(appEUI, devEUI)
+	if err != nil {
+		return err
+	}
+	err = s.Store.Delete(appEUI, devEUI)
+
+	s.mu.Lock()
+	s.remove(device.DevAddr, deviceKey(appEUI, devEUI))
+	s.mu.Unlock()
+
+	s.publishInvalidation(device.DevAddr)
+
+	return err
+}
+
+// remove removes a device from the index. It should be called with the lock held.
+func (s *CachedStore) remove(devAddr types.DevAddr, key string) {
+	entry, ok := s.entries[devAddr]
+	if !ok {
+		return
+	}
+	if entry.loaded {
+		delete(entry.devices, key)
+	} else {
+		delete(s.entries, devAddr)
+	}
+}
+
+func (s *CachedStore) invalidate(devAddrs ...types.DevAddr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, devAddr := range devAddrs {
+		delete(s.entries, devAddr)
+	}
+}
+
+// Purge removes all entries from the index
+func (s *CachedStore) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[types.DevAddr]*cacheEntry)
+}
+
+func (s *CachedStore) publishInvalidation(devAddrs ...types.DevAddr) {
+	if s.publish == nil {
+		return
+	}
+	for _, devAddr := range devAddrs {
+		if !devAddr.IsEmpty() {
+			s.publish(devAddr)
+		}
+	}
+}
+
+// ShareInvalidations publishes the DevAddrs of changed devices on a Redis channel, and invalidates the entries of the
+// DevAddrs that other NetworkServers publish on that channel. If the subscription breaks, the index is purged and the
+// channel is subscribed to again.
+func (s *CachedStore) ShareInvalidations(client *redis.Client, channel string) error {
+	pubsub, err := client.Subscribe(channel)
+	if err != nil {
+		return err
+	}
+
+	s.id = random.String(16)
+	s.publish = func(devAddr types.DevAddr) {
+		if err := client.Publish(channel, s.id+" "+devAddr.String()).Err(); err != nil {
+			log.Get().WithError(err).Warn("Could not publish device cache invalidation")
+		}
+	}
+
+	go func() {
+		for {
+			s.receiveInvalidations(pubsub)
+			pubsub.Close()
+			pubsub = s.resubscribe(client, channel)
+		}
+	}()
+
+	return nil
+}
+
+const (
+	minResubscribeBackoff = 100 * time.Millisecond
+	maxResubscribeBackoff = time.Minute
+)
+
+// receiveInvalidations invalidates the DevAddrs that other NetworkServers publish, until receiving fails
+func (s *CachedStore) receiveInvalidations(pubsub *redis.PubSub) {
+	for {
+		msg, err := pubsub.ReceiveMessage()
+		if err != nil {
+			// We may have missed invalidations, so we start over
+			log.Get().WithError(err).Warn("Could not receive device cache invalidations")
+			s.Purge()
+			return
+		}
+		parts := strings.SplitN(msg.Payload, " ", 2)
+		if len(parts) != 2 || parts[0] == s.id {
+			continue
+		}
+		devAddr, err := types.ParseDevAddr(parts[1])
+		if err != nil {
+			continue
+		}
+		s.invalidate(devAddr)
+	}
+}
+
+// resubscribe subscribes to the channel, with an exponential backoff between the attempts
+func (s *CachedStore) resubscribe(client *redis.Client, channel string) *redis.PubSub {
+	backoff := minResubscribeBackoff
+	for {
+		time.Sleep(backoff)
+		pubsub, err := client.Subscribe(channel)
+		if err == nil {
+			// Invalidations may have been published while we were not subscribed
+			s.Purge()
+			return pubsub
+		}
+		log.Get().WithError(err).WithField("Backoff", backoff).Warn("Could not resubscribe to device cache invalidations")
+		if backoff *= 2; backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+}
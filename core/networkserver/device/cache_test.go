@@ -0,0 +1,190 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestCachedStore(t *testing.T) {
+	a := New(t)
+
+	s := NewCachedStore(NewRedisDeviceStore(GetRedisClient(), "networkserver-test-cached-store"), time.Minute)
+
+	appEUI := types.AppEUI{0, 0, 0, 0, 0, 0, 0, 1}
+	devAddr := types.DevAddr{0, 0, 0, 1}
+
+	err := s.Set(&Device{
+		DevAddr: devAddr,
+		DevEUI:  types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1},
+		AppEUI:  appEUI,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1})
+	}()
+
+	// Miss
+	res, err := s.ListForAddress(devAddr)
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldHaveLength, 1)
+	hits, misses := s.Stats()
+	a.So(hits, ShouldEqual, 0)
+	a.So(misses, ShouldEqual, 1)
+
+	// Hit
+	res, err = s.ListForAddress(devAddr)
+	a.So(err, ShouldBeNil)
+	a.So(res, ShouldHaveLength, 1)
+	hits, misses = s.Stats()
+	a.So(hits, ShouldEqual, 1)
+	a.So(misses, ShouldEqual, 1)
+
+	// Count from the index
+	count, err := s.CountForAddress(devAddr)
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 1)
+	count, err = s.CountForAddress(types.DevAddr{0, 0, 0, 2})
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 0)
+
+	// Changing the returned device does not change the index
+	res[0].FCntUp = 42
+	res, _ = s.ListForAddress(devAddr)
+	a.So(res[0].FCntUp, ShouldEqual, 0)
+
+	// Update is written through
+	dev, err := s.Get(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	dev.StartUpdate()
+	dev.FCntUp = 42
+	err = s.Set(dev)
+	a.So(err, ShouldBeNil)
+	res, _ = s.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 1)
+	a.So(res[0].FCntUp, ShouldEqual, 42)
+
+	// New device is written through
+	err = s.Set(&Device{
+		DevAddr: devAddr,
+		DevEUI:  types.DevEUI{0, 0, 0, 0, 0, 0, 0, 2},
+		AppEUI:  appEUI,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 2})
+	}()
+	res, _ = s.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 2)
+
+	// Changed DevAddr
+	dev, _ = s.Get(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 2})
+	dev.StartUpdate()
+	dev.DevAddr = types.DevAddr{0, 0, 0, 2}
+	err = s.Set(dev)
+	a.So(err, ShouldBeNil)
+	res, _ = s.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 1)
+	res, _ = s.ListForAddress(types.DevAddr{0, 0, 0, 2})
+	a.So(res, ShouldHaveLength, 1)
+
+	// Delete
+	err = s.Delete(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	res, _ = s.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 0)
+
+	hits, misses = s.Stats()
+	a.So(misses, ShouldEqual, 2)
+}
+
+func TestCachedStoreShareInvalidations(t *testing.T) {
+	a := New(t)
+
+	client := GetRedisClient()
+	store := NewRedisDeviceStore(client, "networkserver-test-cached-store-invalidations")
+
+	s1 := NewCachedStore(store, time.Minute)
+	a.So(s1.ShareInvalidations(client, "networkserver-test-cached-store-invalidations"), ShouldBeNil)
+	s2 := NewCachedStore(store, time.Minute)
+	a.So(s2.ShareInvalidations(client, "networkserver-test-cached-store-invalidations"), ShouldBeNil)
+
+	appEUI := types.AppEUI{0, 0, 0, 0, 0, 0, 0, 1}
+	devAddr := types.DevAddr{0, 0, 0, 3}
+
+	res, _ := s2.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 0)
+
+	err := s1.Set(&Device{
+		DevAddr: devAddr,
+		DevEUI:  types.DevEUI{0, 0, 0, 0, 0, 0, 0, 3},
+		AppEUI:  appEUI,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s1.Delete(appEUI, types.DevEUI{0, 0, 0, 0, 0, 0, 0, 3})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	res, _ = s2.ListForAddress(devAddr)
+	a.So(res, ShouldHaveLength, 1)
+}
+
+func TestCachedStorePublishInvalidation(t *testing.T) {
+	a := New(t)
+
+	s := NewCachedStore(NewRedisDeviceStore(GetRedisClient(), "networkserver-test-cached-store-publish"), time.Minute)
+
+	var published []types.DevAddr
+	s.publish = func(devAddr types.DevAddr) {
+		published = append(published, devAddr)
+	}
+
+	appEUI := types.AppEUI{0, 0, 0, 0, 0, 0, 0, 1}
+	devEUI := types.DevEUI{0, 0, 0, 0, 0, 0, 0, 4}
+	devAddr := types.DevAddr{0, 0, 0, 4}
+
+	// New device is published
+	err := s.Set(&Device{
+		DevAddr: devAddr,
+		DevEUI:  devEUI,
+		AppEUI:  appEUI,
+	})
+	a.So(err, ShouldBeNil)
+	defer func() {
+		s.Delete(appEUI, devEUI)
+	}()
+	a.So(published, ShouldResemble, []types.DevAddr{devAddr})
+
+	// Increasing frame counter is not published
+	published = nil
+	dev, _ := s.Get(appEUI, devEUI)
+	dev.StartUpdate()
+	dev.FCntUp = 42
+	dev.LastSeen = time.Now()
+	a.So(s.Set(dev), ShouldBeNil)
+	a.So(published, ShouldBeEmpty)
+
+	// Reset frame counter is published
+	dev, _ = s.Get(appEUI, devEUI)
+	dev.StartUpdate()
+	dev.FCntUp = 0
+	a.So(s.Set(dev), ShouldBeNil)
+	a.So(published, ShouldResemble, []types.DevAddr{devAddr})
+
+	// New session is published for both DevAddrs
+	published = nil
+	dev, _ = s.Get(appEUI, devEUI)
+	dev.StartUpdate()
+	dev.DevAddr = types.DevAddr{0, 0, 0, 5}
+	dev.NwkSKey = types.NwkSKey{1}
+	a.So(s.Set(dev), ShouldBeNil)
+	a.So(published, ShouldResemble, []types.DevAddr{{0, 0, 0, 5}, devAddr})
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/fatih/structs"
 )
 
-const currentDBVersion = "2.4.1"
+const currentDBVersion = "2.5.0"
 
 // Options for the specified device
 type Options struct {
@@ -15,6 +15,7 @@ import (
 type FrameHistory interface {
 	Push(frame *Frame) error
 	Get() ([]*Frame, error)
+	Replace(frames []*Frame) error
 	Clear() error
 }
 
@@ -64,6 +65,28 @@ func (s *RedisFrameHistory) Get() (out []*Frame, err error) {
 	return
 }
 
+// Replace the device's history with frames (most recent first), as returned by Get
+func (s *RedisFrameHistory) Replace(frames []*Frame) error {
+	if err := s.Clear(); err != nil {
+		return err
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		frameBytes, err := json.Marshal(frame)
+		if err != nil {
+			return err
+		}
+		values = append(values, string(frameBytes))
+	}
+	if err := s.store.AddEnd(s.key(), values...); err != nil {
+		return err
+	}
+	return s.Trim()
+}
+
 // Trim frames in the device's history
 func (s *RedisFrameHistory) Trim() error {
 	return s.store.Trim(s.key(), FramesHistorySize)
@@ -66,4 +66,14 @@ func TestFramesStore(t *testing.T) {
 
 	}
 
+	{
+		err := s.Replace([]*Frame{{FCnt: 3}, {FCnt: 2}, {FCnt: 1}})
+		a.So(err, ShouldBeNil)
+		frames, err := s.Get()
+		a.So(err, ShouldBeNil)
+		a.So(frames, ShouldHaveLength, 3)
+		a.So(frames[0].FCnt, ShouldEqual, 3)
+		a.So(frames[2].FCnt, ShouldEqual, 1)
+	}
+
 }
@@ -18,6 +18,7 @@ import (
 type Store interface {
 	List(opts *storage.ListOptions) ([]*Device, error)
 	ListForAddress(devAddr types.DevAddr) ([]*Device, error)
+	CountForAddress(devAddr types.DevAddr) (int, error)
 	Get(appEUI types.AppEUI, devEUI types.DevEUI) (*Device, error)
 	Set(new *Device, properties ...string) (err error)
 	Delete(appEUI types.AppEUI, devEUI types.DevEUI) error
@@ -30,6 +31,9 @@ const redisDevicePrefix = "device"
 const redisDevAddrPrefix = "dev_addr"
 const redisFramesPrefix = "frames"
 
+// encryptedFields are the fields of a Device that are encrypted if a KeyRing is set
+var encryptedFields = []string{"nwk_s_key"}
+
 // NewRedisDeviceStore creates a new Redis-based status store
 func NewRedisDeviceStore(client *redis.Client, prefix string) Store {
 	if prefix == "" {
@@ -37,6 +41,7 @@ func NewRedisDeviceStore(client *redis.Client, prefix string) Store {
 	}
 	store := storage.NewRedisMapStore(client, prefix+":"+redisDevicePrefix)
 	store.SetBase(Device{}, "")
+	store.SetEncryptedFields(encryptedFields...)
 	for v, f := range migrate.DeviceMigrations(prefix) {
 		store.AddMigration(v, f)
 	}
@@ -61,6 +66,21 @@ type RedisDeviceStore struct {
 	devAddrIndex *storage.RedisSetStore
 }
 
+// SetKeyRing enables encryption of the keys of Devices with the KeyRing. The keys of Devices that were stored before
+// version 2.5.0 are encrypted when they are migrated; the keys of newer Devices are encrypted when they are written.
+// Keys that were written without a KeyRing are encrypted when their Device is updated. Use RewrapKeys to encrypt
+// them all at once.
+func (s *RedisDeviceStore) SetKeyRing(keys *storage.KeyRing) {
+	s.store.SetKeyRing(keys)
+	s.store.AddMigration("2.4.1", migrate.EncryptKeys(keys, encryptedFields...))
+}
+
+// RewrapKeys wraps the keys of all Devices with the current KEK of the KeyRing, and returns the number of Devices
+// that changed
+func (s *RedisDeviceStore) RewrapKeys() (int, error) {
+	return s.store.Rewrap("")
+}
+
 // List all Devices
 func (s *RedisDeviceStore) List(opts *storage.ListOptions) ([]*Device, error) {
 	devicesI, err := s.store.List("", opts)
@@ -98,6 +118,11 @@ func (s *RedisDeviceStore) ListForAddress(devAddr types.DevAddr) ([]*Device, err
 	return devices, nil
 }
 
+// CountForAddress returns the number of devices for a specific DevAddr
+func (s *RedisDeviceStore) CountForAddress(devAddr types.DevAddr) (int, error) {
+	return s.devAddrIndex.Count(devAddr.String())
+}
+
 // Get a specific Device
 func (s *RedisDeviceStore) Get(appEUI types.AppEUI, devEUI types.DevEUI) (*Device, error) {
 	deviceI, err := s.store.Get(fmt.Sprintf("%s:%s", appEUI, devEUI))
@@ -55,6 +55,13 @@ func TestDeviceStore(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(res, ShouldHaveLength, 0)
 
+	count, err := s.CountForAddress(types.DevAddr{0, 0, 0, 1})
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 2)
+	count, err = s.CountForAddress(types.DevAddr{0, 0, 0, 2})
+	a.So(err, ShouldBeNil)
+	a.So(count, ShouldEqual, 0)
+
 	// Existing Device, New DevAddr
 	err = s.Set(&Device{
 		old: &Device{
@@ -32,7 +32,10 @@ func (n *networkServer) HandleDownlink(message *pb_broker.DownlinkMessage) (*pb_
 		return nil, errors.NewErrInvalidArgument("Downlink", "AppID and DevID do not match AppEUI and DevEUI")
 	}
 
-	message.Trace = message.Trace.WithEvent(trace.UpdateStateEvent)
+	message.Trace = message.Trace.WithEvent(n.TraceService(), trace.UpdateStateEvent)
+	defer func() {
+		trace.Export(n.TraceService(), message.Trace)
+	}()
 
 	dev.StartUpdate()
 	defer func() {
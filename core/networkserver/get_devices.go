@@ -4,12 +4,21 @@
 package networkserver
 
 import (
+	"time"
+
 	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/utils/fcnt"
 )
 
 func (n *networkServer) HandleGetDevices(req *pb.DevicesRequest) (*pb.DevicesResponse, error) {
+	if n.status != nil {
+		start := time.Now()
+		defer func() {
+			n.status.getDevices.Update(int64(time.Since(start) / time.Microsecond))
+		}()
+	}
+
 	devices, err := n.devices.ListForAddress(*req.DevAddr)
 	if err != nil {
 		return nil, err
@@ -12,6 +12,7 @@ import (
 	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/ratelimit"
+	"github.com/TheThingsNetwork/ttn/core/audit"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
@@ -130,6 +131,14 @@ func (n *networkServerManager) SetDevice(ctx context.Context, in *pb_lorawan.Dev
 		return nil, err
 	}
 
+	n.networkServer.RecordAudit(claims, &audit.Entry{
+		Action:  audit.SetDevice,
+		AppID:   dev.AppID,
+		DevID:   dev.DevID,
+		Target:  fmt.Sprintf("%s/%s", dev.AppEUI, dev.DevEUI),
+		Changed: audit.Changes(dev),
+	})
+
 	frames, err := n.networkServer.devices.Frames(dev.AppEUI, dev.DevEUI)
 	if err != nil {
 		return nil, err
@@ -143,7 +152,11 @@ func (n *networkServerManager) SetDevice(ctx context.Context, in *pb_lorawan.Dev
 }
 
 func (n *networkServerManager) DeleteDevice(ctx context.Context, in *pb_lorawan.DeviceIdentifier) (*empty.Empty, error) {
-	_, err := n.getDevice(ctx, in)
+	claims, err := n.networkServer.Component.ValidateTTNAuthContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dev, err := n.getDevice(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +164,149 @@ func (n *networkServerManager) DeleteDevice(ctx context.Context, in *pb_lorawan.
 	if err != nil {
 		return nil, err
 	}
+	n.networkServer.RecordAudit(claims, &audit.Entry{
+		Action: audit.DeleteDevice,
+		AppID:  dev.AppID,
+		DevID:  dev.DevID,
+		Target: fmt.Sprintf("%s/%s", dev.AppEUI, dev.DevEUI),
+	})
+	return &empty.Empty{}, nil
+}
+
+func (n *networkServerManager) GetMACState(ctx context.Context, in *pb_lorawan.DeviceIdentifier) (*pb_lorawan.MACState, error) {
+	dev, err := n.getDevice(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := n.networkServer.devices.Frames(dev.AppEUI, dev.DevEUI)
+	if err != nil {
+		return nil, err
+	}
+	frames, err := history.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeen := time.Unix(0, 0)
+	if !dev.LastSeen.IsZero() {
+		lastSeen = dev.LastSeen
+	}
+
+	state := &pb_lorawan.MACState{
+		AppId:            dev.AppID,
+		AppEui:           &dev.AppEUI,
+		DevId:            dev.DevID,
+		DevEui:           &dev.DevEUI,
+		DevAddr:          &dev.DevAddr,
+		FCntUp:           dev.FCntUp,
+		FCntDown:         dev.FCntDown,
+		DisableFCntCheck: dev.Options.DisableFCntCheck,
+		Uses32BitFCnt:    dev.Options.Uses32BitFCnt,
+		LastSeen:         lastSeen.UnixNano(),
+		Adr: &pb_lorawan.ADRSettings{
+			Band:     dev.ADR.Band,
+			Margin:   int32(dev.ADR.Margin),
+			DataRate: dev.ADR.DataRate,
+			TxPower:  int32(dev.ADR.TxPower),
+			NbTrans:  int32(dev.ADR.NbTrans),
+			SendReq:  dev.ADR.SendReq,
+			Failed:   int32(dev.ADR.Failed),
+		},
+		LossPercentage: uint32(lossPercentage(frames)),
+	}
+
+	for _, frame := range frames {
+		state.FrameHistory = append(state.FrameHistory, &pb_lorawan.Frame{
+			FCnt:         frame.FCnt,
+			Snr:          frame.SNR,
+			GatewayCount: frame.GatewayCount,
+		})
+	}
+
+	// A LinkADRReq is added to the next downlink if the NetworkServer has enough frames to calculate it
+	if dev.ADR.SendReq && dev.ADR.Failed == 0 && len(frames) >= device.FramesHistorySize {
+		state.PendingMacCommands = append(state.PendingMacCommands, "LinkADRReq")
+	}
+
+	return state, nil
+}
+
+func (n *networkServerManager) ResetMACState(ctx context.Context, in *pb_lorawan.DeviceIdentifier) (*empty.Empty, error) {
+	dev, err := n.getDevice(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	dev.StartUpdate()
+	dev.ADR = device.ADRSettings{Band: dev.ADR.Band, Margin: dev.ADR.Margin}
+
+	err = n.networkServer.devices.Set(dev)
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := n.networkServer.devices.Frames(dev.AppEUI, dev.DevEUI)
+	if err != nil {
+		return nil, err
+	}
+	err = frames.Clear()
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (n *networkServerManager) RestoreMACState(ctx context.Context, in *pb_lorawan.MACState) (*empty.Empty, error) {
+	if err := in.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid MAC State")
+	}
+	dev, err := n.getDevice(ctx, &pb_lorawan.DeviceIdentifier{AppEui: in.AppEui, DevEui: in.DevEui})
+	if err != nil {
+		return nil, err
+	}
+	if dev.DevAddr != *in.DevAddr {
+		return nil, errors.NewErrInvalidArgument("DevAddr", "does not match the session of the device")
+	}
+
+	dev.StartUpdate()
+	dev.ADR = device.ADRSettings{
+		Band:     in.Adr.Band,
+		Margin:   int(in.Adr.Margin),
+		SendReq:  in.Adr.SendReq,
+		Failed:   int(in.Adr.Failed),
+		DataRate: in.Adr.DataRate,
+		TxPower:  int(in.Adr.TxPower),
+		NbTrans:  int(in.Adr.NbTrans),
+	}
+	dev.LastSeen = time.Time{}
+	if in.LastSeen != 0 {
+		dev.LastSeen = time.Unix(0, in.LastSeen)
+	}
+
+	err = n.networkServer.devices.Set(dev)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]*device.Frame, 0, len(in.FrameHistory))
+	for _, frame := range in.FrameHistory {
+		frames = append(frames, &device.Frame{
+			FCnt:         frame.FCnt,
+			SNR:          frame.Snr,
+			GatewayCount: frame.GatewayCount,
+		})
+	}
+	history, err := n.networkServer.devices.Frames(dev.AppEUI, dev.DevEUI)
+	if err != nil {
+		return nil, err
+	}
+	err = history.Replace(frames)
+	if err != nil {
+		return nil, err
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -196,6 +352,7 @@ func (n *networkServer) RegisterManager(s *grpc.Server) {
 	server := &networkServerManager{networkServer: n}
 
 	server.clientRate = ratelimit.NewRegistry(5000, time.Hour)
+	n.ConfigureRate("networkserver.client-rate", server.clientRate, time.Hour)
 
 	pb.RegisterNetworkServerManagerServer(s, server)
 	pb_lorawan.RegisterDeviceManagerServer(s, server)
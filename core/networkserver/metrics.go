@@ -0,0 +1,83 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package networkserver
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exposes the status of the network server as Prometheus metrics
+type collector struct {
+	networkServer *networkServer
+
+	uplink      *prometheus.Desc
+	downlink    *prometheus.Desc
+	activations *prometheus.Desc
+
+	getDevicesDuration *prometheus.Desc
+	cacheHits          *prometheus.Desc
+	cacheMisses        *prometheus.Desc
+
+	devAddrAllocations *prometheus.Desc
+	devAddrCollisions  *prometheus.Desc
+	devAddrLookups     *prometheus.Desc
+}
+
+func newCollector(n *networkServer) *collector {
+	return &collector{
+		networkServer: n,
+		uplink:        n.Component.NewMetricDesc("uplink_total", "Total number of handled uplink messages"),
+		downlink:      n.Component.NewMetricDesc("downlink_total", "Total number of handled downlink messages"),
+		activations:   n.Component.NewMetricDesc("activations_total", "Total number of accepted activations"),
+
+		getDevicesDuration: n.Component.NewMetricDesc("get_devices_duration_seconds", "Duration of the device lookups by DevAddr"),
+		cacheHits:          n.Component.NewMetricDesc("devices_cache_hits_total", "Total number of device lookups by DevAddr that were served from the cache"),
+		cacheMisses:        n.Component.NewMetricDesc("devices_cache_misses_total", "Total number of device lookups by DevAddr that went to the database"),
+
+		devAddrAllocations: n.Component.NewMetricDesc("devaddr_allocations_total", "Total number of allocated DevAddrs"),
+		devAddrCollisions:  n.Component.NewMetricDesc("devaddr_collisions_total", "Total number of allocated DevAddrs that were already used by other devices"),
+		devAddrLookups:     n.Component.NewMetricDesc("devaddr_lookups_total", "Total number of lookups of the devices that use a DevAddr during allocation"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.uplink
+	ch <- c.downlink
+	ch <- c.activations
+	ch <- c.getDevicesDuration
+	ch <- c.cacheHits
+	ch <- c.cacheMisses
+	ch <- c.devAddrAllocations
+	ch <- c.devAddrCollisions
+	ch <- c.devAddrLookups
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	status := c.networkServer.status
+	if status == nil {
+		return
+	}
+	ch <- component.MeterMetric(c.uplink, status.uplink)
+	ch <- component.MeterMetric(c.downlink, status.downlink)
+	ch <- component.MeterMetric(c.activations, status.activations)
+
+	// The durations are recorded in microseconds
+	ch <- component.HistogramMetric(c.getDevicesDuration, status.getDevices, 1e-6)
+
+	if cache := c.networkServer.devicesCache; cache != nil {
+		hits, misses := cache.Stats()
+		ch <- prometheus.MustNewConstMetric(c.cacheHits, prometheus.CounterValue, float64(hits))
+		ch <- prometheus.MustNewConstMetric(c.cacheMisses, prometheus.CounterValue, float64(misses))
+	}
+
+	if allocator := c.networkServer.devAddrAllocator; allocator != nil {
+		stats := allocator.Stats()
+		ch <- prometheus.MustNewConstMetric(c.devAddrAllocations, prometheus.CounterValue, float64(stats.Allocations))
+		ch <- prometheus.MustNewConstMetric(c.devAddrCollisions, prometheus.CounterValue, float64(stats.Collisions))
+		ch <- prometheus.MustNewConstMetric(c.devAddrLookups, prometheus.CounterValue, float64(stats.Lookups))
+	}
+}
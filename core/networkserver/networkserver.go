@@ -4,13 +4,17 @@
 package networkserver
 
 import (
+	"time"
+
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_handler "github.com/TheThingsNetwork/ttn/api/handler"
 	pb "github.com/TheThingsNetwork/ttn/api/networkserver"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
+	"github.com/TheThingsNetwork/ttn/core/storage"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/viper"
 	"gopkg.in/redis.v5"
 )
 
@@ -20,6 +24,10 @@ type NetworkServer interface {
 	component.ManagementInterface
 
 	UsePrefix(prefix types.DevAddrPrefix, usage []string) error
+	UseKeyEncryption(keys *storage.KeyRing)
+	UseDevicesCache(client *redis.Client, ttl time.Duration) error
+	UseDevAddrAllocator(allocator DevAddrAllocator)
+	SetDevAddrCandidates(candidates int)
 	GetPrefixesFor(requiredUsages ...string) []types.DevAddrPrefix
 
 	HandleGetDevices(*pb.DevicesRequest) (*pb.DevicesResponse, error)
@@ -36,15 +44,19 @@ func NewRedisNetworkServer(client *redis.Client, netID int) NetworkServer {
 		prefixes: map[types.DevAddrPrefix][]string{},
 	}
 	ns.netID = [3]byte{byte(netID >> 16), byte(netID >> 8), byte(netID)}
+	ns.devAddrAllocator = NewLeastLoadedDevAddrAllocator(ns.countDevices, DefaultDevAddrCandidates)
 	return ns
 }
 
 type networkServer struct {
 	*component.Component
-	devices  device.Store
-	netID    [3]byte
-	prefixes map[types.DevAddrPrefix][]string
-	status   *status
+	devices      device.Store
+	devicesCache *device.CachedStore
+	netID        [3]byte
+	prefixes     map[types.DevAddrPrefix][]string
+	status       *status
+
+	devAddrAllocator DevAddrAllocator
 }
 
 func (n *networkServer) UsePrefix(prefix types.DevAddrPrefix, usage []string) error {
@@ -58,6 +70,53 @@ func (n *networkServer) UsePrefix(prefix types.DevAddrPrefix, usage []string) er
 	return nil
 }
 
+// keyEncrypter is implemented by stores that can encrypt keys at rest
+type keyEncrypter interface {
+	SetKeyRing(keys *storage.KeyRing)
+}
+
+func (n *networkServer) UseKeyEncryption(keys *storage.KeyRing) {
+	store := n.devices
+	if n.devicesCache != nil {
+		store = n.devicesCache.Store
+	}
+	if devices, ok := store.(keyEncrypter); ok {
+		devices.SetKeyRing(keys)
+	}
+}
+
+// UseDevicesCache keeps an in-memory index of the devices per DevAddr, that is shared with the other NetworkServers
+// that use the same Redis database
+func (n *networkServer) UseDevicesCache(client *redis.Client, ttl time.Duration) error {
+	cache := device.NewCachedStore(n.devices, ttl)
+	if err := cache.ShareInvalidations(client, "ns:device:invalidate"); err != nil {
+		return err
+	}
+	n.devices = cache
+	n.devicesCache = cache
+	return nil
+}
+
+// UseDevAddrAllocator sets the allocator for the DevAddrs of devices that activate
+func (n *networkServer) UseDevAddrAllocator(allocator DevAddrAllocator) {
+	n.devAddrAllocator = allocator
+}
+
+// SetDevAddrCandidates sets the number of candidate DevAddrs that are compared when a device activates. The DevAddr
+// is allocated at random if candidates is 0.
+func (n *networkServer) SetDevAddrCandidates(candidates int) {
+	if candidates > 0 {
+		n.devAddrAllocator = NewLeastLoadedDevAddrAllocator(n.countDevices, candidates)
+	} else {
+		n.devAddrAllocator = NewRandomDevAddrAllocator()
+	}
+}
+
+// countDevices returns the number of devices that use the DevAddr
+func (n *networkServer) countDevices(devAddr types.DevAddr) (int, error) {
+	return n.devices.CountForAddress(devAddr)
+}
+
 func (n *networkServer) GetPrefixesFor(requiredUsages ...string) []types.DevAddrPrefix {
 	var suitablePrefixes []types.DevAddrPrefix
 	for prefix, offeredUsages := range n.prefixes {
@@ -79,12 +138,30 @@ func (n *networkServer) GetPrefixesFor(requiredUsages ...string) []types.DevAddr
 func (n *networkServer) Init(c *component.Component) error {
 	n.Component = c
 	n.InitStatus()
+	n.Component.RegisterMetrics(newCollector(n))
 	err := n.Component.UpdateTokenKey()
 	if err != nil {
 		return err
 	}
+	if err := n.Configure([]string{"networkserver.adr-margin"}, reloadADRMargin); err != nil {
+		n.Ctx.WithError(err).Warn("Invalid ADR margin, using the default")
+	}
 	n.Component.SetStatus(component.StatusHealthy)
 	return nil
 }
 
+// reloadADRMargin validates the configured default ADR margin, and returns a function that applies it
+func reloadADRMargin() (func(), error) {
+	if !viper.IsSet("networkserver.adr-margin") {
+		return func() {}, nil
+	}
+	margin := viper.GetInt("networkserver.adr-margin")
+	if margin <= 0 {
+		return nil, errors.NewErrInvalidArgument("ADR margin", "must be positive")
+	}
+	return func() {
+		SetDefaultADRMargin(margin)
+	}, nil
+}
+
 func (n *networkServer) Shutdown() {}
@@ -29,6 +29,12 @@ func TestNewNetworkServer(t *testing.T) {
 	ns := NewRedisNetworkServer(&client, 19)
 	a.So(ns, ShouldNotBeNil)
 	a.So(ns.(*networkServer).netID, ShouldEqual, [3]byte{0, 0, 0x13})
+	a.So(ns.(*networkServer).devAddrAllocator, ShouldNotBeNil)
+
+	ns.SetDevAddrCandidates(0)
+	a.So(ns.(*networkServer).devAddrAllocator, ShouldHaveSameTypeAs, &randomDevAddrAllocator{})
+	ns.SetDevAddrCandidates(16)
+	a.So(ns.(*networkServer).devAddrAllocator.(*leastLoadedDevAddrAllocator).candidates, ShouldEqual, 16)
 
 	// Other NetID, same NwkID
 	ns = NewRedisNetworkServer(&client, 66067)
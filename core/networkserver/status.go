@@ -14,6 +14,7 @@ type status struct {
 	uplink      metrics.Meter
 	downlink    metrics.Meter
 	activations metrics.Meter
+	getDevices  metrics.Histogram
 }
 
 func (n *networkServer) InitStatus() {
@@ -21,6 +22,7 @@ func (n *networkServer) InitStatus() {
 		uplink:      metrics.NewMeter(),
 		downlink:    metrics.NewMeter(),
 		activations: metrics.NewMeter(),
+		getDevices:  metrics.NewHistogram(metrics.NewUniformSample(512)),
 	}
 }
 
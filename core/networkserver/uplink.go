@@ -7,7 +7,9 @@ import (
 	"time"
 
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	"github.com/TheThingsNetwork/ttn/api/trace"
+	"github.com/TheThingsNetwork/ttn/core/networkserver/device"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
@@ -29,7 +31,16 @@ func (n *networkServer) HandleUplink(message *pb_broker.DeduplicatedUplinkMessag
 		return nil, err
 	}
 
-	message.Trace = message.Trace.WithEvent(trace.UpdateStateEvent)
+	// The Broker checked the FCnt against a device that it may have cached, so we check it again
+	confirmed := message.Message.GetLorawan().MType == pb_lorawan.MType_CONFIRMED_UP
+	if err := checkFCntUp(dev, lorawanUplinkMac.FCnt, confirmed); err != nil {
+		return nil, err
+	}
+
+	message.Trace = message.Trace.WithEvent(n.TraceService(), trace.UpdateStateEvent)
+	defer func() {
+		trace.Export(n.TraceService(), message.Trace)
+	}()
 
 	dev.StartUpdate()
 	defer func() {
@@ -73,3 +84,18 @@ func (n *networkServer) HandleUplink(message *pb_broker.DeduplicatedUplinkMessag
 
 	return message, nil
 }
+
+// checkFCntUp returns an error if the FCnt of an uplink message is not higher than the FCntUp of the device. A
+// confirmed uplink message may be retried with the same FCnt.
+func checkFCntUp(dev *device.Device, fCnt uint32, confirmed bool) error {
+	switch {
+	case dev.Options.DisableFCntCheck:
+	case dev.FCntUp == 0:
+		// FCntUp is reset. We don't know where the device will start sending.
+	case fCnt > dev.FCntUp:
+	case fCnt == dev.FCntUp && confirmed:
+	default:
+		return errors.NewErrInvalidArgument("FCnt", "not high enough")
+	}
+	return nil
+}
@@ -29,7 +29,7 @@ func (n *networkServer) handleUplinkMAC(message *pb_broker.DeduplicatedUplinkMes
 
 	// Confirmed Uplink
 	if lorawanUplinkMsg.IsConfirmed() {
-		message.Trace = message.Trace.WithEvent("set ack")
+		message.Trace = message.Trace.WithEvent(n.TraceService(), "set ack")
 		lorawanDownlinkMac.Ack = true
 	}
 
@@ -51,13 +51,13 @@ func (n *networkServer) handleUplinkMAC(message *pb_broker.DeduplicatedUplinkMes
 				Cid:     uint32(lorawan.LinkCheckAns),
 				Payload: responsePayload,
 			})
-			message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "link-check")
+			message.Trace = message.Trace.WithEvent(n.TraceService(), trace.HandleMACEvent, macCMD, "link-check")
 		case uint32(lorawan.LinkADRAns):
 			var answer lorawan.LinkADRAnsPayload
 			if err := answer.UnmarshalBinary(cmd.Payload); err != nil {
 				break
 			}
-			message.Trace = message.Trace.WithEvent(trace.HandleMACEvent, macCMD, "link-adr",
+			message.Trace = message.Trace.WithEvent(n.TraceService(), trace.HandleMACEvent, macCMD, "link-adr",
 				"data-rate-ack", answer.DataRateACK,
 				"power-ack", answer.PowerACK,
 				"channel-mask-ack", answer.ChannelMaskACK,
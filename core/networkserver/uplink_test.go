@@ -117,4 +117,33 @@ func TestHandleUplink(t *testing.T) {
 	dev, _ := ns.devices.Get(appEUI, devEUI)
 	a.So(dev.FCntUp, ShouldEqual, 1)
 	a.So(time.Now().Sub(dev.LastSeen), ShouldBeLessThan, 1*time.Second)
+
+	// Replayed Uplink
+	message = &pb_broker.DeduplicatedUplinkMessage{
+		AppEui:  &appEUI,
+		DevEui:  &devEUI,
+		Payload: bytes,
+		ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{
+			Lorawan: &pb_lorawan.Metadata{
+				DataRate: "SF7BW125",
+			},
+		}},
+	}
+	_, err = ns.HandleUplink(message)
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestCheckFCntUp(t *testing.T) {
+	a := New(t)
+
+	dev := &device.Device{FCntUp: 5}
+	a.So(checkFCntUp(dev, 6, false), ShouldBeNil)
+	a.So(checkFCntUp(dev, 5, false), ShouldNotBeNil)
+	a.So(checkFCntUp(dev, 5, true), ShouldBeNil)
+	a.So(checkFCntUp(dev, 4, true), ShouldNotBeNil)
+
+	dev.Options.DisableFCntCheck = true
+	a.So(checkFCntUp(dev, 4, false), ShouldBeNil)
+
+	a.So(checkFCntUp(&device.Device{}, 0, false), ShouldBeNil)
 }
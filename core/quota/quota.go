@@ -0,0 +1,234 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package quota implements daily fair-use quotas for the uplink airtime and the
+// number of downlinks of applications and devices
+package quota
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/toa"
+)
+
+// Quotas
+const (
+	UplinkAirtime = "uplink_airtime"
+	Downlinks     = "downlinks"
+)
+
+// Scopes of a quota
+const (
+	ApplicationScope = "application"
+	DeviceScope      = "device"
+)
+
+// Limits per day. A zero value means that there is no limit
+type Limits struct {
+	UplinkAirtime time.Duration
+	Downlinks     uint64
+}
+
+func (l Limits) enabled(quota string) bool {
+	switch quota {
+	case UplinkAirtime:
+		return l.UplinkAirtime > 0
+	case Downlinks:
+		return l.Downlinks > 0
+	}
+	return false
+}
+
+func (l Limits) exhausted(quota string, usage Usage) bool {
+	switch quota {
+	case UplinkAirtime:
+		return l.UplinkAirtime > 0 && usage.UplinkAirtime >= l.UplinkAirtime
+	case Downlinks:
+		return l.Downlinks > 0 && usage.Downlinks >= l.Downlinks
+	}
+	return false
+}
+
+// Usage on a single day
+type Usage struct {
+	UplinkAirtime time.Duration
+	Downlinks     uint64
+}
+
+func (u Usage) sub(other Usage) Usage {
+	if other.UplinkAirtime > u.UplinkAirtime {
+		u.UplinkAirtime = 0
+	} else {
+		u.UplinkAirtime -= other.UplinkAirtime
+	}
+	if other.Downlinks > u.Downlinks {
+		u.Downlinks = 0
+	} else {
+		u.Downlinks -= other.Downlinks
+	}
+	return u
+}
+
+// Exhausted indicates which quota was exhausted
+type Exhausted struct {
+	Scope string
+	Quota string
+	Reset time.Time
+}
+
+// Err returns the error for the exhausted quota
+func (e *Exhausted) Err() error {
+	return errors.NewErrResourceExhausted(fmt.Sprintf("%s quota of %s", strings.Replace(e.Quota, "_", " ", -1), e.Scope))
+}
+
+// Enforcer enforces the quotas of applications and devices
+type Enforcer struct {
+	store       Store
+	mu          sync.RWMutex
+	application Limits
+	device      Limits
+}
+
+// NewEnforcer returns a new Enforcer that keeps the usage in the given Store
+func NewEnforcer(store Store, application, device Limits) *Enforcer {
+	return &Enforcer{
+		store:       store,
+		application: application,
+		device:      device,
+	}
+}
+
+// SetLimits changes the limits. The usage that was already accounted is kept.
+func (e *Enforcer) SetLimits(application, device Limits) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.application = application
+	e.device = device
+}
+
+// Uplink accounts the airtime of an uplink message of a device. If the uplink
+// airtime quota of the application or device was already exhausted, the uplink
+// is not accounted and an ErrResourceExhausted is returned. The returned
+// Exhausted is non-nil if the quota is exhausted, including by this uplink.
+func (e *Enforcer) Uplink(appID, devID string, airtime time.Duration) (*Exhausted, error) {
+	return e.account(appID, devID, UplinkAirtime, Usage{UplinkAirtime: airtime})
+}
+
+// Downlink accounts a downlink message of a device. It behaves like Uplink.
+func (e *Enforcer) Downlink(appID, devID string) (*Exhausted, error) {
+	return e.account(appID, devID, Downlinks, Usage{Downlinks: 1})
+}
+
+// RefundDownlink removes a downlink message that was accounted with Downlink,
+// but could not be sent
+func (e *Enforcer) RefundDownlink(appID, devID string) error {
+	return e.refund(appID, devID, Downlinks, Usage{Downlinks: 1})
+}
+
+// Usage returns the usage of the application and device today
+func (e *Enforcer) Usage(appID, devID string) (application Usage, device Usage, err error) {
+	day := today()
+	application, err = e.store.Get(applicationKey(appID), day)
+	if err != nil {
+		return
+	}
+	device, err = e.store.Get(deviceKey(appID, devID), day)
+	return
+}
+
+type entity struct {
+	scope  string
+	key    string
+	limits Limits
+}
+
+func (e *Enforcer) entities(appID, devID string) []entity {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return []entity{
+		{ApplicationScope, applicationKey(appID), e.application},
+		{DeviceScope, deviceKey(appID, devID), e.device},
+	}
+}
+
+// account adds the usage to the application and device and checks the quota
+// using the totals that the store returns, so that concurrent messages can not
+// exceed the quota. If the quota was already exhausted before this message, the
+// usage is removed again.
+func (e *Enforcer) account(appID, devID, quota string, usage Usage) (*Exhausted, error) {
+	day := today()
+	var accounted []entity
+	var exhausted *Exhausted
+	for _, entity := range e.entities(appID, devID) {
+		if !entity.limits.enabled(quota) {
+			continue
+		}
+		total, err := e.store.Add(entity.key, day, usage)
+		if err != nil {
+			e.remove(accounted, day, usage)
+			return nil, err
+		}
+		accounted = append(accounted, entity)
+		if entity.limits.exhausted(quota, total.sub(usage)) {
+			e.remove(accounted, day, usage)
+			exhausted := &Exhausted{Scope: entity.scope, Quota: quota, Reset: day.Add(24 * time.Hour)}
+			return exhausted, exhausted.Err()
+		}
+		if exhausted == nil && entity.limits.exhausted(quota, total) {
+			exhausted = &Exhausted{Scope: entity.scope, Quota: quota, Reset: day.Add(24 * time.Hour)}
+		}
+	}
+
+	return exhausted, nil
+}
+
+// refund removes usage that was accounted today from the application and device
+func (e *Enforcer) refund(appID, devID, quota string, usage Usage) error {
+	day := today()
+	for _, entity := range e.entities(appID, devID) {
+		if !entity.limits.enabled(quota) {
+			continue
+		}
+		if err := e.store.Remove(entity.key, day, usage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) remove(entities []entity, day time.Time, usage Usage) {
+	for _, entity := range entities {
+		e.store.Remove(entity.key, day, usage)
+	}
+}
+
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func applicationKey(appID string) string {
+	return fmt.Sprintf("app:%s", appID)
+}
+
+func deviceKey(appID, devID string) string {
+	return fmt.Sprintf("dev:%s:%s", appID, devID)
+}
+
+// Airtime computes the time-on-air of a LoRaWAN message
+func Airtime(payload []byte, metadata *pb_lorawan.Metadata) (time.Duration, error) {
+	if metadata == nil {
+		return 0, errors.NewErrInvalidArgument("Metadata", "does not contain LoRaWAN metadata")
+	}
+	switch metadata.Modulation {
+	case pb_lorawan.Modulation_LORA:
+		return toa.ComputeLoRa(uint(len(payload)), metadata.DataRate, metadata.CodingRate)
+	case pb_lorawan.Modulation_FSK:
+		return toa.ComputeFSK(uint(len(payload)), int(metadata.BitRate))
+	}
+	return 0, errors.NewErrInvalidArgument("Modulation", "unknown")
+}
@@ -0,0 +1,153 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package quota
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestEnforcerUplink(t *testing.T) {
+	a := New(t)
+
+	e := NewEnforcer(NewMemoryStore(), Limits{UplinkAirtime: time.Minute}, Limits{UplinkAirtime: 30 * time.Second})
+
+	exhausted, err := e.Uplink("app", "dev", 20*time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldBeNil)
+
+	// This uplink exhausts the device quota, but is still allowed
+	exhausted, err = e.Uplink("app", "dev", 20*time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldNotBeNil)
+	a.So(exhausted.Scope, ShouldEqual, DeviceScope)
+	a.So(exhausted.Quota, ShouldEqual, UplinkAirtime)
+	a.So(exhausted.Reset, ShouldHappenAfter, time.Now())
+
+	// Further uplinks of the device are not allowed and not accounted
+	exhausted, err = e.Uplink("app", "dev", 20*time.Second)
+	a.So(err, ShouldNotBeNil)
+	a.So(errors.GetErrType(err), ShouldEqual, errors.ResourceExhausted)
+	a.So(exhausted, ShouldNotBeNil)
+	a.So(exhausted.Scope, ShouldEqual, DeviceScope)
+
+	app, dev, err := e.Usage("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(app.UplinkAirtime, ShouldEqual, 40*time.Second)
+	a.So(dev.UplinkAirtime, ShouldEqual, 40*time.Second)
+
+	// Other devices of the application exhaust the application quota
+	exhausted, err = e.Uplink("app", "other", 20*time.Second)
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldNotBeNil)
+	a.So(exhausted.Scope, ShouldEqual, ApplicationScope)
+
+	exhausted, err = e.Uplink("app", "another", time.Second)
+	a.So(err, ShouldNotBeNil)
+	a.So(exhausted.Scope, ShouldEqual, ApplicationScope)
+
+	// Downlinks are not limited
+	exhausted, err = e.Downlink("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldBeNil)
+}
+
+func TestEnforcerDownlink(t *testing.T) {
+	a := New(t)
+
+	e := NewEnforcer(NewMemoryStore(), Limits{}, Limits{Downlinks: 2})
+
+	for i := 0; i < 2; i++ {
+		_, err := e.Downlink("app", "dev")
+		a.So(err, ShouldBeNil)
+	}
+
+	exhausted, err := e.Downlink("app", "dev")
+	a.So(err, ShouldNotBeNil)
+	a.So(exhausted.Quota, ShouldEqual, Downlinks)
+
+	_, err = e.Downlink("app", "other")
+	a.So(err, ShouldBeNil)
+
+	// A refunded downlink can be sent again
+	err = e.RefundDownlink("app", "dev")
+	a.So(err, ShouldBeNil)
+	_, err = e.Downlink("app", "dev")
+	a.So(err, ShouldBeNil)
+}
+
+func TestEnforcerConcurrent(t *testing.T) {
+	a := New(t)
+
+	e := NewEnforcer(NewMemoryStore(), Limits{}, Limits{Downlinks: 10})
+
+	var accepted uint64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := e.Downlink("app", "dev"); err == nil {
+				atomic.AddUint64(&accepted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	a.So(accepted, ShouldEqual, 10)
+
+	_, dev, err := e.Usage("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(dev.Downlinks, ShouldEqual, 10)
+}
+
+func TestEnforcerSetLimits(t *testing.T) {
+	a := New(t)
+
+	e := NewEnforcer(NewMemoryStore(), Limits{}, Limits{Downlinks: 1})
+
+	_, err := e.Downlink("app", "dev")
+	a.So(err, ShouldBeNil)
+	_, err = e.Downlink("app", "dev")
+	a.So(err, ShouldNotBeNil)
+
+	// The usage is kept when the limits change
+	e.SetLimits(Limits{}, Limits{Downlinks: 2})
+	exhausted, err := e.Downlink("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldNotBeNil)
+
+	e.SetLimits(Limits{}, Limits{})
+	exhausted, err = e.Downlink("app", "dev")
+	a.So(err, ShouldBeNil)
+	a.So(exhausted, ShouldBeNil)
+}
+
+func TestAirtime(t *testing.T) {
+	a := New(t)
+
+	_, err := Airtime(make([]byte, 10), nil)
+	a.So(err, ShouldNotBeNil)
+
+	airtime, err := Airtime(make([]byte, 10), &pb_lorawan.Metadata{
+		Modulation: pb_lorawan.Modulation_LORA,
+		DataRate:   "SF7BW125",
+		CodingRate: "4/5",
+	})
+	a.So(err, ShouldBeNil)
+	a.So(airtime, ShouldAlmostEqual, 41216*time.Microsecond)
+
+	airtime, err = Airtime(make([]byte, 10), &pb_lorawan.Metadata{
+		Modulation: pb_lorawan.Modulation_FSK,
+		BitRate:    50000,
+	})
+	a.So(err, ShouldBeNil)
+	a.So(airtime, ShouldBeGreaterThan, 0)
+}
@@ -0,0 +1,150 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package quota
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/redis.v5"
+)
+
+// Store keeps the daily usage of applications and devices
+type Store interface {
+	// Get the usage of the given key on the given day
+	Get(key string, day time.Time) (Usage, error)
+	// Add usage to the given key on the given day and return the total usage of that day
+	Add(key string, day time.Time, usage Usage) (Usage, error)
+	// Remove usage that was added to the given key on the given day
+	Remove(key string, day time.Time, usage Usage) error
+}
+
+type dailyUsage struct {
+	day time.Time
+	Usage
+}
+
+type memoryStore struct {
+	sync.Mutex
+	usage map[string]*dailyUsage
+}
+
+// NewMemoryStore returns a new Store that keeps the usage in memory
+func NewMemoryStore() Store {
+	return &memoryStore{
+		usage: make(map[string]*dailyUsage),
+	}
+}
+
+func (s *memoryStore) Get(key string, day time.Time) (Usage, error) {
+	s.Lock()
+	defer s.Unlock()
+	if usage, ok := s.usage[key]; ok && usage.day.Equal(day) {
+		return usage.Usage, nil
+	}
+	return Usage{}, nil
+}
+
+func (s *memoryStore) Add(key string, day time.Time, usage Usage) (Usage, error) {
+	s.Lock()
+	defer s.Unlock()
+	current, ok := s.usage[key]
+	if !ok || !current.day.Equal(day) {
+		current = &dailyUsage{day: day}
+		s.usage[key] = current
+	}
+	current.UplinkAirtime += usage.UplinkAirtime
+	current.Downlinks += usage.Downlinks
+	return current.Usage, nil
+}
+
+func (s *memoryStore) Remove(key string, day time.Time, usage Usage) error {
+	s.Lock()
+	defer s.Unlock()
+	if current, ok := s.usage[key]; ok && current.day.Equal(day) {
+		current.Usage = current.Usage.sub(usage)
+	}
+	return nil
+}
+
+// RedisExpiration is the time after which the usage of a day is removed from Redis
+var RedisExpiration = 48 * time.Hour
+
+const (
+	redisUplinkAirtimeField = "uplink_airtime"
+	redisDownlinksField     = "downlinks"
+)
+
+type redisStore struct {
+	prefix string
+	client *redis.Client
+}
+
+// NewRedisStore returns a new Store that keeps the usage in Redis
+func NewRedisStore(client *redis.Client, prefix string) Store {
+	if !strings.HasSuffix(prefix, ":") {
+		prefix += ":"
+	}
+	return &redisStore{
+		prefix: prefix + "quota:",
+		client: client,
+	}
+}
+
+func (s *redisStore) key(key string, day time.Time) string {
+	return s.prefix + key + ":" + day.Format("2006-01-02")
+}
+
+func (s *redisStore) Get(key string, day time.Time) (usage Usage, err error) {
+	res, err := s.client.HGetAll(s.key(key, day)).Result()
+	if err != nil {
+		return usage, err
+	}
+	if airtime, ok := res[redisUplinkAirtimeField]; ok {
+		ns, err := strconv.ParseInt(airtime, 10, 64)
+		if err != nil {
+			return usage, err
+		}
+		usage.UplinkAirtime = time.Duration(ns)
+	}
+	if downlinks, ok := res[redisDownlinksField]; ok {
+		usage.Downlinks, err = strconv.ParseUint(downlinks, 10, 64)
+		if err != nil {
+			return usage, err
+		}
+	}
+	return usage, nil
+}
+
+// incrBy increments the usage of the given key in a single round trip and returns the new totals
+func (s *redisStore) incrBy(key string, day time.Time, airtime, downlinks int64) (Usage, error) {
+	key = s.key(key, day)
+
+	pipe := s.client.Pipeline()
+	defer pipe.Close()
+
+	airtimeTotal := pipe.HIncrBy(key, redisUplinkAirtimeField, airtime)
+	downlinksTotal := pipe.HIncrBy(key, redisDownlinksField, downlinks)
+	pipe.Expire(key, RedisExpiration)
+
+	if _, err := pipe.Exec(); err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{
+		UplinkAirtime: time.Duration(airtimeTotal.Val()),
+		Downlinks:     uint64(downlinksTotal.Val()),
+	}, nil
+}
+
+func (s *redisStore) Add(key string, day time.Time, usage Usage) (Usage, error) {
+	return s.incrBy(key, day, int64(usage.UplinkAirtime), int64(usage.Downlinks))
+}
+
+func (s *redisStore) Remove(key string, day time.Time, usage Usage) error {
+	_, err := s.incrBy(key, day, -int64(usage.UplinkAirtime), -int64(usage.Downlinks))
+	return err
+}
@@ -0,0 +1,68 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package quota
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/assertions"
+	"gopkg.in/redis.v5"
+)
+
+func getRedisClient() *redis.Client {
+	host := os.Getenv("REDIS_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:6379", host),
+		Password: "", // no password set
+		DB:       1,  // use default DB
+	})
+}
+
+func testStore(a *Assertion, s Store) {
+	day := time.Date(2017, 6, 12, 0, 0, 0, 0, time.UTC)
+
+	usage, err := s.Get("dev:test", day)
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{})
+
+	usage, err = s.Add("dev:test", day, Usage{UplinkAirtime: time.Second})
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{UplinkAirtime: time.Second})
+
+	usage, err = s.Add("dev:test", day, Usage{UplinkAirtime: time.Second, Downlinks: 1})
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{UplinkAirtime: 2 * time.Second, Downlinks: 1})
+
+	usage, err = s.Get("dev:test", day)
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{UplinkAirtime: 2 * time.Second, Downlinks: 1})
+
+	err = s.Remove("dev:test", day, Usage{UplinkAirtime: time.Second})
+	a.So(err, ShouldBeNil)
+
+	usage, err = s.Get("dev:test", day)
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{UplinkAirtime: time.Second, Downlinks: 1})
+
+	// The next day starts without usage
+	usage, err = s.Get("dev:test", day.Add(24*time.Hour))
+	a.So(err, ShouldBeNil)
+	a.So(usage, ShouldResemble, Usage{})
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(New(t), NewMemoryStore())
+}
+
+func TestRedisStore(t *testing.T) {
+	c := getRedisClient()
+	defer c.Del("test-quota-store:quota:dev:test:2017-06-12").Result()
+	testStore(New(t), NewRedisStore(c, "test-quota-store"))
+}
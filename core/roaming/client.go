@@ -0,0 +1,147 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package roaming
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// Partner is a network that has a roaming agreement with this network
+type Partner struct {
+	NetID types.NetID
+	URL   *url.URL
+}
+
+// NewPartner returns a Partner with the URL of its Backend Interfaces endpoint. If the URL contains credentials,
+// these are used for HTTP basic authentication of the requests to the partner, and required for the requests of the
+// partner. Partners without credentials have to authenticate their requests with a TLS client certificate for the
+// host of their URL.
+func NewPartner(netID types.NetID, rawurl string) (*Partner, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, errors.NewErrInvalidArgument("Roaming partner URL", err.Error())
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.NewErrInvalidArgument("Roaming partner URL", "must be an http or https URL")
+	}
+	return &Partner{NetID: netID, URL: u}, nil
+}
+
+// HasCredentials returns true if the URL of the partner contains credentials
+func (p *Partner) HasCredentials() bool {
+	return p.URL.User != nil
+}
+
+// authorized returns true if the request has the credentials of the partner, or, if the partner has no credentials,
+// a verified TLS client certificate for the host of the partner URL
+func (p *Partner) authorized(r *http.Request) bool {
+	if p.URL.User == nil {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			return false
+		}
+		host := p.URL.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		return r.TLS.VerifiedChains[0][0].VerifyHostname(host) == nil
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	expected, _ := p.URL.User.Password()
+	return subtle.ConstantTimeCompare([]byte(username), []byte(p.URL.User.Username())) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1
+}
+
+// ResultError is returned when a roaming partner answers with a result other than Success
+type ResultError struct {
+	Result
+}
+
+func (e *ResultError) Error() string {
+	if e.Description == "" {
+		return e.ResultCode
+	}
+	return fmt.Sprintf("%s: %s", e.ResultCode, e.Description)
+}
+
+// DefaultTimeout is the timeout of requests to roaming partners
+var DefaultTimeout = 5 * time.Second
+
+// Client sends requests to roaming partners
+type Client struct {
+	netID         types.NetID
+	client        *http.Client
+	transactionID uint32
+}
+
+// NewClient returns a Client that sends requests on behalf of the network with the given NetID
+func NewClient(netID types.NetID) *Client {
+	return &Client{
+		netID:  netID,
+		client: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// PRStart sends a PRStartReq to the partner
+func (c *Client) PRStart(partner *Partner, req *PRStartReq) (*PRStartAns, error) {
+	ans := new(PRStartAns)
+	if err := c.do(partner, PRStartReqType, req, ans, &ans.Result); err != nil {
+		return ans, err
+	}
+	return ans, nil
+}
+
+// XmitData sends an XmitDataReq to the partner
+func (c *Client) XmitData(partner *Partner, req *XmitDataReq) (*XmitDataAns, error) {
+	ans := new(XmitDataAns)
+	if err := c.do(partner, XmitDataReqType, req, ans, &ans.Result); err != nil {
+		return ans, err
+	}
+	return ans, nil
+}
+
+func (c *Client) do(partner *Partner, messageType string, req message, ans message, result *Result) error {
+	header := req.header()
+	header.ProtocolVersion = ProtocolVersion
+	header.SenderID = netIDString(c.netID)
+	header.ReceiverID = netIDString(partner.NetID)
+	header.TransactionID = atomic.AddUint32(&c.transactionID, 1)
+	header.MessageType = messageType
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Post(partner.URL.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Could not send %s to roaming partner %s", messageType, partner.NetID))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewErrInternal(fmt.Sprintf("Roaming partner %s returned %s", partner.NetID, resp.Status))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(ans); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Invalid answer from roaming partner %s", partner.NetID))
+	}
+	if ans.header().TransactionID != header.TransactionID {
+		return errors.NewErrInternal(fmt.Sprintf("Roaming partner %s answered a different transaction", partner.NetID))
+	}
+	if result.ResultCode != ResultSuccess {
+		return &ResultError{*result}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+// Package roaming implements the passive roaming messages of the LoRaWAN Backend Interfaces 1.0 specification
+package roaming
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// ProtocolVersion is the version of the LoRaWAN Backend Interfaces that is implemented
+const ProtocolVersion = "1.0"
+
+// Message types
+const (
+	PRStartReqType  = "PRStartReq"
+	PRStartAnsType  = "PRStartAns"
+	XmitDataReqType = "XmitDataReq"
+	XmitDataAnsType = "XmitDataAns"
+)
+
+// Result codes
+const (
+	ResultSuccess          = "Success"
+	ResultMICFailed        = "MICFailed"
+	ResultUnknownDevAddr   = "UnknownDevAddr"
+	ResultUnknownSender    = "UnknownSender"
+	ResultUnknownReceiver  = "UnknownReceiver"
+	ResultMalformedRequest = "MalformedRequest"
+	ResultXmitFailed       = "XmitFailed"
+	ResultOther            = "Other"
+)
+
+// HEXBytes is a byte slice that is encoded as a hex string
+type HEXBytes []byte
+
+// MarshalText implements the TextMarshaler interface.
+func (b HEXBytes) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(hex.EncodeToString(b))), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface.
+func (b *HEXBytes) UnmarshalText(data []byte) error {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// netIDString formats a NetID as it is used in the SenderID and ReceiverID of messages
+func netIDString(netID types.NetID) string {
+	return strings.ToUpper(hex.EncodeToString(netID[:]))
+}
+
+// Header is the header of all messages
+type Header struct {
+	ProtocolVersion string
+	SenderID        string
+	ReceiverID      string
+	TransactionID   uint32
+	MessageType     string
+	SenderToken     HEXBytes `json:",omitempty"`
+	ReceiverToken   HEXBytes `json:",omitempty"`
+}
+
+// Result is the result of a request
+type Result struct {
+	ResultCode  string
+	Description string `json:",omitempty"`
+}
+
+// GWInfo contains the metadata of a gateway
+type GWInfo struct {
+	ID        HEXBytes `json:",omitempty"`
+	RFRegion  string   `json:",omitempty"`
+	ULToken   HEXBytes `json:",omitempty"`
+	RSSI      *int     `json:",omitempty"`
+	SNR       *float64 `json:",omitempty"`
+	Lat       *float64 `json:",omitempty"`
+	Lon       *float64 `json:",omitempty"`
+	DLAllowed bool     `json:",omitempty"`
+}
+
+// ULMetaData contains the metadata of an uplink message
+type ULMetaData struct {
+	DevAddr    *types.DevAddr `json:",omitempty"`
+	DataRate   *int           `json:",omitempty"`
+	ULFreq     *float64       `json:",omitempty"` // MHz
+	RecvTime   string         `json:",omitempty"` // ISO 8601
+	RFRegion   string         `json:",omitempty"`
+	FNSULToken HEXBytes       `json:",omitempty"`
+	GWCnt      int            `json:",omitempty"`
+	GWInfo     []GWInfo       `json:",omitempty"`
+}
+
+// DLMetaData contains the parameters for sending a downlink message
+type DLMetaData struct {
+	DevEUI         *types.DevEUI `json:",omitempty"`
+	DLFreq1        *float64      `json:",omitempty"` // MHz
+	DLFreq2        *float64      `json:",omitempty"` // MHz
+	RXDelay1       *int          `json:",omitempty"` // seconds
+	ClassMode      string        `json:",omitempty"`
+	DataRate1      *int          `json:",omitempty"`
+	DataRate2      *int          `json:",omitempty"`
+	FNSULToken     HEXBytes      `json:",omitempty"`
+	GWInfo         []GWInfo      `json:",omitempty"`
+	HiPriorityFlag bool          `json:",omitempty"`
+}
+
+// PRStartReq is sent by the forwarding network to the serving network of a device to start passive roaming
+type PRStartReq struct {
+	Header
+	PHYPayload HEXBytes
+	ULMetaData ULMetaData
+}
+
+// PRStartAns is the answer to a PRStartReq
+type PRStartAns struct {
+	Header
+	Result   Result
+	DevEUI   *types.DevEUI `json:",omitempty"`
+	Lifetime *int          `json:",omitempty"` // seconds
+}
+
+// XmitDataReq is used to send uplink messages to the serving network during a roaming session, and to send downlink
+// messages to the forwarding network
+type XmitDataReq struct {
+	Header
+	PHYPayload HEXBytes
+	ULMetaData *ULMetaData `json:",omitempty"`
+	DLMetaData *DLMetaData `json:",omitempty"`
+}
+
+// XmitDataAns is the answer to an XmitDataReq
+type XmitDataAns struct {
+	Header
+	Result Result
+}
+
+// message is implemented by all messages
+type message interface {
+	header() *Header
+}
+
+func (h *Header) header() *Header { return h }
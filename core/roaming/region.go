@@ -0,0 +1,34 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package roaming
+
+import (
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+)
+
+var rfRegions = map[pb_lorawan.Region]string{
+	pb_lorawan.Region_EU_863_870: "EU868",
+	pb_lorawan.Region_US_902_928: "US902",
+	pb_lorawan.Region_CN_779_787: "China779",
+	pb_lorawan.Region_EU_433:     "EU433",
+	pb_lorawan.Region_AU_915_928: "Australia915",
+	pb_lorawan.Region_CN_470_510: "China470",
+	pb_lorawan.Region_AS_923:     "AS923",
+	pb_lorawan.Region_KR_920_923: "SouthKorea920",
+}
+
+// RFRegion returns the name of the region as it is used in the Backend Interfaces
+func RFRegion(region pb_lorawan.Region) string {
+	return rfRegions[region]
+}
+
+// Region returns the region for a name that is used in the Backend Interfaces
+func Region(rfRegion string) (pb_lorawan.Region, bool) {
+	for region, name := range rfRegions {
+		if name == rfRegion {
+			return region, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,140 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package roaming
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// maxRequestSize is the maximum size of the requests of roaming partners
+const maxRequestSize = 1 << 20
+
+// Handler handles the requests of roaming partners
+type Handler interface {
+	// HandleUplink handles an uplink message of a device of this network that was received by the gateways of the
+	// partner
+	HandleUplink(partner *Partner, phyPayload []byte, ulMetaData *ULMetaData) Result
+	// HandleDownlink handles a downlink message that the partner wants to send through the gateways of this network
+	HandleDownlink(partner *Partner, phyPayload []byte, dlMetaData *DLMetaData) Result
+}
+
+type server struct {
+	netID    types.NetID
+	partners map[types.NetID]*Partner
+	handler  Handler
+}
+
+// NewServer returns an http.Handler that accepts the passive roaming requests of the partners. The answers are sent
+// synchronously in the HTTP response.
+func NewServer(netID types.NetID, partners []*Partner, handler Handler) http.Handler {
+	s := &server{
+		netID:    netID,
+		partners: make(map[types.NetID]*Partner),
+		handler:  handler,
+	}
+	for _, partner := range partners {
+		s.partners[partner.NetID] = partner
+	}
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var header Header
+	if err := json.Unmarshal(body, &header); err != nil {
+		http.Error(w, "Invalid message", http.StatusBadRequest)
+		return
+	}
+
+	ansHeader := Header{
+		ProtocolVersion: ProtocolVersion,
+		SenderID:        netIDString(s.netID),
+		ReceiverID:      header.SenderID,
+		TransactionID:   header.TransactionID,
+		ReceiverToken:   header.SenderToken,
+	}
+	switch header.MessageType {
+	case PRStartReqType:
+		ansHeader.MessageType = PRStartAnsType
+	case XmitDataReqType:
+		ansHeader.MessageType = XmitDataAnsType
+	default:
+		http.Error(w, "Unsupported MessageType", http.StatusBadRequest)
+		return
+	}
+
+	var partner *Partner
+	result := Result{ResultCode: ResultSuccess}
+	if senderID, err := types.ParseNetID(header.SenderID); err == nil {
+		partner = s.partners[senderID]
+	}
+	receiverID, err := types.ParseNetID(header.ReceiverID)
+	switch {
+	case partner == nil:
+		result = Result{ResultCode: ResultUnknownSender, Description: header.SenderID}
+	case !partner.authorized(r):
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	case err != nil || receiverID != s.netID:
+		result = Result{ResultCode: ResultUnknownReceiver, Description: header.ReceiverID}
+	}
+
+	var ans interface{}
+	switch header.MessageType {
+	case PRStartReqType:
+		var req PRStartReq
+		if result.ResultCode == ResultSuccess {
+			result = s.handlePRStart(partner, body, &req)
+		}
+		prStartAns := &PRStartAns{Header: ansHeader, Result: result}
+		if result.ResultCode == ResultSuccess {
+			// We don't share session keys, so the forwarding network has to send a PRStartReq for every uplink
+			var lifetime int
+			prStartAns.Lifetime = &lifetime
+		}
+		ans = prStartAns
+	case XmitDataReqType:
+		var req XmitDataReq
+		if result.ResultCode == ResultSuccess {
+			result = s.handleXmitData(partner, body, &req)
+		}
+		ans = &XmitDataAns{Header: ansHeader, Result: result}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ans)
+}
+
+func (s *server) handlePRStart(partner *Partner, body []byte, req *PRStartReq) Result {
+	if err := json.Unmarshal(body, req); err != nil {
+		return Result{ResultCode: ResultMalformedRequest, Description: err.Error()}
+	}
+	return s.handler.HandleUplink(partner, req.PHYPayload, &req.ULMetaData)
+}
+
+func (s *server) handleXmitData(partner *Partner, body []byte, req *XmitDataReq) Result {
+	if err := json.Unmarshal(body, req); err != nil {
+		return Result{ResultCode: ResultMalformedRequest, Description: err.Error()}
+	}
+	switch {
+	case req.ULMetaData != nil:
+		return s.handler.HandleUplink(partner, req.PHYPayload, req.ULMetaData)
+	case req.DLMetaData != nil:
+		return s.handler.HandleDownlink(partner, req.PHYPayload, req.DLMetaData)
+	}
+	return Result{ResultCode: ResultMalformedRequest, Description: "no ULMetaData or DLMetaData"}
+}
@@ -18,20 +18,31 @@ import (
 	"github.com/TheThingsNetwork/ttn/utils/errors"
 )
 
+type activationResponseWithBroker struct {
+	client   pb_broker.BrokerClient
+	response *pb_broker.DeviceActivationResponse
+}
+
 func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivationRequest) (res *pb.DeviceActivationResponse, err error) {
 	ctx := r.Ctx.WithField("GatewayID", gatewayID).WithFields(fields.Get(activation))
 	start := time.Now()
 	defer func() {
 		if err != nil {
-			activation.Trace = activation.Trace.WithEvent(trace.DropEvent, "reason", err)
+			activation.Trace = activation.Trace.WithEvent(r.TraceService(), trace.DropEvent, "reason", err)
 			ctx.WithError(err).Warn("Could not handle activation")
 		} else {
 			ctx.WithField("Duration", time.Now().Sub(start)).Info("Handled activation")
 		}
+		trace.Export(r.TraceService(), activation.Trace)
 	}()
 	r.status.activations.Mark(1)
 
-	activation.Trace = activation.Trace.WithEvent(trace.ReceiveEvent, "gateway", gatewayID)
+	if !r.inFlight.Add() {
+		return nil, errors.NewErrInternal("Router is shutting down")
+	}
+	defer r.inFlight.Done()
+
+	activation.Trace = activation.Trace.WithEvent(r.TraceService(), trace.ReceiveEvent, "gateway", gatewayID)
 
 	gateway := r.getGateway(gatewayID)
 	gateway.LastSeen = time.Now()
@@ -52,7 +63,7 @@ func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivat
 	}
 
 	downlinkOptions := r.buildDownlinkOptions(uplink, true, gateway)
-	activation.Trace = uplink.Trace.WithEvent(trace.BuildDownlinkEvent,
+	activation.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.BuildDownlinkEvent,
 		"options", len(downlinkOptions),
 	)
 
@@ -107,13 +118,14 @@ func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivat
 	}
 
 	ctx = ctx.WithField("NumBrokers", len(brokers))
-	request.Trace = request.Trace.WithEvent(trace.ForwardEvent,
+	request.Trace = request.Trace.WithEvent(r.TraceService(), trace.ForwardEvent,
 		"brokers", len(brokers),
 	)
+	trace.Export(r.TraceService(), request.Trace)
 
 	// Forward to all brokers and collect responses
 	var wg sync.WaitGroup
-	responses := make(chan *pb_broker.DeviceActivationResponse, len(brokers))
+	responses := make(chan *activationResponseWithBroker, len(brokers))
 	for _, broker := range brokers {
 		broker, err := r.getBroker(broker)
 		if err != nil {
@@ -125,7 +137,10 @@ func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivat
 		go func() {
 			res, err := broker.client.Activate(r.Component.GetContext(""), request)
 			if err == nil && res != nil {
-				responses <- res
+				responses <- &activationResponseWithBroker{
+					client:   broker.client,
+					response: res,
+				}
 			}
 			wg.Done()
 		}()
@@ -144,15 +159,18 @@ func (r *router) HandleActivation(gatewayID string, activation *pb.DeviceActivat
 		} else {
 			gotFirst = true
 			downlink := &pb_broker.DownlinkMessage{
-				Payload:        res.Payload,
-				Message:        res.Message,
-				DownlinkOption: res.DownlinkOption,
-				Trace:          res.Trace,
+				Payload:        res.response.Payload,
+				Message:        res.response.Message,
+				DownlinkOption: res.response.DownlinkOption,
+				Trace:          res.response.Trace,
 			}
 			err := r.HandleDownlink(downlink)
 			if err != nil {
 				ctx.Warn("Could not send downlink for Activation")
-				gotFirst = false // try again
+				// The Broker fails over to the next-best DownlinkOption of the Activation
+				if err := r.reportDownlinkFailure(res.client, downlink, err); err != nil {
+					gotFirst = false // try again
+				}
 			}
 		}
 	}
@@ -0,0 +1,79 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/amqp"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+func (r *router) assertAMQPExchange() error {
+	ch, err := r.amqpClient.(*amqp.DefaultClient).GetChannel()
+	if err != nil {
+		return err
+	}
+	err = ch.ExchangeDeclarePassive(r.amqpExchange, "topic", true, false, false, false, nil)
+	if err != nil {
+		r.Ctx.Warnf("Could not assert presence of AMQP Exchange %s, trying to create...", r.amqpExchange)
+		ch, err := r.amqpClient.(*amqp.DefaultClient).GetChannel()
+		if err != nil {
+			return err
+		}
+		err = ch.ExchangeDeclare(r.amqpExchange, "topic", true, false, false, false, nil)
+		if err != nil {
+			r.Ctx.Errorf("Could not create AMQP Exchange %s.", r.amqpExchange)
+			return err
+		}
+		r.Ctx.Infof("Created AMQP Exchange %s", r.amqpExchange)
+	}
+	return nil
+}
+
+// HandleAMQP connects to the AMQP broker and publishes the events of gateways on gateways.<id>.events.<type>
+func (r *router) HandleAMQP(username, password, host, exchange string) error {
+	r.amqpClient = amqp.NewClient(r.Ctx, username, password, host)
+	r.amqpExchange = exchange
+
+	err := r.amqpClient.Connect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			r.amqpClient.Disconnect()
+		}
+	}()
+
+	if err = r.assertAMQPExchange(); err != nil {
+		return err
+	}
+
+	publisher := r.amqpClient.NewPublisher(r.amqpExchange)
+	if err = publisher.Open(); err != nil {
+		return err
+	}
+
+	r.amqpEvent = make(chan *pb_gateway.Event, EventBufferSize)
+
+	ctx := r.Ctx.WithField("Protocol", "AMQP")
+
+	go func() {
+		defer publisher.Close()
+		for event := range r.amqpEvent {
+			ctx := ctx.WithFields(ttnlog.Fields{
+				"GatewayID": event.GatewayId,
+				"Event":     event.Type,
+			})
+			ctx.Debug("Publish Event")
+			err := publisher.PublishGatewayEvent(event.GatewayId, types.EventType(event.Type), gatewayEventData(event))
+			if err != nil {
+				ctx.WithError(err).Warn("Could not publish Event")
+			}
+		}
+	}()
+
+	return nil
+}
@@ -54,7 +54,7 @@ func (r *router) UnsubscribeDownlink(gatewayID string, subscriptionID string) er
 func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) error {
 	r.status.downlink.Mark(1)
 
-	downlink.Trace = downlink.Trace.WithEvent(trace.ReceiveEvent)
+	downlink.Trace = downlink.Trace.WithEvent(r.TraceService(), trace.ReceiveEvent)
 
 	option := downlink.DownlinkOption
 
@@ -73,6 +73,22 @@ func (r *router) HandleDownlink(downlink *pb_broker.DownlinkMessage) error {
 	return r.getGateway(downlink.DownlinkOption.GatewayId).HandleDownlink(identifier, downlinkMessage)
 }
 
+// reportDownlinkFailure tells the Broker that the downlink could not be scheduled,
+// so that it can try the next-best DownlinkOption. It returns an error if the
+// Broker did not fail over the downlink.
+func (r *router) reportDownlinkFailure(client pb_broker.BrokerClient, downlink *pb_broker.DownlinkMessage, err error) error {
+	ctx := r.Ctx.WithFields(fields.Get(downlink)).WithError(err)
+	ctx.Warn("Could not handle downlink")
+	downlink.Trace = downlink.Trace.WithEvent(r.TraceService(), trace.DropEvent, "reason", err)
+	trace.Export(r.TraceService(), downlink.Trace)
+	if _, err := client.DownlinkFailed(r.GetContext(""), downlink); err != nil {
+		err = errors.FromGRPCError(err)
+		ctx.WithField("FailoverError", err).Debug("Broker did not fail over downlink")
+		return err
+	}
+	return nil
+}
+
 // buildDownlinkOption builds a DownlinkOption with default values
 func (r *router) buildDownlinkOption(gatewayID string, band band.FrequencyPlan) *pb_broker.DownlinkOption {
 	dataRate, _ := types.ConvertDataRate(band.DataRates[band.RX2DataRate])
@@ -178,6 +194,9 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 	computeDownlinkScores(gateway, uplink, options)
 
 	for _, option := range options {
+		// Add the deadline for sending the downlink to the gateway
+		option.Deadline = downlinkDeadline(uplink, option)
+
 		// Add router ID to downlink option
 		if r.Component != nil && r.Component.Identity != nil {
 			option.Identifier = fmt.Sprintf("%s:%s", r.Component.Identity.Id, option.Identifier)
@@ -192,6 +211,13 @@ func (r *router) buildDownlinkOptions(uplink *pb.UplinkMessage, isActivation boo
 	return
 }
 
+// downlinkDeadline returns the time (as Unix nanoseconds) before which the
+// downlink for this option should reach the Router
+func downlinkDeadline(uplink *pb.UplinkMessage, option *pb_broker.DownlinkOption) int64 {
+	delay := time.Duration(option.GatewayConfig.Timestamp-uplink.GatewayMetadata.Timestamp) * time.Microsecond
+	return time.Now().Add(delay).Add(-1 * gateway.GetDeadline()).UnixNano()
+}
+
 // Calculating the score for each downlink option; lower is better, 0 is best
 // If a score is over 1000, it may should not be used as feasible option.
 // TODO: The weights of these parameters should be optimized. I'm sure someone
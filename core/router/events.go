@@ -0,0 +1,81 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/viper"
+)
+
+// EventBufferSize indicates the size of the buffers for gateway events that are published to MQTT and AMQP
+var EventBufferSize = 100
+
+// handleGatewayEvent publishes a gateway event to MQTT and AMQP
+func (r *router) handleGatewayEvent(event *pb_gateway.Event) {
+	for _, ch := range []chan *pb_gateway.Event{r.mqttEvent, r.amqpEvent} {
+		if ch == nil {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+			r.Ctx.WithField("GatewayID", event.GatewayId).WithField("Event", event.Type).Warn("Not publishing gateway event, buffer full")
+		}
+	}
+}
+
+// gatewayEventData converts a gateway event to the data that is published to MQTT and AMQP
+func gatewayEventData(event *pb_gateway.Event) types.GatewayEventData {
+	data := types.GatewayEventData{
+		GatewayID:  event.GatewayId,
+		Time:       types.BuildTime(event.Time),
+		Stream:     event.Stream,
+		ClockDrift: event.ClockDrift,
+	}
+	if event.LastStatus != 0 {
+		lastStatus := types.BuildTime(event.LastStatus)
+		data.LastStatus = &lastStatus
+	}
+	return data
+}
+
+// checkGatewayStatus emits status-stale events for the gateways that did not send a status message in time
+func (r *router) checkGatewayStatus() {
+	r.gatewaysLock.RLock()
+	defer r.gatewaysLock.RUnlock()
+	for _, gtw := range r.gateways {
+		gtw.CheckStatus()
+	}
+}
+
+// reloadGatewayEvents validates the configured status timeout and clock drift threshold, and returns a function that
+// applies them
+func reloadGatewayEvents() (func(), error) {
+	var timeout, threshold time.Duration
+	if viper.IsSet("router.status-timeout") {
+		timeout = viper.GetDuration("router.status-timeout")
+		if timeout <= 0 {
+			return nil, errors.NewErrInvalidArgument("Status timeout", "must be positive")
+		}
+	}
+	if viper.IsSet("router.clock-drift-threshold") {
+		threshold = viper.GetDuration("router.clock-drift-threshold")
+		if threshold <= 0 {
+			return nil, errors.NewErrInvalidArgument("Clock drift threshold", "must be positive")
+		}
+	}
+	return func() {
+		if timeout != 0 {
+			gateway.SetStatusTimeout(timeout)
+		}
+		if threshold != 0 {
+			gateway.SetClockDriftThreshold(threshold)
+		}
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestHandleGatewayEvent(t *testing.T) {
+	a := New(t)
+	r := getTestRouter(t)
+	r.mqttEvent = make(chan *pb_gateway.Event, 1)
+
+	gtw := r.getGateway("eui-0102030405060708")
+	gtw.Connect(gateway.StatusStream)
+
+	select {
+	case event := <-r.mqttEvent:
+		a.So(event.GatewayId, ShouldEqual, "eui-0102030405060708")
+		a.So(event.Type, ShouldEqual, string(types.GatewayConnectEvent))
+		a.So(event.Stream, ShouldEqual, gateway.StatusStream)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Did not receive gateway event")
+	}
+
+	// Events are dropped when the buffer is full
+	gtw.Disconnect(gateway.StatusStream)
+	gtw.Connect(gateway.StatusStream)
+	a.So(r.mqttEvent, ShouldHaveLength, 1)
+}
+
+func TestGatewayEventData(t *testing.T) {
+	a := New(t)
+
+	now := time.Now()
+	data := gatewayEventData(&pb_gateway.Event{
+		GatewayId: "eui-0102030405060708",
+		Time:      now.UnixNano(),
+		Type:      string(types.GatewayDisconnectEvent),
+		Stream:    gateway.UplinkStream,
+	})
+	a.So(data.GatewayID, ShouldEqual, "eui-0102030405060708")
+	a.So(time.Time(data.Time).Equal(now), ShouldBeTrue)
+	a.So(data.Stream, ShouldEqual, gateway.UplinkStream)
+	a.So(data.LastStatus, ShouldBeNil)
+
+	data = gatewayEventData(&pb_gateway.Event{
+		GatewayId:  "eui-0102030405060708",
+		Type:       string(types.GatewayStatusStaleEvent),
+		LastStatus: now.UnixNano(),
+	})
+	a.So(data.LastStatus, ShouldNotBeNil)
+	a.So(time.Time(*data.LastStatus).Equal(now), ShouldBeTrue)
+}
+
+func TestCheckGatewayStatus(t *testing.T) {
+	a := New(t)
+	r := getTestRouter(t)
+	r.mqttEvent = make(chan *pb_gateway.Event, 10)
+
+	defer gateway.SetStatusTimeout(gateway.GetStatusTimeout())
+	gateway.SetStatusTimeout(10 * time.Millisecond)
+
+	a.So(r.getGateway("eui-0102030405060708").HandleStatus(&pb_gateway.Status{}), ShouldBeNil)
+	r.checkGatewayStatus()
+	a.So(r.mqttEvent, ShouldBeEmpty)
+
+	time.Sleep(20 * time.Millisecond)
+	r.checkGatewayStatus()
+	a.So(r.mqttEvent, ShouldHaveLength, 1)
+	event := <-r.mqttEvent
+	a.So(event.Type, ShouldEqual, string(types.GatewayStatusStaleEvent))
+}
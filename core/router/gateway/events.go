@@ -0,0 +1,117 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"sync/atomic"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// StatusTimeout is the time after the last status message of a gateway after which its status is considered stale.
+// Use SetStatusTimeout to change it while the router is running.
+var StatusTimeout = 5 * time.Minute
+
+// SetStatusTimeout changes the StatusTimeout
+func SetStatusTimeout(timeout time.Duration) {
+	atomic.StoreInt64((*int64)(&StatusTimeout), int64(timeout))
+}
+
+// GetStatusTimeout returns the StatusTimeout
+func GetStatusTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&StatusTimeout)))
+}
+
+// ClockDriftThreshold is the drift of the gateway clock between two uplink messages above which a clock-drift event
+// is emitted. Use SetClockDriftThreshold to change it while the router is running.
+var ClockDriftThreshold = 500 * time.Millisecond
+
+// SetClockDriftThreshold changes the ClockDriftThreshold
+func SetClockDriftThreshold(threshold time.Duration) {
+	atomic.StoreInt64((*int64)(&ClockDriftThreshold), int64(threshold))
+}
+
+// GetClockDriftThreshold returns the ClockDriftThreshold
+func GetClockDriftThreshold() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&ClockDriftThreshold)))
+}
+
+// EventHandler is called for the events of a gateway
+type EventHandler func(event *pb.Event)
+
+// Connect adds a connect of a gateway stream to the history and emits a connect event
+func (g *Gateway) Connect(stream string) {
+	g.addConnection(stream, true)
+	g.emitEvent(&pb.Event{Type: string(types.GatewayConnectEvent), Stream: stream})
+}
+
+// Disconnect adds a disconnect of a gateway stream to the history and emits a disconnect event
+func (g *Gateway) Disconnect(stream string) {
+	g.addConnection(stream, false)
+	g.emitEvent(&pb.Event{Type: string(types.GatewayDisconnectEvent), Stream: stream})
+}
+
+// CheckStatus emits a status-stale event if the gateway did not send a status message within the StatusTimeout. The
+// event is emitted once, until the gateway sends a new status message.
+func (g *Gateway) CheckStatus() {
+	g.eventsMu.Lock()
+	if g.lastStatus.IsZero() || g.statusStale || time.Since(g.lastStatus) < GetStatusTimeout() {
+		g.eventsMu.Unlock()
+		return
+	}
+	g.statusStale = true
+	lastStatus := g.lastStatus
+	g.eventsMu.Unlock()
+
+	g.emitEvent(&pb.Event{Type: string(types.GatewayStatusStaleEvent), LastStatus: lastStatus.UnixNano()})
+}
+
+func (g *Gateway) statusReceived() {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+	g.lastStatus = time.Now()
+	g.statusStale = false
+}
+
+// checkClockDrift emits a clock-drift event if the drift is beyond the ClockDriftThreshold. The event is emitted once,
+// until the drift is back within half of the threshold.
+func (g *Gateway) checkClockDrift(drift time.Duration) {
+	abs := drift
+	if abs < 0 {
+		abs = -abs
+	}
+	threshold := GetClockDriftThreshold()
+	g.eventsMu.Lock()
+	if abs <= threshold/2 {
+		g.clockDrifting = false
+	}
+	if g.clockDrifting || abs <= threshold {
+		g.eventsMu.Unlock()
+		return
+	}
+	g.clockDrifting = true
+	g.eventsMu.Unlock()
+
+	g.emitEvent(&pb.Event{Type: string(types.GatewayClockDriftEvent), ClockDrift: int64(drift)})
+}
+
+func (g *Gateway) emitEvent(event *pb.Event) {
+	event.GatewayId = g.ID
+	if event.Time == 0 {
+		event.Time = time.Now().UnixNano()
+	}
+	g.Ctx.WithField("Event", event.Type).Debug("Emit gateway event")
+	if g.EventHandler != nil {
+		g.EventHandler(event)
+	}
+	if g.Monitors == nil {
+		return
+	}
+	for _, monitor := range g.Monitors.GatewayClients(g.ID) {
+		clone := *event // Avoid race conditions
+		go monitor.SendEvent(&clone)
+	}
+}
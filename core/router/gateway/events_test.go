@@ -0,0 +1,89 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestGatewayEvents(t *testing.T) {
+	a := New(t)
+
+	gtw := NewGateway(GetLogger(t, "TestGatewayEvents"), "test")
+	var events []*pb.Event
+	gtw.EventHandler = func(event *pb.Event) {
+		events = append(events, event)
+	}
+
+	gtw.Connect(UplinkStream)
+	gtw.Disconnect(UplinkStream)
+	a.So(events, ShouldHaveLength, 2)
+	a.So(events[0].GatewayId, ShouldEqual, "test")
+	a.So(events[0].Type, ShouldEqual, string(types.GatewayConnectEvent))
+	a.So(events[0].Stream, ShouldEqual, UplinkStream)
+	a.So(events[0].Time, ShouldNotEqual, 0)
+	a.So(events[1].Type, ShouldEqual, string(types.GatewayDisconnectEvent))
+
+	events = nil
+	gtw.checkClockDrift(10 * time.Millisecond)
+	gtw.checkClockDrift(-10 * time.Millisecond)
+	a.So(events, ShouldBeEmpty)
+	gtw.checkClockDrift(-1 * time.Second)
+	a.So(events, ShouldHaveLength, 1)
+	a.So(events[0].Type, ShouldEqual, string(types.GatewayClockDriftEvent))
+	a.So(events[0].ClockDrift, ShouldEqual, int64(-1*time.Second))
+
+	// Suppressed until the drift is back within half of the threshold
+	gtw.checkClockDrift(-1 * time.Second)
+	gtw.checkClockDrift(400 * time.Millisecond)
+	gtw.checkClockDrift(1 * time.Second)
+	a.So(events, ShouldHaveLength, 1)
+	gtw.checkClockDrift(100 * time.Millisecond)
+	gtw.checkClockDrift(1 * time.Second)
+	a.So(events, ShouldHaveLength, 2)
+	a.So(events[1].ClockDrift, ShouldEqual, int64(time.Second))
+}
+
+func TestGatewayCheckStatus(t *testing.T) {
+	a := New(t)
+
+	defer SetStatusTimeout(GetStatusTimeout())
+	SetStatusTimeout(10 * time.Millisecond)
+
+	gtw := NewGateway(GetLogger(t, "TestGatewayCheckStatus"), "test")
+	var events []*pb.Event
+	gtw.EventHandler = func(event *pb.Event) {
+		events = append(events, event)
+	}
+
+	// No status received yet
+	gtw.CheckStatus()
+	a.So(events, ShouldBeEmpty)
+
+	a.So(gtw.HandleStatus(&pb.Status{}), ShouldBeNil)
+	gtw.CheckStatus()
+	a.So(events, ShouldBeEmpty)
+
+	time.Sleep(20 * time.Millisecond)
+	gtw.CheckStatus()
+	a.So(events, ShouldHaveLength, 1)
+	a.So(events[0].Type, ShouldEqual, string(types.GatewayStatusStaleEvent))
+	a.So(events[0].LastStatus, ShouldNotEqual, 0)
+
+	// The event is only emitted once
+	gtw.CheckStatus()
+	a.So(events, ShouldHaveLength, 1)
+
+	// Until a new status is received
+	a.So(gtw.HandleStatus(&pb.Status{}), ShouldBeNil)
+	time.Sleep(20 * time.Millisecond)
+	gtw.CheckStatus()
+	a.So(events, ShouldHaveLength, 2)
+}
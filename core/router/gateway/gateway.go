@@ -13,6 +13,7 @@ import (
 	pb_monitor "github.com/TheThingsNetwork/ttn/api/monitor"
 	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
 	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/api/trace"
 )
 
 // NewGateway creates a new in-memory Gateway structure
@@ -44,6 +45,22 @@ type Gateway struct {
 
 	Monitors pb_monitor.Registry
 
+	History      HistoryStore
+	historyMu    sync.Mutex // Protect traffic and lastStatusAt
+	traffic      map[int64]*Traffic
+	lastStatusAt time.Time
+
+	EventHandler  EventHandler
+	eventsMu      sync.Mutex // Protect lastStatus, statusStale and clockDrifting
+	lastStatus    time.Time
+	statusStale   bool
+	clockDrifting bool
+
+	TrafficHandler TrafficHandler
+
+	// TraceService is the identity of the Router in the traces of downlink messages
+	TraceService trace.Service
+
 	Ctx ttnlog.Interface
 }
 
@@ -70,6 +87,8 @@ func (g *Gateway) HandleStatus(status *pb.Status) (err error) {
 		return err
 	}
 	g.updateLastSeen()
+	g.statusReceived()
+	g.addStatusHistory(status)
 
 	clone := *status // Avoid race conditions
 	for _, monitor := range g.Monitors.GatewayClients(g.ID) {
@@ -83,8 +102,9 @@ func (g *Gateway) HandleUplink(uplink *pb_router.UplinkMessage) (err error) {
 	if err = g.Utilization.AddRx(uplink); err != nil {
 		return err
 	}
-	g.Schedule.Sync(uplink.GatewayMetadata.Timestamp)
+	g.checkClockDrift(g.Schedule.Sync(uplink.GatewayMetadata.Timestamp))
 	g.updateLastSeen()
+	g.addUplinkHistory(uplink)
 
 	status, err := g.Status.Get()
 	if err == nil {
@@ -110,6 +130,7 @@ func (g *Gateway) HandleUplink(uplink *pb_router.UplinkMessage) (err error) {
 	for _, monitor := range g.Monitors.GatewayClients(g.ID) {
 		go monitor.SendUplink(&clone)
 	}
+	g.handleTraffic(&pb_router.TrafficMessage{Uplink: &clone})
 	return nil
 }
 
@@ -120,10 +141,24 @@ func (g *Gateway) HandleDownlink(identifier string, downlink *pb_router.Downlink
 		return err
 	}
 	ctx.Debug("Scheduled downlink")
+	g.addDownlinkHistory()
 
 	clone := *downlink // Avoid race conditions
 	for _, monitor := range g.Monitors.GatewayClients(g.ID) {
 		go monitor.SendDownlink(&clone)
 	}
+	g.handleTraffic(&pb_router.TrafficMessage{Downlink: &clone})
 	return nil
 }
+
+// TrafficHandler is called for the uplink and downlink messages of a gateway
+type TrafficHandler func(message *pb_router.TrafficMessage)
+
+func (g *Gateway) handleTraffic(message *pb_router.TrafficMessage) {
+	if g.TrafficHandler == nil {
+		return
+	}
+	message.Time = time.Now().UnixNano()
+	message.GatewayId = g.ID
+	g.TrafficHandler(message)
+}
@@ -0,0 +1,493 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"gopkg.in/redis.v5"
+)
+
+// HistoryRetention is the time that the history of a gateway is kept
+var HistoryRetention = 7 * 24 * time.Hour
+
+// HistoryLength is the maximum number of connections and statuses that is kept per gateway
+var HistoryLength = 2500
+
+// StatusHistoryInterval is the minimum time between two statuses in the history of a gateway
+var StatusHistoryInterval = 5 * time.Minute
+
+// Traffic is the traffic of a gateway in one hour
+type Traffic struct {
+	Hour      time.Time
+	Uplink    uint64
+	Downlink  uint64
+	Channels  map[uint64]uint64 // Uplink messages per frequency
+	DataRates map[string]uint64 // Uplink messages per data rate
+}
+
+func newTraffic(hour time.Time) *Traffic {
+	return &Traffic{
+		Hour:      hour,
+		Channels:  make(map[uint64]uint64),
+		DataRates: make(map[string]uint64),
+	}
+}
+
+func (t *Traffic) add(other *Traffic) {
+	t.Uplink += other.Uplink
+	t.Downlink += other.Downlink
+	for frequency, count := range other.Channels {
+		t.Channels[frequency] += count
+	}
+	for dataRate, count := range other.DataRates {
+		t.DataRates[dataRate] += count
+	}
+}
+
+type trafficByHour []*Traffic
+
+func (t trafficByHour) Len() int           { return len(t) }
+func (t trafficByHour) Less(i, j int) bool { return t[i].Hour.Before(t[j].Hour) }
+func (t trafficByHour) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
+// Connection is a connect or disconnect of a gateway stream
+type Connection struct {
+	Time      time.Time `json:"time"`
+	Stream    string    `json:"stream"`
+	Connected bool      `json:"connected"`
+}
+
+// Gateway streams
+const (
+	UplinkStream   = "uplink"
+	DownlinkStream = "downlink"
+	StatusStream   = "status"
+)
+
+// HistoryStore keeps the traffic, connection and status history of gateways
+type HistoryStore interface {
+	// AddTraffic adds traffic to the hour of the traffic
+	AddTraffic(gatewayID string, traffic *Traffic) error
+	// GetTraffic returns the hourly traffic since the given time, oldest first
+	GetTraffic(gatewayID string, since time.Time) ([]*Traffic, error)
+	// AddConnection adds a connect or disconnect
+	AddConnection(gatewayID string, connection *Connection) error
+	// GetConnections returns the connects and disconnects since the given time, oldest first
+	GetConnections(gatewayID string, since time.Time) ([]*Connection, error)
+	// AddStatus adds a status
+	AddStatus(gatewayID string, status *pb.Status) error
+	// GetStatuses returns the statuses since the given time, oldest first
+	GetStatuses(gatewayID string, since time.Time) ([]*pb.Status, error)
+}
+
+// historySince returns the start of the history that is kept
+func historySince(since time.Time) time.Time {
+	if oldest := time.Now().Add(-1 * HistoryRetention); since.Before(oldest) {
+		return oldest
+	}
+	return since
+}
+
+type memoryHistory struct {
+	traffic     map[int64]*Traffic
+	connections []*Connection
+	statuses    []*pb.Status
+}
+
+type memoryHistoryStore struct {
+	sync.Mutex
+	gateways map[string]*memoryHistory
+}
+
+// NewMemoryHistoryStore returns a new HistoryStore that keeps the history in memory
+func NewMemoryHistoryStore() HistoryStore {
+	return &memoryHistoryStore{
+		gateways: make(map[string]*memoryHistory),
+	}
+}
+
+func (s *memoryHistoryStore) get(gatewayID string) *memoryHistory {
+	history, ok := s.gateways[gatewayID]
+	if !ok {
+		history = &memoryHistory{traffic: make(map[int64]*Traffic)}
+		s.gateways[gatewayID] = history
+	}
+	return history
+}
+
+func (s *memoryHistoryStore) AddTraffic(gatewayID string, traffic *Traffic) error {
+	s.Lock()
+	defer s.Unlock()
+	history := s.get(gatewayID)
+	hour := traffic.Hour.Truncate(time.Hour)
+	current, ok := history.traffic[hour.UnixNano()]
+	if !ok {
+		current = newTraffic(hour)
+		history.traffic[hour.UnixNano()] = current
+	}
+	current.add(traffic)
+	oldest := time.Now().Add(-1 * HistoryRetention).Truncate(time.Hour)
+	for hour, traffic := range history.traffic {
+		if traffic.Hour.Before(oldest) {
+			delete(history.traffic, hour)
+		}
+	}
+	return nil
+}
+
+func (s *memoryHistoryStore) GetTraffic(gatewayID string, since time.Time) ([]*Traffic, error) {
+	s.Lock()
+	defer s.Unlock()
+	since = historySince(since).Truncate(time.Hour)
+	var res []*Traffic
+	for _, traffic := range s.get(gatewayID).traffic {
+		if traffic.Hour.Before(since) {
+			continue
+		}
+		clone := newTraffic(traffic.Hour)
+		clone.add(traffic)
+		res = append(res, clone)
+	}
+	sort.Sort(trafficByHour(res))
+	return res, nil
+}
+
+func (s *memoryHistoryStore) AddConnection(gatewayID string, connection *Connection) error {
+	s.Lock()
+	defer s.Unlock()
+	history := s.get(gatewayID)
+	clone := *connection
+	history.connections = append(history.connections, &clone)
+	if len(history.connections) > HistoryLength {
+		history.connections = history.connections[len(history.connections)-HistoryLength:]
+	}
+	return nil
+}
+
+func (s *memoryHistoryStore) GetConnections(gatewayID string, since time.Time) ([]*Connection, error) {
+	s.Lock()
+	defer s.Unlock()
+	since = historySince(since)
+	var res []*Connection
+	for _, connection := range s.get(gatewayID).connections {
+		if connection.Time.After(since) {
+			clone := *connection
+			res = append(res, &clone)
+		}
+	}
+	return res, nil
+}
+
+func (s *memoryHistoryStore) AddStatus(gatewayID string, status *pb.Status) error {
+	s.Lock()
+	defer s.Unlock()
+	history := s.get(gatewayID)
+	clone := *status
+	history.statuses = append(history.statuses, &clone)
+	if len(history.statuses) > HistoryLength {
+		history.statuses = history.statuses[len(history.statuses)-HistoryLength:]
+	}
+	return nil
+}
+
+func (s *memoryHistoryStore) GetStatuses(gatewayID string, since time.Time) ([]*pb.Status, error) {
+	s.Lock()
+	defer s.Unlock()
+	since = historySince(since)
+	var res []*pb.Status
+	for _, status := range s.get(gatewayID).statuses {
+		if time.Unix(0, status.Time).After(since) {
+			clone := *status
+			res = append(res, &clone)
+		}
+	}
+	return res, nil
+}
+
+const (
+	redisUplinkField       = "uplink"
+	redisDownlinkField     = "downlink"
+	redisChannelPrefix     = "channel:"
+	redisDataRatePrefix    = "data_rate:"
+	redisTrafficHourFormat = "2006-01-02T15"
+)
+
+type redisHistoryStore struct {
+	prefix string
+	client *redis.Client
+}
+
+// NewRedisHistoryStore returns a new HistoryStore that keeps the history in Redis
+func NewRedisHistoryStore(client *redis.Client, prefix string) HistoryStore {
+	if !strings.HasSuffix(prefix, ":") {
+		prefix += ":"
+	}
+	return &redisHistoryStore{
+		prefix: prefix + "gateway-history:",
+		client: client,
+	}
+}
+
+func (s *redisHistoryStore) trafficKey(gatewayID string, hour time.Time) string {
+	return s.prefix + gatewayID + ":traffic:" + hour.UTC().Format(redisTrafficHourFormat)
+}
+
+func (s *redisHistoryStore) connectionsKey(gatewayID string) string {
+	return s.prefix + gatewayID + ":connections"
+}
+
+func (s *redisHistoryStore) statusesKey(gatewayID string) string {
+	return s.prefix + gatewayID + ":statuses"
+}
+
+func (s *redisHistoryStore) AddTraffic(gatewayID string, traffic *Traffic) error {
+	key := s.trafficKey(gatewayID, traffic.Hour)
+
+	pipe := s.client.Pipeline()
+	defer pipe.Close()
+
+	if traffic.Uplink > 0 {
+		pipe.HIncrBy(key, redisUplinkField, int64(traffic.Uplink))
+	}
+	if traffic.Downlink > 0 {
+		pipe.HIncrBy(key, redisDownlinkField, int64(traffic.Downlink))
+	}
+	for frequency, count := range traffic.Channels {
+		pipe.HIncrBy(key, redisChannelPrefix+strconv.FormatUint(frequency, 10), int64(count))
+	}
+	for dataRate, count := range traffic.DataRates {
+		pipe.HIncrBy(key, redisDataRatePrefix+dataRate, int64(count))
+	}
+	pipe.Expire(key, HistoryRetention)
+
+	_, err := pipe.Exec()
+	return err
+}
+
+func (s *redisHistoryStore) GetTraffic(gatewayID string, since time.Time) ([]*Traffic, error) {
+	pipe := s.client.Pipeline()
+	defer pipe.Close()
+
+	var hours []time.Time
+	var results []*redis.StringStringMapCmd
+	for hour := historySince(since).Truncate(time.Hour); !hour.After(time.Now()); hour = hour.Add(time.Hour) {
+		hours = append(hours, hour)
+		results = append(results, pipe.HGetAll(s.trafficKey(gatewayID, hour)))
+	}
+	if _, err := pipe.Exec(); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	var res []*Traffic
+	for i, result := range results {
+		fields, err := result.Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		traffic := newTraffic(hours[i])
+		for field, value := range fields {
+			count, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case field == redisUplinkField:
+				traffic.Uplink = count
+			case field == redisDownlinkField:
+				traffic.Downlink = count
+			case strings.HasPrefix(field, redisChannelPrefix):
+				frequency, err := strconv.ParseUint(strings.TrimPrefix(field, redisChannelPrefix), 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				traffic.Channels[frequency] = count
+			case strings.HasPrefix(field, redisDataRatePrefix):
+				traffic.DataRates[strings.TrimPrefix(field, redisDataRatePrefix)] = count
+			}
+		}
+		res = append(res, traffic)
+	}
+	return res, nil
+}
+
+// addToList appends the value to the list at key, and trims the list to the HistoryLength
+func (s *redisHistoryStore) addToList(key string, value []byte) error {
+	pipe := s.client.Pipeline()
+	defer pipe.Close()
+
+	pipe.RPush(key, value)
+	pipe.LTrim(key, int64(-1*HistoryLength), -1)
+	pipe.Expire(key, HistoryRetention)
+
+	_, err := pipe.Exec()
+	return err
+}
+
+func (s *redisHistoryStore) AddConnection(gatewayID string, connection *Connection) error {
+	value, err := json.Marshal(connection)
+	if err != nil {
+		return err
+	}
+	return s.addToList(s.connectionsKey(gatewayID), value)
+}
+
+func (s *redisHistoryStore) GetConnections(gatewayID string, since time.Time) ([]*Connection, error) {
+	values, err := s.client.LRange(s.connectionsKey(gatewayID), 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	since = historySince(since)
+	var res []*Connection
+	for _, value := range values {
+		connection := new(Connection)
+		if err := json.Unmarshal([]byte(value), connection); err != nil {
+			return nil, err
+		}
+		if connection.Time.After(since) {
+			res = append(res, connection)
+		}
+	}
+	return res, nil
+}
+
+func (s *redisHistoryStore) AddStatus(gatewayID string, status *pb.Status) error {
+	value, err := status.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.addToList(s.statusesKey(gatewayID), value)
+}
+
+func (s *redisHistoryStore) GetStatuses(gatewayID string, since time.Time) ([]*pb.Status, error) {
+	values, err := s.client.LRange(s.statusesKey(gatewayID), 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	since = historySince(since)
+	var res []*pb.Status
+	for _, value := range values {
+		status := new(pb.Status)
+		if err := status.Unmarshal([]byte(value)); err != nil {
+			return nil, err
+		}
+		if time.Unix(0, status.Time).After(since) {
+			res = append(res, status)
+		}
+	}
+	return res, nil
+}
+
+// addTraffic adds to the traffic of the given hour, that is kept until the history is flushed
+func (g *Gateway) addTraffic(hour time.Time, add func(traffic *Traffic)) {
+	if g.History == nil {
+		return
+	}
+	hour = hour.Truncate(time.Hour)
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+	if g.traffic == nil {
+		g.traffic = make(map[int64]*Traffic)
+	}
+	traffic, ok := g.traffic[hour.UnixNano()]
+	if !ok {
+		traffic = newTraffic(hour)
+		g.traffic[hour.UnixNano()] = traffic
+	}
+	add(traffic)
+}
+
+func (g *Gateway) addUplinkHistory(uplink *pb_router.UplinkMessage) {
+	g.addTraffic(time.Now(), func(traffic *Traffic) {
+		traffic.Uplink++
+		if frequency := uplink.GetGatewayMetadata().GetFrequency(); frequency != 0 {
+			traffic.Channels[frequency]++
+		}
+		if lorawan := uplink.GetProtocolMetadata().GetLorawan(); lorawan != nil {
+			dataRate := lorawan.DataRate
+			if dataRate == "" && lorawan.BitRate != 0 {
+				dataRate = strconv.FormatUint(uint64(lorawan.BitRate), 10)
+			}
+			if dataRate != "" {
+				traffic.DataRates[dataRate]++
+			}
+		}
+	})
+}
+
+func (g *Gateway) addDownlinkHistory() {
+	g.addTraffic(time.Now(), func(traffic *Traffic) {
+		traffic.Downlink++
+	})
+}
+
+// addStatusHistory adds the status to the history if the last status in the history is older than the
+// StatusHistoryInterval. The status in the history has the time at which it was received.
+func (g *Gateway) addStatusHistory(status *pb.Status) {
+	if g.History == nil {
+		return
+	}
+	now := time.Now()
+	g.historyMu.Lock()
+	if now.Sub(g.lastStatusAt) < StatusHistoryInterval {
+		g.historyMu.Unlock()
+		return
+	}
+	g.lastStatusAt = now
+	g.historyMu.Unlock()
+
+	clone := *status
+	clone.Time = now.UnixNano()
+	if err := g.History.AddStatus(g.ID, &clone); err != nil {
+		g.Ctx.WithError(err).Warn("Could not add status to gateway history")
+	}
+}
+
+func (g *Gateway) addConnection(stream string, connected bool) {
+	if g.History == nil {
+		return
+	}
+	err := g.History.AddConnection(g.ID, &Connection{
+		Time:      time.Now(),
+		Stream:    stream,
+		Connected: connected,
+	})
+	if err != nil {
+		g.Ctx.WithError(err).WithField("Stream", stream).Warn("Could not add connection to gateway history")
+	}
+}
+
+// FlushHistory adds the traffic that was collected since the last flush to the history. Traffic that could not be
+// added is kept for the next flush.
+func (g *Gateway) FlushHistory() (err error) {
+	if g.History == nil {
+		return nil
+	}
+	g.historyMu.Lock()
+	traffic := g.traffic
+	g.traffic = nil
+	g.historyMu.Unlock()
+
+	for _, hour := range traffic {
+		if addErr := g.History.AddTraffic(g.ID, hour); addErr != nil {
+			err = errors.Wrap(addErr, "Could not add traffic to gateway history")
+			failed := hour
+			g.addTraffic(failed.Hour, func(traffic *Traffic) {
+				traffic.add(failed)
+			})
+		}
+	}
+	return err
+}
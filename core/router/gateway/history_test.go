@@ -0,0 +1,162 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package gateway
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	pb_protocol "github.com/TheThingsNetwork/ttn/api/protocol"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	pb_router "github.com/TheThingsNetwork/ttn/api/router"
+	. "github.com/TheThingsNetwork/ttn/utils/testing"
+	. "github.com/smartystreets/assertions"
+	"gopkg.in/redis.v5"
+)
+
+func getRedisClient() *redis.Client {
+	host := os.Getenv("REDIS_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	return redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:6379", host),
+		Password: "", // no password set
+		DB:       1,  // use default DB
+	})
+}
+
+func testHistoryStore(a *Assertion, s HistoryStore, gatewayID string) {
+	hour := time.Now().Truncate(time.Hour)
+
+	traffic, err := s.GetTraffic(gatewayID, time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(traffic, ShouldBeEmpty)
+
+	a.So(s.AddTraffic(gatewayID, &Traffic{
+		Hour:      hour.Add(-1 * time.Hour),
+		Uplink:    2,
+		Channels:  map[uint64]uint64{868100000: 2},
+		DataRates: map[string]uint64{"SF7BW125": 2},
+	}), ShouldBeNil)
+	a.So(s.AddTraffic(gatewayID, &Traffic{
+		Hour:      hour,
+		Uplink:    1,
+		Downlink:  1,
+		Channels:  map[uint64]uint64{868100000: 1},
+		DataRates: map[string]uint64{"SF12BW125": 1},
+	}), ShouldBeNil)
+	a.So(s.AddTraffic(gatewayID, &Traffic{
+		Hour:      hour,
+		Uplink:    1,
+		Channels:  map[uint64]uint64{868300000: 1},
+		DataRates: map[string]uint64{"SF12BW125": 1},
+	}), ShouldBeNil)
+
+	traffic, err = s.GetTraffic(gatewayID, time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(traffic, ShouldHaveLength, 2)
+	a.So(traffic[0].Hour.Equal(hour.Add(-1*time.Hour)), ShouldBeTrue)
+	a.So(traffic[0].Uplink, ShouldEqual, 2)
+	a.So(traffic[1].Uplink, ShouldEqual, 2)
+	a.So(traffic[1].Downlink, ShouldEqual, 1)
+	a.So(traffic[1].Channels, ShouldResemble, map[uint64]uint64{868100000: 1, 868300000: 1})
+	a.So(traffic[1].DataRates, ShouldResemble, map[string]uint64{"SF12BW125": 2})
+
+	traffic, err = s.GetTraffic(gatewayID, hour)
+	a.So(err, ShouldBeNil)
+	a.So(traffic, ShouldHaveLength, 1)
+
+	start := time.Now()
+	a.So(s.AddConnection(gatewayID, &Connection{Time: start, Stream: UplinkStream, Connected: true}), ShouldBeNil)
+	a.So(s.AddConnection(gatewayID, &Connection{Time: start.Add(time.Second), Stream: UplinkStream}), ShouldBeNil)
+
+	connections, err := s.GetConnections(gatewayID, time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(connections, ShouldHaveLength, 2)
+	a.So(connections[0].Connected, ShouldBeTrue)
+	a.So(connections[1].Connected, ShouldBeFalse)
+
+	connections, err = s.GetConnections(gatewayID, start)
+	a.So(err, ShouldBeNil)
+	a.So(connections, ShouldHaveLength, 1)
+
+	a.So(s.AddStatus(gatewayID, &pb_gateway.Status{Time: start.UnixNano(), RxOk: 1}), ShouldBeNil)
+	a.So(s.AddStatus(gatewayID, &pb_gateway.Status{Time: start.Add(time.Second).UnixNano(), RxOk: 2}), ShouldBeNil)
+
+	statuses, err := s.GetStatuses(gatewayID, time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(statuses, ShouldHaveLength, 2)
+	a.So(statuses[0].RxOk, ShouldEqual, 1)
+	a.So(statuses[1].RxOk, ShouldEqual, 2)
+
+	statuses, err = s.GetStatuses(gatewayID, start)
+	a.So(err, ShouldBeNil)
+	a.So(statuses, ShouldHaveLength, 1)
+}
+
+func TestMemoryHistoryStore(t *testing.T) {
+	testHistoryStore(New(t), NewMemoryHistoryStore(), "test")
+}
+
+func TestRedisHistoryStore(t *testing.T) {
+	c := getRedisClient()
+	defer func() {
+		keys, _ := c.Keys("test-history-store:gateway-history:*").Result()
+		for _, key := range keys {
+			c.Del(key).Result()
+		}
+	}()
+	testHistoryStore(New(t), NewRedisHistoryStore(c, "test-history-store"), "test")
+}
+
+func TestGatewayHistory(t *testing.T) {
+	a := New(t)
+
+	gtw := NewGateway(GetLogger(t, "TestGatewayHistory"), "test")
+	gtw.History = NewMemoryHistoryStore()
+
+	uplink := &pb_router.UplinkMessage{
+		GatewayMetadata: &pb_gateway.RxMetadata{Frequency: 868100000},
+		ProtocolMetadata: &pb_protocol.RxMetadata{Protocol: &pb_protocol.RxMetadata_Lorawan{Lorawan: &pb_lorawan.Metadata{
+			Modulation: pb_lorawan.Modulation_LORA,
+			DataRate:   "SF7BW125",
+		}}},
+	}
+	gtw.addUplinkHistory(uplink)
+	gtw.addUplinkHistory(uplink)
+	gtw.addDownlinkHistory()
+
+	// Traffic is kept until it is flushed
+	traffic, err := gtw.History.GetTraffic("test", time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(traffic, ShouldBeEmpty)
+
+	a.So(gtw.FlushHistory(), ShouldBeNil)
+	traffic, err = gtw.History.GetTraffic("test", time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(traffic, ShouldHaveLength, 1)
+	a.So(traffic[0].Uplink, ShouldEqual, 2)
+	a.So(traffic[0].Downlink, ShouldEqual, 1)
+	a.So(traffic[0].Channels, ShouldResemble, map[uint64]uint64{868100000: 2})
+	a.So(traffic[0].DataRates, ShouldResemble, map[string]uint64{"SF7BW125": 2})
+
+	// Only one status per StatusHistoryInterval is kept
+	gtw.addStatusHistory(&pb_gateway.Status{RxOk: 1})
+	gtw.addStatusHistory(&pb_gateway.Status{RxOk: 2})
+	statuses, err := gtw.History.GetStatuses("test", time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(statuses, ShouldHaveLength, 1)
+	a.So(statuses[0].RxOk, ShouldEqual, 1)
+	a.So(statuses[0].Time, ShouldNotEqual, 0)
+
+	gtw.Connect(UplinkStream)
+	gtw.Disconnect(UplinkStream)
+	connections, err := gtw.History.GetConnections("test", time.Time{})
+	a.So(err, ShouldBeNil)
+	a.So(connections, ShouldHaveLength, 2)
+}
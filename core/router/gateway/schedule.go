@@ -21,8 +21,9 @@ import (
 // Schedule is used to schedule downlink transmissions
 type Schedule interface {
 	fmt.GoStringer
-	// Synchronize the schedule with the gateway timestamp (in microseconds)
-	Sync(timestamp uint32)
+	// Synchronize the schedule with the gateway timestamp (in microseconds) and return the drift of the gateway
+	// clock since the previous synchronization
+	Sync(timestamp uint32) (drift time.Duration)
 	// Get an "option" on a transmission slot at timestamp for the maximum duration of length (both in microseconds)
 	GetOption(timestamp uint32, length uint32) (id string, score uint)
 	// Schedule a transmission on a slot
@@ -31,6 +32,8 @@ type Schedule interface {
 	Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMessage
 	// Whether the gateway has active downlink
 	IsActive() bool
+	// Number of scheduled transmissions that were not yet sent to the gateway
+	Pending() int
 	// Stop the subscription
 	Stop(subscriptionID string)
 }
@@ -76,6 +79,7 @@ type schedule struct {
 	sync.RWMutex
 	ctx                       ttnlog.Interface
 	offset                    int64
+	pending                   int64
 	items                     map[string]*scheduledItem
 	downlink                  chan *router_pb.DownlinkMessage
 	downlinkSubscriptionsLock sync.RWMutex
@@ -92,10 +96,19 @@ func (s *schedule) GoString() (str string) {
 	return
 }
 
-// Deadline for sending a downlink back to the gateway
-// TODO: Make configurable
+// Deadline for sending a downlink back to the gateway. Use SetDeadline to change it while the router is running.
 var Deadline = 400 * time.Millisecond
 
+// SetDeadline changes the Deadline for sending a downlink back to the gateway
+func SetDeadline(deadline time.Duration) {
+	atomic.StoreInt64((*int64)(&Deadline), int64(deadline))
+}
+
+// GetDeadline returns the Deadline for sending a downlink back to the gateway
+func GetDeadline() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&Deadline)))
+}
+
 const uintmax = 1 << 32
 
 // getConflicts walks over the schedule and returns the number of conflicts.
@@ -138,9 +151,23 @@ func (s *schedule) realtime(timestamp uint32) (t time.Time) {
 	return
 }
 
+// rollover is the duration after which the gateway timestamp (in microseconds) rolls over
+const rollover = time.Duration(uintmax) * time.Microsecond
+
 // see interface
-func (s *schedule) Sync(timestamp uint32) {
-	atomic.StoreInt64(&s.offset, time.Now().UnixNano()-int64(timestamp)*1000)
+func (s *schedule) Sync(timestamp uint32) (drift time.Duration) {
+	offset := time.Now().UnixNano() - int64(timestamp)*1000
+	previous := atomic.SwapInt64(&s.offset, offset)
+	if previous == 0 {
+		return 0
+	}
+	drift = time.Duration(offset-previous) % rollover
+	if drift > rollover/2 {
+		drift -= rollover
+	} else if drift < -rollover/2 {
+		drift += rollover
+	}
+	return drift
 }
 
 // see interface
@@ -149,7 +176,7 @@ func (s *schedule) GetOption(timestamp uint32, length uint32) (id string, score
 	score = s.getConflicts(timestamp, length)
 	item := &scheduledItem{
 		id:         id,
-		deadlineAt: s.realtime(timestamp).Add(-1 * Deadline),
+		deadlineAt: s.realtime(timestamp).Add(-1 * GetDeadline()),
 		timestamp:  timestamp,
 		length:     length,
 		score:      score,
@@ -189,17 +216,20 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 			item.length = uint32(time / 1000)
 		}
 
+		atomic.AddInt64(&s.pending, 1)
 		if time.Now().Before(item.deadlineAt) {
 			// Schedule transmission before the Deadline
 			go func() {
 				waitTime := item.deadlineAt.Sub(time.Now())
 				ctx.WithField("Remaining", waitTime).Info("Scheduled downlink")
-				downlink.Trace = downlink.Trace.WithEvent("schedule")
+				downlink.Trace = downlink.Trace.WithEvent(s.traceService(), "schedule")
 				<-time.After(waitTime)
 				s.RLock()
 				defer s.RUnlock()
 				if s.downlink != nil {
-					s.downlink <- item.payload
+					s.downlink <- item.payload // pending is decremented when the downlink is sent to the gateway
+				} else {
+					atomic.AddInt64(&s.pending, -1)
 				}
 			}()
 		} else {
@@ -208,16 +238,17 @@ func (s *schedule) Schedule(id string, downlink *router_pb.DownlinkMessage) erro
 				defer s.RUnlock()
 				if s.downlink != nil {
 					overdue := time.Now().Sub(item.deadlineAt)
-					if overdue < Deadline {
+					if overdue < GetDeadline() {
 						// Immediately send it
 						ctx.WithField("Overdue", overdue).Warn("Send Late Downlink")
 						s.downlink <- item.payload
-					} else {
-						ctx.WithField("Overdue", overdue).Warn("Discard Late Downlink")
+						return
 					}
+					ctx.WithField("Overdue", overdue).Warn("Discard Late Downlink")
 				} else {
 					ctx.Warn("Unable to send Downlink")
 				}
+				atomic.AddInt64(&s.pending, -1)
 			}()
 		}
 
@@ -250,7 +281,8 @@ func (s *schedule) Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMe
 				if s.gateway != nil && s.gateway.Utilization != nil {
 					s.gateway.Utilization.AddTx(downlink) // FIXME: Issue #420
 				}
-				downlink.Trace = downlink.Trace.WithEvent(trace.SendEvent)
+				downlink.Trace = downlink.Trace.WithEvent(s.traceService(), trace.SendEvent)
+				trace.Export(s.traceService(), downlink.Trace)
 				s.downlinkSubscriptionsLock.RLock()
 				for _, ch := range s.downlinkSubscriptions {
 					select {
@@ -260,6 +292,7 @@ func (s *schedule) Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMe
 					}
 				}
 				s.downlinkSubscriptionsLock.RUnlock()
+				atomic.AddInt64(&s.pending, -1)
 			}
 		}()
 	}
@@ -276,6 +309,18 @@ func (s *schedule) Subscribe(subscriptionID string) <-chan *router_pb.DownlinkMe
 	return sub
 }
 
+func (s *schedule) Pending() int {
+	return int(atomic.LoadInt64(&s.pending))
+}
+
+// traceService returns the identity of the Router in the traces of downlink messages
+func (s *schedule) traceService() trace.Service {
+	if s.gateway == nil {
+		return trace.Service{}
+	}
+	return s.gateway.TraceService
+}
+
 func (s *schedule) IsActive() bool {
 	s.RLock()
 	defer s.RUnlock()
@@ -25,6 +25,26 @@ func TestScheduleSync(t *testing.T) {
 	a.So(s.offset, ShouldAlmostEqual, time.Now().UnixNano()-1000*1000, almostEqual)
 }
 
+func TestScheduleSyncDrift(t *testing.T) {
+	a := New(t)
+	s := &schedule{}
+
+	// The first sync has no drift
+	a.So(s.Sync(0), ShouldEqual, time.Duration(0))
+
+	// The gateway clock is 10ms ahead
+	a.So(s.Sync(10*1000), ShouldAlmostEqual, -10*time.Millisecond, almostEqual)
+
+	// The gateway clock is 10ms behind
+	a.So(s.Sync(0), ShouldAlmostEqual, 10*time.Millisecond, almostEqual)
+
+	// The gateway timestamp rolls over
+	s.offset = time.Now().UnixNano() - int64(uintmax-10)*1000
+	a.So(s.Sync(10), ShouldAlmostEqual, 0, almostEqual)
+	s.offset = time.Now().UnixNano() - 10*1000
+	a.So(s.Sync(uintmax-10), ShouldAlmostEqual, 0, almostEqual)
+}
+
 func TestScheduleRealtime(t *testing.T) {
 	a := New(t)
 	s := &schedule{}
@@ -158,3 +178,30 @@ func TestScheduleSubscribe(t *testing.T) {
 	<-time.After(500 * time.Millisecond)
 
 }
+
+func TestSchedulePending(t *testing.T) {
+	a := New(t)
+	s := NewSchedule(GetLogger(t, "TestSchedulePending")).(*schedule)
+	s.Sync(0)
+	defer func(deadline time.Duration) { Deadline = deadline }(Deadline)
+	Deadline = 1 * time.Millisecond // Very short deadline
+
+	sub := s.Subscribe("")
+	defer s.Stop("")
+
+	a.So(s.Pending(), ShouldEqual, 0)
+
+	downlink := &router_pb.DownlinkMessage{Payload: []byte{1}}
+	id, _ := s.GetOption(20000, 50)
+	s.Schedule(id, downlink)
+	a.So(s.Pending(), ShouldEqual, 1)
+
+	select {
+	case out := <-sub:
+		a.So(out, ShouldEqual, downlink)
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive downlink")
+	}
+	<-time.After(10 * time.Millisecond)
+	a.So(s.Pending(), ShouldEqual, 0)
+}
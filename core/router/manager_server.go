@@ -5,9 +5,15 @@ package router
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/TheThingsNetwork/go-account-lib/claims"
+	"github.com/TheThingsNetwork/ttn/api"
 	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/router/gateway"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/TheThingsNetwork/ttn/utils/random"
 	"golang.org/x/net/context" // See https://github.com/grpc/grpc-go/issues/711"
 	"google.golang.org/grpc"
 )
@@ -40,6 +46,140 @@ func (r *routerManager) GatewayStatus(ctx context.Context, in *pb.GatewayStatusR
 	}, nil
 }
 
+func (r *routerManager) GatewayHistory(ctx context.Context, in *pb.GatewayHistoryRequest) (*pb.GatewayHistoryResponse, error) {
+	if in.GatewayId == "" {
+		return nil, errors.NewErrInvalidArgument("Gateway History Request", "ID is required")
+	}
+	_, err := r.router.ValidateTTNAuthContext(ctx)
+	if err != nil {
+		return nil, errors.NewErrPermissionDenied("No access")
+	}
+	if r.router.history == nil {
+		return nil, errors.NewErrNotFound(fmt.Sprintf("History of gateway %s", in.GatewayId))
+	}
+
+	// Make sure that the traffic of the current hour is included
+	r.router.gatewaysLock.RLock()
+	gtw, ok := r.router.gateways[in.GatewayId]
+	r.router.gatewaysLock.RUnlock()
+	if ok {
+		if err := gtw.FlushHistory(); err != nil {
+			gtw.Ctx.WithError(err).Warn("Could not flush gateway history")
+		}
+	}
+
+	var since time.Time
+	if in.Since > 0 {
+		since = time.Unix(0, in.Since)
+	}
+	traffic, err := r.router.history.GetTraffic(in.GatewayId, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not get gateway traffic")
+	}
+	connections, err := r.router.history.GetConnections(in.GatewayId, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not get gateway connections")
+	}
+	statuses, err := r.router.history.GetStatuses(in.GatewayId, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not get gateway statuses")
+	}
+
+	res := &pb.GatewayHistoryResponse{Statuses: statuses}
+	for _, hour := range traffic {
+		res.Traffic = append(res.Traffic, gatewayTraffic(hour))
+	}
+	for _, connection := range connections {
+		res.Connections = append(res.Connections, &pb.GatewayConnection{
+			Time:      connection.Time.UnixNano(),
+			Stream:    connection.Stream,
+			Connected: connection.Connected,
+		})
+	}
+	return res, nil
+}
+
+func gatewayTraffic(traffic *gateway.Traffic) *pb.GatewayTraffic {
+	res := &pb.GatewayTraffic{
+		Hour:     traffic.Hour.UnixNano(),
+		Uplink:   uint32(traffic.Uplink),
+		Downlink: uint32(traffic.Downlink),
+	}
+	frequencies := make([]int, 0, len(traffic.Channels))
+	for frequency := range traffic.Channels {
+		frequencies = append(frequencies, int(frequency))
+	}
+	sort.Ints(frequencies)
+	for _, frequency := range frequencies {
+		res.Channels = append(res.Channels, &pb.GatewayChannelTraffic{
+			Frequency: uint64(frequency),
+			Uplink:    uint32(traffic.Channels[uint64(frequency)]),
+		})
+	}
+	dataRates := make([]string, 0, len(traffic.DataRates))
+	for dataRate := range traffic.DataRates {
+		dataRates = append(dataRates, dataRate)
+	}
+	sort.Strings(dataRates)
+	for _, dataRate := range dataRates {
+		res.DataRates = append(res.DataRates, &pb.GatewayDataRateTraffic{
+			DataRate: dataRate,
+			Uplink:   uint32(traffic.DataRates[dataRate]),
+		})
+	}
+	return res
+}
+
+// validateTrafficClient checks if the client is allowed to subscribe to the requested traffic. Gateway owners can
+// subscribe to the traffic of their gateway with a gateway token, network operators need access to this Router.
+func (r *routerManager) validateTrafficClient(ctx context.Context, in *pb.TrafficRequest) error {
+	token, err := api.TokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if r.router.TokenKeyProvider == nil {
+		return errors.NewErrInternal("No token provider configured")
+	}
+	if in.GatewayId != "" {
+		if gatewayClaims, err := claims.FromGatewayToken(r.router.TokenKeyProvider, token); err == nil && gatewayClaims.Subject == in.GatewayId {
+			return nil
+		}
+	}
+	claims, err := r.router.ValidateTTNAuthContext(ctx)
+	if err != nil {
+		return errors.NewErrPermissionDenied("No access")
+	}
+	if !claims.ComponentAccess(r.router.Identity.Id) {
+		return errors.NewErrPermissionDenied(fmt.Sprintf("Claims do not grant access to %s", r.router.Identity.Id))
+	}
+	return nil
+}
+
+func (r *routerManager) Traffic(in *pb.TrafficRequest, stream pb.RouterManager_TrafficServer) error {
+	if err := r.validateTrafficClient(stream.Context(), in); err != nil {
+		return err
+	}
+	subscriptionID := random.String(10)
+	ch, err := r.router.SubscribeTraffic(subscriptionID, in)
+	if err != nil {
+		return err
+	}
+	defer r.router.UnsubscribeTraffic(subscriptionID)
+	ctx := r.router.Ctx.WithField("SubscriptionID", subscriptionID).WithField("GatewayID", in.GatewayId)
+	ctx.Debug("Subscribed to traffic")
+	defer ctx.Debug("Unsubscribed from traffic")
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case message := <-ch:
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (r *routerManager) GetStatus(ctx context.Context, in *pb.StatusRequest) (*pb.Status, error) {
 	if r.router.Identity.Id != "dev" {
 		claims, err := r.router.ValidateTTNAuthContext(ctx)
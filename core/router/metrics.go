@@ -0,0 +1,71 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"github.com/TheThingsNetwork/ttn/core/component"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collector exposes the status of the router as Prometheus metrics
+type collector struct {
+	router *router
+
+	uplink               *prometheus.Desc
+	downlink             *prometheus.Desc
+	activations          *prometheus.Desc
+	gatewayStatus        *prometheus.Desc
+	connectedGateways    *prometheus.Desc
+	connectedBrokers     *prometheus.Desc
+	gatewayRxUtilization *prometheus.Desc
+	gatewayTxUtilization *prometheus.Desc
+}
+
+func newCollector(r *router) *collector {
+	return &collector{
+		router:               r,
+		uplink:               r.Component.NewMetricDesc("uplink_total", "Total number of received uplink messages"),
+		downlink:             r.Component.NewMetricDesc("downlink_total", "Total number of scheduled downlink messages"),
+		activations:          r.Component.NewMetricDesc("activations_total", "Total number of received activation requests"),
+		gatewayStatus:        r.Component.NewMetricDesc("gateway_status_total", "Total number of received gateway status messages"),
+		connectedGateways:    r.Component.NewMetricDesc("connected_gateways", "Number of connected gateways"),
+		connectedBrokers:     r.Component.NewMetricDesc("connected_brokers", "Number of connected brokers"),
+		gatewayRxUtilization: r.Component.NewMetricDesc("gateway_rx_utilization", "Fraction of time that a gateway was receiving in the last minute", "gateway_id"),
+		gatewayTxUtilization: r.Component.NewMetricDesc("gateway_tx_utilization", "Fraction of time that a gateway was transmitting in the last minute", "gateway_id"),
+	}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.uplink
+	ch <- c.downlink
+	ch <- c.activations
+	ch <- c.gatewayStatus
+	ch <- c.connectedGateways
+	ch <- c.connectedBrokers
+	ch <- c.gatewayRxUtilization
+	ch <- c.gatewayTxUtilization
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	status := c.router.status
+	if status == nil {
+		return
+	}
+	ch <- component.MeterMetric(c.uplink, status.uplink)
+	ch <- component.MeterMetric(c.downlink, status.downlink)
+	ch <- component.MeterMetric(c.activations, status.activations)
+	ch <- component.MeterMetric(c.gatewayStatus, status.gatewayStatus)
+	ch <- component.GaugeMetric(c.connectedGateways, status.connectedGateways)
+	ch <- component.GaugeMetric(c.connectedBrokers, status.connectedBrokers)
+
+	c.router.gatewaysLock.RLock()
+	defer c.router.gatewaysLock.RUnlock()
+	for id, gtw := range c.router.gateways {
+		rx, tx := gtw.Utilization.Get()
+		ch <- prometheus.MustNewConstMetric(c.gatewayRxUtilization, prometheus.GaugeValue, rx, id)
+		ch <- prometheus.MustNewConstMetric(c.gatewayTxUtilization, prometheus.GaugeValue, tx, id)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/mqtt"
+)
+
+// MQTTTimeout indicates how long we should wait for an MQTT publish
+var MQTTTimeout = 2 * time.Second
+
+// HandleMQTT connects to the MQTT broker and publishes the events of gateways on gateways/<id>/events/<type>
+func (r *router) HandleMQTT(username, password string, mqttBrokers ...string) error {
+	r.mqttClient = mqtt.NewClient(r.Ctx, "ttnrtr", username, password, mqttBrokers...)
+
+	err := r.mqttClient.Connect()
+	if err != nil {
+		return err
+	}
+
+	r.mqttEvent = make(chan *pb_gateway.Event, EventBufferSize)
+
+	ctx := r.Ctx.WithField("Protocol", "MQTT")
+
+	go func() {
+		for event := range r.mqttEvent {
+			ctx := ctx.WithFields(ttnlog.Fields{
+				"GatewayID": event.GatewayId,
+				"Event":     event.Type,
+			})
+			ctx.Debug("Publish Event")
+			token := r.mqttClient.PublishGatewayEvent(event.GatewayId, types.EventType(event.Type), gatewayEventData(event))
+			go func() {
+				if token.WaitTimeout(MQTTTimeout) {
+					if token.Error() != nil {
+						ctx.WithError(token.Error()).Warn("Could not publish Event")
+					}
+				} else {
+					ctx.Warn("Event publish timeout")
+				}
+			}()
+		}
+	}()
+
+	return nil
+}
@@ -4,17 +4,20 @@
 package router
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 
+	"github.com/TheThingsNetwork/ttn/amqp"
 	pb_broker "github.com/TheThingsNetwork/ttn/api/broker"
 	pb_discovery "github.com/TheThingsNetwork/ttn/api/discovery"
 	pb_gateway "github.com/TheThingsNetwork/ttn/api/gateway"
 	pb "github.com/TheThingsNetwork/ttn/api/router"
 	"github.com/TheThingsNetwork/ttn/core/component"
 	"github.com/TheThingsNetwork/ttn/core/router/gateway"
+	"github.com/TheThingsNetwork/ttn/mqtt"
 	"golang.org/x/net/context"
 )
 
@@ -23,6 +26,11 @@ type Router interface {
 	component.Interface
 	component.ManagementInterface
 
+	// Publish gateway events to MQTT
+	WithMQTT(username, password string, brokers ...string) Router
+	// Publish gateway events to AMQP
+	WithAMQP(username, password, host, exchange string) Router
+
 	// Handle a status message from a gateway
 	HandleGatewayStatus(gatewayID string, status *pb_gateway.Status) error
 	// Handle an uplink message from a gateway
@@ -35,6 +43,13 @@ type Router interface {
 	UnsubscribeDownlink(gatewayID string, subscriptionID string) error
 	// Handle a device activation
 	HandleActivation(gatewayID string, activation *pb.DeviceActivationRequest) (*pb.DeviceActivationResponse, error)
+	// Subscribe to the uplink and downlink messages of gateways
+	SubscribeTraffic(subscriptionID string, filter *pb.TrafficRequest) (<-chan *pb.TrafficMessage, error)
+	// Unsubscribe from the uplink and downlink messages of gateways
+	UnsubscribeTraffic(subscriptionID string) error
+
+	// SetGatewayHistory sets the store for the traffic, connection and status history of gateways
+	SetGatewayHistory(store gateway.HistoryStore)
 
 	getGateway(gatewayID string) *gateway.Gateway
 }
@@ -52,6 +67,7 @@ func NewRouter() Router {
 	return &router{
 		gateways: make(map[string]*gateway.Gateway),
 		brokers:  make(map[string]*broker),
+		history:  gateway.NewMemoryHistoryStore(),
 	}
 }
 
@@ -61,7 +77,43 @@ type router struct {
 	gatewaysLock sync.RWMutex
 	brokers      map[string]*broker
 	brokersLock  sync.RWMutex
+	history      gateway.HistoryStore
 	status       *status
+	inFlight     component.InFlight
+	traffic      map[string]*trafficSubscription
+	trafficLock  sync.RWMutex
+
+	mqttClient   mqtt.Client
+	mqttUsername string
+	mqttPassword string
+	mqttBrokers  []string
+	mqttEnabled  bool
+	mqttEvent    chan *pb_gateway.Event
+
+	amqpClient   amqp.Client
+	amqpUsername string
+	amqpPassword string
+	amqpHost     string
+	amqpExchange string
+	amqpEnabled  bool
+	amqpEvent    chan *pb_gateway.Event
+}
+
+func (r *router) WithMQTT(username, password string, brokers ...string) Router {
+	r.mqttUsername = username
+	r.mqttPassword = password
+	r.mqttBrokers = brokers
+	r.mqttEnabled = true
+	return r
+}
+
+func (r *router) WithAMQP(username, password, host, exchange string) Router {
+	r.amqpUsername = username
+	r.amqpPassword = password
+	r.amqpHost = host
+	r.amqpExchange = exchange
+	r.amqpEnabled = true
+	return r
 }
 
 func (r *router) tickGateways() {
@@ -72,9 +124,25 @@ func (r *router) tickGateways() {
 	}
 }
 
+func (r *router) SetGatewayHistory(store gateway.HistoryStore) {
+	r.history = store
+}
+
+// flushGatewayHistory adds the traffic of the gateways to their history
+func (r *router) flushGatewayHistory() {
+	r.gatewaysLock.RLock()
+	defer r.gatewaysLock.RUnlock()
+	for _, gtw := range r.gateways {
+		if err := gtw.FlushHistory(); err != nil {
+			gtw.Ctx.WithError(err).Warn("Could not flush gateway history")
+		}
+	}
+}
+
 func (r *router) Init(c *component.Component) error {
 	r.Component = c
 	r.InitStatus()
+	r.Component.RegisterMetrics(newCollector(r))
 	err := r.Component.UpdateTokenKey()
 	if err != nil {
 		return err
@@ -85,9 +153,37 @@ func (r *router) Init(c *component.Component) error {
 	}
 	r.Discovery.GetAll("broker") // Update cache
 
+	if r.mqttEnabled {
+		var brokers []string
+		for _, broker := range r.mqttBrokers {
+			brokers = append(brokers, fmt.Sprintf("tcp://%s", broker))
+		}
+		err = r.HandleMQTT(r.mqttUsername, r.mqttPassword, brokers...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.amqpEnabled {
+		err = r.HandleAMQP(r.amqpUsername, r.amqpPassword, r.amqpHost, r.amqpExchange)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := r.Configure([]string{"router.status-timeout", "router.clock-drift-threshold"}, reloadGatewayEvents); err != nil {
+		r.Ctx.WithError(err).Warn("Invalid gateway event settings, using the defaults")
+	}
+
 	go func() {
 		for range time.Tick(5 * time.Second) {
 			r.tickGateways()
+			r.checkGatewayStatus()
+		}
+	}()
+	go func() {
+		for range time.Tick(time.Minute) {
+			r.flushGatewayHistory()
 		}
 	}()
 	r.Component.SetStatus(component.StatusHealthy)
@@ -95,6 +191,21 @@ func (r *router) Init(c *component.Component) error {
 }
 
 func (r *router) Shutdown() {
+	deadline := r.ShutdownDeadline()
+	if dropped := r.inFlight.Close(deadline); dropped > 0 {
+		r.Ctx.WithField("Messages", dropped).Warn("Dropped messages that were still being handled")
+	}
+	if dropped := component.Drain(deadline, r.pendingDownlinks); dropped > 0 {
+		r.Ctx.WithField("Downlinks", dropped).Warn("Dropped scheduled downlink messages")
+	}
+	r.flushGatewayHistory()
+	if r.mqttEnabled {
+		r.mqttClient.Disconnect()
+	}
+	if r.amqpEnabled {
+		r.amqpClient.Disconnect()
+	}
+
 	r.brokersLock.Lock()
 	defer r.brokersLock.Unlock()
 	for _, broker := range r.brokers {
@@ -103,6 +214,16 @@ func (r *router) Shutdown() {
 	}
 }
 
+// pendingDownlinks returns the number of scheduled downlink messages that were not yet sent to the gateways
+func (r *router) pendingDownlinks() (pending int) {
+	r.gatewaysLock.RLock()
+	defer r.gatewaysLock.RUnlock()
+	for _, gtw := range r.gateways {
+		pending += gtw.Schedule.Pending()
+	}
+	return
+}
+
 // getGateway gets or creates a Gateway
 func (r *router) getGateway(id string) *gateway.Gateway {
 	// We're going to be optimistic and guess that the gateway is already active
@@ -120,6 +241,10 @@ func (r *router) getGateway(id string) *gateway.Gateway {
 	if !ok {
 		gtw = gateway.NewGateway(r.Ctx, id)
 		gtw.Monitors = r.Component.Monitors
+		gtw.History = r.history
+		gtw.EventHandler = r.handleGatewayEvent
+		gtw.TrafficHandler = r.handleTraffic
+		gtw.TraceService = r.TraceService()
 
 		r.gateways[id] = gtw
 	}
@@ -169,7 +294,11 @@ func (r *router) getBroker(brokerAnnouncement *pb_discovery.Announcement) (*brok
 				case message := <-brk.uplink:
 					association.Send(message)
 				case message := <-downlink:
-					go r.HandleDownlink(message)
+					go func(message *pb_broker.DownlinkMessage) {
+						if err := r.HandleDownlink(message); err != nil {
+							r.reportDownlinkFailure(client, message, err)
+						}
+					}(message)
 				}
 			}
 		}()
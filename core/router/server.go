@@ -73,18 +73,20 @@ func (r *routerRPC) gatewayFromContext(ctx context.Context) (gtw *gateway.Gatewa
 }
 
 func (r *routerRPC) getUplink(md metadata.MD) (ch chan *pb.UplinkMessage, err error) {
-	gateway, err := r.gatewayFromMetadata(md)
+	gtw, err := r.gatewayFromMetadata(md)
 	if err != nil {
 		return nil, err
 	}
+	gtw.Connect(gateway.UplinkStream)
 	ch = make(chan *pb.UplinkMessage)
 	go func() {
+		defer gtw.Disconnect(gateway.UplinkStream)
 		for uplink := range ch {
-			if waitTime := r.uplinkRate.Wait(gateway.ID); waitTime != 0 {
-				r.router.Ctx.WithField("GatewayID", gateway.ID).WithField("Wait", waitTime).Warn("Gateway reached uplink rate limit")
+			if waitTime := r.uplinkRate.Wait(gtw.ID); waitTime != 0 {
+				r.router.Ctx.WithField("GatewayID", gtw.ID).WithField("Wait", waitTime).Warn("Gateway reached uplink rate limit")
 				time.Sleep(waitTime)
 			}
-			r.router.HandleUplink(gateway.ID, uplink)
+			r.router.HandleUplink(gtw.ID, uplink)
 
 		}
 	}()
@@ -92,37 +94,41 @@ func (r *routerRPC) getUplink(md metadata.MD) (ch chan *pb.UplinkMessage, err er
 }
 
 func (r *routerRPC) getGatewayStatus(md metadata.MD) (ch chan *pb_gateway.Status, err error) {
-	gateway, err := r.gatewayFromMetadata(md)
+	gtw, err := r.gatewayFromMetadata(md)
 	if err != nil {
 		return nil, err
 	}
+	gtw.Connect(gateway.StatusStream)
 	ch = make(chan *pb_gateway.Status)
 	go func() {
+		defer gtw.Disconnect(gateway.StatusStream)
 		for status := range ch {
-			if waitTime := r.statusRate.Wait(gateway.ID); waitTime != 0 {
-				r.router.Ctx.WithField("GatewayID", gateway.ID).WithField("Wait", waitTime).Warn("Gateway reached status rate limit")
+			if waitTime := r.statusRate.Wait(gtw.ID); waitTime != 0 {
+				r.router.Ctx.WithField("GatewayID", gtw.ID).WithField("Wait", waitTime).Warn("Gateway reached status rate limit")
 				time.Sleep(waitTime)
 			}
-			r.router.HandleGatewayStatus(gateway.ID, status)
+			r.router.HandleGatewayStatus(gtw.ID, status)
 		}
 	}()
 	return
 }
 
 func (r *routerRPC) getDownlink(md metadata.MD) (ch <-chan *pb.DownlinkMessage, cancel func(), err error) {
-	gateway, err := r.gatewayFromMetadata(md)
+	gtw, err := r.gatewayFromMetadata(md)
 	if err != nil {
 		return nil, nil, err
 	}
 	subscriptionID := random.String(10)
 	ch = make(chan *pb.DownlinkMessage)
 	cancel = func() {
-		r.router.UnsubscribeDownlink(gateway.ID, subscriptionID)
+		r.router.UnsubscribeDownlink(gtw.ID, subscriptionID)
+		gtw.Disconnect(gateway.DownlinkStream)
 	}
-	downlinkChannel, err := r.router.SubscribeDownlink(gateway.ID, subscriptionID)
+	downlinkChannel, err := r.router.SubscribeDownlink(gtw.ID, subscriptionID)
 	if err != nil {
 		return nil, nil, err
 	}
+	gtw.Connect(gateway.DownlinkStream)
 	return downlinkChannel, cancel, nil
 }
 
@@ -158,6 +164,25 @@ func (r *router) RegisterRPC(s *grpc.Server) {
 
 	server.uplinkRate = ratelimit.NewRegistry(1500, time.Minute) // includes activations
 	server.statusRate = ratelimit.NewRegistry(10, time.Minute)   // 10 per minute (pkt fwd default is 2 per minute)
+	r.ConfigureRate("router.uplink-rate", server.uplinkRate, time.Minute)
+	r.ConfigureRate("router.status-rate", server.statusRate, time.Minute)
+	if err := r.Configure([]string{"router.downlink-deadline"}, reloadDownlinkDeadline); err != nil {
+		r.Ctx.WithError(err).Warn("Invalid downlink deadline, using the default")
+	}
 
 	pb.RegisterRouterServer(s, server)
 }
+
+// reloadDownlinkDeadline validates the configured downlink deadline, and returns a function that applies it
+func reloadDownlinkDeadline() (func(), error) {
+	if !viper.IsSet("router.downlink-deadline") {
+		return func() {}, nil
+	}
+	deadline := viper.GetDuration("router.downlink-deadline")
+	if deadline <= 0 {
+		return nil, errors.NewErrInvalidArgument("Downlink deadline", "must be positive")
+	}
+	return func() {
+		gateway.SetDeadline(deadline)
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"fmt"
+
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// TrafficBufferSize indicates the number of traffic messages that are buffered for each subscription. Messages are
+// dropped for subscribers that do not keep up.
+var TrafficBufferSize = 100
+
+type trafficSubscription struct {
+	filter *pb.TrafficRequest
+	ch     chan *pb.TrafficMessage
+}
+
+func (r *router) SubscribeTraffic(subscriptionID string, filter *pb.TrafficRequest) (<-chan *pb.TrafficMessage, error) {
+	r.trafficLock.Lock()
+	defer r.trafficLock.Unlock()
+	if r.traffic == nil {
+		r.traffic = make(map[string]*trafficSubscription)
+	}
+	if _, ok := r.traffic[subscriptionID]; ok {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Already subscribed to traffic with %s", subscriptionID))
+	}
+	sub := &trafficSubscription{
+		filter: filter,
+		ch:     make(chan *pb.TrafficMessage, TrafficBufferSize),
+	}
+	r.traffic[subscriptionID] = sub
+	return sub.ch, nil
+}
+
+func (r *router) UnsubscribeTraffic(subscriptionID string) error {
+	r.trafficLock.Lock()
+	defer r.trafficLock.Unlock()
+	if sub, ok := r.traffic[subscriptionID]; ok {
+		close(sub.ch)
+		delete(r.traffic, subscriptionID)
+	}
+	return nil
+}
+
+// handleTraffic sends an uplink or downlink message of a gateway to the matching traffic subscriptions
+func (r *router) handleTraffic(message *pb.TrafficMessage) {
+	r.trafficLock.RLock()
+	defer r.trafficLock.RUnlock()
+	for subscriptionID, sub := range r.traffic {
+		if !sub.filter.Matches(message) {
+			continue
+		}
+		select {
+		case sub.ch <- message:
+		default:
+			r.Ctx.WithField("SubscriptionID", subscriptionID).Debug("Not sending traffic message, buffer full")
+		}
+	}
+}
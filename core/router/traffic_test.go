@@ -0,0 +1,56 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package router
+
+import (
+	"testing"
+
+	pb "github.com/TheThingsNetwork/ttn/api/router"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestTraffic(t *testing.T) {
+	a := New(t)
+	r := getTestRouter(t)
+
+	all, err := r.SubscribeTraffic("all", &pb.TrafficRequest{})
+	a.So(err, ShouldBeNil)
+	gtw1, err := r.SubscribeTraffic("gtw-1", &pb.TrafficRequest{GatewayId: "gtw-1"})
+	a.So(err, ShouldBeNil)
+
+	_, err = r.SubscribeTraffic("all", &pb.TrafficRequest{})
+	a.So(err, ShouldNotBeNil)
+
+	r.handleTraffic(&pb.TrafficMessage{GatewayId: "gtw-1", Uplink: &pb.UplinkMessage{}})
+	r.handleTraffic(&pb.TrafficMessage{GatewayId: "gtw-2", Downlink: &pb.DownlinkMessage{}})
+
+	a.So(all, ShouldHaveLength, 2)
+	a.So(gtw1, ShouldHaveLength, 1)
+	a.So((<-gtw1).GatewayId, ShouldEqual, "gtw-1")
+
+	// Messages are dropped when the buffer is full
+	for i := 0; i < TrafficBufferSize; i++ {
+		r.handleTraffic(&pb.TrafficMessage{GatewayId: "gtw-2"})
+	}
+	a.So(all, ShouldHaveLength, TrafficBufferSize)
+
+	a.So(r.UnsubscribeTraffic("gtw-1"), ShouldBeNil)
+	_, open := <-gtw1
+	a.So(open, ShouldBeFalse)
+	a.So(r.UnsubscribeTraffic("all"), ShouldBeNil)
+}
+
+func TestGatewayTraffic(t *testing.T) {
+	a := New(t)
+	r := getTestRouter(t)
+
+	ch, err := r.SubscribeTraffic("test", &pb.TrafficRequest{GatewayId: "eui-0102030405060708"})
+	a.So(err, ShouldBeNil)
+	defer r.UnsubscribeTraffic("test")
+
+	gtw := r.getGateway("eui-0102030405060708")
+	a.So(gtw.TrafficHandler, ShouldNotBeNil)
+	gtw.TrafficHandler(&pb.TrafficMessage{GatewayId: gtw.ID, Uplink: &pb.UplinkMessage{}})
+	a.So(ch, ShouldHaveLength, 1)
+}
@@ -22,13 +22,19 @@ func (r *router) HandleUplink(gatewayID string, uplink *pb.UplinkMessage) (err e
 	start := time.Now()
 	defer func() {
 		if err != nil {
-			uplink.Trace = uplink.Trace.WithEvent(trace.DropEvent, "reason", err)
+			uplink.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.DropEvent, "reason", err)
 			ctx.WithError(err).Warn("Could not handle uplink")
 		}
+		trace.Export(r.TraceService(), uplink.Trace)
 	}()
 	r.status.uplink.Mark(1)
 
-	uplink.Trace = uplink.Trace.WithEvent(trace.ReceiveEvent, "gateway", gatewayID)
+	if !r.inFlight.Add() {
+		return errors.NewErrInternal("Router is shutting down")
+	}
+	defer r.inFlight.Done()
+
+	uplink.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.ReceiveEvent, "gateway", gatewayID)
 
 	// LoRaWAN: Unmarshal
 	var phyPayload lorawan.PHYPayload
@@ -54,7 +60,7 @@ func (r *router) HandleUplink(gatewayID string, uplink *pb.UplinkMessage) (err e
 			AppEui:           &appEUI,
 			ProtocolMetadata: uplink.ProtocolMetadata,
 			GatewayMetadata:  uplink.GatewayMetadata,
-			Trace:            uplink.Trace.WithEvent("handle uplink as activation"),
+			Trace:            uplink.Trace.WithEvent(r.TraceService(), "handle uplink as activation"),
 		})
 		return nil
 	}
@@ -96,7 +102,7 @@ func (r *router) HandleUplink(gatewayID string, uplink *pb.UplinkMessage) (err e
 	var downlinkOptions []*pb_broker.DownlinkOption
 	if gateway.Schedule.IsActive() {
 		downlinkOptions = r.buildDownlinkOptions(uplink, false, gateway)
-		uplink.Trace = uplink.Trace.WithEvent(trace.BuildDownlinkEvent,
+		uplink.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.BuildDownlinkEvent,
 			"options", len(downlinkOptions),
 		)
 	}
@@ -111,13 +117,13 @@ func (r *router) HandleUplink(gatewayID string, uplink *pb.UplinkMessage) (err e
 
 	if len(brokers) == 0 {
 		ctx.Debug("No brokers to forward message to")
-		uplink.Trace = uplink.Trace.WithEvent(trace.DropEvent, "reason", "no brokers")
+		uplink.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.DropEvent, "reason", "no brokers")
 		return nil
 	}
 
 	ctx = ctx.WithField("NumBrokers", len(brokers))
 
-	uplink.Trace = uplink.Trace.WithEvent(trace.ForwardEvent,
+	uplink.Trace = uplink.Trace.WithEvent(r.TraceService(), trace.ForwardEvent,
 		"brokers", len(brokers),
 	)
 
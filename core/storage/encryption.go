@@ -0,0 +1,266 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+)
+
+// encryptedPrefix is the prefix of encrypted values. Encrypted values have the format
+// enc2:<KEK ID>:<wrapped data key>:<encrypted value>
+// The encrypted value is authenticated together with the Redis key and the field of the value, so that it can not be
+// moved to another field or record.
+const encryptedPrefix = "enc2:"
+
+// unboundPrefix is the prefix of values that were encrypted before they were bound to their key and field. These
+// values can still be decrypted, and are encrypted again when they are re-wrapped.
+const unboundPrefix = "enc:"
+
+// KEK is a key-encryption key that wraps the data keys of encrypted values
+type KEK struct {
+	// ID is the version of the KEK. It is stored with the encrypted values, so that the KEK can be rotated
+	ID  string
+	Key []byte
+}
+
+// KeyRing holds the KEK that is used to encrypt values, and older KEKs that are still needed to decrypt values
+// that have not been re-wrapped yet.
+type KeyRing struct {
+	current string
+	keks    map[string]cipher.AEAD
+}
+
+// NewKeyRing returns a KeyRing that encrypts with the current KEK, and decrypts with the current and the previous KEKs
+func NewKeyRing(current KEK, previous ...KEK) (*KeyRing, error) {
+	k := &KeyRing{
+		current: current.ID,
+		keks:    make(map[string]cipher.AEAD),
+	}
+	for _, kek := range append([]KEK{current}, previous...) {
+		if kek.ID == "" || strings.Contains(kek.ID, ":") {
+			return nil, errors.NewErrInvalidArgument("KEK ID", "must be non-empty and can not contain colons")
+		}
+		if _, ok := k.keks[kek.ID]; ok {
+			return nil, errors.NewErrInvalidArgument("KEK ID", fmt.Sprintf("%s is used more than once", kek.ID))
+		}
+		aead, err := newAEAD(kek.Key)
+		if err != nil {
+			return nil, errors.NewErrInvalidArgument(fmt.Sprintf("KEK %s", kek.ID), err.Error())
+		}
+		k.keks[kek.ID] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyRing parses a KeyRing from a list of KEKs in the format <ID>:<hex key>, separated by commas or newlines.
+// The first KEK is the current KEK. Empty lines and lines that start with # are ignored.
+func ParseKeyRing(str string) (*KeyRing, error) {
+	var keks []KEK
+	for _, line := range strings.FieldsFunc(str, func(r rune) bool { return r == ',' || r == '\n' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, errors.NewErrInvalidArgument("KEK", "should be in the format <ID>:<hex key>")
+		}
+		key, err := hex.DecodeString(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.NewErrInvalidArgument(fmt.Sprintf("KEK %s", parts[0]), "invalid hex key")
+		}
+		keks = append(keks, KEK{ID: strings.TrimSpace(parts[0]), Key: key})
+	}
+	if len(keks) == 0 {
+		return nil, errors.NewErrInvalidArgument("KeyRing", "no KEKs")
+	}
+	return NewKeyRing(keks[0], keks[1:]...)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+}
+
+// location returns the additional data that binds an encrypted value to its key and field
+func location(key, field string) []byte {
+	return []byte(key + "\x00" + field)
+}
+
+// IsEncrypted returns true if the value was encrypted by a KeyRing
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) || strings.HasPrefix(value, unboundPrefix)
+}
+
+// isBound returns true if the encrypted value is bound to its key and field
+func isBound(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// CurrentID returns the ID of the KEK that is used to encrypt values
+func (k *KeyRing) CurrentID() string {
+	return k.current
+}
+
+// Encrypt encrypts the value of the field of the given key with a new data key, and wraps the data key with the
+// current KEK. The encrypted value can only be decrypted for the same key and field.
+func (k *KeyRing) Encrypt(value, key, field string) (string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := seal(dataAEAD, []byte(value), location(key, field))
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := seal(k.keks[k.current], dataKey, nil)
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + k.current + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(encrypted), nil
+}
+
+// unwrap returns the ID of the KEK, the data key and the encrypted value of an encrypted value
+func (k *KeyRing) unwrap(value string) (kekID string, dataKey []byte, encrypted string, err error) {
+	parts := strings.SplitN(strings.SplitN(value, ":", 2)[1], ":", 3)
+	if len(parts) != 3 {
+		return "", nil, "", errors.NewErrInvalidArgument("Encrypted value", "invalid format")
+	}
+	kekID, encrypted = parts[0], parts[2]
+	kek, ok := k.keks[kekID]
+	if !ok {
+		return "", nil, "", errors.NewErrNotFound(fmt.Sprintf("KEK %s", kekID))
+	}
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, "", errors.NewErrInvalidArgument("Encrypted value", "invalid data key")
+	}
+	dataKey, err = open(kek, wrapped, nil)
+	if err != nil {
+		return "", nil, "", errors.Wrap(err, fmt.Sprintf("Could not unwrap data key with KEK %s", kekID))
+	}
+	return
+}
+
+// Decrypt decrypts the value of the field of the given key. Values that are not encrypted are returned as they are.
+func (k *KeyRing) Decrypt(value, key, field string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	_, dataKey, encrypted, err := k.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", errors.NewErrInvalidArgument("Encrypted value", "invalid ciphertext")
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	var additionalData []byte
+	if isBound(value) {
+		additionalData = location(key, field)
+	}
+	plaintext, err := open(dataAEAD, ciphertext, additionalData)
+	if err != nil {
+		return "", errors.Wrap(err, "Could not decrypt value")
+	}
+	return string(plaintext), nil
+}
+
+// Rewrap wraps the data key of the value of the field of the given key with the current KEK, without decrypting the
+// value itself. Values that are not encrypted yet, or that are not bound to their key and field, are encrypted. It
+// returns false if the value did not change.
+func (k *KeyRing) Rewrap(value, key, field string) (string, bool, error) {
+	if !IsEncrypted(value) {
+		encrypted, err := k.Encrypt(value, key, field)
+		return encrypted, err == nil, err
+	}
+	if !isBound(value) {
+		decrypted, err := k.Decrypt(value, key, field)
+		if err != nil {
+			return "", false, err
+		}
+		encrypted, err := k.Encrypt(decrypted, key, field)
+		return encrypted, err == nil, err
+	}
+	kekID, dataKey, encrypted, err := k.unwrap(value)
+	if err != nil {
+		return "", false, err
+	}
+	if kekID == k.current {
+		return value, false, nil
+	}
+	wrapped, err := seal(k.keks[k.current], dataKey, nil)
+	if err != nil {
+		return "", false, err
+	}
+	return encryptedPrefix + k.current + ":" + base64.RawStdEncoding.EncodeToString(wrapped) + ":" + encrypted, true, nil
+}
+
+// EncryptFields encrypts the non-empty values of the given fields of the given key that are not encrypted yet
+func (k *KeyRing) EncryptFields(key string, obj map[string]string, fields ...string) error {
+	for _, field := range fields {
+		value, ok := obj[field]
+		if !ok || value == "" || IsEncrypted(value) {
+			continue
+		}
+		encrypted, err := k.Encrypt(value, key, field)
+		if err != nil {
+			return err
+		}
+		obj[field] = encrypted
+	}
+	return nil
+}
+
+// DecryptFields decrypts the values of the given fields of the given key
+func (k *KeyRing) DecryptFields(key string, obj map[string]string, fields ...string) error {
+	for _, field := range fields {
+		value, ok := obj[field]
+		if !ok || !IsEncrypted(value) {
+			continue
+		}
+		decrypted, err := k.Decrypt(value, key, field)
+		if err != nil {
+			return err
+		}
+		obj[field] = decrypted
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+var (
+	testKEK1 = KEK{ID: "1", Key: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}}
+	testKEK2 = KEK{ID: "2", Key: []byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}}
+)
+
+func TestNewKeyRing(t *testing.T) {
+	a := New(t)
+
+	_, err := NewKeyRing(KEK{ID: "", Key: testKEK1.Key})
+	a.So(err, ShouldNotBeNil)
+	_, err = NewKeyRing(KEK{ID: "a:b", Key: testKEK1.Key})
+	a.So(err, ShouldNotBeNil)
+	_, err = NewKeyRing(KEK{ID: "1", Key: []byte{0x01, 0x02}})
+	a.So(err, ShouldNotBeNil)
+	_, err = NewKeyRing(testKEK1, testKEK1)
+	a.So(err, ShouldNotBeNil)
+
+	keys, err := NewKeyRing(testKEK2, testKEK1)
+	a.So(err, ShouldBeNil)
+	a.So(keys.CurrentID(), ShouldEqual, "2")
+}
+
+func TestParseKeyRing(t *testing.T) {
+	a := New(t)
+
+	_, err := ParseKeyRing("")
+	a.So(err, ShouldNotBeNil)
+	_, err = ParseKeyRing("0102030405060708")
+	a.So(err, ShouldNotBeNil)
+	_, err = ParseKeyRing("1:nothex")
+	a.So(err, ShouldNotBeNil)
+
+	keys, err := ParseKeyRing("2:08070605040302010807060504030201,1:01020304050607080102030405060708")
+	a.So(err, ShouldBeNil)
+	a.So(keys.CurrentID(), ShouldEqual, "2")
+
+	keys, err = ParseKeyRing("# KEKs\n2: 08070605040302010807060504030201\n\n1: 01020304050607080102030405060708\n")
+	a.So(err, ShouldBeNil)
+	a.So(keys.CurrentID(), ShouldEqual, "2")
+}
+
+func TestKeyRingEncryptDecrypt(t *testing.T) {
+	a := New(t)
+
+	keys, _ := NewKeyRing(testKEK1)
+
+	encrypted, err := keys.Encrypt("01020304050607080102030405060708", "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(IsEncrypted(encrypted), ShouldBeTrue)
+	a.So(encrypted, ShouldStartWith, "enc2:1:")
+	a.So(encrypted, ShouldNotContainSubstring, "01020304050607080102030405060708")
+
+	// Every value has its own data key
+	other, _ := keys.Encrypt("01020304050607080102030405060708", "devices:app:dev", "app_key")
+	a.So(other, ShouldNotEqual, encrypted)
+
+	decrypted, err := keys.Decrypt(encrypted, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "01020304050607080102030405060708")
+
+	// Plain values are not changed
+	decrypted, err = keys.Decrypt("01020304050607080102030405060708", "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "01020304050607080102030405060708")
+
+	// Unknown KEK
+	otherKeys, _ := NewKeyRing(testKEK2)
+	_, err = otherKeys.Decrypt(encrypted, "devices:app:dev", "app_key")
+	a.So(err, ShouldNotBeNil)
+
+	// Wrong KEK with the same ID
+	wrongKeys, _ := NewKeyRing(KEK{ID: "1", Key: testKEK2.Key})
+	_, err = wrongKeys.Decrypt(encrypted, "devices:app:dev", "app_key")
+	a.So(err, ShouldNotBeNil)
+
+	// Tampered value
+	_, err = keys.Decrypt(encrypted[:len(encrypted)-2]+"AA", "devices:app:dev", "app_key")
+	a.So(err, ShouldNotBeNil)
+	_, err = keys.Decrypt("enc2:1:invalid", "devices:app:dev", "app_key")
+	a.So(err, ShouldNotBeNil)
+
+	// Value that was moved to another field or record
+	_, err = keys.Decrypt(encrypted, "devices:app:dev", "nwk_s_key")
+	a.So(err, ShouldNotBeNil)
+	_, err = keys.Decrypt(encrypted, "devices:app:other-dev", "app_key")
+	a.So(err, ShouldNotBeNil)
+}
+
+// encryptUnbound encrypts the value in the format that does not bind values to their key and field
+func encryptUnbound(k *KeyRing, value string) string {
+	dataKey := make([]byte, 32)
+	dataAEAD, _ := newAEAD(dataKey)
+	encrypted, _ := seal(dataAEAD, []byte(value), nil)
+	wrapped, _ := seal(k.keks[k.current], dataKey, nil)
+	return unboundPrefix + k.current + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(encrypted)
+}
+
+func TestKeyRingUnbound(t *testing.T) {
+	a := New(t)
+
+	keys, _ := NewKeyRing(testKEK1)
+	unbound := encryptUnbound(keys, "secret")
+	a.So(IsEncrypted(unbound), ShouldBeTrue)
+
+	decrypted, err := keys.Decrypt(unbound, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "secret")
+
+	// Values that are not bound are encrypted again, even if they use the current KEK
+	rewrapped, changed, err := keys.Rewrap(unbound, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldBeTrue)
+	a.So(rewrapped, ShouldStartWith, "enc2:1:")
+	_, err = keys.Decrypt(rewrapped, "devices:app:dev", "nwk_s_key")
+	a.So(err, ShouldNotBeNil)
+	decrypted, err = keys.Decrypt(rewrapped, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "secret")
+}
+
+func TestKeyRingRewrap(t *testing.T) {
+	a := New(t)
+
+	oldKeys, _ := NewKeyRing(testKEK1)
+	encrypted, _ := oldKeys.Encrypt("secret", "devices:app:dev", "app_key")
+
+	keys, _ := NewKeyRing(testKEK2, testKEK1)
+
+	rewrapped, changed, err := keys.Rewrap(encrypted, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldBeTrue)
+	a.So(rewrapped, ShouldStartWith, "enc2:2:")
+
+	// The value itself is not encrypted again
+	a.So(strings.SplitN(rewrapped, ":", 4)[3], ShouldEqual, strings.SplitN(encrypted, ":", 4)[3])
+
+	decrypted, err := keys.Decrypt(rewrapped, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "secret")
+
+	// The old KEK is no longer needed
+	newKeys, _ := NewKeyRing(testKEK2)
+	decrypted, err = newKeys.Decrypt(rewrapped, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(decrypted, ShouldEqual, "secret")
+
+	// Values that already use the current KEK don't change
+	same, changed, err := keys.Rewrap(rewrapped, "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldBeFalse)
+	a.So(same, ShouldEqual, rewrapped)
+
+	// Plain values are encrypted
+	plain, changed, err := keys.Rewrap("secret", "devices:app:dev", "app_key")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldBeTrue)
+	a.So(plain, ShouldStartWith, "enc2:2:")
+}
+
+func TestKeyRingFields(t *testing.T) {
+	a := New(t)
+
+	keys, _ := NewKeyRing(testKEK1)
+
+	obj := map[string]string{
+		"app_key":     "01020304050607080102030405060708",
+		"nwk_s_key":   "",
+		"description": "secret",
+	}
+	err := keys.EncryptFields("devices:app:dev", obj, "app_key", "nwk_s_key", "app_s_key")
+	a.So(err, ShouldBeNil)
+	a.So(IsEncrypted(obj["app_key"]), ShouldBeTrue)
+	a.So(obj["nwk_s_key"], ShouldEqual, "")
+	a.So(obj, ShouldNotContainKey, "app_s_key")
+	a.So(obj["description"], ShouldEqual, "secret")
+
+	// Encrypted fields are not encrypted twice
+	encrypted := obj["app_key"]
+	keys.EncryptFields("devices:app:dev", obj, "app_key")
+	a.So(obj["app_key"], ShouldEqual, encrypted)
+
+	err = keys.DecryptFields("devices:app:dev", obj, "app_key", "nwk_s_key", "app_s_key")
+	a.So(err, ShouldBeNil)
+	a.So(obj["app_key"], ShouldEqual, "01020304050607080102030405060708")
+}
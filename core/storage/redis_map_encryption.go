@@ -0,0 +1,156 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	redis "gopkg.in/redis.v5"
+)
+
+// SetEncryptedFields sets the fields that contain sensitive values. If a KeyRing is set, these fields are encrypted
+// before they are stored. When a record is updated, the encrypted fields that were stored in plaintext or with an old
+// KEK are encrypted with the current KEK as well, even if they are not updated themselves.
+func (s *RedisMapStore) SetEncryptedFields(fields ...string) {
+	s.encryptedFields = fields
+}
+
+// SetKeyRing sets the KeyRing that is used to encrypt and decrypt the encrypted fields
+func (s *RedisMapStore) SetKeyRing(keys *KeyRing) {
+	s.keys = keys
+}
+
+func (s *RedisMapStore) encode(key string, input interface{}, properties ...string) (map[string]string, error) {
+	vmap, err := s.encoder(input, properties...)
+	if err != nil {
+		return nil, err
+	}
+	if s.keys != nil {
+		if err := s.keys.EncryptFields(key, vmap, s.encryptedFields...); err != nil {
+			return nil, err
+		}
+	}
+	return vmap, nil
+}
+
+func (s *RedisMapStore) decode(key string, input map[string]string) (interface{}, error) {
+	if s.keys != nil {
+		if err := s.keys.DecryptFields(key, input, s.encryptedFields...); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, field := range s.encryptedFields {
+			if IsEncrypted(input[field]) {
+				return nil, errors.NewErrInternal("Record contains encrypted values, but no KEK is configured")
+			}
+		}
+	}
+	return s.decoder(input)
+}
+
+// Rewrap wraps the data keys of the encrypted fields of all documents matching the selector with the current KEK,
+// and encrypts the fields that are not encrypted yet or that are not bound to their key and field. It returns the number of documents that changed.
+func (s *RedisMapStore) Rewrap(selector string) (changed int, err error) {
+	if s.keys == nil {
+		return 0, errors.NewErrInternal("No KEK configured")
+	}
+	if len(s.encryptedFields) == 0 {
+		return 0, nil
+	}
+	if selector == "" {
+		selector = "*"
+	}
+	if !strings.HasPrefix(selector, s.prefix) {
+		selector = s.prefix + selector
+	}
+
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(cursor, selector, 0).Result()
+		if err != nil {
+			return changed, err
+		}
+
+		for _, key := range keys {
+			rewrapped, err := s.rewrap(key)
+			if err != nil {
+				return changed, err
+			}
+			if rewrapped {
+				changed++
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return changed, nil
+}
+
+func (s *RedisMapStore) rewrap(key string) (rewrapped bool, err error) {
+	err = s.client.Watch(func(tx *redis.Tx) error { // Make sure the document is not changed while we're re-wrapping it
+		update, err := s.rewrapFields(tx, key, s.encryptedFields...)
+		if err != nil {
+			return err
+		}
+		if len(update) == 0 {
+			return nil
+		}
+		_, err = tx.Pipelined(func(pipe *redis.Pipeline) error {
+			pipe.HMSet(key, update)
+			return nil
+		})
+		if err == nil {
+			rewrapped = true
+		}
+		return err
+	}, key)
+	return
+}
+
+// rewrapFields returns the stored values of the given encrypted fields of the key that have to be encrypted or
+// re-wrapped with the current KEK
+func (s *RedisMapStore) rewrapFields(tx *redis.Tx, key string, fields ...string) (map[string]string, error) {
+	update := make(map[string]string)
+	if len(fields) == 0 {
+		return update, nil
+	}
+	values, err := tx.HMGet(key, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, field := range fields {
+		value, ok := values[i].(string)
+		if !ok || value == "" {
+			continue
+		}
+		value, changed, err := s.keys.Rewrap(value, key, field)
+		if err != nil {
+			return nil, errors.Wrap(err, key)
+		}
+		if changed {
+			update[field] = value
+		}
+	}
+	return update, nil
+}
+
+// storedFieldUpdates returns the encrypted fields of the key that are not in vmap, but that are stored in plaintext
+// or with an old KEK. These are encrypted with the current KEK when the record is updated.
+func (s *RedisMapStore) storedFieldUpdates(tx *redis.Tx, key string, vmap map[string]string) (map[string]string, error) {
+	if s.keys == nil {
+		return nil, nil
+	}
+	var fields []string
+	for _, field := range s.encryptedFields {
+		if _, ok := vmap[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	return s.rewrapFields(tx, key, fields...)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package storage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/assertions"
+)
+
+type testEncryptedStruct struct {
+	Name string `redis:"name"`
+	Key  string `redis:"key"`
+}
+
+func TestRedisMapStoreEncryption(t *testing.T) {
+	a := New(t)
+	c := getRedisClient()
+
+	defer func() {
+		c.Del("test-redis-map-store-encryption:plain").Result()
+		c.Del("test-redis-map-store-encryption:encrypted").Result()
+	}()
+
+	s := NewRedisMapStore(c, "test-redis-map-store-encryption")
+	s.SetBase(testEncryptedStruct{}, "")
+	s.SetEncryptedFields("key")
+
+	// Without KeyRing
+	err := s.Create("plain", testEncryptedStruct{Name: "plain", Key: "secret"})
+	a.So(err, ShouldBeNil)
+	a.So(c.HGet("test-redis-map-store-encryption:plain", "key").Val(), ShouldEqual, "secret")
+
+	keys1, _ := NewKeyRing(testKEK1)
+	s.SetKeyRing(keys1)
+
+	// With KeyRing
+	err = s.Create("encrypted", testEncryptedStruct{Name: "encrypted", Key: "secret"})
+	a.So(err, ShouldBeNil)
+	stored := c.HGet("test-redis-map-store-encryption:encrypted", "key").Val()
+	a.So(stored, ShouldStartWith, "enc2:1:")
+	a.So(c.HGet("test-redis-map-store-encryption:encrypted", "name").Val(), ShouldEqual, "encrypted")
+
+	res, err := s.Get("encrypted")
+	a.So(err, ShouldBeNil)
+	a.So(res.(testEncryptedStruct).Key, ShouldEqual, "secret")
+
+	res, err = s.GetFields("encrypted", "key")
+	a.So(err, ShouldBeNil)
+	a.So(res.(testEncryptedStruct).Key, ShouldEqual, "secret")
+
+	// Plain values can still be read
+	res, err = s.Get("plain")
+	a.So(err, ShouldBeNil)
+	a.So(res.(testEncryptedStruct).Key, ShouldEqual, "secret")
+
+	// Plain values are encrypted when the record is updated, even if they are not updated themselves
+	err = s.Update("plain", testEncryptedStruct{Name: "updated"}, "name")
+	a.So(err, ShouldBeNil)
+	a.So(c.HGet("test-redis-map-store-encryption:plain", "key").Val(), ShouldStartWith, "enc2:1:")
+	res, err = s.Get("plain")
+	a.So(err, ShouldBeNil)
+	a.So(res.(testEncryptedStruct).Key, ShouldEqual, "secret")
+	a.So(res.(testEncryptedStruct).Name, ShouldEqual, "updated")
+
+	// Encrypted values can not be copied to another record
+	c.HSet("test-redis-map-store-encryption:plain", "key", stored)
+	_, err = s.Get("plain")
+	a.So(err, ShouldNotBeNil)
+	c.HSet("test-redis-map-store-encryption:plain", "key", "secret")
+
+	// Rotate the KEK
+	keys2, _ := NewKeyRing(testKEK2, testKEK1)
+	s.SetKeyRing(keys2)
+
+	changed, err := s.Rewrap("")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldEqual, 2)
+	a.So(c.HGet("test-redis-map-store-encryption:plain", "key").Val(), ShouldStartWith, "enc2:2:")
+	a.So(c.HGet("test-redis-map-store-encryption:encrypted", "key").Val(), ShouldStartWith, "enc2:2:")
+
+	changed, err = s.Rewrap("")
+	a.So(err, ShouldBeNil)
+	a.So(changed, ShouldEqual, 0)
+
+	// The old KEK can be removed
+	keys3, _ := NewKeyRing(testKEK2)
+	s.SetKeyRing(keys3)
+	list, err := s.List("", nil)
+	a.So(err, ShouldBeNil)
+	a.So(list, ShouldHaveLength, 2)
+	for _, item := range list {
+		a.So(item.(testEncryptedStruct).Key, ShouldEqual, "secret")
+	}
+
+	// Without KeyRing, encrypted values can not be read
+	s.SetKeyRing(nil)
+	_, err = s.Get("encrypted")
+	a.So(err, ShouldNotBeNil)
+}
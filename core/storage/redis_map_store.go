@@ -19,6 +19,9 @@ type RedisMapStore struct {
 	encoder    func(input interface{}, properties ...string) (map[string]string, error)
 	decoder    func(input map[string]string) (output interface{}, err error)
 	migrations map[string]MigrateFunction
+
+	keys            *KeyRing
+	encryptedFields []string
 }
 
 // NewRedisMapStore returns a new RedisMapStore that talks to the given Redis client and respects the given prefix
@@ -96,7 +99,7 @@ func (s *RedisMapStore) GetAll(keys []string, options *ListOptions) ([]interface
 	for i, key := range selectedKeys {
 		if result, err := cmds[key].Result(); err == nil {
 			result, _ = s.migrate(key, result)
-			if result, err := s.decoder(result); err == nil {
+			if result, err := s.decode(key, result); err == nil {
 				results[i] = result
 			}
 		}
@@ -129,6 +132,47 @@ func (s *RedisMapStore) List(selector string, options *ListOptions) ([]interface
 	return s.GetAll(allKeys, options)
 }
 
+// Range calls fn with the results matching the selector, prepending the prefix to the selector if necessary. The
+// results are read in batches of about batchSize, following a Redis SCAN cursor, so that large selections are not
+// read at once or scanned again for every batch. The results are not sorted. Range stops when fn returns an error.
+func (s *RedisMapStore) Range(selector string, batchSize int64, fn func(results []interface{}) error) error {
+	if selector == "" {
+		selector = "*"
+	}
+	if !strings.HasPrefix(selector, s.prefix) {
+		selector = s.prefix + selector
+	}
+	seen := make(map[string]struct{})
+	var cursor uint64
+	for {
+		keys, next, err := s.client.Scan(cursor, selector, batchSize).Result()
+		if err != nil {
+			return err
+		}
+		batch := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if _, ok := seen[key]; ok { // SCAN can return a key more than once
+				continue
+			}
+			seen[key] = struct{}{}
+			batch = append(batch, key)
+		}
+		results, err := s.GetAll(batch, nil)
+		if err != nil {
+			return err
+		}
+		if len(results) > 0 {
+			if err := fn(results); err != nil {
+				return err
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
 // Get one result, prepending the prefix to the key if necessary
 // This function will migrate outdated results to newer versions if migrations are set
 func (s *RedisMapStore) Get(key string) (interface{}, error) {
@@ -143,7 +187,7 @@ func (s *RedisMapStore) Get(key string) (interface{}, error) {
 		return nil, err
 	}
 	result, _ = s.migrate(key, result)
-	i, err := s.decoder(result)
+	i, err := s.decode(key, result)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +213,7 @@ func (s *RedisMapStore) GetFields(key string, fields ...string) (interface{}, er
 			res[field] = str
 		}
 	}
-	i, err := s.decoder(res)
+	i, err := s.decode(key, res)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +237,7 @@ func (s *RedisMapStore) Create(key string, value interface{}, properties ...stri
 		}
 	}
 
-	vmap, err := s.encoder(value, properties...)
+	vmap, err := s.encode(key, value, properties...)
 	if err != nil {
 		return err
 	}
@@ -241,7 +285,7 @@ func (s *RedisMapStore) Update(key string, value interface{}, properties ...stri
 		}
 	}
 
-	vmap, err := s.encoder(value, properties...)
+	vmap, err := s.encode(key, value, properties...)
 	if err != nil {
 		return err
 	}
@@ -261,6 +305,13 @@ func (s *RedisMapStore) Update(key string, value interface{}, properties ...stri
 		if !exists {
 			return errors.NewErrNotFound(key)
 		}
+		stored, err := s.storedFieldUpdates(tx, key, vmap)
+		if err != nil {
+			return err
+		}
+		for field, value := range stored {
+			vmap[field] = value
+		}
 		_, err = tx.Pipelined(func(pipe *redis.Pipeline) error {
 			pipe.HMSet(key, vmap)
 			return nil
@@ -168,3 +168,38 @@ func TestRedisMapStore(t *testing.T) {
 	}
 
 }
+
+func TestRedisMapStoreRange(t *testing.T) {
+	a := New(t)
+	c := getRedisClient()
+	s := NewRedisMapStore(c, "test-redis-map-store-range")
+	s.SetBase(testRedisStruct{}, "")
+
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("app:%d", i)
+		s.Create(key, &testRedisStruct{Name: key})
+		defer c.Del("test-redis-map-store-range:" + key)
+	}
+	s.Create("other:1", &testRedisStruct{Name: "other:1"})
+	defer c.Del("test-redis-map-store-range:other:1")
+
+	names := make(map[string]bool)
+	err := s.Range("app:*", 10, func(results []interface{}) error {
+		for _, result := range results {
+			names[result.(testRedisStruct).Name] = true
+		}
+		return nil
+	})
+	a.So(err, ShouldBeNil)
+	a.So(names, ShouldHaveLength, 25)
+	a.So(names, ShouldNotContainKey, "other:1")
+
+	// Range stops at the first error
+	var calls int
+	err = s.Range("app:*", 10, func(results []interface{}) error {
+		calls++
+		return errors.New("stop")
+	})
+	a.So(err, ShouldNotBeNil)
+	a.So(calls, ShouldEqual, 1)
+}
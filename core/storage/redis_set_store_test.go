@@ -26,6 +26,10 @@ func TestRedisSetStore(t *testing.T) {
 		contains, err := s.Contains("test", "value")
 		a.So(err, ShouldBeNil)
 		a.So(contains, ShouldBeFalse)
+
+		count, err := s.Count("test")
+		a.So(err, ShouldBeNil)
+		a.So(count, ShouldEqual, 0)
 	}
 
 	defer func() {
@@ -60,6 +64,10 @@ func TestRedisSetStore(t *testing.T) {
 		res, err := s.Get("test")
 		a.So(err, ShouldBeNil)
 		a.So(res, ShouldHaveLength, 2)
+
+		count, err := s.Count("test")
+		a.So(err, ShouldBeNil)
+		a.So(count, ShouldEqual, 2)
 	}
 
 	// Remove
@@ -4,6 +4,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"strings"
@@ -166,6 +167,33 @@ type NetID [3]byte
 
 var emptyNetID NetID
 
+// ParseNetID parses a 24-bit hex-encoded string to a NetID
+func ParseNetID(input string) (netID NetID, err error) {
+	bytes, err := ParseHEX(input, 3)
+	if err != nil {
+		return
+	}
+	copy(netID[:], bytes)
+	return
+}
+
+// Type returns the type of the NetID
+func (n NetID) Type() int {
+	return int(n[0] >> 5)
+}
+
+// DevAddrPrefix returns the prefix of the DevAddrs of the network with this NetID
+func (n NetID) DevAddrPrefix() DevAddrPrefix {
+	t := uint(n.Type())
+	id := uint32(n[0]&0x1f)<<16 | uint32(n[1])<<8 | uint32(n[2])
+	nwkID := id & (1<<nwkIDBits[t] - 1)
+	typePrefix := uint32(0xff<<(8-t)) & 0xff
+	var prefix DevAddrPrefix
+	binary.BigEndian.PutUint32(prefix.DevAddr[:], typePrefix<<24|nwkID<<(31-t-nwkIDBits[t]))
+	prefix.Length = int(t + 1 + nwkIDBits[t])
+	return prefix
+}
+
 // Bytes returns the NetID as a byte slice
 func (n NetID) Bytes() []byte {
 	return n[:]
@@ -182,3 +182,23 @@ func TestNetID(t *testing.T) {
 	a.So(err, ShouldBeNil)
 	a.So(uOut, ShouldResemble, &nid)
 }
+
+func TestNetIDDevAddrPrefix(t *testing.T) {
+	a := New(t)
+
+	netID, err := ParseNetID("000013")
+	a.So(err, ShouldBeNil)
+	a.So(netID.Type(), ShouldEqual, 0)
+	a.So(netID.DevAddrPrefix().String(), ShouldEqual, "26000000/7")
+
+	netID = NetID{0x20, 0x00, 0x02}
+	a.So(netID.Type(), ShouldEqual, 1)
+	a.So(netID.DevAddrPrefix().String(), ShouldEqual, "82000000/8")
+
+	netID = NetID{0xE0, 0x00, 0x03}
+	a.So(netID.Type(), ShouldEqual, 7)
+	a.So(netID.DevAddrPrefix().String(), ShouldEqual, "FE000180/25")
+
+	_, err = ParseNetID("no-net-id")
+	a.So(err, ShouldNotBeNil)
+}
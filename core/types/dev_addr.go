@@ -4,6 +4,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"regexp"
@@ -217,3 +218,30 @@ func (addr DevAddr) WithPrefix(prefix DevAddrPrefix) (prefixed DevAddr) {
 func (addr DevAddr) HasPrefix(prefix DevAddrPrefix) bool {
 	return addr.Mask(prefix.Length) == prefix.DevAddr.Mask(prefix.Length)
 }
+
+// nwkIDBits is the number of bits of the NwkID in a DevAddr for each NetID type
+var nwkIDBits = [8]uint{6, 6, 9, 11, 12, 13, 15, 17}
+
+// NetIDType returns the type of the NetID that the DevAddr belongs to. The type is encoded in the DevAddr as the
+// number of leading ones.
+func (addr DevAddr) NetIDType() int {
+	for i := uint(0); i < 7; i++ {
+		if addr[0]&(0x80>>i) == 0 {
+			return int(i)
+		}
+	}
+	return 7
+}
+
+// NwkID returns the network identifier in the DevAddr, which consists of the least significant bits of the NetID
+func (addr DevAddr) NwkID() uint32 {
+	t := uint(addr.NetIDType())
+	return binary.BigEndian.Uint32(addr[:]) << (t + 1) >> (32 - nwkIDBits[t])
+}
+
+// NetID returns the NetID of the network that the DevAddr belongs to. For NetID types 3 to 7 the DevAddr only
+// contains the least significant bits of the NetID, so the returned NetID is only correct if the other bits are zero.
+func (addr DevAddr) NetID() NetID {
+	id := uint32(addr.NetIDType())<<21 | addr.NwkID()
+	return NetID{byte(id >> 16), byte(id >> 8), byte(id)}
+}
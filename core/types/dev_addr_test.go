@@ -175,3 +175,36 @@ func TestMarshalUnmarshalDevAddrPrefix(t *testing.T) {
 	a.So(prefix.DevAddr, ShouldEqual, DevAddr{128, 0, 0, 0})
 	a.So(prefix.Length, ShouldEqual, 1)
 }
+
+func TestDevAddrNetID(t *testing.T) {
+	a := New(t)
+
+	// The Things Network
+	addr := DevAddr{0x26, 0x01, 0x23, 0x45}
+	a.So(addr.NetIDType(), ShouldEqual, 0)
+	a.So(addr.NwkID(), ShouldEqual, 0x13)
+	a.So(addr.NetID(), ShouldEqual, NetID{0x00, 0x00, 0x13})
+
+	// Type 1
+	addr = DevAddr{0x81, 0x23, 0x45, 0x67}
+	a.So(addr.NetIDType(), ShouldEqual, 1)
+	a.So(addr.NwkID(), ShouldEqual, 0x01)
+	a.So(addr.NetID(), ShouldEqual, NetID{0x20, 0x00, 0x01})
+
+	// Type 3
+	addr = DevAddr{0xE0, 0x2A, 0x00, 0x01}
+	a.So(addr.NetIDType(), ShouldEqual, 3)
+	a.So(addr.NwkID(), ShouldEqual, 0x15)
+	a.So(addr.NetID(), ShouldEqual, NetID{0x60, 0x00, 0x15})
+
+	// Type 7
+	addr = DevAddr{0xFE, 0x00, 0x01, 0x80}
+	a.So(addr.NetIDType(), ShouldEqual, 7)
+	a.So(addr.NwkID(), ShouldEqual, 0x03)
+	a.So(addr.NetID(), ShouldEqual, NetID{0xE0, 0x00, 0x03})
+
+	// The DevAddr has the prefix of its NetID
+	for _, addr := range []DevAddr{{0x26, 0x01, 0x23, 0x45}, {0x81, 0x23, 0x45, 0x67}, {0xC1, 0x23, 0x45, 0x67}, {0xE0, 0x2A, 0x00, 0x01}, {0xF0, 0x12, 0x34, 0x56}, {0xF8, 0x12, 0x34, 0x56}, {0xFC, 0x12, 0x34, 0x56}, {0xFE, 0x00, 0x01, 0x80}} {
+		a.So(addr.HasPrefix(addr.NetID().DevAddrPrefix()), ShouldBeTrue)
+	}
+}
@@ -17,6 +17,7 @@ const (
 type DownlinkMessage struct {
 	AppID         string                 `json:"app_id,omitempty"`
 	DevID         string                 `json:"dev_id,omitempty"`
+	MessageID     string                 `json:"message_id,omitempty"` // optional, identifies the message in a Handler group
 	FPort         uint8                  `json:"port"`
 	Confirmed     bool                   `json:"confirmed,omitempty"`
 	Schedule      ScheduleType           `json:"schedule,omitempty"` // allowed values: "replace" (default), "first", "last"
@@ -3,6 +3,8 @@
 
 package types
 
+import "time"
+
 // EventType represents the type of event
 type EventType string
 
@@ -18,9 +20,16 @@ const (
 	ActivationEvent      EventType = "activations"
 	ActivationErrorEvent EventType = "activations/errors"
 
+	QuotaExceededEvent EventType = "quota/exceeded"
+
 	CreateEvent EventType = "create"
 	UpdateEvent EventType = "update"
 	DeleteEvent EventType = "delete"
+
+	GatewayConnectEvent     EventType = "connect"
+	GatewayDisconnectEvent  EventType = "disconnect"
+	GatewayStatusStaleEvent EventType = "status-stale"
+	GatewayClockDriftEvent  EventType = "clock-drift"
 )
 
 // DeviceEvent represents an application-layer event message for a device event
@@ -43,6 +52,10 @@ type ActivationEventData struct {
 	DevEUI   DevEUI   `json:"dev_eui"`
 	DevAddr  DevAddr  `json:"dev_addr"`
 	Metadata Metadata `json:"metadata"`
+
+	// For rejected activations
+	Reason     string     `json:"reason,omitempty"`
+	RetryAfter *time.Time `json:"retry_after,omitempty"`
 }
 
 // DownlinkEventConfigInfo contains configuration information for a downlink message, all fields are optional
@@ -63,3 +76,20 @@ type DownlinkEventData struct {
 	GatewayID string                  `json:"gateway_id,omitempty"`
 	Config    DownlinkEventConfigInfo `json:"config,omitempty"`
 }
+
+// QuotaEventData is added to quota events
+type QuotaEventData struct {
+	ErrorEventData
+	Scope string    `json:"scope"`
+	Quota string    `json:"quota"`
+	Reset time.Time `json:"reset"`
+}
+
+// GatewayEventData is added to gateway events
+type GatewayEventData struct {
+	GatewayID  string    `json:"gateway_id"`
+	Time       JSONTime  `json:"time"`
+	Stream     string    `json:"stream,omitempty"`
+	LastStatus *JSONTime `json:"last_status,omitempty"`
+	ClockDrift int64     `json:"clock_drift,omitempty"` // in nanoseconds
+}
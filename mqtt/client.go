@@ -57,6 +57,11 @@ type Client interface {
 	UnsubscribeAppEvents(appID string, eventType types.EventType) Token
 	UnsubscribeDeviceEvents(appID string, devID string, eventType types.EventType) Token
 
+	// Gateway event pub/sub
+	PublishGatewayEvent(gatewayID string, eventType types.EventType, payload interface{}) Token
+	SubscribeGatewayEvents(gatewayID string, eventType types.EventType, handler GatewayEventHandler) Token
+	UnsubscribeGatewayEvents(gatewayID string, eventType types.EventType) Token
+
 	// Activation pub/sub
 	PublishActivation(payload types.Activation) Token
 	SubscribeDeviceActivations(appID string, devID string, handler ActivationHandler) Token
@@ -17,6 +17,9 @@ type AppEventHandler func(client Client, appID string, eventType types.EventType
 // DeviceEventHandler is called for events
 type DeviceEventHandler func(client Client, appID string, devID string, eventType types.EventType, payload []byte)
 
+// GatewayEventHandler is called for gateway events
+type GatewayEventHandler func(client Client, gatewayID string, eventType types.EventType, payload []byte)
+
 // PublishAppEvent publishes an event to the topic for application events of the given type
 // it will marshal the payload to json
 func (c *DefaultClient) PublishAppEvent(appID string, eventType types.EventType, payload interface{}) Token {
@@ -39,6 +42,17 @@ func (c *DefaultClient) PublishDeviceEvent(appID string, devID string, eventType
 	return c.publish(topic.String(), msg)
 }
 
+// PublishGatewayEvent publishes an event to the topic for gateway events of the given type
+// it will marshal the payload to json
+func (c *DefaultClient) PublishGatewayEvent(gatewayID string, eventType types.EventType, payload interface{}) Token {
+	topic := GatewayTopic{gatewayID, GatewayEvents, string(eventType)}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return &simpleToken{fmt.Errorf("Unable to marshal the message payload")}
+	}
+	return c.publish(topic.String(), msg)
+}
+
 // SubscribeAppEvents subscribes to events of the given type for the given application. In order to subscribe to
 // application events from all applications the user has access to, pass an empty string as appID.
 func (c *DefaultClient) SubscribeAppEvents(appID string, eventType types.EventType, handler AppEventHandler) Token {
@@ -68,6 +82,20 @@ func (c *DefaultClient) SubscribeDeviceEvents(appID string, devID string, eventT
 	})
 }
 
+// SubscribeGatewayEvents subscribes to events of the given type for the given gateway. In order to subscribe to
+// events from all gateways, pass an empty string as gatewayID.
+func (c *DefaultClient) SubscribeGatewayEvents(gatewayID string, eventType types.EventType, handler GatewayEventHandler) Token {
+	topic := GatewayTopic{gatewayID, GatewayEvents, string(eventType)}
+	return c.subscribe(topic.String(), func(mqtt MQTT.Client, msg MQTT.Message) {
+		topic, err := ParseGatewayTopic(msg.Topic())
+		if err != nil {
+			c.ctx.Warnf("Received message on invalid events topic: %s", msg.Topic())
+			return
+		}
+		handler(c, topic.GatewayID, types.EventType(topic.Field), msg.Payload())
+	})
+}
+
 // UnsubscribeAppEvents unsubscribes from the events that were subscribed to by SubscribeAppEvents
 func (c *DefaultClient) UnsubscribeAppEvents(appID string, eventType types.EventType) Token {
 	topic := ApplicationTopic{appID, AppEvents, string(eventType)}
@@ -79,3 +107,9 @@ func (c *DefaultClient) UnsubscribeDeviceEvents(appID string, devID string, even
 	topic := DeviceTopic{appID, devID, DeviceEvents, string(eventType)}
 	return c.unsubscribe(topic.String())
 }
+
+// UnsubscribeGatewayEvents unsubscribes from the events that were subscribed to by SubscribeGatewayEvents
+func (c *DefaultClient) UnsubscribeGatewayEvents(gatewayID string, eventType types.EventType) Token {
+	topic := GatewayTopic{gatewayID, GatewayEvents, string(eventType)}
+	return c.unsubscribe(topic.String())
+}
@@ -55,3 +55,24 @@ func TestPublishSubscribeDeviceEvents(t *testing.T) {
 	waitForOK(unsubToken, a)
 	wg.WaitFor(100 * time.Millisecond)
 }
+
+func TestPublishSubscribeGatewayEvents(t *testing.T) {
+	a := New(t)
+	c := NewClient(getLogger(t, "Test"), "test", "", "", fmt.Sprintf("tcp://%s", host))
+	c.Connect()
+	defer c.Disconnect()
+	var wg WaitGroup
+	wg.Add(1)
+	subToken := c.SubscribeGatewayEvents("gtw-id", "", func(_ Client, gatewayID string, eventType types.EventType, payload []byte) {
+		a.So(gatewayID, ShouldEqual, "gtw-id")
+		a.So(eventType, ShouldEqual, "some-event")
+		a.So(string(payload), ShouldEqual, `"payload"`)
+		wg.Done()
+	})
+	waitForOK(subToken, a)
+	pubToken := c.PublishGatewayEvent("gtw-id", "some-event", "payload")
+	waitForOK(pubToken, a)
+	unsubToken := c.UnsubscribeGatewayEvents("gtw-id", "")
+	waitForOK(unsubToken, a)
+	wg.WaitFor(100 * time.Millisecond)
+}
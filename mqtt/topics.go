@@ -122,3 +122,53 @@ func (t ApplicationTopic) String() string {
 	}
 	return topic
 }
+
+// GatewayTopicType represents the type of a gateway topic
+type GatewayTopicType string
+
+// Topic types for Gateways
+const (
+	GatewayEvents GatewayTopicType = "events"
+)
+
+// GatewayTopic represents an MQTT topic for gateways
+type GatewayTopic struct {
+	GatewayID string
+	Type      GatewayTopicType
+	Field     string
+}
+
+// ParseGatewayTopic parses an MQTT gateway topic string to a GatewayTopic struct
+func ParseGatewayTopic(topic string) (*GatewayTopic, error) {
+	pattern := regexp.MustCompile("^(gateways)/([0-9a-z](?:[_-]?[0-9a-z]){1,35}|\\+)/(events)([0-9a-z/-]+|/#)?$")
+	matches := pattern.FindStringSubmatch(topic)
+	if len(matches) < 4 {
+		return nil, fmt.Errorf("Invalid topic format")
+	}
+	var gatewayID string
+	if matches[2] != simpleWildcard {
+		gatewayID = matches[2]
+	}
+	topicType := GatewayTopicType(matches[3])
+	gatewayTopic := &GatewayTopic{gatewayID, topicType, ""}
+	if topicType == GatewayEvents && len(matches) > 4 {
+		gatewayTopic.Field = strings.Trim(matches[4], "/")
+	}
+	return gatewayTopic, nil
+}
+
+// String implements the Stringer interface
+func (t GatewayTopic) String() string {
+	gatewayID := simpleWildcard
+	if t.GatewayID != "" {
+		gatewayID = t.GatewayID
+	}
+	if t.Type == GatewayEvents && t.Field == "" {
+		t.Field = wildcard
+	}
+	topic := fmt.Sprintf("%s/%s/%s", "gateways", gatewayID, t.Type)
+	if t.Type == GatewayEvents && t.Field != "" {
+		topic += "/" + t.Field
+	}
+	return topic
+}
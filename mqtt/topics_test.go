@@ -139,3 +139,53 @@ func TestAppTopicParseAndString(t *testing.T) {
 	}
 
 }
+
+func TestParseGatewayTopicInvalid(t *testing.T) {
+	a := New(t)
+
+	_, err := ParseGatewayTopic("gateways/gtwid:Invalid/events")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseGatewayTopic("gateways/gtwid/randomstuff")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ParseGatewayTopic("appid/devices/devid/events")
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestGatewayTopicString(t *testing.T) {
+	a := New(t)
+
+	topic := &GatewayTopic{
+		GatewayID: "gtwid-1",
+		Type:      GatewayEvents,
+	}
+
+	a.So(topic.String(), ShouldResemble, "gateways/gtwid-1/events/#")
+
+	topic = &GatewayTopic{
+		GatewayID: "gtwid-1",
+		Type:      GatewayEvents,
+		Field:     "disconnect",
+	}
+
+	a.So(topic.String(), ShouldResemble, "gateways/gtwid-1/events/disconnect")
+}
+
+func TestGatewayTopicParseAndString(t *testing.T) {
+	a := New(t)
+
+	expectedList := []string{
+		"gateways/+/events/#",
+		"gateways/gtwid/events/#",
+		"gateways/+/events/status-stale",
+		"gateways/gtwid/events/clock-drift",
+	}
+
+	for _, expected := range expectedList {
+		topic, err := ParseGatewayTopic(expected)
+		a.So(err, ShouldBeNil)
+		a.So(topic.String(), ShouldEqual, expected)
+	}
+
+}
@@ -0,0 +1,85 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+var applicationsAuditLogCmd = &cobra.Command{
+	Use:   "audit-log [Device ID]",
+	Short: "Get the audit log of an application",
+	Long: `ttnctl applications audit-log can be used to get the changes that were made to the
+application and its devices on the Handler, NetworkServer and Discovery server.
+The values of keys are not included in the audit log.`,
+	Example: `$ ttnctl applications audit-log
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Found 2 audit log entries
+
+Time                          	Action         	DevID	Actor     	Service               	Changed
+2017-06-12 14:46:33 +0200 CEST	set_device     	test 	user:alice	handler:ttn-handler-eu	AppKey=<redacted> DevEUI=0004A30B001B7AD2
+2017-06-12 14:40:12 +0200 CEST	set_application	     	user:alice	handler:ttn-handler-eu	Decoder=function Decoder(bytes, port) {...
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 1)
+
+		var devID string
+		if len(args) == 1 {
+			devID = args[0]
+			if !api.ValidID(devID) {
+				ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+			}
+		}
+
+		appID := util.GetAppID(ctx)
+
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		entries, err := manager.GetAuditLog(appID, devID, limit)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get audit log.")
+		}
+
+		ctx.Infof("Found %d audit log entries", len(entries))
+
+		table := uitable.New()
+		table.MaxColWidth = 70
+		table.AddRow("Time", "Action", "DevID", "Actor", "Service", "Changed")
+		for _, entry := range entries {
+			var changed []string
+			for _, field := range entry.Changed {
+				changed = append(changed, fmt.Sprintf("%s=%s", field.Name, field.Value))
+			}
+			table.AddRow(
+				time.Unix(0, entry.Time).Round(time.Second),
+				entry.Action,
+				entry.DevId,
+				entry.Actor,
+				fmt.Sprintf("%s:%s", entry.ServiceName, entry.ServiceId),
+				strings.Join(changed, " "),
+			)
+		}
+
+		fmt.Println()
+		fmt.Println(table)
+		fmt.Println()
+	},
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsAuditLogCmd)
+	applicationsAuditLogCmd.Flags().Int("limit", 20, "Maximum number of entries")
+}
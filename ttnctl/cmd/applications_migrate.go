@@ -0,0 +1,91 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var applicationsMigrateCmd = &cobra.Command{
+	Use:   "migrate [Handler ID]",
+	Short: "Migrate this application to another handler",
+	Long: `ttnctl applications migrate can be used to move this application from the current handler to another handler.
+
+The application, its devices, their downlink queues and frame counters are exported from the current handler and
+imported into the other handler, so that devices do not have to join again. Until the other handler has imported the
+application, devices can not join and downlink is not sent. Afterwards, uplink messages are sent to the other handler. When the Brokers have seen the change (after a few minutes), the application can be unregistered
+from the old handler with ttnctl applications unregister.`,
+	Example: `$ ttnctl applications migrate ttn-handler-us-west
+Are you sure you want to migrate application test from ttn-handler-eu to ttn-handler-us-west?
+> yes
+  INFO Discovering Handler...                   Handler=ttn-handler-eu
+  INFO Connecting with Handler...               Handler=eu.thethings.network:1904
+  INFO Exported application                     AppID=test Handler=ttn-handler-eu
+  INFO Discovering Handler...                   Handler=ttn-handler-us-west
+  INFO Connecting with Handler...               Handler=us-west.thethings.network:1904
+  INFO Imported application                     AppID=test Handler=ttn-handler-us-west
+  INFO Discovering Handler...                   Handler=ttn-handler-eu
+  INFO Connecting with Handler...               Handler=eu.thethings.network:1904
+  INFO Migrated application                     AppID=test Handler=ttn-handler-us-west
+  INFO Use --handler-id ttn-handler-us-west for this application from now on
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		appID := util.GetAppID(ctx)
+		from, to := viper.GetString("handler-id"), args[0]
+		if from == to {
+			ctx.Fatalf("Application is already on %s", to)
+		}
+
+		if !confirm(fmt.Sprintf("Are you sure you want to migrate application %s from %s to %s?", appID, from, to)) {
+			ctx.Info("Not doing anything")
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		export, err := manager.ExportApplication(appID, to)
+		conn.Close()
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not export application")
+		}
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":   appID,
+			"Handler": from,
+		}).Info("Exported application")
+
+		conn, manager = util.GetHandlerManagerForID(ctx, appID, to)
+		receipt, err := manager.ImportApplication(export)
+		conn.Close()
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not import application")
+		}
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":   appID,
+			"Handler": to,
+		}).Info("Imported application")
+
+		conn, manager = util.GetHandlerManagerForID(ctx, appID, from)
+		defer conn.Close()
+		err = manager.ConfirmApplicationExport(receipt)
+		if err != nil {
+			ctx.WithError(err).Fatalf("Could not confirm the migration to %s with %s", to, from)
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":   appID,
+			"Handler": to,
+		}).Info("Migrated application")
+		ctx.Infof("Use --handler-id %s for this application from now on", to)
+	},
+}
+
+func init() {
+	applicationsCmd.AddCommand(applicationsMigrateCmd)
+}
@@ -46,6 +46,10 @@ function Decoder(bytes, port) {
 
 		ctx.Info("Found Application")
 
+		if app.Engine != "" {
+			ctx.WithField("Engine", app.Engine).Info("Payload functions engine")
+		}
+
 		if app.Decoder != "" {
 			ctx.Info("Decoder function")
 			fmt.Println(app.Decoder)
@@ -19,7 +19,11 @@ var applicationsPayloadFunctionsSetCmd = &cobra.Command{
 	Use:   "set [decoder/converter/validator/encoder] [file.js]",
 	Short: "Set payload functions of an application",
 	Long: `ttnctl pf set can be used to get or set payload functions of an application.
-The functions are read from the supplied file or from STDIN.`,
+The functions are read from the supplied file or from STDIN.
+
+With the --engine flag, the engine that runs the payload functions can be selected.
+The "otto" engine (default) supports ECMAScript 5.1, the "goja" engine supports
+ECMAScript 2015+ features such as let/const, arrow functions and typed arrays.`,
 	Example: `$ ttnctl applications pf set decoder
   INFO Discovering Handler...
   INFO Connecting with Handler...
@@ -70,6 +74,10 @@ Port: 1
 			ctx.WithError(err).Fatal("Could not get existing application.")
 		}
 
+		if engine, _ := cmd.Flags().GetString("engine"); engine != "" {
+			app.Engine = engine
+		}
+
 		function := args[0]
 
 		if len(args) == 2 {
@@ -217,6 +225,7 @@ Function read from %s:
 
 func init() {
 	applicationsPayloadFunctionsCmd.AddCommand(applicationsPayloadFunctionsSetCmd)
+	applicationsPayloadFunctionsSetCmd.Flags().String("engine", "", "The engine that runs the payload functions (otto or goja)")
 }
 
 func readFunction(ctx log.Interface) string {
@@ -0,0 +1,73 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"os"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var devicesExportCmd = &cobra.Command{
+	Use:   "export [file.csv|file.json]",
+	Short: "Export devices to a file",
+	Long: `ttnctl devices export can be used to export all devices of the current application to a file.
+
+The file has the same format as the files for ttnctl devices import, and includes the keys of the devices.
+Frame counters are not exported.`,
+	Example: `$ ttnctl devices export devices.csv
+  INFO Using Application                        AppEUI=70B3D57EF0000024 AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Exported devices                         AppID=test Devices=2 File=devices.csv
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		format, err := util.DeviceFileFormat(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Invalid file")
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		devices, err := manager.ExportDevices(appID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not export devices")
+		}
+
+		records := make([]*util.DeviceRecord, 0, len(devices))
+		for _, dev := range devices {
+			records = append(records, util.NewDeviceRecord(dev))
+		}
+
+		// The file contains keys, so only the user can read it
+		file, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not create file")
+		}
+		err = util.WriteDeviceRecords(file, format, records)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not write devices")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":   appID,
+			"Devices": len(records),
+			"File":    args[0],
+		}).Info("Exported devices")
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesExportCmd)
+}
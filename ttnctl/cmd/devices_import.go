@@ -0,0 +1,134 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"os"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var devicesImportCmd = &cobra.Command{
+	Use:   "import [file.csv|file.json]",
+	Short: "Import devices from a file",
+	Long: `ttnctl devices import can be used to create or update many devices at once.
+
+The devices are read from a CSV file with a header, or from a JSON file with an array of devices. The columns (or
+fields) are dev_id, app_eui, dev_eui, app_key, dev_addr, nwk_s_key, app_s_key, description, latitude, longitude,
+altitude, uses_32_bit_fcnt, disable_fcnt_check and activation_constraints. Only dev_id and dev_eui are required;
+the AppEUI of the application is used if app_eui is empty. Empty addresses and keys keep the values of existing
+devices, and devices that would not change are skipped, so that a file can be imported more than once.
+
+With --dry-run, the file is only validated.`,
+	Example: `$ ttnctl devices import devices.csv
+  INFO Using Application                        AppEUI=70B3D57EF0000024 AppID=test
+  INFO Validated devices                        Devices=2
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Imported devices                         AppID=test Created=1 Unchanged=0 Updated=1
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		format, err := util.DeviceFileFormat(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Invalid file")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not open file")
+		}
+		records, err := util.ReadDeviceRecords(file, format)
+		file.Close()
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not read devices")
+		}
+
+		appID := util.GetAppID(ctx)
+		appEUI := util.GetAppEUI(ctx)
+
+		devices := make([]*handler.Device, 0, len(records))
+		devIDs := make(map[string]bool)
+		devEUIs := make(map[types.AppEUI]map[types.DevEUI]bool)
+		var invalid int
+		for i, record := range records {
+			logCtx := ctx.WithFields(ttnlog.Fields{"Device": i + 1, "DevID": record.DevID})
+			dev, err := record.Device(appID, appEUI)
+			if err != nil {
+				logCtx.WithError(err).Warn("Invalid device")
+				invalid++
+				continue
+			}
+			lorawan := dev.GetLorawanDevice()
+			if devIDs[dev.DevId] {
+				logCtx.Warn("Duplicate DevID")
+				invalid++
+				continue
+			}
+			if devEUIs[*lorawan.AppEui] == nil {
+				devEUIs[*lorawan.AppEui] = make(map[types.DevEUI]bool)
+			}
+			if devEUIs[*lorawan.AppEui][*lorawan.DevEui] {
+				logCtx.Warn("Duplicate AppEUI and DevEUI")
+				invalid++
+				continue
+			}
+			devIDs[dev.DevId] = true
+			devEUIs[*lorawan.AppEui][*lorawan.DevEui] = true
+			devices = append(devices, dev)
+		}
+		if invalid > 0 {
+			ctx.Fatalf("%d of %d devices are invalid", invalid, len(records))
+		}
+		ctx.WithField("Devices", len(devices)).Info("Validated devices")
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		results, err := manager.BulkSetDevices(devices)
+		var created, updated, unchanged, failed int
+		for _, res := range results {
+			switch {
+			case res.Error != "":
+				ctx.WithFields(ttnlog.Fields{
+					"Device": res.Index + 1,
+					"DevID":  res.DevId,
+				}).Warnf("Could not import device: %s", res.Error)
+				failed++
+			case res.Created:
+				created++
+			case res.Unchanged:
+				unchanged++
+			default:
+				updated++
+			}
+		}
+		if err != nil {
+			ctx.WithError(err).Fatalf("Could not import devices, %d of %d devices were imported", created+updated+unchanged, len(devices))
+		}
+		if failed > 0 {
+			ctx.Fatalf("Could not import %d of %d devices", failed, len(devices))
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":     appID,
+			"Created":   created,
+			"Updated":   updated,
+			"Unchanged": unchanged,
+		}).Info("Imported devices")
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesImportCmd)
+	devicesImportCmd.Flags().Bool("dry-run", false, "Only validate the devices in the file")
+}
@@ -0,0 +1,143 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+)
+
+var devicesMACStateCmd = &cobra.Command{
+	Use:   "mac-state [Device ID]",
+	Short: "Get the MAC state of a device",
+	Long: `ttnctl devices mac-state can be used to get the MAC state of a device, as it is known by the NetworkServer.
+This includes the ADR settings, the frame history that is used for ADR and the MAC commands that are pending.
+
+With the --reset flag, the ADR settings and the frame history of the device are reset.`,
+	Example: `$ ttnctl devices mac-state test
+  INFO Using Application                        AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Found MAC state
+
+  Application ID: test
+       Device ID: test
+       Last Seen: 2017-06-12 14:46:33.183 +0200 CEST
+
+     FCntUp: 42
+   FCntDown: 3
+
+   ADR Band: EU_863_870
+ ADR Margin: 15
+  Data Rate: SF7BW125
+   TX Power: 14
+    NbTrans: 1
+   Send Req: true
+     Failed: 0
+
+  Loss Percentage: 0%
+      Pending MAC: LinkADRReq
+
+  FCnt  SNR   Gateways
+  42    7.5   2
+  41    8.2   2
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		devID := args[0]
+		if !api.ValidID(devID) {
+			ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+		}
+
+		appID := util.GetAppID(ctx)
+
+		reset, _ := cmd.Flags().GetBool("reset")
+		if reset && !confirm(fmt.Sprintf("Are you sure you want to reset the MAC state of device %s in application %s?", devID, appID)) {
+			ctx.Info("Not doing anything")
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		if reset {
+			err := manager.ResetDeviceMACState(appID, devID)
+			if err != nil {
+				ctx.WithError(err).Fatal("Could not reset MAC state.")
+			}
+			ctx.WithFields(ttnlog.Fields{
+				"AppID": appID,
+				"DevID": devID,
+			}).Info("Reset MAC state")
+			return
+		}
+
+		state, err := manager.GetDeviceMACState(appID, devID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get MAC state.")
+		}
+
+		ctx.Info("Found MAC state")
+
+		fmt.Println()
+
+		fmt.Printf("  Application ID: %s\n", state.AppId)
+		fmt.Printf("       Device ID: %s\n", state.DevId)
+
+		lastSeen := "never"
+		if state.LastSeen > 0 {
+			lastSeen = fmt.Sprintf("%s", time.Unix(0, 0).Add(time.Duration(state.LastSeen)))
+		}
+		fmt.Printf("       Last Seen: %s\n", lastSeen)
+		fmt.Println()
+
+		fmt.Printf("     FCntUp: %d\n", state.FCntUp)
+		fmt.Printf("   FCntDown: %d\n", state.FCntDown)
+		fmt.Println()
+
+		if adr := state.Adr; adr != nil {
+			fmt.Printf("   ADR Band: %s\n", adr.Band)
+			fmt.Printf(" ADR Margin: %d\n", adr.Margin)
+			fmt.Printf("  Data Rate: %s\n", adr.DataRate)
+			fmt.Printf("   TX Power: %d\n", adr.TxPower)
+			fmt.Printf("    NbTrans: %d\n", adr.NbTrans)
+			fmt.Printf("   Send Req: %t\n", adr.SendReq)
+			fmt.Printf("     Failed: %d\n", adr.Failed)
+			fmt.Println()
+		}
+
+		pending := "none"
+		if len(state.PendingMacCommands) > 0 {
+			pending = strings.Join(state.PendingMacCommands, ", ")
+		}
+		fmt.Printf("  Loss Percentage: %d%%\n", state.LossPercentage)
+		fmt.Printf("      Pending MAC: %s\n", pending)
+
+		if len(state.FrameHistory) > 0 {
+			table := uitable.New()
+			table.MaxColWidth = 70
+			table.AddRow("FCnt", "SNR", "Gateways")
+			for _, frame := range state.FrameHistory {
+				table.AddRow(frame.FCnt, fmt.Sprintf("%.1f", frame.Snr), frame.GatewayCount)
+			}
+			fmt.Println()
+			fmt.Println(table)
+		}
+
+		fmt.Println()
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesMACStateCmd)
+	devicesMACStateCmd.Flags().Bool("reset", false, "Reset the ADR settings and frame history of the device")
+}
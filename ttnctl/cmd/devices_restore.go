@@ -0,0 +1,84 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var devicesRestoreCmd = &cobra.Command{
+	Use:   "restore [file.json]",
+	Short: "Restore the session state of a device from a file",
+	Long: `ttnctl devices restore can be used to restore the session state of a device from a file that was saved with
+ttnctl devices snapshot. The device is created if it does not exist.
+
+Unlike ttnctl devices personalize, this keeps the frame counters and ADR settings of the device. The snapshot can be
+restored in the same or another deployment, into an application with the same ID. A snapshot that is older than the
+current session of the device is refused, because its frame counters would allow replay attacks. A snapshot of another
+session than the current session of the device is refused as well, unless --force is given.`,
+	Example: `$ ttnctl devices restore test.json
+  INFO Using Application                        AppEUI=70B3D57EF0000024 AppID=test
+Are you sure you want to restore device test in application test to the snapshot of 2017-06-12 14:46:33 +0200 CEST?
+> yes
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Restored device snapshot                 AppID=test DevID=test FCntDown=3 FCntUp=42
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not read device snapshot")
+		}
+		snapshot := new(handler.DeviceSnapshot)
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			ctx.WithError(err).Fatal("Could not decode device snapshot")
+		}
+		if err := snapshot.Validate(); err != nil {
+			ctx.WithError(err).Fatal("Invalid device snapshot")
+		}
+
+		appID := util.GetAppID(ctx)
+		devID := snapshot.Device.DevId
+		if snapshot.Device.AppId != appID {
+			ctx.Fatalf("Snapshot is of a device in application %s", snapshot.Device.AppId)
+		}
+		snapshot.Force, _ = cmd.Flags().GetBool("force")
+
+		taken := time.Unix(0, snapshot.Time).Round(time.Second)
+		if !confirm(fmt.Sprintf("Are you sure you want to restore device %s in application %s to the snapshot of %s?", devID, appID, taken)) {
+			ctx.Info("Not doing anything")
+			return
+		}
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		err = manager.RestoreDeviceSnapshot(snapshot)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not restore device snapshot")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":    appID,
+			"DevID":    devID,
+			"FCntUp":   snapshot.MacState.FCntUp,
+			"FCntDown": snapshot.MacState.FCntDown,
+		}).Info("Restored device snapshot")
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesRestoreCmd)
+	devicesRestoreCmd.Flags().Bool("force", false, "Restore the snapshot even if the device has another session")
+}
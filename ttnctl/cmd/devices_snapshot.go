@@ -0,0 +1,74 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/spf13/cobra"
+)
+
+var devicesSnapshotCmd = &cobra.Command{
+	Use:   "snapshot [Device ID] [file.json]",
+	Short: "Save the session state of a device to a file",
+	Long: `ttnctl devices snapshot can be used to save the complete session state of a device to a file.
+
+The snapshot contains the DevAddr, the session keys, the frame counters, the ADR settings and the options of the
+device, as they are known by the Handler and the NetworkServer. It can be restored with ttnctl devices restore.
+
+If the Handler encrypts keys, the keys in the snapshot are encrypted as well, and the snapshot can only be restored
+to a Handler that has the key-encryption key. Otherwise the snapshot contains the keys in plaintext, so keep it as
+safe as the keys themselves.`,
+	Example: `$ ttnctl devices snapshot test test.json
+  INFO Using Application                        AppEUI=70B3D57EF0000024 AppID=test
+  INFO Discovering Handler...
+  INFO Connecting with Handler...
+  INFO Saved device snapshot                    AppID=test DevID=test FCntDown=3 FCntUp=42 File=test.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 2, 2)
+
+		devID := args[0]
+		if !api.ValidID(devID) {
+			ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetHandlerManager(ctx, appID)
+		defer conn.Close()
+
+		snapshot, err := manager.GetDeviceSnapshot(appID, devID)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not get device snapshot")
+		}
+
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not encode device snapshot")
+		}
+
+		// The file contains keys, so only the user can read it
+		err = ioutil.WriteFile(args[1], append(data, '\n'), 0600)
+		if err != nil {
+			ctx.WithError(err).Fatal("Could not write device snapshot")
+		}
+
+		ctx.WithFields(ttnlog.Fields{
+			"AppID":    appID,
+			"DevID":    devID,
+			"FCntUp":   snapshot.MacState.FCntUp,
+			"FCntDown": snapshot.MacState.FCntDown,
+			"File":     args[1],
+		}).Info("Saved device snapshot")
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesSnapshotCmd)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/go-account-lib/scope"
+	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+var devicesTrafficCmd = &cobra.Command{
+	Use:   "traffic [Device ID]",
+	Short: "Show the live traffic of the devices in this application",
+	Long: `ttnctl devices traffic shows the uplink and downlink messages of the devices in
+this application as they are handled by the Broker, and decodes their LoRaWAN frames.
+
+If a Device ID is given, only the traffic of that device is shown.`,
+	Example: `$ ttnctl devices traffic test
+  INFO Using Application                        AppID=test
+  INFO Discovering Broker...
+  INFO Connecting with Broker...
+  INFO Connected to Broker
+  INFO Subscribed to device traffic             AppID=test DevID=test
+  INFO Uplink                                   AppID=test DevID=test
+                Time: 2017-06-12 15:25:27.98518232 +0200 CEST
+            Gateways: 2
+           Data Rate: SF7BW125
+               MType: UNCONFIRMED_UP
+             DevAddr: 26001ADA
+                FCnt: 42
+               FPort: 1
+   FRMPayload (enc.): 4D61
+
+  INFO Downlink                                 AppID=test DevID=test
+                Time: 2017-06-12 15:25:28.00134771 +0200 CEST
+             Gateway: test
+           Frequency: 868100000
+           Data Rate: SF7BW125
+               MType: UNCONFIRMED_DOWN
+             DevAddr: 26001ADA
+                FCnt: 3
+                 ACK: true
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 0, 1)
+
+		var devID string
+		if len(args) == 1 {
+			devID = args[0]
+			if !api.ValidID(devID) {
+				ctx.Fatalf("Invalid Device ID") // TODO: Add link to wiki explaining device IDs
+			}
+		}
+
+		appID := util.GetAppID(ctx)
+
+		conn, manager := util.GetBrokerManager(ctx)
+		defer conn.Close()
+
+		ctx = ctx.WithField("AppID", appID)
+		if devID != "" {
+			ctx = ctx.WithField("DevID", devID)
+		}
+
+		reqCtx := api.ContextWithToken(util.GetContext(ctx), util.TokenForScope(ctx, scope.App(appID)))
+		stream, err := manager.Traffic(reqCtx, &broker.TrafficRequest{AppId: appID})
+		if err != nil {
+			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not subscribe to device traffic")
+		}
+		ctx.Info("Subscribed to device traffic")
+
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				ctx.WithError(errors.FromGRPCError(err)).Fatal("Stopped receiving device traffic")
+			}
+			if uplink := msg.GetUplink(); uplink != nil && (devID == "" || uplink.DevId == devID) {
+				ctx.WithField("DevID", uplink.DevId).Info("Uplink")
+				printKV("Time", time.Unix(0, msg.Time))
+				printKV("Gateways", len(uplink.GatewayMetadata))
+				if lorawan := uplink.GetProtocolMetadata().GetLorawan(); lorawan != nil {
+					printKV("Data Rate", lorawan.DataRate)
+				}
+				printLoRaWANFrame(uplink.Payload)
+				fmt.Println()
+			}
+			if downlink := msg.GetDownlink(); downlink != nil && (devID == "" || downlink.DevId == devID) {
+				ctx.WithField("DevID", downlink.DevId).Info("Downlink")
+				printKV("Time", time.Unix(0, msg.Time))
+				if option := downlink.DownlinkOption; option != nil {
+					printKV("Gateway", option.GatewayId)
+					if gateway := option.GatewayConfig; gateway != nil {
+						printKV("Frequency", gateway.Frequency)
+					}
+					if lorawan := option.GetProtocolConfig().GetLorawan(); lorawan != nil {
+						printKV("Data Rate", lorawan.DataRate)
+					}
+				}
+				printLoRaWANFrame(downlink.Payload)
+				fmt.Println()
+			}
+		}
+	},
+}
+
+func init() {
+	devicesCmd.AddCommand(devicesTrafficCmd)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/TheThingsNetwork/ttn/api"
 	"github.com/TheThingsNetwork/ttn/core/types"
 	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/TheThingsNetwork/ttn/utils/random"
 	"github.com/spf13/cobra"
 )
 
@@ -59,6 +60,7 @@ $ ttnctl downlink test --json '{"led":"on"}'
 		message := types.DownlinkMessage{
 			AppID:     appID,
 			DevID:     devID,
+			MessageID: random.String(16),
 			FPort:     uint8(fPort),
 			Confirmed: confirmed,
 		}
@@ -9,16 +9,21 @@ import (
 	"time"
 
 	"github.com/TheThingsNetwork/ttn/api"
+	"github.com/TheThingsNetwork/ttn/api/gateway"
 	"github.com/TheThingsNetwork/ttn/api/router"
 	"github.com/TheThingsNetwork/ttn/ttnctl/util"
 	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
 var gatewaysStatusCmd = &cobra.Command{
 	Use:   "status [gatewayID]",
 	Short: "Get status of a gateway",
-	Long:  `ttnctl gateways status can be used to get status of gateways.`,
+	Long: `ttnctl gateways status can be used to get status of gateways.
+
+With the --history flag, the hourly traffic, the connects and disconnects and
+the status messages of the gateway are also shown.`,
 	Example: `$ ttnctl gateways status test
   INFO Discovering Router...
   INFO Connecting with Router...
@@ -32,6 +37,34 @@ var gatewaysStatusCmd = &cobra.Command{
                  Rtt: not available
                   Rx: (in: 0; ok: 0)
                   Tx: (in: 0; ok: 0)
+
+$ ttnctl gateways status test --history --since 2h
+  INFO Discovering Router...
+  INFO Connecting with Router...
+  INFO Connected to Router
+  INFO Received status
+
+           Last seen: 2017-06-12 15:25:27.94138808 +0200 CEST
+           Timestamp: 0
+       Reported time: 2017-06-12 15:25:26 +0200 CEST
+     GPS coordinates: (52.372791 4.900300)
+                 Rtt: not available
+                  Rx: (in: 1023; ok: 988)
+                  Tx: (in: 12; ok: 12)
+
+  INFO Received history
+
+Hour                       	Uplink	Downlink	Channels                   	Data Rates
+2017-06-12 14:00 +0200 CEST	412   	5       	868100000:140 868300000:139	SF7BW125:301 SF12BW125:111
+2017-06-12 15:00 +0200 CEST	187   	2       	868100000:63 868300000:61  	SF7BW125:150 SF12BW125:37
+
+Time                          	Stream	Event
+2017-06-12 14:12:03 +0200 CEST	uplink	disconnected
+2017-06-12 14:12:09 +0200 CEST	uplink	connected
+
+Time                          	GPS                 	Temperature	Rx (in/ok)	Tx (in/ok)
+2017-06-12 14:25:26 +0200 CEST	(52.372791 4.900300)	41.0       	812/785   	10/10
+2017-06-12 15:25:26 +0200 CEST	(52.372791 4.900300)	43.5       	1023/988  	12/12
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		assertArgsLength(cmd, args, 1, 1)
@@ -46,43 +79,120 @@ var gatewaysStatusCmd = &cobra.Command{
 
 		ctx = ctx.WithField("GatewayID", gtwID)
 
+		history, _ := cmd.Flags().GetBool("history")
+
 		resp, err := manager.GatewayStatus(util.GetContext(ctx), &router.GatewayStatusRequest{
 			GatewayId: gtwID,
 		})
 		if err != nil {
-			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not get status of gateway.")
+			if !history {
+				ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not get status of gateway.")
+			}
+			ctx.WithError(errors.FromGRPCError(err)).Warn("Could not get status of gateway.")
+		} else {
+			printGatewayStatus(resp)
 		}
 
-		ctx.Infof("Received status")
-		fmt.Println()
-		printKV("Last seen", time.Unix(0, resp.LastSeen))
-		printKV("Timestamp", resp.Status.Timestamp)
-		if t := resp.Status.Time; t != 0 {
-			printKV("Reported time", time.Unix(0, t))
-		}
-		printKV("Description", resp.Status.Description)
-		printKV("Platform", resp.Status.Platform)
-		printKV("Contact email", resp.Status.ContactEmail)
-		printKV("Region", resp.Status.Region)
-		printKV("IP Address", strings.Join(resp.Status.Ip, ", "))
-		printKV("GPS coordinates", func() interface{} {
-			if gps := resp.Status.Gps; gps != nil && !(gps.Latitude == 0 && gps.Longitude == 0) {
-				return fmt.Sprintf("(%.6f %.6f)", gps.Latitude, gps.Longitude)
+		if history {
+			since, _ := cmd.Flags().GetDuration("since")
+			resp, err := manager.GatewayHistory(util.GetContext(ctx), &router.GatewayHistoryRequest{
+				GatewayId: gtwID,
+				Since:     time.Now().Add(-1 * since).UnixNano(),
+			})
+			if err != nil {
+				ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not get history of gateway.")
 			}
-			return "not available"
-		}())
-		printKV("Rtt", func() interface{} {
-			if t := resp.Status.Rtt; t != 0 {
-				return time.Duration(t)
-			}
-			return "not available"
-		}())
-		printKV("Rx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.RxIn, resp.Status.RxOk))
-		printKV("Tx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.TxIn, resp.Status.TxOk))
-		fmt.Println()
+			printGatewayHistory(resp)
+		}
 	},
 }
 
+func printGatewayStatus(resp *router.GatewayStatusResponse) {
+
+	ctx.Infof("Received status")
+	fmt.Println()
+	printKV("Last seen", time.Unix(0, resp.LastSeen))
+	printKV("Timestamp", resp.Status.Timestamp)
+	if t := resp.Status.Time; t != 0 {
+		printKV("Reported time", time.Unix(0, t))
+	}
+	printKV("Description", resp.Status.Description)
+	printKV("Platform", resp.Status.Platform)
+	printKV("Contact email", resp.Status.ContactEmail)
+	printKV("Region", resp.Status.Region)
+	printKV("IP Address", strings.Join(resp.Status.Ip, ", "))
+	printKV("GPS coordinates", formatGPS(resp.Status.Gps))
+	printKV("Rtt", func() interface{} {
+		if t := resp.Status.Rtt; t != 0 {
+			return time.Duration(t)
+		}
+		return "not available"
+	}())
+	printKV("Rx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.RxIn, resp.Status.RxOk))
+	printKV("Tx", fmt.Sprintf("(in: %d; ok: %d)", resp.Status.TxIn, resp.Status.TxOk))
+	fmt.Println()
+}
+
+func formatGPS(gps *gateway.GPSMetadata) string {
+	if gps != nil && !(gps.Latitude == 0 && gps.Longitude == 0) {
+		return fmt.Sprintf("(%.6f %.6f)", gps.Latitude, gps.Longitude)
+	}
+	return "not available"
+}
+
+func printGatewayHistory(resp *router.GatewayHistoryResponse) {
+	ctx.Infof("Received history")
+	fmt.Println()
+
+	traffic := uitable.New()
+	traffic.MaxColWidth = 40
+	traffic.AddRow("Hour", "Uplink", "Downlink", "Channels", "Data Rates")
+	for _, hour := range resp.Traffic {
+		var channels, dataRates []string
+		for _, channel := range hour.Channels {
+			channels = append(channels, fmt.Sprintf("%d:%d", channel.Frequency, channel.Uplink))
+		}
+		for _, dataRate := range hour.DataRates {
+			dataRates = append(dataRates, fmt.Sprintf("%s:%d", dataRate.DataRate, dataRate.Uplink))
+		}
+		traffic.AddRow(time.Unix(0, hour.Hour).Format("2006-01-02 15:04 -0700 MST"), hour.Uplink, hour.Downlink, strings.Join(channels, " "), strings.Join(dataRates, " "))
+	}
+	fmt.Println(traffic)
+	fmt.Println()
+
+	connections := uitable.New()
+	connections.AddRow("Time", "Stream", "Event")
+	for _, connection := range resp.Connections {
+		event := "disconnected"
+		if connection.Connected {
+			event = "connected"
+		}
+		connections.AddRow(time.Unix(0, connection.Time).Round(time.Second), connection.Stream, event)
+	}
+	fmt.Println(connections)
+	fmt.Println()
+
+	statuses := uitable.New()
+	statuses.AddRow("Time", "GPS", "Temperature", "Rx (in/ok)", "Tx (in/ok)")
+	for _, status := range resp.Statuses {
+		temperature := "-"
+		if metrics := status.Os; metrics != nil && metrics.Temperature != 0 {
+			temperature = fmt.Sprintf("%.1f", metrics.Temperature)
+		}
+		statuses.AddRow(
+			time.Unix(0, status.Time).Round(time.Second),
+			formatGPS(status.Gps),
+			temperature,
+			fmt.Sprintf("%d/%d", status.RxIn, status.RxOk),
+			fmt.Sprintf("%d/%d", status.TxIn, status.TxOk),
+		)
+	}
+	fmt.Println(statuses)
+	fmt.Println()
+}
+
 func init() {
 	gatewaysCmd.AddCommand(gatewaysStatusCmd)
+	gatewaysStatusCmd.Flags().Bool("history", false, "Also show the traffic, connection and status history of the gateway")
+	gatewaysStatusCmd.Flags().Duration("since", 24*time.Hour, "How far back the history goes")
 }
@@ -0,0 +1,173 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TheThingsNetwork/ttn/api"
+	pb_lorawan "github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/api/router"
+	"github.com/TheThingsNetwork/ttn/core/types"
+	"github.com/TheThingsNetwork/ttn/ttnctl/util"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/cobra"
+)
+
+var gatewaysTrafficCmd = &cobra.Command{
+	Use:   "traffic [GatewayID]",
+	Short: "Show the live traffic of a gateway",
+	Long: `ttnctl gateways traffic shows the uplink and downlink messages of a gateway
+as they are handled by the Router, and decodes their LoRaWAN frames.
+
+The owner of a gateway can inspect its traffic. Network operators that have
+access to the Router can inspect the traffic of all gateways.`,
+	Example: `$ ttnctl gateways traffic test
+  INFO Discovering Router...
+  INFO Connecting with Router...
+  INFO Connected to Router
+  INFO Subscribed to gateway traffic            GatewayID=test
+  INFO Uplink                                   GatewayID=test
+                Time: 2017-06-12 15:25:27.94138808 +0200 CEST
+           Frequency: 868100000
+                RSSI: -45
+                 SNR: 7.5
+           Data Rate: SF7BW125
+               MType: UNCONFIRMED_UP
+             DevAddr: 26001ADA
+                FCnt: 42
+               FPort: 1
+   FRMPayload (enc.): 4D61
+
+  INFO Downlink                                 GatewayID=test
+                Time: 2017-06-12 15:25:28.01834771 +0200 CEST
+           Frequency: 868100000
+               Power: 14
+           Data Rate: SF7BW125
+               MType: UNCONFIRMED_DOWN
+             DevAddr: 26001ADA
+                FCnt: 3
+                 ACK: true
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		assertArgsLength(cmd, args, 1, 1)
+
+		gtwID := args[0]
+		if !api.ValidID(gtwID) {
+			ctx.Fatal("Invalid Gateway ID")
+		}
+
+		req := &router.TrafficRequest{GatewayId: gtwID}
+		if devAddr, _ := cmd.Flags().GetString("dev-addr"); devAddr != "" {
+			addr, err := types.ParseDevAddr(devAddr)
+			if err != nil {
+				ctx.WithError(err).Fatal("Invalid DevAddr")
+			}
+			req.DevAddr = &addr
+		}
+
+		conn, manager := util.GetRouterManager(ctx)
+		defer conn.Close()
+
+		ctx = ctx.WithField("GatewayID", gtwID)
+
+		// Gateway owners use the gateway token, network operators their own token
+		reqCtx := util.GetContext(ctx)
+		token, err := util.GetAccount(ctx).GetGatewayToken(gtwID)
+		if err != nil {
+			ctx.WithError(err).Debug("Could not get gateway token")
+		} else if token != nil && token.AccessToken != "" {
+			reqCtx = api.ContextWithToken(reqCtx, token.AccessToken)
+		}
+
+		stream, err := manager.Traffic(reqCtx, req)
+		if err != nil {
+			ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not subscribe to gateway traffic")
+		}
+		ctx.Info("Subscribed to gateway traffic")
+
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				ctx.WithError(errors.FromGRPCError(err)).Fatal("Stopped receiving gateway traffic")
+			}
+			printGatewayTraffic(msg)
+		}
+	},
+}
+
+func printGatewayTraffic(msg *router.TrafficMessage) {
+	if uplink := msg.GetUplink(); uplink != nil {
+		ctx.Info("Uplink")
+		printKV("Time", time.Unix(0, msg.Time))
+		if gateway := uplink.GatewayMetadata; gateway != nil {
+			printKV("Frequency", gateway.Frequency)
+			printKV("RSSI", gateway.Rssi)
+			printKV("SNR", gateway.Snr)
+		}
+		if lorawan := uplink.GetProtocolMetadata().GetLorawan(); lorawan != nil {
+			printKV("Data Rate", lorawan.DataRate)
+		}
+		printLoRaWANFrame(uplink.Payload)
+	}
+	if downlink := msg.GetDownlink(); downlink != nil {
+		ctx.Info("Downlink")
+		printKV("Time", time.Unix(0, msg.Time))
+		if gateway := downlink.GatewayConfiguration; gateway != nil {
+			printKV("Frequency", gateway.Frequency)
+			printKV("Power", gateway.Power)
+		}
+		if lorawan := downlink.GetProtocolConfiguration().GetLorawan(); lorawan != nil {
+			printKV("Data Rate", lorawan.DataRate)
+		}
+		printLoRaWANFrame(downlink.Payload)
+	}
+	fmt.Println()
+}
+
+// printLoRaWANFrame prints the fields of a LoRaWAN frame. The FRMPayload is printed as it is on the air, because it
+// is encrypted with the session keys of the device.
+func printLoRaWANFrame(payload []byte) {
+	msg, err := pb_lorawan.MessageFromPHYPayloadBytes(payload)
+	if err != nil {
+		printKV("Payload", payload)
+		printKV("Error", err)
+		return
+	}
+	printKV("MType", msg.MType)
+	if mac := msg.GetMacPayload(); mac != nil {
+		printKV("DevAddr", mac.DevAddr)
+		printKV("FCnt", mac.FCnt)
+		if mac.Adr {
+			printKV("ADR", mac.Adr)
+		}
+		if mac.AdrAckReq {
+			printKV("ADRAckReq", mac.AdrAckReq)
+		}
+		if mac.Ack {
+			printKV("ACK", mac.Ack)
+		}
+		if mac.FPending {
+			printKV("FPending", mac.FPending)
+		}
+		for _, command := range mac.FOpts {
+			printKV("MAC Command", fmt.Sprintf("0x%02X %X", command.Cid, command.Payload))
+		}
+		if len(mac.FrmPayload) > 0 {
+			printKV("FPort", mac.FPort)
+			printKV("FRMPayload (enc.)", mac.FrmPayload)
+		}
+	}
+	if join := msg.GetJoinRequestPayload(); join != nil {
+		printKV("AppEUI", join.AppEui)
+		printKV("DevEUI", join.DevEui)
+		printKV("DevNonce", join.DevNonce)
+	}
+}
+
+func init() {
+	gatewaysCmd.AddCommand(gatewaysTrafficCmd)
+	gatewaysTrafficCmd.Flags().String("dev-addr", "", "Only show the traffic of this device address")
+}
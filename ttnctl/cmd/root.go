@@ -73,6 +73,9 @@ func init() {
 	RootCmd.PersistentFlags().String("router-id", "ttn-router-eu", "The ID of the TTN Router as announced in the Discovery server")
 	viper.BindPFlag("router-id", RootCmd.PersistentFlags().Lookup("router-id"))
 
+	RootCmd.PersistentFlags().String("broker-id", "ttn-broker-eu", "The ID of the TTN Broker as announced in the Discovery server")
+	viper.BindPFlag("broker-id", RootCmd.PersistentFlags().Lookup("broker-id"))
+
 	RootCmd.PersistentFlags().String("handler-id", "ttn-handler-eu", "The ID of the TTN Handler as announced in the Discovery server")
 	viper.BindPFlag("handler-id", RootCmd.PersistentFlags().Lookup("handler-id"))
 
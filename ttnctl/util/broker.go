@@ -0,0 +1,34 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package util
+
+import (
+	ttnlog "github.com/TheThingsNetwork/go-utils/log"
+	"github.com/TheThingsNetwork/ttn/api/broker"
+	"github.com/TheThingsNetwork/ttn/api/discovery"
+	"github.com/TheThingsNetwork/ttn/utils/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// GetBrokerManager starts a management connection with the broker
+func GetBrokerManager(ctx ttnlog.Interface) (*grpc.ClientConn, broker.BrokerManagerClient) {
+	ctx.Info("Discovering Broker...")
+	dscConn, client := GetDiscovery(ctx)
+	defer dscConn.Close()
+	brokerAnnouncement, err := client.Get(GetContext(ctx), &discovery.GetRequest{
+		ServiceName: "broker",
+		Id:          viper.GetString("broker-id"),
+	})
+	if err != nil {
+		ctx.WithError(errors.FromGRPCError(err)).Fatal("Could not get Broker from Discovery")
+	}
+	ctx.Info("Connecting with Broker...")
+	brkConn, err := brokerAnnouncement.Dial()
+	if err != nil {
+		ctx.WithError(err).Fatal("Could not connect to Broker")
+	}
+	ctx.Info("Connected to Broker")
+	return brkConn, broker.NewBrokerManagerClient(brkConn)
+}
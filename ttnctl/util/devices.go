@@ -0,0 +1,316 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/TheThingsNetwork/ttn/api/handler"
+	"github.com/TheThingsNetwork/ttn/api/protocol/lorawan"
+	"github.com/TheThingsNetwork/ttn/core/types"
+)
+
+// DeviceRecord is a device in a CSV or JSON file for importing or exporting devices. Empty EUIs, addresses and keys
+// keep the value of an existing device.
+type DeviceRecord struct {
+	DevID                 string  `json:"dev_id"`
+	AppEUI                string  `json:"app_eui,omitempty"`
+	DevEUI                string  `json:"dev_eui"`
+	AppKey                string  `json:"app_key,omitempty"`
+	DevAddr               string  `json:"dev_addr,omitempty"`
+	NwkSKey               string  `json:"nwk_s_key,omitempty"`
+	AppSKey               string  `json:"app_s_key,omitempty"`
+	Description           string  `json:"description,omitempty"`
+	Latitude              float32 `json:"latitude,omitempty"`
+	Longitude             float32 `json:"longitude,omitempty"`
+	Altitude              int32   `json:"altitude,omitempty"`
+	Uses32BitFCnt         *bool   `json:"uses_32_bit_fcnt,omitempty"` // Defaults to true
+	DisableFCntCheck      bool    `json:"disable_fcnt_check,omitempty"`
+	ActivationConstraints string  `json:"activation_constraints,omitempty"`
+}
+
+// deviceRecordColumns are the columns of a CSV file with device records
+var deviceRecordColumns = []string{
+	"dev_id", "app_eui", "dev_eui", "app_key", "dev_addr", "nwk_s_key", "app_s_key", "description",
+	"latitude", "longitude", "altitude", "uses_32_bit_fcnt", "disable_fcnt_check", "activation_constraints",
+}
+
+func isDeviceRecordColumn(column string) bool {
+	for _, known := range deviceRecordColumns {
+		if column == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceFileFormat returns the format (csv or json) of a device file, based on its extension
+func DeviceFileFormat(filename string) (string, error) {
+	switch format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."); format {
+	case "csv", "json":
+		return format, nil
+	default:
+		return "", fmt.Errorf("Unknown file format \"%s\", use .csv or .json", format)
+	}
+}
+
+// ReadDeviceRecords reads device records in the given format (csv or json). A CSV file must start with a header with
+// the names of the columns; unknown columns are not allowed, missing columns are left empty.
+func ReadDeviceRecords(r io.Reader, format string) ([]*DeviceRecord, error) {
+	switch format {
+	case "json":
+		var records []*DeviceRecord
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	case "csv":
+		return readDeviceRecordsCSV(r)
+	default:
+		return nil, fmt.Errorf("Unknown file format \"%s\"", format)
+	}
+}
+
+func readDeviceRecordsCSV(r io.Reader) ([]*DeviceRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !isDeviceRecordColumn(header[i]) {
+			return nil, fmt.Errorf("Unknown column \"%s\"", column)
+		}
+	}
+	var records []*DeviceRecord
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := new(DeviceRecord)
+		for i, column := range header {
+			if err := record.setColumn(column, strings.TrimSpace(row[i])); err != nil {
+				return nil, fmt.Errorf("Line %d: invalid %s: %s", line, column, err)
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+func (r *DeviceRecord) setColumn(column, value string) (err error) {
+	switch column {
+	case "dev_id":
+		r.DevID = value
+	case "app_eui":
+		r.AppEUI = value
+	case "dev_eui":
+		r.DevEUI = value
+	case "app_key":
+		r.AppKey = value
+	case "dev_addr":
+		r.DevAddr = value
+	case "nwk_s_key":
+		r.NwkSKey = value
+	case "app_s_key":
+		r.AppSKey = value
+	case "description":
+		r.Description = value
+	case "activation_constraints":
+		r.ActivationConstraints = value
+	}
+	if value == "" {
+		return nil
+	}
+	switch column {
+	case "latitude", "longitude":
+		var f float64
+		f, err = strconv.ParseFloat(value, 32)
+		if column == "latitude" {
+			r.Latitude = float32(f)
+		} else {
+			r.Longitude = float32(f)
+		}
+	case "altitude":
+		var i int64
+		i, err = strconv.ParseInt(value, 10, 32)
+		r.Altitude = int32(i)
+	case "uses_32_bit_fcnt":
+		var b bool
+		b, err = strconv.ParseBool(value)
+		r.Uses32BitFCnt = &b
+	case "disable_fcnt_check":
+		r.DisableFCntCheck, err = strconv.ParseBool(value)
+	}
+	return err
+}
+
+func (r *DeviceRecord) columns() []string {
+	uses32BitFCnt := ""
+	if r.Uses32BitFCnt != nil {
+		uses32BitFCnt = strconv.FormatBool(*r.Uses32BitFCnt)
+	}
+	return []string{
+		r.DevID, r.AppEUI, r.DevEUI, r.AppKey, r.DevAddr, r.NwkSKey, r.AppSKey, r.Description,
+		strconv.FormatFloat(float64(r.Latitude), 'f', -1, 32),
+		strconv.FormatFloat(float64(r.Longitude), 'f', -1, 32),
+		strconv.FormatInt(int64(r.Altitude), 10),
+		uses32BitFCnt, strconv.FormatBool(r.DisableFCntCheck), r.ActivationConstraints,
+	}
+}
+
+// WriteDeviceRecords writes device records in the given format (csv or json)
+func WriteDeviceRecords(w io.Writer, format string, records []*DeviceRecord) error {
+	switch format {
+	case "json":
+		if records == nil {
+			records = []*DeviceRecord{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write(deviceRecordColumns)
+		for _, record := range records {
+			writer.Write(record.columns())
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("Unknown file format \"%s\"", format)
+	}
+}
+
+// Device validates the record and returns it as a device of the application. The appEUI is used if the record has
+// no AppEUI.
+func (r *DeviceRecord) Device(appID string, appEUI types.AppEUI) (*handler.Device, error) {
+	dev := &lorawan.Device{
+		AppId:                 appID,
+		DevId:                 r.DevID,
+		AppEui:                &appEUI,
+		Uses32BitFCnt:         true,
+		DisableFCntCheck:      r.DisableFCntCheck,
+		ActivationConstraints: r.ActivationConstraints,
+	}
+	if r.Uses32BitFCnt != nil {
+		dev.Uses32BitFCnt = *r.Uses32BitFCnt
+	}
+	if r.AppEUI != "" {
+		appEUI, err := types.ParseAppEUI(r.AppEUI)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid AppEUI: %s", err)
+		}
+		dev.AppEui = &appEUI
+	}
+	devEUI, err := types.ParseDevEUI(r.DevEUI)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid DevEUI: %s", err)
+	}
+	dev.DevEui = &devEUI
+	if r.AppKey != "" {
+		appKey, err := types.ParseAppKey(r.AppKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid AppKey: %s", err)
+		}
+		dev.AppKey = &appKey
+	}
+	if r.DevAddr != "" {
+		devAddr, err := types.ParseDevAddr(r.DevAddr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid DevAddr: %s", err)
+		}
+		dev.DevAddr = &devAddr
+	}
+	if r.NwkSKey != "" {
+		nwkSKey, err := types.ParseNwkSKey(r.NwkSKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid NwkSKey: %s", err)
+		}
+		dev.NwkSKey = &nwkSKey
+	}
+	if r.AppSKey != "" {
+		appSKey, err := types.ParseAppSKey(r.AppSKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid AppSKey: %s", err)
+		}
+		dev.AppSKey = &appSKey
+	}
+	switch r.ActivationConstraints {
+	case "", "local", "public", "private":
+	default:
+		return nil, fmt.Errorf("Invalid activation constraints \"%s\"", r.ActivationConstraints)
+	}
+	if r.Latitude < -90 || r.Latitude > 90 {
+		return nil, fmt.Errorf("Latitude should be in range [-90, 90]")
+	}
+	if r.Longitude < -180 || r.Longitude > 180 {
+		return nil, fmt.Errorf("Longitude should be in range [-180, 180]")
+	}
+	device := &handler.Device{
+		AppId:       appID,
+		DevId:       r.DevID,
+		Description: r.Description,
+		Latitude:    r.Latitude,
+		Longitude:   r.Longitude,
+		Altitude:    r.Altitude,
+		Device:      &handler.Device_LorawanDevice{LorawanDevice: dev},
+	}
+	if err := device.Validate(); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// NewDeviceRecord returns the record of a device. Empty EUIs, addresses and keys are left empty.
+func NewDeviceRecord(dev *handler.Device) *DeviceRecord {
+	record := &DeviceRecord{
+		DevID:       dev.DevId,
+		Description: dev.Description,
+		Latitude:    dev.Latitude,
+		Longitude:   dev.Longitude,
+		Altitude:    dev.Altitude,
+	}
+	lorawan := dev.GetLorawanDevice()
+	if lorawan == nil {
+		return record
+	}
+	uses32BitFCnt := lorawan.Uses32BitFCnt
+	record.Uses32BitFCnt = &uses32BitFCnt
+	record.DisableFCntCheck = lorawan.DisableFCntCheck
+	record.ActivationConstraints = lorawan.ActivationConstraints
+	if lorawan.AppEui != nil && !lorawan.AppEui.IsEmpty() {
+		record.AppEUI = lorawan.AppEui.String()
+	}
+	if lorawan.DevEui != nil && !lorawan.DevEui.IsEmpty() {
+		record.DevEUI = lorawan.DevEui.String()
+	}
+	if lorawan.AppKey != nil && !lorawan.AppKey.IsEmpty() {
+		record.AppKey = lorawan.AppKey.String()
+	}
+	if lorawan.DevAddr != nil && !lorawan.DevAddr.IsEmpty() {
+		record.DevAddr = lorawan.DevAddr.String()
+	}
+	if lorawan.NwkSKey != nil && !lorawan.NwkSKey.IsEmpty() {
+		record.NwkSKey = lorawan.NwkSKey.String()
+	}
+	if lorawan.AppSKey != nil && !lorawan.AppSKey.IsEmpty() {
+		record.AppSKey = lorawan.AppSKey.String()
+	}
+	return record
+}
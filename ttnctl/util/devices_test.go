@@ -0,0 +1,108 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/TheThingsNetwork/ttn/core/types"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestDeviceFileFormat(t *testing.T) {
+	a := New(t)
+
+	format, err := DeviceFileFormat("devices.csv")
+	a.So(err, ShouldBeNil)
+	a.So(format, ShouldEqual, "csv")
+
+	format, err = DeviceFileFormat("/tmp/Devices.JSON")
+	a.So(err, ShouldBeNil)
+	a.So(format, ShouldEqual, "json")
+
+	_, err = DeviceFileFormat("devices.xml")
+	a.So(err, ShouldNotBeNil)
+}
+
+func TestReadDeviceRecords(t *testing.T) {
+	a := New(t)
+
+	records, err := ReadDeviceRecords(strings.NewReader(`dev_id, dev_eui, app_key, latitude, uses_32_bit_fcnt
+dev-1, 0102030405060708, 01020304050607080102030405060708, 52.5, false
+dev-2, 0807060504030201, , ,
+`), "csv")
+	a.So(err, ShouldBeNil)
+	a.So(records, ShouldHaveLength, 2)
+	a.So(records[0].DevID, ShouldEqual, "dev-1")
+	a.So(records[0].DevEUI, ShouldEqual, "0102030405060708")
+	a.So(records[0].AppKey, ShouldEqual, "01020304050607080102030405060708")
+	a.So(records[0].Latitude, ShouldEqual, 52.5)
+	a.So(*records[0].Uses32BitFCnt, ShouldBeFalse)
+	a.So(records[1].AppKey, ShouldBeEmpty)
+	a.So(records[1].Uses32BitFCnt, ShouldBeNil)
+
+	_, err = ReadDeviceRecords(strings.NewReader("dev_id,unknown\ndev-1,value\n"), "csv")
+	a.So(err, ShouldNotBeNil)
+
+	_, err = ReadDeviceRecords(strings.NewReader("dev_id,altitude\ndev-1,high\n"), "csv")
+	a.So(err, ShouldNotBeNil)
+
+	records, err = ReadDeviceRecords(strings.NewReader(`[{"dev_id":"dev-1","dev_eui":"0102030405060708","altitude":10}]`), "json")
+	a.So(err, ShouldBeNil)
+	a.So(records, ShouldHaveLength, 1)
+	a.So(records[0].Altitude, ShouldEqual, 10)
+}
+
+func TestDeviceRecordDevice(t *testing.T) {
+	a := New(t)
+
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+
+	record := &DeviceRecord{DevID: "dev-1", DevEUI: "0102030405060708", AppKey: "01020304050607080102030405060708"}
+	dev, err := record.Device("app-1", appEUI)
+	a.So(err, ShouldBeNil)
+	a.So(dev.AppId, ShouldEqual, "app-1")
+	a.So(dev.DevId, ShouldEqual, "dev-1")
+	lorawan := dev.GetLorawanDevice()
+	a.So(*lorawan.AppEui, ShouldEqual, appEUI)
+	a.So(*lorawan.DevEui, ShouldEqual, types.DevEUI{1, 2, 3, 4, 5, 6, 7, 8})
+	a.So(lorawan.AppKey, ShouldNotBeNil)
+	a.So(lorawan.NwkSKey, ShouldBeNil)
+	a.So(lorawan.Uses32BitFCnt, ShouldBeTrue)
+
+	for _, invalid := range []*DeviceRecord{
+		{DevID: "dev-1"},
+		{DevID: "Dev 1", DevEUI: "0102030405060708"},
+		{DevID: "dev-1", DevEUI: "01020304"},
+		{DevID: "dev-1", DevEUI: "0102030405060708", AppEUI: "not-hex"},
+		{DevID: "dev-1", DevEUI: "0102030405060708", AppKey: "0102"},
+		{DevID: "dev-1", DevEUI: "0102030405060708", DevAddr: "260000"},
+		{DevID: "dev-1", DevEUI: "0102030405060708", ActivationConstraints: "everywhere"},
+		{DevID: "dev-1", DevEUI: "0102030405060708", Latitude: 91},
+	} {
+		_, err := invalid.Device("app-1", appEUI)
+		a.So(err, ShouldNotBeNil)
+	}
+}
+
+func TestWriteDeviceRecords(t *testing.T) {
+	a := New(t)
+
+	appEUI := types.AppEUI{1, 2, 3, 4, 5, 6, 7, 8}
+	dev, _ := (&DeviceRecord{DevID: "dev-1", DevEUI: "0102030405060708", Description: "Device, one"}).Device("app-1", appEUI)
+	record := NewDeviceRecord(dev)
+	a.So(record.AppEUI, ShouldEqual, "0102030405060708")
+	a.So(record.AppKey, ShouldBeEmpty)
+
+	for _, format := range []string{"csv", "json"} {
+		var buf bytes.Buffer
+		err := WriteDeviceRecords(&buf, format, []*DeviceRecord{record})
+		a.So(err, ShouldBeNil)
+		records, err := ReadDeviceRecords(&buf, format)
+		a.So(err, ShouldBeNil)
+		a.So(records, ShouldResemble, []*DeviceRecord{record})
+	}
+}
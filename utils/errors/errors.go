@@ -18,13 +18,14 @@ type ErrType string
 
 // These constants represent error types
 const (
-	AlreadyExists    ErrType = "already exists"
-	Internal         ErrType = "internal"
-	InvalidArgument  ErrType = "invalid argument"
-	NotFound         ErrType = "not found"
-	OutOfRange       ErrType = "out of range"
-	PermissionDenied ErrType = "permission denied"
-	Unknown          ErrType = "unknown"
+	AlreadyExists     ErrType = "already exists"
+	Internal          ErrType = "internal"
+	InvalidArgument   ErrType = "invalid argument"
+	NotFound          ErrType = "not found"
+	OutOfRange        ErrType = "out of range"
+	PermissionDenied  ErrType = "permission denied"
+	ResourceExhausted ErrType = "resource exhausted"
+	Unknown           ErrType = "unknown"
 )
 
 // GetErrType returns the type of err
@@ -40,6 +41,8 @@ func GetErrType(err error) ErrType {
 		return NotFound
 	case *ErrPermissionDenied:
 		return PermissionDenied
+	case *ErrResourceExhausted:
+		return ResourceExhausted
 	}
 	return Unknown
 }
@@ -64,6 +67,8 @@ func BuildGRPCError(err error) error {
 		code = codes.NotFound
 	case *ErrPermissionDenied:
 		code = codes.PermissionDenied
+	case *ErrResourceExhausted:
+		code = codes.ResourceExhausted
 	}
 	switch err {
 	case context.Canceled:
@@ -95,6 +100,8 @@ func FromGRPCError(err error) error {
 		return NewErrNotFound(strings.TrimSuffix(desc, " not found"))
 	case codes.PermissionDenied:
 		return NewErrPermissionDenied(strings.TrimPrefix(desc, "permission denied: "))
+	case codes.ResourceExhausted:
+		return NewErrResourceExhausted(strings.TrimSuffix(desc, " exhausted"))
 	case codes.Unknown: // This also includes all non-gRPC errors
 		if desc == "EOF" {
 			return io.EOF
@@ -180,6 +187,21 @@ func (err ErrPermissionDenied) Error() string {
 	return fmt.Sprintf("permission denied: %s", err.reason)
 }
 
+// NewErrResourceExhausted returns a new ErrResourceExhausted for the given resource
+func NewErrResourceExhausted(resource string) error {
+	return &ErrResourceExhausted{resource: resource}
+}
+
+// ErrResourceExhausted indicates that a resource, such as a quota, was exhausted
+type ErrResourceExhausted struct {
+	resource string
+}
+
+// Error implements the error interface
+func (err ErrResourceExhausted) Error() string {
+	return fmt.Sprintf("%s exhausted", err.resource)
+}
+
 // Wrapf returns an error annotating err with the format specifier.
 // If err is nil, Wrapf returns nil.
 func Wrapf(err error, format string, args ...interface{}) error {
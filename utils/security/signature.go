@@ -0,0 +1,29 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Sign signs the data with the given private key, using the same algorithm (ES256) as the JSON Web Tokens of
+// components
+func Sign(data []byte, privateKey *ecdsa.PrivateKey) (signature string, err error) {
+	return jwt.SigningMethodES256.Sign(string(data), privateKey)
+}
+
+// VerifySignature verifies the signature of the data with the given public key
+func VerifySignature(data []byte, signature string, publicKey []byte) error {
+	key, err := jwt.ParseECPublicKeyFromPEM(publicKey)
+	if err != nil {
+		return err
+	}
+	if err := jwt.SigningMethodES256.Verify(string(data), signature, key); err != nil {
+		return fmt.Errorf("Unable to verify signature: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright © 2017 The Things Network
+// Use of this source code is governed by the MIT license that can be found in the LICENSE file.
+
+package security
+
+import (
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/assertions"
+)
+
+func TestSignature(t *testing.T) {
+	a := New(t)
+
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(privKey))
+	a.So(err, ShouldBeNil)
+
+	signature, err := Sign([]byte("the data"), key)
+	a.So(err, ShouldBeNil)
+
+	err = VerifySignature([]byte("the data"), signature, []byte(pubKey))
+	a.So(err, ShouldBeNil)
+
+	// Other data
+	err = VerifySignature([]byte("other data"), signature, []byte(pubKey))
+	a.So(err, ShouldNotBeNil)
+
+	// Wrong public key
+	err = VerifySignature([]byte("the data"), signature, []byte("this is no key"))
+	a.So(err, ShouldNotBeNil)
+}